@@ -0,0 +1,171 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+)
+
+const (
+	activityPubTimeout     = 10 * time.Second
+	maxRemoteDocumentBytes = 1 << 20
+)
+
+type ActivityPubClient interface {
+	FetchActor(ctx context.Context, actorURI string) (*models.Actor, error)
+	Deliver(ctx context.Context, delivery *models.Delivery) error
+}
+
+type activityPubClient struct {
+	hs     pkgs.HTTPSigner
+	client *http.Client
+}
+
+// NewActivityPubClient returns a client that only speaks https to public
+// addresses. Remote servers choose the URLs it fetches, so the address is
+// checked when dialing, after DNS resolution, where a rebinding answer cannot
+// slip past it.
+func NewActivityPubClient(httpSigner pkgs.HTTPSigner) ActivityPubClient {
+	dialer := &net.Dialer{Timeout: activityPubTimeout, Control: refuseInternalAddress}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return NewActivityPubClientWithHTTPClient(httpSigner, &http.Client{
+		Timeout:   activityPubTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return CheckRemoteURL(req.URL.String())
+		},
+	})
+}
+
+// NewActivityPubClientWithHTTPClient uses client as is, without the address
+// checks of NewActivityPubClient.
+func NewActivityPubClientWithHTTPClient(httpSigner pkgs.HTTPSigner, client *http.Client) ActivityPubClient {
+	return &activityPubClient{
+		hs:     httpSigner,
+		client: client,
+	}
+}
+
+// CheckRemoteURL rejects URLs that are not https or that name an internal
+// address directly. Host names are checked again once resolved.
+func CheckRemoteURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("%s is not an https url: %w", rawURL, models.ErrUnsafeRemoteURL)
+	}
+
+	if u.Hostname() == "localhost" {
+		return fmt.Errorf("%s: %w", rawURL, models.ErrUnsafeRemoteURL)
+	}
+
+	if ip, err := netip.ParseAddr(u.Hostname()); err == nil && isInternalAddress(ip) {
+		return fmt.Errorf("%s: %w", rawURL, models.ErrUnsafeRemoteURL)
+	}
+
+	return nil
+}
+
+func refuseInternalAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("parse address %s: %w", address, err)
+	}
+
+	if isInternalAddress(addrPort.Addr()) {
+		return fmt.Errorf("dial %s: %w", address, models.ErrUnsafeRemoteURL)
+	}
+
+	return nil
+}
+
+func isInternalAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+func (a *activityPubClient) FetchActor(ctx context.Context, actorURI string) (*models.Actor, error) {
+	if err := CheckRemoteURL(actorURI); err != nil {
+		return nil, fmt.Errorf("fetch actor: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, actorURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	req.Header.Set("Accept", models.ActivityContentType)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch actor %s: %w", actorURI, models.ErrRemoteActorUnavailable)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch actor %s: status %d: %w", actorURI, resp.StatusCode, models.ErrRemoteActorUnavailable)
+	}
+
+	var actor models.Actor
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRemoteDocumentBytes)).Decode(&actor); err != nil {
+		return nil, fmt.Errorf("decode actor %s: %w", actorURI, err)
+	}
+
+	return &actor, nil
+}
+
+// Deliver POSTs the signed activity to the remote inbox. A 4xx other than
+// 429 is wrapped in ErrDeliveryRejected, since retrying will not help.
+func (a *activityPubClient) Deliver(ctx context.Context, delivery *models.Delivery) error {
+	if err := CheckRemoteURL(delivery.Inbox); err != nil {
+		return fmt.Errorf("deliver: %w: %v", models.ErrDeliveryRejected, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Inbox, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", models.ActivityContentType)
+	req.Header.Set("Accept", models.ActivityContentType)
+
+	if err := a.hs.Sign(req, delivery.KeyID, delivery.PrivateKeyPEM, delivery.Payload); err != nil {
+		return fmt.Errorf("sign request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("deliver to %s: %w", delivery.Inbox, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxRemoteDocumentBytes))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("deliver to %s: status %d: %w", delivery.Inbox, resp.StatusCode, models.ErrDeliveryRejected)
+	}
+
+	return fmt.Errorf("deliver to %s: status %d", delivery.Inbox, resp.StatusCode)
+}
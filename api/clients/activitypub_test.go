@@ -0,0 +1,44 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityPubClient_FetchActor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should not connect to internal addresses", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+		}))
+		defer server.Close()
+
+		client := NewActivityPubClient(nil)
+
+		_, err := client.FetchActor(ctx, server.URL+"/users/alice")
+
+		assert.ErrorIs(t, err, models.ErrUnsafeRemoteURL)
+		assert.Zero(t, requests)
+	})
+
+	t.Run("should refuse internal addresses when dialing", func(t *testing.T) {
+		for _, address := range []string{"127.0.0.1:443", "10.1.2.3:443", "192.168.0.1:443", "169.254.169.254:80", "[::1]:443", "[fe80::1]:443", "0.0.0.0:443", "[::ffff:127.0.0.1]:443"} {
+			assert.ErrorIs(t, refuseInternalAddress("tcp", address, nil), models.ErrUnsafeRemoteURL, address)
+		}
+
+		assert.NoError(t, refuseInternalAddress("tcp", "93.184.216.34:443", nil))
+	})
+
+	t.Run("should reject plain http", func(t *testing.T) {
+		_, err := NewActivityPubClient(nil).FetchActor(ctx, "http://remote.test/users/alice")
+
+		assert.ErrorIs(t, err, models.ErrUnsafeRemoteURL)
+	})
+}
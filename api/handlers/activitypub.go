@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+const maxInboxBodyBytes = 1 << 20
+
+type ActivityPubHandler interface {
+	WebFinger(w http.ResponseWriter, r *http.Request)
+	GetActor(w http.ResponseWriter, r *http.Request)
+	GetOutbox(w http.ResponseWriter, r *http.Request)
+	GetNote(w http.ResponseWriter, r *http.Request)
+	PostInbox(w http.ResponseWriter, r *http.Request)
+}
+
+type activityPubHandler struct {
+	fs services.FederationService
+}
+
+func NewActivityPubHandler(federationService services.FederationService) ActivityPubHandler {
+	return &activityPubHandler{
+		fs: federationService,
+	}
+}
+
+func (a *activityPubHandler) WebFinger(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "activitypub"),
+		slog.String("method", "WebFinger"),
+	)
+
+	resource := r.URL.Query().Get("resource")
+	if resource == "" {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O parâmetro resource é obrigatório.")
+		return
+	}
+
+	webFinger, err := a.fs.GetWebFinger(r.Context(), resource)
+	if err != nil {
+		logger.Error("get webfinger", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	writeActivityJSON(w, r, http.StatusOK, models.JRDContentType, webFinger)
+}
+
+func (a *activityPubHandler) GetActor(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "activitypub"),
+		slog.String("method", "GetActor"),
+	)
+
+	actor, err := a.fs.GetActor(r.Context(), r.PathValue("username"))
+	if err != nil {
+		logger.Error("get actor", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	writeActivityJSON(w, r, http.StatusOK, models.ActivityContentType, actor)
+}
+
+func (a *activityPubHandler) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "activitypub"),
+		slog.String("method", "GetOutbox"),
+	)
+
+	outbox, err := a.fs.GetOutbox(r.Context(), r.PathValue("username"))
+	if err != nil {
+		logger.Error("get outbox", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	writeActivityJSON(w, r, http.StatusOK, models.ActivityContentType, outbox)
+}
+
+func (a *activityPubHandler) GetNote(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "activitypub"),
+		slog.String("method", "GetNote"),
+	)
+
+	note, err := a.fs.GetNote(r.Context(), r.PathValue("postId"))
+	if err != nil {
+		logger.Error("get note", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	writeActivityJSON(w, r, http.StatusOK, models.ActivityContentType, note)
+}
+
+func (a *activityPubHandler) PostInbox(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "activitypub"),
+		slog.String("method", "PostInbox"),
+	)
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxInboxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			WriteProblem(w, r, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "A atividade excede o tamanho máximo permitido.")
+			return
+		}
+
+		WriteProblem(w, r, http.StatusBadRequest, CodeMalformedBody, "Não foi possível ler a atividade.")
+		return
+	}
+
+	if err := a.fs.HandleInbox(r.Context(), r.PathValue("username"), r, body); err != nil {
+		logger.Error("handle inbox", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusAccepted)
+}
+
+// writeActivityJSON encodes data before writing the header, so an encoding
+// failure can still be answered with a problem.
+func writeActivityJSON(w http.ResponseWriter, r *http.Request, statusCode int, contentType string, data any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(data); err != nil {
+		WriteError(w, r, fmt.Errorf("encode activity: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(buf.Bytes())
+}
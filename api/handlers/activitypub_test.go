@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteActivityJSON(t *testing.T) {
+	t.Run("should write the activity with its content type", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ap/users/bob", nil)
+		rr := httptest.NewRecorder()
+
+		writeActivityJSON(rr, req, http.StatusOK, models.ActivityContentType, map[string]string{"type": "Person"})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, models.ActivityContentType, rr.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"type":"Person"}`, rr.Body.String())
+	})
+
+	t.Run("should answer with a problem if the activity cannot be encoded", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ap/users/bob", nil)
+		rr := httptest.NewRecorder()
+
+		writeActivityJSON(rr, req, http.StatusOK, models.ActivityContentType, math.Inf(1))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	})
+}
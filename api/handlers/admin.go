@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+// AdminHandler serves operator actions. Its routes are guarded by the admin
+// API key instead of a user session.
+type AdminHandler interface {
+	BanUser(w http.ResponseWriter, r *http.Request)
+}
+
+type adminHandler struct {
+	as services.AuthService
+}
+
+func NewAdminHandler(authService services.AuthService) AdminHandler {
+	return &adminHandler{
+		as: authService,
+	}
+}
+
+func (a *adminHandler) BanUser(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("method", "BanUser"),
+	)
+
+	userID := r.PathValue("userId")
+
+	if err := a.as.BanUser(r.Context(), userID); err != nil {
+		logger.Error("ban user", "userID", userID, "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	logger.Info("user banned", "userID", userID)
+	NoContent(w, http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminHandler_BanUser(t *testing.T) {
+	newRequest := func(userID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userID+"/ban", nil)
+		req.SetPathValue("userId", userID)
+		return req
+	}
+
+	t.Run("should return 204 after banning the user", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		h := NewAdminHandler(as)
+
+		as.On("BanUser", mock.Anything, "user-1").Return(nil)
+
+		rr := httptest.NewRecorder()
+		h.BanUser(rr, newRequest("user-1"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		as.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 if the user does not exist", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		h := NewAdminHandler(as)
+
+		as.On("BanUser", mock.Anything, "missing").Return(models.ErrUserNotFound)
+
+		rr := httptest.NewRecorder()
+		h.BanUser(rr, newRequest("missing"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -1,9 +1,10 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/models"
@@ -14,18 +15,25 @@ import (
 type AuthHandler interface {
 	SendAuthenticationLink(w http.ResponseWriter, r *http.Request)
 	AuthenticateFromLink(w http.ResponseWriter, r *http.Request)
+	ApproveLogin(w http.ResponseWriter, r *http.Request)
+	PollPendingLogin(w http.ResponseWriter, r *http.Request)
+	AuthenticateWithCode(w http.ResponseWriter, r *http.Request)
+	ExchangeLinkToken(w http.ResponseWriter, r *http.Request)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
 }
 
 type authHandler struct {
-	rc pkgs.RequestContext
-	as services.AuthService
+	rc  pkgs.RequestContext
+	as  services.AuthService
+	geo pkgs.GeoIP
 }
 
-func NewAuthHandler(authService services.AuthService, requestContext pkgs.RequestContext) AuthHandler {
+func NewAuthHandler(authService services.AuthService, requestContext pkgs.RequestContext, geoIP pkgs.GeoIP) AuthHandler {
 	return &authHandler{
-		as: authService,
-		rc: requestContext,
+		as:  authService,
+		rc:  requestContext,
+		geo: geoIP,
 	}
 }
 
@@ -36,25 +44,28 @@ func (a *authHandler) SendAuthenticationLink(w http.ResponseWriter, r *http.Requ
 	)
 
 	var sendAuthenticationLinkPayload models.SendAuthenticationLinkPayload
-	if err := json.NewDecoder(r.Body).Decode(&sendAuthenticationLinkPayload); err != nil {
-		logger.Error("failed to decode request body", "error", err)
-		NoContent(w, http.StatusBadRequest)
+	if !DecodeAndValidate(w, r, &sendAuthenticationLinkPayload) {
+		logger.Error("invalid request body")
 		return
 	}
 
-	if err := a.as.SendAuthenticationLink(r.Context(), sendAuthenticationLinkPayload.Email); err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Warn("user not found")
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
+	login, err := a.as.SendAuthenticationLink(r.Context(), sendAuthenticationLinkPayload.Email, sendAuthenticationLinkPayload.WithCode)
+	if err != nil {
 		logger.Error("send authentication link", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	NoContent(w, http.StatusOK)
+	expiresIn := time.Until(login.ExpiresAt)
+
+	w.Header().Set("Cache-Control", "no-store")
+	SetPendingLoginCookie(w, login.PollSecret, expiresIn)
+	JSON(w, http.StatusOK, models.PendingLoginResponse{
+		PendingLoginID:   login.SessionID,
+		VerificationCode: login.VerificationCode,
+		ExpiresIn:        int(expiresIn.Seconds()),
+		PollInterval:     models.PendingLoginPollInterval,
+	})
 }
 
 func (a *authHandler) AuthenticateFromLink(w http.ResponseWriter, r *http.Request) {
@@ -66,12 +77,23 @@ func (a *authHandler) AuthenticateFromLink(w http.ResponseWriter, r *http.Reques
 	token := r.URL.Query().Get("token")
 	if token == "" {
 		logger.Error("missing token")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O token é obrigatório.")
 		return
 	}
 
-	authResponse, err := a.as.AuthenticateFromLink(r.Context(), token)
+	pollSecret, _ := GetPendingLoginCookie(r)
+	here := r.URL.Query().Get("here") == "1"
+
+	authResponse, err := a.as.AuthenticateFromLink(r.Context(), token, pollSecret, here, clientInfo(r, a.geo))
 	if err != nil {
+		if err == models.ErrLoginApprovalRequired {
+			logger.Info("link opened on another device")
+			if err := writeLoginApproval(w, http.StatusOK, loginApprovalPage{Token: token}); err != nil {
+				logger.Error("write login approval", "error", err)
+			}
+			return
+		}
+
 		if err == models.ErrSessionNotFound {
 			logger.Warn("invalid token (session not found)")
 			http.Redirect(w, r, configs.Env.RedirectURL+"auth/fail?error=invalid_token", http.StatusFound)
@@ -84,15 +106,222 @@ func (a *authHandler) AuthenticateFromLink(w http.ResponseWriter, r *http.Reques
 			return
 		}
 
+		if err == models.ErrUserBanned {
+			logger.Warn("user banned")
+			http.Redirect(w, r, configs.Env.RedirectURL+"auth/fail?error=user_banned", http.StatusFound)
+			return
+		}
+
+		if err == models.ErrUserInactive {
+			logger.Warn("user inactive")
+			http.Redirect(w, r, configs.Env.RedirectURL+"auth/fail?error=user_inactive", http.StatusFound)
+			return
+		}
+
 		logger.Error("authenticate from link", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	SetTokenCookie(w, authResponse.Token)
+	setAuthCookies(w, authResponse)
+	DeletePendingLoginCookie(w)
 	http.Redirect(w, r, configs.Env.RedirectURL, http.StatusFound)
 }
 
+// ApproveLogin is posted by the approval page of a magic link opened on
+// another device. A wrong code shows the page again; once approved, the page
+// tells the user to close the tab.
+func (a *authHandler) ApproveLogin(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("method", "ApproveLogin"),
+	)
+
+	token := r.PostFormValue("token")
+	if token == "" {
+		logger.Error("missing token")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O token é obrigatório.")
+		return
+	}
+
+	page := loginApprovalPage{Token: token}
+
+	if err := a.as.ApproveLogin(r.Context(), token, r.PostFormValue("code")); err != nil {
+		switch err {
+		case models.ErrInvalidLoginCode:
+			logger.Warn("invalid verification code")
+			page.Error = "Código incorreto. Confira o código na tela do outro dispositivo."
+			if err := writeLoginApproval(w, http.StatusUnauthorized, page); err != nil {
+				logger.Error("write login approval", "error", err)
+			}
+		case models.ErrLoginCodeAttemptsExceeded:
+			logger.Warn("verification code attempts exceeded")
+			http.Redirect(w, r, configs.Env.RedirectURL+"auth/fail?error=too_many_attempts", http.StatusSeeOther)
+		case models.ErrSessionNotFound:
+			logger.Warn("invalid token (session not found)")
+			http.Redirect(w, r, configs.Env.RedirectURL+"auth/fail?error=invalid_token", http.StatusSeeOther)
+		case models.ErrSessionExpired:
+			logger.Warn("expired token")
+			http.Redirect(w, r, configs.Env.RedirectURL+"auth/fail?error=expired_token", http.StatusSeeOther)
+		default:
+			logger.Error("approve login", "error", err)
+			WriteError(w, r, err)
+		}
+		return
+	}
+
+	page.Approved = true
+	if err := writeLoginApproval(w, http.StatusOK, page); err != nil {
+		logger.Error("write login approval", "error", err)
+	}
+}
+
+// PollPendingLogin is polled by the browser that asked for a login email
+// while its link is opened elsewhere. Once approved, it answers with the
+// session cookies; only the browser holding the pending login cookie can
+// claim them.
+func (a *authHandler) PollPendingLogin(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("method", "PollPendingLogin"),
+	)
+
+	pollSecret, err := GetPendingLoginCookie(r)
+	if err != nil || pollSecret == "" {
+		logger.Warn("missing pending login cookie")
+		WriteError(w, r, models.ErrPendingLoginNotFound)
+		return
+	}
+
+	authResponse, err := a.as.PollLogin(r.Context(), r.PathValue("pendingLoginId"), pollSecret, clientInfo(r, a.geo))
+	if err != nil {
+		logger.Warn("poll login", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	if authResponse == nil {
+		JSON(w, http.StatusOK, models.PendingLoginStatusResponse{Status: models.PendingLoginStatusPending})
+		return
+	}
+
+	setAuthCookies(w, authResponse)
+	DeletePendingLoginCookie(w)
+	JSON(w, http.StatusOK, models.PendingLoginStatusResponse{Status: models.PendingLoginStatusApproved})
+}
+
+// AuthenticateWithCode signs the browser in with the code of a login email,
+// setting the same cookies as AuthenticateFromLink.
+func (a *authHandler) AuthenticateWithCode(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("method", "AuthenticateWithCode"),
+	)
+
+	var payload models.LoginCodePayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	authResponse, err := a.as.AuthenticateWithCode(r.Context(), payload.Email, payload.Code, clientInfo(r, a.geo))
+	if err != nil {
+		logger.Warn("authenticate with code", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	setAuthCookies(w, authResponse)
+	NoContent(w, http.StatusNoContent)
+}
+
+// ExchangeLinkToken is the non-redirecting variant of AuthenticateFromLink
+// for native clients: it answers with the auth and refresh tokens as JSON,
+// the auth token to be sent as a Bearer token, and sets no cookie.
+func (a *authHandler) ExchangeLinkToken(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("method", "ExchangeLinkToken"),
+	)
+
+	var payload models.ExchangeLinkTokenPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	authResponse, err := a.as.AuthenticateFromLink(r.Context(), payload.Token, "", true, clientInfo(r, a.geo))
+	if err != nil {
+		logger.Warn("exchange link token", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	authResponse.TokenType = "Bearer"
+
+	w.Header().Set("Cache-Control", "no-store")
+	JSON(w, http.StatusOK, authResponse)
+}
+
+// RefreshToken renews the session of a refresh token. Native clients send it
+// in the body and get the new tokens as JSON; browsers send the refresh
+// cookie and get new cookies, so the refresh token never reaches scripts.
+func (a *authHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("method", "RefreshToken"),
+	)
+
+	fromCookie := r.ContentLength == 0
+
+	var payload models.RefreshTokenPayload
+	if fromCookie {
+		refreshToken, err := GetRefreshTokenCookie(r)
+		if err != nil || refreshToken == "" {
+			logger.Warn("missing refresh token")
+			Unauthorized(w, r)
+			return
+		}
+		payload.RefreshToken = refreshToken
+	} else if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	authResponse, err := a.as.RefreshSession(r.Context(), payload.RefreshToken)
+	if err != nil {
+		if errors.Is(err, models.ErrRefreshTokenReused) {
+			logger.Warn("refresh token reused, session revoked")
+		} else {
+			logger.Warn("refresh session", "error", err)
+		}
+
+		// Only a refresh token that can never work again is cleared; other
+		// failures are worth retrying with the same cookie.
+		if fromCookie && isDeadRefreshToken(err) {
+			DeleteTokenCookie(w)
+			DeleteRefreshTokenCookie(w)
+		}
+
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	if fromCookie {
+		setAuthCookies(w, authResponse)
+		NoContent(w, http.StatusNoContent)
+		return
+	}
+
+	authResponse.TokenType = "Bearer"
+	JSON(w, http.StatusOK, authResponse)
+}
+
 func (a *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	logger := slog.With(
 		slog.String("handler", "auth"),
@@ -102,21 +331,31 @@ func (a *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	sessionID, ok := a.rc.GetSessionID(r.Context())
 	if !ok {
 		logger.Error("missing session ID")
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	if err := a.as.Logout(r.Context(), sessionID); err != nil {
 		if err == models.ErrSessionNotFound {
 			logger.Warn("session not found (silenced)")
+			DeleteTokenCookie(w)
+			DeleteRefreshTokenCookie(w)
 			NoContent(w, http.StatusOK)
+			return
 		}
 
 		logger.Error("logout", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
 	DeleteTokenCookie(w)
+	DeleteRefreshTokenCookie(w)
 	NoContent(w, http.StatusOK)
 }
+
+func isDeadRefreshToken(err error) bool {
+	return errors.Is(err, models.ErrInvalidRefreshToken) ||
+		errors.Is(err, models.ErrRefreshTokenReused) ||
+		errors.Is(err, models.ErrSessionExpired)
+}
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/mocks"
@@ -20,7 +23,7 @@ func TestAuthHandler_SendAuthenticationLink(t *testing.T) {
 
 	t.Run("should return 400 if body is invalid", func(t *testing.T) {
 		as := new(mocks.AuthServiceMock)
-		h := NewAuthHandler(as, rc)
+		h := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		req := httptest.NewRequest(http.MethodPost, "/authenticate", bytes.NewBufferString("invalid-json"))
 		rr := httptest.NewRecorder()
@@ -32,13 +35,13 @@ func TestAuthHandler_SendAuthenticationLink(t *testing.T) {
 
 	t.Run("should return 200 even if user not found (silent)", func(t *testing.T) {
 		as := new(mocks.AuthServiceMock)
-		h := NewAuthHandler(as, rc)
+		h := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		payload := models.SendAuthenticationLinkPayload{Email: "naoexiste@email.com"}
 		body, _ := json.Marshal(payload)
 
-		as.On("SendAuthenticationLink", mock.Anything, payload.Email).
-			Return(models.ErrUserNotFound)
+		as.On("SendAuthenticationLink", mock.Anything, payload.Email, false).
+			Return(nil, models.ErrUserNotFound)
 
 		req := httptest.NewRequest(http.MethodPost, "/authenticate", bytes.NewBuffer(body))
 		rr := httptest.NewRecorder()
@@ -51,13 +54,13 @@ func TestAuthHandler_SendAuthenticationLink(t *testing.T) {
 
 	t.Run("should return 500 on error", func(t *testing.T) {
 		as := new(mocks.AuthServiceMock)
-		h := NewAuthHandler(as, rc)
+		h := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		payload := models.SendAuthenticationLinkPayload{Email: "error@email.com"}
 		body, _ := json.Marshal(payload)
 
-		as.On("SendAuthenticationLink", mock.Anything, payload.Email).
-			Return(assert.AnError)
+		as.On("SendAuthenticationLink", mock.Anything, payload.Email, false).
+			Return(nil, assert.AnError)
 
 		req := httptest.NewRequest(http.MethodPost, "/authenticate", bytes.NewBuffer(body))
 		rr := httptest.NewRecorder()
@@ -68,15 +71,20 @@ func TestAuthHandler_SendAuthenticationLink(t *testing.T) {
 		as.AssertExpectations(t)
 	})
 
-	t.Run("should return 200 on success", func(t *testing.T) {
+	t.Run("should return the pending login and keep its poll secret in a cookie", func(t *testing.T) {
 		as := new(mocks.AuthServiceMock)
-		h := NewAuthHandler(as, rc)
+		h := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		payload := models.SendAuthenticationLinkPayload{Email: "ok@email.com"}
 		body, _ := json.Marshal(payload)
 
-		as.On("SendAuthenticationLink", mock.Anything, payload.Email).
-			Return(nil)
+		as.On("SendAuthenticationLink", mock.Anything, payload.Email, false).
+			Return(&models.LoginSession{
+				SessionID:        "sess-1",
+				PollSecret:       "poll-secret",
+				VerificationCode: "381204",
+				ExpiresAt:        time.Now().Add(15 * time.Minute),
+			}, nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/authenticate", bytes.NewBuffer(body))
 		rr := httptest.NewRecorder()
@@ -85,13 +93,25 @@ func TestAuthHandler_SendAuthenticationLink(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 		as.AssertExpectations(t)
+
+		var response models.PendingLoginResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, "sess-1", response.PendingLoginID)
+		assert.Equal(t, "381204", response.VerificationCode)
+		assert.Equal(t, models.PendingLoginPollInterval, response.PollInterval)
+
+		cookies := rr.Result().Cookies()
+		assert.Len(t, cookies, 1)
+		assert.Equal(t, "tabnotes_pending", cookies[0].Name)
+		assert.Equal(t, "poll-secret", cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
 	})
 }
 
 func TestAuthHandler_AuthenticateFromLink(t *testing.T) {
 	rc := pkgs.NewRequestContext()
 	as := new(mocks.AuthServiceMock)
-	handler := NewAuthHandler(as, rc)
+	handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 	configs.Env.RedirectURL = "http://localhost:5173/"
 
@@ -108,7 +128,7 @@ func TestAuthHandler_AuthenticateFromLink(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=abc", nil)
 		rr := httptest.NewRecorder()
 
-		as.On("AuthenticateFromLink", mock.Anything, "abc").
+		as.On("AuthenticateFromLink", mock.Anything, "abc", "", false, mock.Anything).
 			Return(nil, models.ErrSessionNotFound)
 
 		handler.AuthenticateFromLink(rr, req)
@@ -121,7 +141,7 @@ func TestAuthHandler_AuthenticateFromLink(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=expired", nil)
 		rr := httptest.NewRecorder()
 
-		as.On("AuthenticateFromLink", mock.Anything, "expired").
+		as.On("AuthenticateFromLink", mock.Anything, "expired", "", false, mock.Anything).
 			Return(nil, models.ErrSessionExpired)
 
 		handler.AuthenticateFromLink(rr, req)
@@ -130,11 +150,24 @@ func TestAuthHandler_AuthenticateFromLink(t *testing.T) {
 		assert.Equal(t, "http://localhost:5173/auth/fail?error=expired_token", rr.Header().Get("Location"))
 	})
 
+	t.Run("should redirect to fail if user is banned", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=banned", nil)
+		rr := httptest.NewRecorder()
+
+		as.On("AuthenticateFromLink", mock.Anything, "banned", "", false, mock.Anything).
+			Return(nil, models.ErrUserBanned)
+
+		handler.AuthenticateFromLink(rr, req)
+
+		assert.Equal(t, http.StatusFound, rr.Code)
+		assert.Equal(t, "http://localhost:5173/auth/fail?error=user_banned", rr.Header().Get("Location"))
+	})
+
 	t.Run("should return 500 if unknown error", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=err", nil)
 		rr := httptest.NewRecorder()
 
-		as.On("AuthenticateFromLink", mock.Anything, "err").
+		as.On("AuthenticateFromLink", mock.Anything, "err", "", false, mock.Anything).
 			Return(nil, assert.AnError)
 
 		handler.AuthenticateFromLink(rr, req)
@@ -146,7 +179,7 @@ func TestAuthHandler_AuthenticateFromLink(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=valid", nil)
 		rr := httptest.NewRecorder()
 
-		as.On("AuthenticateFromLink", mock.Anything, "valid").
+		as.On("AuthenticateFromLink", mock.Anything, "valid", "", false, mock.Anything).
 			Return(&models.AuthResponse{Token: "abc.def.ghi"}, nil)
 
 		handler.AuthenticateFromLink(rr, req)
@@ -163,13 +196,284 @@ func TestAuthHandler_AuthenticateFromLink(t *testing.T) {
 		}
 		assert.True(t, found, "Token cookie not set")
 	})
+
+	t.Run("should show the approval page when opened on another device", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=elsewhere", nil)
+		rr := httptest.NewRecorder()
+
+		as.On("AuthenticateFromLink", mock.Anything, "elsewhere", "", false, mock.Anything).
+			Return(nil, models.ErrLoginApprovalRequired)
+
+		handler.AuthenticateFromLink(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, authPageCSP, rr.Header().Get("Content-Security-Policy"))
+		assert.Contains(t, rr.Body.String(), `name="token" value="elsewhere"`)
+		assert.Empty(t, rr.Result().Cookies())
+	})
+
+	t.Run("should sign in where the link was opened when asked to", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/magic-link/authenticate?token=here&here=1", nil)
+		req.AddCookie(&http.Cookie{Name: "tabnotes_pending", Value: "poll-secret"})
+		rr := httptest.NewRecorder()
+
+		as.On("AuthenticateFromLink", mock.Anything, "here", "poll-secret", true, mock.Anything).
+			Return(&models.AuthResponse{Token: "abc.def.ghi"}, nil)
+
+		handler.AuthenticateFromLink(rr, req)
+
+		assert.Equal(t, http.StatusFound, rr.Code)
+		assert.Equal(t, "http://localhost:5173/", rr.Header().Get("Location"))
+	})
+}
+
+func TestAuthHandler_ApproveLogin(t *testing.T) {
+	rc := pkgs.NewRequestContext()
+
+	configs.Env.RedirectURL = "http://localhost:5173/"
+
+	newRequest := func(token string, code string) *http.Request {
+		form := url.Values{"token": {token}, "code": {code}}
+		req := httptest.NewRequest(http.MethodPost, "/magic-link/approve", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	t.Run("should tell the user to close the tab once approved", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("ApproveLogin", mock.Anything, "magic-token", "381204").Return(nil)
+
+		rr := httptest.NewRecorder()
+		handler.ApproveLogin(rr, newRequest("magic-token", "381204"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Você pode fechar esta aba.")
+		assert.Empty(t, rr.Result().Cookies())
+		as.AssertExpectations(t)
+	})
+
+	t.Run("should show the form again for a wrong code", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("ApproveLogin", mock.Anything, "magic-token", "000000").Return(models.ErrInvalidLoginCode)
+
+		rr := httptest.NewRecorder()
+		handler.ApproveLogin(rr, newRequest("magic-token", "000000"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Código incorreto.")
+		assert.Contains(t, rr.Body.String(), `name="token" value="magic-token"`)
+	})
+
+	t.Run("should redirect to fail after too many attempts", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("ApproveLogin", mock.Anything, "magic-token", "000000").Return(models.ErrLoginCodeAttemptsExceeded)
+
+		rr := httptest.NewRecorder()
+		handler.ApproveLogin(rr, newRequest("magic-token", "000000"))
+
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "http://localhost:5173/auth/fail?error=too_many_attempts", rr.Header().Get("Location"))
+	})
+}
+
+func TestAuthHandler_PollPendingLogin(t *testing.T) {
+	rc := pkgs.NewRequestContext()
+
+	newRequest := func(pollSecret string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/authenticate/pending/sess-1", nil)
+		req.SetPathValue("pendingLoginId", "sess-1")
+		if pollSecret != "" {
+			req.AddCookie(&http.Cookie{Name: "tabnotes_pending", Value: pollSecret})
+		}
+		return req
+	}
+
+	t.Run("should return 404 without the pending login cookie", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		rr := httptest.NewRecorder()
+		handler.PollPendingLogin(rr, newRequest(""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		as.AssertNotCalled(t, "PollLogin", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report a login waiting for approval", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("PollLogin", mock.Anything, "sess-1", "poll-secret", mock.Anything).Return(nil, nil)
+
+		rr := httptest.NewRecorder()
+		handler.PollPendingLogin(rr, newRequest("poll-secret"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"status":"pending"}`, rr.Body.String())
+		assert.Empty(t, rr.Result().Cookies())
+	})
+
+	t.Run("should set the session cookies once approved", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("PollLogin", mock.Anything, "sess-1", "poll-secret", mock.Anything).
+			Return(&models.AuthResponse{Token: "abc.def.ghi", ExpiresIn: 900, RefreshToken: "refresh", RefreshExpiresIn: 3600}, nil)
+
+		rr := httptest.NewRecorder()
+		handler.PollPendingLogin(rr, newRequest("poll-secret"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"status":"approved"}`, rr.Body.String())
+
+		cookies := map[string]*http.Cookie{}
+		for _, c := range rr.Result().Cookies() {
+			cookies[c.Name] = c
+		}
+		assert.Equal(t, "abc.def.ghi", cookies["tabnotes_id"].Value)
+		assert.Equal(t, "refresh", cookies["tabnotes_refresh"].Value)
+		assert.Equal(t, -1, cookies["tabnotes_pending"].MaxAge)
+	})
+}
+
+func TestAuthHandler_ExchangeLinkToken(t *testing.T) {
+	rc := pkgs.NewRequestContext()
+
+	t.Run("should return the token as JSON without setting a cookie", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("AuthenticateFromLink", mock.Anything, "valid", "", true, mock.Anything).
+			Return(&models.AuthResponse{Token: "abc.def.ghi"}, nil)
+
+		body, _ := json.Marshal(models.ExchangeLinkTokenPayload{Token: "valid"})
+		req := httptest.NewRequest(http.MethodPost, "/magic-link/token", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.ExchangeLinkToken(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+		assert.Empty(t, rr.Result().Cookies())
+
+		var response models.AuthResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, models.AuthResponse{Token: "abc.def.ghi", TokenType: "Bearer"}, response)
+	})
+
+	t.Run("should return a problem instead of redirecting if session expired", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("AuthenticateFromLink", mock.Anything, "expired", "", true, mock.Anything).
+			Return(nil, models.ErrSessionExpired)
+
+		body, _ := json.Marshal(models.ExchangeLinkTokenPayload{Token: "expired"})
+		req := httptest.NewRequest(http.MethodPost, "/magic-link/token", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.ExchangeLinkToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, rr.Header().Get("Location"))
+	})
+}
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	rc := pkgs.NewRequestContext()
+	refreshed := &models.AuthResponse{Token: "new.access.token", ExpiresIn: 900, RefreshToken: "new-refresh", RefreshExpiresIn: 3600}
+
+	t.Run("should answer native clients with JSON and no cookies", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("RefreshSession", mock.Anything, "old-refresh").Return(refreshed, nil)
+
+		body, _ := json.Marshal(models.RefreshTokenPayload{RefreshToken: "old-refresh"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.RefreshToken(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+		assert.Empty(t, rr.Result().Cookies())
+
+		var response models.AuthResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, "Bearer", response.TokenType)
+		assert.Equal(t, "new-refresh", response.RefreshToken)
+	})
+
+	t.Run("should rotate the cookies of browsers without exposing the refresh token", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("RefreshSession", mock.Anything, "old-refresh").Return(refreshed, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "tabnotes_refresh", Value: "old-refresh"})
+		rr := httptest.NewRecorder()
+
+		handler.RefreshToken(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Body.String())
+
+		cookies := map[string]*http.Cookie{}
+		for _, c := range rr.Result().Cookies() {
+			cookies[c.Name] = c
+		}
+		assert.Equal(t, "new.access.token", cookies["tabnotes_id"].Value)
+		assert.Equal(t, 900, cookies["tabnotes_id"].MaxAge)
+		assert.Equal(t, "new-refresh", cookies["tabnotes_refresh"].Value)
+		assert.Equal(t, "/auth/refresh", cookies["tabnotes_refresh"].Path)
+	})
+
+	t.Run("should clear the cookies when the refresh token was reused", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		as.On("RefreshSession", mock.Anything, "old-refresh").Return(nil, models.ErrRefreshTokenReused)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "tabnotes_refresh", Value: "old-refresh"})
+		rr := httptest.NewRecorder()
+
+		handler.RefreshToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		for _, c := range rr.Result().Cookies() {
+			assert.Equal(t, -1, c.MaxAge, c.Name)
+		}
+		assert.Len(t, rr.Result().Cookies(), 2)
+	})
+
+	t.Run("should return 401 without a refresh token", func(t *testing.T) {
+		as := new(mocks.AuthServiceMock)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		rr := httptest.NewRecorder()
+
+		handler.RefreshToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		as.AssertNotCalled(t, "RefreshSession", mock.Anything, mock.Anything)
+	})
 }
 
 func TestAuthHandler_Logout(t *testing.T) {
 	t.Run("should return 401 if session ID is missing", func(t *testing.T) {
 		rc := pkgs.NewRequestContext()
 		as := new(mocks.AuthServiceMock)
-		handler := NewAuthHandler(as, rc)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
 		rr := httptest.NewRecorder()
@@ -182,7 +486,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 	t.Run("should return 200 OK if session not found", func(t *testing.T) {
 		rc := pkgs.NewRequestContext()
 		as := new(mocks.AuthServiceMock)
-		handler := NewAuthHandler(as, rc)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
 		ctx := rc.SetSessionID(req.Context(), "sess-123")
@@ -195,12 +499,21 @@ func TestAuthHandler_Logout(t *testing.T) {
 		handler.Logout(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Condition(t, func() bool {
+			for _, c := range rr.Result().Cookies() {
+				if c.Name == tokenCookieName && c.MaxAge == -1 {
+					return true
+				}
+			}
+			return false
+		}, "Expected token cookie to be deleted (MaxAge = -1)")
 	})
 
 	t.Run("should return 500 if logout returns error", func(t *testing.T) {
 		rc := pkgs.NewRequestContext()
 		as := new(mocks.AuthServiceMock)
-		handler := NewAuthHandler(as, rc)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
 		ctx := rc.SetSessionID(req.Context(), "sess-123")
@@ -218,7 +531,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 	t.Run("should return 200 and clear cookie on success", func(t *testing.T) {
 		rc := pkgs.NewRequestContext()
 		as := new(mocks.AuthServiceMock)
-		handler := NewAuthHandler(as, rc)
+		handler := NewAuthHandler(as, rc, pkgs.NewNoopGeoIP())
 
 		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
 		ctx := rc.SetSessionID(req.Context(), "sess-123")
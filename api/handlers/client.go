@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+)
+
+const maxUserAgentLength = 512
+
+func clientInfo(r *http.Request, geoIP pkgs.GeoIP) *models.ClientInfo {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	client := &models.ClientInfo{
+		IP:        clientIP(r, configs.Env.TrustedProxies),
+		UserAgent: userAgent,
+	}
+
+	if ip, err := netip.ParseAddr(client.IP); err == nil {
+		client.Location = geoIP.Lookup(ip)
+	}
+
+	return client
+}
+
+// clientIP believes X-Forwarded-For only as far as trusted proxies wrote
+// it: walking back from the connection's peer, the first address outside
+// them is the client. Anything further left could have been sent by the
+// client itself.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	peer, err := netip.ParseAddr(host)
+	if err != nil {
+		return ""
+	}
+	peer = peer.Unmap()
+
+	if !isTrustedProxy(peer, trustedProxies) {
+		return peer.String()
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+
+	for _, entry := range slices.Backward(forwarded) {
+		addr, err := netip.ParseAddr(strings.TrimSpace(entry))
+		if err != nil {
+			break
+		}
+		addr = addr.Unmap()
+
+		peer = addr
+		if !isTrustedProxy(addr, trustedProxies) {
+			break
+		}
+	}
+
+	return peer.String()
+}
+
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	return slices.ContainsFunc(trustedProxies, func(prefix netip.Prefix) bool {
+		return prefix.Contains(addr)
+	})
+}
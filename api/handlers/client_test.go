@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	newRequest := func(remoteAddr string, forwardedFor string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		return req
+	}
+
+	t.Run("should ignore X-Forwarded-For from an untrusted peer", func(t *testing.T) {
+		req := newRequest("203.0.113.7:5123", "198.51.100.1")
+
+		assert.Equal(t, "203.0.113.7", clientIP(req, trusted))
+	})
+
+	t.Run("should take the address a trusted proxy forwarded", func(t *testing.T) {
+		req := newRequest("10.0.0.2:5123", "198.51.100.1")
+
+		assert.Equal(t, "198.51.100.1", clientIP(req, trusted))
+	})
+
+	t.Run("should skip trusted hops but not addresses the client sent", func(t *testing.T) {
+		req := newRequest("10.0.0.2:5123", "1.2.3.4, 198.51.100.1, 10.0.0.3")
+
+		assert.Equal(t, "198.51.100.1", clientIP(req, trusted))
+	})
+
+	t.Run("should stop at a malformed entry", func(t *testing.T) {
+		req := newRequest("10.0.0.2:5123", "198.51.100.1, not-an-ip")
+
+		assert.Equal(t, "10.0.0.2", clientIP(req, trusted))
+	})
+}
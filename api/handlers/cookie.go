@@ -3,22 +3,34 @@ package handlers
 import (
 	"net/http"
 	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+const (
+	tokenCookieName        = "tabnotes_id"
+	refreshTokenCookieName = "tabnotes_refresh"
+	pendingLoginCookieName = "tabnotes_pending"
+
+	// refreshTokenCookiePath keeps the refresh token off every request but
+	// the one that spends it.
+	refreshTokenCookiePath = "/auth/refresh"
 )
 
-func SetTokenCookie(w http.ResponseWriter, token string) {
+func SetTokenCookie(w http.ResponseWriter, token string, maxAge time.Duration) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     "tabnotes_id",
+		Name:     tokenCookieName,
 		Value:    token,
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   false,
 		SameSite: http.SameSiteLaxMode,
-		MaxAge:   int((time.Hour * 24 * 7).Seconds()),
+		MaxAge:   int(maxAge.Seconds()),
 	})
 }
 
 func GetTokenCookie(r *http.Request) (string, error) {
-	cookie, err := r.Cookie("tabnotes_id")
+	cookie, err := r.Cookie(tokenCookieName)
 	if err != nil {
 		return "", err
 	}
@@ -27,7 +39,7 @@ func GetTokenCookie(r *http.Request) (string, error) {
 
 func DeleteTokenCookie(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     "tabnotes_id",
+		Name:     tokenCookieName,
 		Value:    "",
 		Path:     "/",
 		HttpOnly: true,
@@ -36,3 +48,77 @@ func DeleteTokenCookie(w http.ResponseWriter) {
 		MaxAge:   -1,
 	})
 }
+
+func SetRefreshTokenCookie(w http.ResponseWriter, token string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookieName,
+		Value:    token,
+		Path:     refreshTokenCookiePath,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(maxAge.Seconds()),
+	})
+}
+
+func GetRefreshTokenCookie(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(refreshTokenCookieName)
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, nil
+}
+
+func DeleteRefreshTokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookieName,
+		Value:    "",
+		Path:     refreshTokenCookiePath,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+}
+
+// SetPendingLoginCookie keeps the poll secret of a login requested from this
+// browser. It is sent to the magic link too, which tells a link opened here
+// from one opened on another device.
+func SetPendingLoginCookie(w http.ResponseWriter, pollSecret string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     pendingLoginCookieName,
+		Value:    pollSecret,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(maxAge.Seconds()),
+	})
+}
+
+func GetPendingLoginCookie(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(pendingLoginCookieName)
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, nil
+}
+
+func DeletePendingLoginCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     pendingLoginCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+}
+
+// setAuthCookies stores both tokens of a sign in or refresh, each for as long
+// as it lasts.
+func setAuthCookies(w http.ResponseWriter, authResponse *models.AuthResponse) {
+	SetTokenCookie(w, authResponse.Token, time.Duration(authResponse.ExpiresIn)*time.Second)
+	SetRefreshTokenCookie(w, authResponse.RefreshToken, time.Duration(authResponse.RefreshExpiresIn)*time.Second)
+}
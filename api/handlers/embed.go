@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+const embedCSS = `*{box-sizing:border-box;margin:0}` +
+	`body{font-family:system-ui,-apple-system,"Segoe UI",Roboto,sans-serif;color:#303030;background:#fff}` +
+	`.card{border:1px solid #e5e7eb;border-radius:12px;padding:16px;display:flex;flex-direction:column;gap:8px}` +
+	`a{color:inherit;text-decoration:none}` +
+	`.title{font-size:18px;font-weight:600;line-height:1.3}` +
+	`.title:hover{text-decoration:underline}` +
+	`.author{font-size:14px}` +
+	`.author span,footer{color:#6b7280}` +
+	`footer{font-size:13px}`
+
+var embedTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}} · @{{.AuthorUsername}}</title>
+<style>` + embedCSS + `</style>
+</head>
+<body>
+<article class="card">
+<a class="title" href="{{.PostURL}}" target="_blank" rel="noopener noreferrer">{{.Title}}</a>
+<p class="author"><a href="{{.ProfileURL}}" target="_blank" rel="noopener noreferrer">{{.AuthorName}} <span>@{{.AuthorUsername}}</span></a></p>
+<footer><time datetime="{{.PublishedAt}}">{{.PublishedOn}}</time> · {{.Likes}} curtida{{if ne .Likes 1}}s{{end}}</footer>
+</article>
+</body>
+</html>
+`))
+
+// embedCSP only allows the inline stylesheet above, pinned by its hash, and
+// lets any site frame the card.
+var embedCSP = fmt.Sprintf(
+	"default-src 'none'; style-src 'sha256-%s'; base-uri 'none'; form-action 'none'; frame-ancestors *",
+	cssHash(embedCSS),
+)
+
+type embedCard struct {
+	Title          string
+	AuthorName     string
+	AuthorUsername string
+	PostURL        string
+	ProfileURL     string
+	PublishedAt    string
+	PublishedOn    string
+	Likes          int
+}
+
+func renderEmbed(post *models.PostEmbed) ([]byte, error) {
+	card := embedCard{
+		Title:          post.Title,
+		AuthorName:     post.AuthorName,
+		AuthorUsername: post.AuthorUsername,
+		PostURL:        postURL(post.AuthorUsername, post.ID),
+		ProfileURL:     profileURL(post.AuthorUsername),
+		PublishedAt:    post.CreatedAt.UTC().Format(time.RFC3339),
+		PublishedOn:    post.CreatedAt.UTC().Format("02/01/2006"),
+		Likes:          post.Likes,
+	}
+
+	var body bytes.Buffer
+	if err := embedTemplate.Execute(&body, card); err != nil {
+		return nil, fmt.Errorf("render embed: %w", err)
+	}
+
+	return body.Bytes(), nil
+}
+
+func writeEmbed(w http.ResponseWriter, r *http.Request, post *models.PostEmbed) error {
+	body, err := renderEmbed(post)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Security-Policy", embedCSP)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", models.EmbedCacheMaxAge))
+	serveCached(w, r, "text/html; charset=utf-8", post.UpdatedAt, body)
+
+	return nil
+}
+
+// newOEmbed describes the embed iframe, sized to fit maxwidth and maxheight
+// when the consumer sends them.
+func newOEmbed(post *models.PostEmbed, maxWidth int, maxHeight int) *models.OEmbedResponse {
+	width := fitEmbedSize(models.DefaultEmbedWidth, maxWidth)
+	height := fitEmbedSize(models.DefaultEmbedHeight, maxHeight)
+
+	iframe := fmt.Sprintf(
+		`<iframe src="%s" width="%d" height="%d" style="border:0;max-width:100%%" loading="lazy" sandbox="allow-popups allow-popups-to-escape-sandbox" title="%s"></iframe>`,
+		template.HTMLEscapeString(embedURL(post.ID)),
+		width,
+		height,
+		template.HTMLEscapeString(post.Title),
+	)
+
+	return &models.OEmbedResponse{
+		Type:         "rich",
+		Version:      models.OEmbedVersion,
+		Title:        post.Title,
+		AuthorName:   post.AuthorName,
+		AuthorURL:    profileURL(post.AuthorUsername),
+		ProviderName: models.OEmbedProviderName,
+		ProviderURL:  configs.Env.RedirectURL,
+		CacheAge:     models.EmbedCacheMaxAge,
+		HTML:         iframe,
+		Width:        width,
+		Height:       height,
+	}
+}
+
+// embedPostID extracts the post ID from the URLs we hand out for a post:
+// the profile link with the post as fragment, the API resource, its embed
+// card and its ActivityPub note.
+func embedPostID(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+
+	if app, err := url.Parse(configs.Env.RedirectURL); err == nil && strings.EqualFold(u.Host, app.Host) {
+		username := strings.Trim(u.Path, "/")
+		if username == "" || strings.Contains(username, "/") || u.Fragment == "" {
+			return "", false
+		}
+		return u.Fragment, true
+	}
+
+	if api, err := url.Parse(configs.Env.APIURL); err != nil || !strings.EqualFold(u.Host, api.Host) {
+		return "", false
+	}
+
+	path := strings.TrimSuffix(u.Path, "/embed")
+	for _, prefix := range []string{"/posts/", "/ap/posts/"} {
+		if postID, ok := strings.CutPrefix(path, prefix); ok && postID != "" && !strings.Contains(postID, "/") {
+			return postID, true
+		}
+	}
+
+	return "", false
+}
+
+func embedURL(postID string) string {
+	return fmt.Sprintf("%s/posts/%s/embed", configs.Env.APIURL, postID)
+}
+
+// parseEmbedSize reads an optional positive maxwidth or maxheight; zero
+// means the consumer set no limit.
+func parseEmbedSize(value string) (int, bool) {
+	if value == "" {
+		return 0, true
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, false
+	}
+
+	return size, true
+}
+
+func fitEmbedSize(size int, maxSize int) int {
+	if maxSize == 0 {
+		return size
+	}
+
+	return min(size, maxSize)
+}
+
+func cssHash(css string) string {
+	sum := sha256.Sum256([]byte(css))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
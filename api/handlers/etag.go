@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func SetETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// GetIfMatchVersion reads the version from the If-Match header. It returns
+// 0 for "*", and ok is false when the header is missing. A header that is not
+// one of our ETags yields -1, which never matches a stored version.
+func GetIfMatchVersion(r *http.Request) (version int, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, false
+	}
+
+	if header == "*" {
+		return 0, true
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return -1, true
+	}
+
+	version, err = strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return -1, true
+	}
+
+	return version, true
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+type ExportHandler interface {
+	RequestExport(w http.ResponseWriter, r *http.Request)
+	DownloadExport(w http.ResponseWriter, r *http.Request)
+}
+
+type exportHandler struct {
+	rc pkgs.RequestContext
+	es services.ExportService
+}
+
+func NewExportHandler(requestContext pkgs.RequestContext, exportService services.ExportService) ExportHandler {
+	return &exportHandler{
+		rc: requestContext,
+		es: exportService,
+	}
+}
+
+func (e *exportHandler) RequestExport(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "export"),
+		slog.String("method", "RequestExport"),
+	)
+
+	userID, ok := e.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("userID not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	response, err := e.es.RequestExport(r.Context(), userID)
+	if err != nil {
+		logger.Error("error requesting export", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusAccepted, response)
+}
+
+func (e *exportHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "export"),
+		slog.String("method", "DownloadExport"),
+	)
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		logger.Error("token not found in query")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O parâmetro token é obrigatório.")
+		return
+	}
+
+	path, err := e.es.GetExportFile(r.Context(), token)
+	if err != nil {
+		logger.Error("error getting export file", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Error("export file not found", "path", path)
+			WriteError(w, r, models.ErrExportExpired)
+			return
+		}
+
+		logger.Error("error opening export file", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		logger.Error("error reading export file", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "tab-notes-export.zip"))
+	http.ServeContent(w, r, "", info.ModTime(), file)
+}
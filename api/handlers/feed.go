@@ -37,7 +37,7 @@ func (f *feedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("userID not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
@@ -57,7 +57,7 @@ func (f *feedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 	feed, err := f.fs.GetFeed(r.Context(), userID, limit, offset)
 	if err != nil {
 		logger.Error("error getting feed", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -39,14 +39,14 @@ func (f *followerHandler) FollowUser(w http.ResponseWriter, r *http.Request) {
 	username := r.PathValue("username")
 
 	if username == "" {
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
 		return
 	}
 
 	followerID, ok := f.rc.GetUserID(r.Context())
 	if !ok {
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
@@ -54,15 +54,15 @@ func (f *followerHandler) FollowUser(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case models.ErrUserNotFound:
 			logger.Warn("user not found")
-			NoContent(w, http.StatusNotFound)
+			WriteError(w, r, err)
 			return
 		case models.ErrCannotFollowSelf:
 			logger.Warn("cannot follow self")
-			NoContent(w, http.StatusForbidden)
+			WriteError(w, r, err)
 			return
 		default:
 			logger.Error("error following user", slog.String("error", err.Error()))
-			NoContent(w, http.StatusInternalServerError)
+			WriteError(w, r, err)
 			return
 		}
 	}
@@ -78,14 +78,14 @@ func (f *followerHandler) UnfollowUser(w http.ResponseWriter, r *http.Request) {
 	username := r.PathValue("username")
 
 	if username == "" {
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
 		return
 	}
 
 	followerID, ok := f.rc.GetUserID(r.Context())
 	if !ok {
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
@@ -93,15 +93,15 @@ func (f *followerHandler) UnfollowUser(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case models.ErrUserNotFound:
 			logger.Warn("user not found")
-			NoContent(w, http.StatusNotFound)
+			WriteError(w, r, err)
 			return
 		case models.ErrCannotUnfollowSelf:
 			logger.Warn("cannot unfollow self")
-			NoContent(w, http.StatusForbidden)
+			WriteError(w, r, err)
 			return
 		default:
 			logger.Error("error unfollowing user", slog.String("error", err.Error()))
-			NoContent(w, http.StatusInternalServerError)
+			WriteError(w, r, err)
 			return
 		}
 	}
@@ -118,20 +118,14 @@ func (f *followerHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
 	username := r.PathValue("username")
 
 	if username == "" {
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
 		return
 	}
 
 	followers, err := f.fs.GetFollowers(r.Context(), username)
 	if err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Warn("user not found")
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error getting followers", slog.String("error", err.Error()))
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -151,20 +145,14 @@ func (f *followerHandler) GetFollowing(w http.ResponseWriter, r *http.Request) {
 	username := r.PathValue("username")
 
 	if username == "" {
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
 		return
 	}
 
 	following, err := f.fs.GetFollowing(r.Context(), username)
 	if err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Warn("user not found")
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error getting following", slog.String("error", err.Error()))
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -184,14 +172,14 @@ func (f *followerHandler) GetMyFollowers(w http.ResponseWriter, r *http.Request)
 	userID, ok := f.rc.GetUserID(r.Context())
 	if !ok {
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	followers, err := f.fs.GetMyFollowers(r.Context(), userID)
 	if err != nil {
 		logger.Error("error getting followers", slog.String("error", err.Error()))
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -211,14 +199,14 @@ func (f *followerHandler) GetMyFollowing(w http.ResponseWriter, r *http.Request)
 	userID, ok := f.rc.GetUserID(r.Context())
 	if !ok {
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	following, err := f.fs.GetMyFollowing(r.Context(), userID)
 	if err != nil {
 		logger.Error("error getting following", slog.String("error", err.Error()))
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
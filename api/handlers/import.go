@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+type ImportHandler interface {
+	RequestImport(w http.ResponseWriter, r *http.Request)
+	GetImport(w http.ResponseWriter, r *http.Request)
+}
+
+type importHandler struct {
+	rc pkgs.RequestContext
+	is services.ImportService
+}
+
+func NewImportHandler(requestContext pkgs.RequestContext, importService services.ImportService) ImportHandler {
+	return &importHandler{
+		rc: requestContext,
+		is: importService,
+	}
+}
+
+func (i *importHandler) RequestImport(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "import"),
+		slog.String("method", "RequestImport"),
+	)
+
+	userID, ok := i.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("userID not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			logger.Error("import archive too large", "limit", maxBytesErr.Limit)
+			WriteProblem(w, r, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "")
+			return
+		}
+
+		logger.Error("error reading import archive", "error", err)
+		WriteProblem(w, r, http.StatusBadRequest, CodeMalformedBody, "O campo file é obrigatório.")
+		return
+	}
+	defer file.Close()
+
+	archive, err := io.ReadAll(file)
+	if err != nil {
+		logger.Error("error reading import archive", "error", err)
+		WriteProblem(w, r, http.StatusBadRequest, CodeMalformedBody, "")
+		return
+	}
+
+	response, err := i.is.RequestImport(r.Context(), userID, archive)
+	if err != nil {
+		logger.Error("error requesting import", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusAccepted, response)
+}
+
+func (i *importHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "import"),
+		slog.String("method", "GetImport"),
+	)
+
+	importID := r.PathValue("importId")
+	if importID == "" {
+		logger.Error("importId not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID da importação é obrigatório.")
+		return
+	}
+
+	userID, ok := i.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("userID not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	response, err := i.is.GetImport(r.Context(), userID, importID)
+	if err != nil {
+		logger.Error("error getting import", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+)
+
+type JWKSHandler interface {
+	GetJWKS(w http.ResponseWriter, r *http.Request)
+}
+
+type jwksHandler struct {
+	kr pkgs.Keyring
+}
+
+func NewJWKSHandler(keyring pkgs.Keyring) JWKSHandler {
+	return &jwksHandler{
+		kr: keyring,
+	}
+}
+
+// GetJWKS publishes the public keys that verify our tokens. The short cache
+// lets verifiers pick up a rotation well within the grace period.
+func (j *jwksHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", models.JWKSCacheMaxAge))
+	JSON(w, http.StatusOK, j.kr.JWKS())
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+)
+
+// authPageCSS styles the small pages served to links opened from emails.
+const authPageCSS = `*{box-sizing:border-box;margin:0}` +
+	`body{font-family:system-ui,-apple-system,"Segoe UI",Roboto,sans-serif;color:#303030;background:#f9fafb;padding:24px}` +
+	`main{max-width:420px;margin:48px auto;background:#fff;border:1px solid #e5e7eb;border-radius:12px;padding:24px;display:flex;flex-direction:column;gap:16px}` +
+	`h1{font-size:20px;font-weight:600}` +
+	`p{font-size:15px;line-height:1.5;color:#4b5563}` +
+	`form{display:flex;flex-direction:column;gap:12px}` +
+	`input{font-size:24px;letter-spacing:8px;text-align:center;padding:8px;border:1px solid #d1d5db;border-radius:8px}` +
+	`button{font-size:15px;font-weight:600;padding:10px;border:0;border-radius:8px;background:#303030;color:#fff;cursor:pointer}` +
+	`.error{color:#b91c1c}` +
+	`a{font-size:14px;color:#6b7280}`
+
+var loginApprovalTemplate = template.Must(template.New("login-approval").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Entrar no TabNotes</title>
+<style>` + authPageCSS + `</style>
+</head>
+<body>
+<main>
+{{if .Approved}}
+<h1>Login aprovado</h1>
+<p>O outro dispositivo já está entrando na sua conta. Você pode fechar esta aba.</p>
+{{else}}
+<h1>Aprovar login em outro dispositivo</h1>
+<p>Este link foi pedido em outro dispositivo. Para entrar nele, digite o código que aparece na tela dele.</p>
+<p>Só aprove se foi você quem pediu o link.</p>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<form method="post" action="{{.ApproveURL}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<input name="code" inputmode="numeric" pattern="[0-9]{6}" maxlength="6" autocomplete="one-time-code" required aria-label="Código de verificação">
+<button type="submit">Aprovar</button>
+</form>
+<a href="{{.HereURL}}">Entrar neste dispositivo</a>
+{{end}}
+</main>
+</body>
+</html>
+`))
+
+// authPageCSP allows the inline stylesheet above, pinned by its hash, and
+// forms posting back to the API, and keeps the pages from being framed to
+// trick a click.
+var authPageCSP = fmt.Sprintf(
+	"default-src 'none'; style-src 'sha256-%s'; base-uri 'none'; form-action 'self'; frame-ancestors 'none'",
+	cssHash(authPageCSS),
+)
+
+type loginApprovalPage struct {
+	Approved   bool
+	Token      string
+	Error      string
+	ApproveURL string
+	HereURL    string
+}
+
+func writeLoginApproval(w http.ResponseWriter, status int, page loginApprovalPage) error {
+	page.ApproveURL = configs.Env.APIURL + "/magic-link/approve"
+	page.HereURL = fmt.Sprintf("%s/magic-link/authenticate?token=%s&here=1", configs.Env.APIURL, url.QueryEscape(page.Token))
+
+	if err := writeAuthPage(w, status, loginApprovalTemplate, page); err != nil {
+		return fmt.Errorf("render login approval: %w", err)
+	}
+
+	return nil
+}
+
+func writeAuthPage(w http.ResponseWriter, status int, tmpl *template.Template, data any) error {
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", authPageCSP)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, err := w.Write(body.Bytes())
+
+	return err
+}
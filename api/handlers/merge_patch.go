@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) body into dst, a
+// pointer to a struct of pointer fields, and validates it like
+// DecodeAndValidate. Absent members stay nil. None of our patchable fields
+// can be removed, so a null member is reported as a field error, as is any
+// member dst does not know. Other media types get a 415.
+func DecodeMergePatch(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+			WriteProblem(w, r, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, "Use o Content-Type application/merge-patch+json.")
+			return false
+		}
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&members); err != nil || members == nil {
+		writeDecodeError(w, r, err)
+		return false
+	}
+
+	known := jsonFields(dst)
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fieldErrors []*models.FieldError
+	for _, name := range names {
+		field, ok := known[name]
+		if !ok {
+			fieldErrors = append(fieldErrors, &models.FieldError{Field: name, Code: "unknown", Message: fmt.Sprintf("%s não é um campo conhecido", name)})
+			continue
+		}
+
+		if bytes.Equal(bytes.TrimSpace(members[name]), []byte("null")) {
+			fieldErrors = append(fieldErrors, &models.FieldError{Field: name, Code: "not_nullable", Message: fmt.Sprintf("%s não pode ser removido", name)})
+			continue
+		}
+
+		target := reflect.New(field.Type.Elem())
+		if err := json.Unmarshal(members[name], target.Interface()); err != nil {
+			fieldErrors = append(fieldErrors, &models.FieldError{Field: name, Code: "type", Message: fmt.Sprintf("%s deve ser do tipo %s", name, field.Type.Elem().String())})
+			continue
+		}
+		reflect.ValueOf(dst).Elem().FieldByIndex(field.Index).Set(target)
+	}
+
+	if len(fieldErrors) > 0 {
+		ValidationError(w, r, fieldErrors)
+		return false
+	}
+
+	return validatePayload(w, r, dst)
+}
+
+func jsonFields(dst any) map[string]reflect.StructField {
+	t := reflect.TypeOf(dst).Elem()
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := validateFieldName(field)
+		if name == "" {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
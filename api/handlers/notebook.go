@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+type NotebookHandler interface {
+	CreateNotebook(w http.ResponseWriter, r *http.Request)
+	GetMyNotebooks(w http.ResponseWriter, r *http.Request)
+	GetMyNotebook(w http.ResponseWriter, r *http.Request)
+	UpdateNotebook(w http.ResponseWriter, r *http.Request)
+	DeleteNotebook(w http.ResponseWriter, r *http.Request)
+	AddPost(w http.ResponseWriter, r *http.Request)
+	RemovePost(w http.ResponseWriter, r *http.Request)
+	ReorderPosts(w http.ResponseWriter, r *http.Request)
+	GetNotebookBySlug(w http.ResponseWriter, r *http.Request)
+}
+
+type notebookHandler struct {
+	rc pkgs.RequestContext
+	ns services.NotebookService
+}
+
+func NewNotebookHandler(
+	requestContext pkgs.RequestContext,
+	notebookService services.NotebookService) NotebookHandler {
+	return &notebookHandler{
+		rc: requestContext,
+		ns: notebookService,
+	}
+}
+
+func (n *notebookHandler) CreateNotebook(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "CreateNotebook"),
+	)
+
+	var payload models.CreateNotebookPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	response, err := n.ns.CreateNotebook(r.Context(), userID, payload.Title, payload.Description)
+	if err != nil {
+		logger.Error("create notebook", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, response)
+}
+
+func (n *notebookHandler) GetMyNotebooks(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "GetMyNotebooks"),
+	)
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	notebooks, err := n.ns.GetMyNotebooks(r.Context(), userID)
+	if err != nil {
+		logger.Error("get my notebooks", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, notebooks)
+}
+
+func (n *notebookHandler) GetMyNotebook(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "GetMyNotebook"),
+	)
+
+	notebookID := r.PathValue("notebookId")
+	if notebookID == "" {
+		logger.Error("get my notebook", "error", "notebook id not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do caderno é obrigatório.")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	notebook, err := n.ns.GetMyNotebook(r.Context(), userID, notebookID)
+	if err != nil {
+		logger.Error("get my notebook", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, notebook)
+}
+
+func (n *notebookHandler) UpdateNotebook(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "UpdateNotebook"),
+	)
+
+	notebookID := r.PathValue("notebookId")
+	if notebookID == "" {
+		logger.Error("update notebook", "error", "notebook id not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do caderno é obrigatório.")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	var payload models.UpdateNotebookPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	if err := n.ns.UpdateNotebook(r.Context(), userID, notebookID, payload.Title, payload.Description); err != nil {
+		logger.Error("update notebook", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
+
+func (n *notebookHandler) DeleteNotebook(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "DeleteNotebook"),
+	)
+
+	notebookID := r.PathValue("notebookId")
+	if notebookID == "" {
+		logger.Error("delete notebook", "error", "notebook id not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do caderno é obrigatório.")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	if err := n.ns.DeleteNotebook(r.Context(), userID, notebookID); err != nil {
+		logger.Error("delete notebook", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
+
+func (n *notebookHandler) AddPost(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "AddPost"),
+	)
+
+	notebookID := r.PathValue("notebookId")
+	if notebookID == "" {
+		logger.Error("add post", "error", "notebook id not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do caderno é obrigatório.")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	var payload models.AddNotebookPostPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	if err := n.ns.AddPost(r.Context(), userID, notebookID, payload.PostID); err != nil {
+		switch err {
+		case models.ErrNotebookNotFound, models.ErrPostNotFound:
+			logger.Warn("add post", "error", err)
+			WriteError(w, r, err)
+			return
+		case models.ErrPostNotBelongToUser:
+			logger.Warn("add post", "error", err)
+			WriteError(w, r, err)
+			return
+		default:
+			logger.Error("add post", "error", err)
+			WriteError(w, r, err)
+			return
+		}
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
+
+func (n *notebookHandler) RemovePost(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "RemovePost"),
+	)
+
+	notebookID := r.PathValue("notebookId")
+	postID := r.PathValue("postId")
+	if notebookID == "" || postID == "" {
+		logger.Error("remove post", "error", "notebook id or post id not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "Os IDs do caderno e do post são obrigatórios.")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	if err := n.ns.RemovePost(r.Context(), userID, notebookID, postID); err != nil {
+		logger.Error("remove post", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
+
+func (n *notebookHandler) ReorderPosts(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "ReorderPosts"),
+	)
+
+	notebookID := r.PathValue("notebookId")
+	if notebookID == "" {
+		logger.Error("reorder posts", "error", "notebook id not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do caderno é obrigatório.")
+		return
+	}
+
+	userID, ok := n.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	var payload models.ReorderNotebookPostsPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	if err := n.ns.ReorderPosts(r.Context(), userID, notebookID, payload.PostIDs); err != nil {
+		switch err {
+		case models.ErrNotebookNotFound:
+			logger.Warn("reorder posts", "error", err)
+			WriteError(w, r, err)
+			return
+		case models.ErrInvalidNotebookOrder:
+			logger.Warn("reorder posts", "error", err)
+			WriteError(w, r, err)
+			return
+		default:
+			logger.Error("reorder posts", "error", err)
+			WriteError(w, r, err)
+			return
+		}
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
+
+func (n *notebookHandler) GetNotebookBySlug(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "notebook"),
+		slog.String("method", "GetNotebookBySlug"),
+	)
+
+	username := strings.ToLower(r.PathValue("username"))
+	slug := strings.ToLower(r.PathValue("slug"))
+	if username == "" || slug == "" {
+		logger.Error("get notebook by slug", "error", "username or slug not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário e o slug são obrigatórios.")
+		return
+	}
+
+	viewerID, _ := n.rc.GetUserID(r.Context())
+
+	notebook, err := n.ns.GetNotebookBySlug(r.Context(), viewerID, username, slug)
+	if err != nil {
+		logger.Error("get notebook by slug", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, notebook)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+type PersonalAccessTokenHandler interface {
+	CreateToken(w http.ResponseWriter, r *http.Request)
+	GetTokens(w http.ResponseWriter, r *http.Request)
+	RevokeToken(w http.ResponseWriter, r *http.Request)
+}
+
+type personalAccessTokenHandler struct {
+	rc   pkgs.RequestContext
+	pats services.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandler(
+	requestContext pkgs.RequestContext,
+	personalAccessTokenService services.PersonalAccessTokenService) PersonalAccessTokenHandler {
+	return &personalAccessTokenHandler{
+		rc:   requestContext,
+		pats: personalAccessTokenService,
+	}
+}
+
+// CreateToken answers with the token itself, which cannot be read again.
+func (p *personalAccessTokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "personal_access_token"),
+		slog.String("method", "CreateToken"),
+	)
+
+	var payload models.CreatePersonalAccessTokenPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	userID, ok := p.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	token, err := p.pats.CreateToken(r.Context(), userID, &payload)
+	if err != nil {
+		logger.Error("create personal access token", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	JSON(w, http.StatusCreated, token)
+}
+
+func (p *personalAccessTokenHandler) GetTokens(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "personal_access_token"),
+		slog.String("method", "GetTokens"),
+	)
+
+	userID, ok := p.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	tokens, err := p.pats.GetTokens(r.Context(), userID)
+	if err != nil {
+		logger.Error("get personal access tokens", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, tokens)
+}
+
+func (p *personalAccessTokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "personal_access_token"),
+		slog.String("method", "RevokeToken"),
+	)
+
+	userID, ok := p.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	if err := p.pats.RevokeToken(r.Context(), userID, r.PathValue("tokenId")); err != nil {
+		logger.Error("revoke personal access token", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+type PollHandler interface {
+	Vote(w http.ResponseWriter, r *http.Request)
+}
+
+type pollHandler struct {
+	rc  pkgs.RequestContext
+	pos services.PollService
+}
+
+func NewPollHandler(
+	requestContext pkgs.RequestContext,
+	pollService services.PollService) PollHandler {
+	return &pollHandler{
+		rc:  requestContext,
+		pos: pollService,
+	}
+}
+
+// Vote answers with the updated tallies. Voting again moves the user's vote
+// to the new option.
+func (p *pollHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "poll"),
+		slog.String("method", "Vote"),
+	)
+
+	var payload models.VotePollPayload
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	userID, ok := p.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	poll, err := p.pos.Vote(r.Context(), userID, r.PathValue("postId"), payload.OptionID)
+	if err != nil {
+		logger.Error("vote in poll", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, poll)
+}
@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -15,10 +15,16 @@ type PostHandler interface {
 	GetPostByID(w http.ResponseWriter, r *http.Request)
 	DeletePost(w http.ResponseWriter, r *http.Request)
 	UpdatePost(w http.ResponseWriter, r *http.Request)
+	PatchPost(w http.ResponseWriter, r *http.Request)
 	LikePost(w http.ResponseWriter, r *http.Request)
 	UnlikePost(w http.ResponseWriter, r *http.Request)
 	GetPostsByUsername(w http.ResponseWriter, r *http.Request)
+	GetUserRSSFeed(w http.ResponseWriter, r *http.Request)
+	GetUserAtomFeed(w http.ResponseWriter, r *http.Request)
+	GetOEmbed(w http.ResponseWriter, r *http.Request)
+	GetPostEmbed(w http.ResponseWriter, r *http.Request)
 	GetPostsByAuthorID(w http.ResponseWriter, r *http.Request)
+	GetBacklinks(w http.ResponseWriter, r *http.Request)
 }
 
 type postHandler struct {
@@ -42,9 +48,8 @@ func (p *postHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	)
 
 	var payload models.CreatePostPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		logger.Error("decode payload", "error", err)
-		NoContent(w, http.StatusBadRequest)
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
 		return
 	}
 
@@ -52,14 +57,14 @@ func (p *postHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("get user id from context", "error", "user id not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
-	response, err := p.ps.CreatePost(r.Context(), userID, payload.Title, payload.Content)
+	response, err := p.ps.CreatePost(r.Context(), userID, payload.Title, payload.Content, payload.Visibility, payload.Poll)
 	if err != nil {
 		logger.Error("create post", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -75,31 +80,26 @@ func (p *postHandler) GetPostByID(w http.ResponseWriter, r *http.Request) {
 	postID := r.PathValue("postId")
 	if postID == "" {
 		logger.Error("get post by id", "error", "post id not found in query params")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
 		return
 	}
 
-	userID, ok := p.rc.GetUserID(r.Context())
-	if !ok {
-		logger.Error("get user id from context", "error", "user id not found in context")
-		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
-		return
-	}
+	userID, _ := p.rc.GetUserID(r.Context())
 
 	post, err := p.ps.GetPostByID(r.Context(), userID, postID)
 	if err != nil {
-		if err == models.ErrPostNotFound {
-			logger.Error("get post by id", "error", err)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("get post by id", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
+	if post == nil {
+		logger.Error("get post by id", "error", models.ErrPostNotFound)
+		WriteError(w, r, models.ErrPostNotFound)
+		return
+	}
+
+	SetETag(w, post.Version)
 	JSON(w, http.StatusOK, post)
 }
 
@@ -112,7 +112,7 @@ func (p *postHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
 	postID := r.PathValue("postId")
 	if postID == "" {
 		logger.Error("delete post", "error", "post id not found in query params")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
 		return
 	}
 
@@ -120,25 +120,13 @@ func (p *postHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("get user id from context", "error", "user id not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	if err := p.ps.DeletePost(r.Context(), userID, postID); err != nil {
-		if err == models.ErrPostNotFound {
-			logger.Error("delete post", "error", err)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
-		if err == models.ErrPostNotBelongToUser {
-			logger.Error("delete post", "error", err)
-			NoContent(w, http.StatusForbidden)
-			return
-		}
-
 		logger.Error("delete post", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -154,7 +142,7 @@ func (p *postHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	postID := r.PathValue("postId")
 	if postID == "" {
 		logger.Error("update post", "error", "post id not found in query params")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
 		return
 	}
 
@@ -162,38 +150,113 @@ func (p *postHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("get user id from context", "error", "user id not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
+		return
+	}
+
+	version, ok := GetIfMatchVersion(r)
+	if !ok {
+		logger.Error("update post", "error", "If-Match header not found")
+		WriteProblem(w, r, http.StatusPreconditionRequired, CodePreconditionRequired, "O cabeçalho If-Match é obrigatório.")
 		return
 	}
 
 	var payload models.UpdatePostPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		logger.Error("decode payload", "error", err)
-		NoContent(w, http.StatusBadRequest)
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
 		return
 	}
 
-	if err := p.ps.UpdatePost(r.Context(), userID, postID, payload.Title, payload.Content); err != nil {
-		if err == models.ErrPostNotFound {
+	newVersion, err := p.ps.UpdatePost(r.Context(), userID, postID, payload.Title, payload.Content, payload.Visibility, version)
+	if err != nil {
+		if err == models.ErrPostVersionMismatch {
 			logger.Error("update post", "error", err)
-			NoContent(w, http.StatusNotFound)
+			p.writeConflict(w, r, userID, postID)
 			return
 		}
 
-		if err == models.ErrPostNotBelongToUser {
-			logger.Error("update post", "error", err)
-			NoContent(w, http.StatusForbidden)
+		logger.Error("update post", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	SetETag(w, newVersion)
+	NoContent(w, http.StatusNoContent)
+}
+
+func (p *postHandler) PatchPost(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "post"),
+		slog.String("method", "PatchPost"),
+	)
+
+	postID := r.PathValue("postId")
+	if postID == "" {
+		logger.Error("patch post", "error", "post id not found in query params")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
+		return
+	}
+
+	userID, ok := p.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	version, ok := GetIfMatchVersion(r)
+	if !ok {
+		logger.Error("patch post", "error", "If-Match header not found")
+		WriteProblem(w, r, http.StatusPreconditionRequired, CodePreconditionRequired, "O cabeçalho If-Match é obrigatório.")
+		return
+	}
+
+	var payload models.PatchPostPayload
+	if !DecodeMergePatch(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	newVersion, err := p.ps.PatchPost(r.Context(), userID, postID, &payload, version)
+	if err != nil {
+		if err == models.ErrPostVersionMismatch {
+			logger.Error("patch post", "error", err)
+			p.writeConflict(w, r, userID, postID)
 			return
 		}
 
-		logger.Error("update post", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		logger.Error("patch post", "error", err)
+		WriteError(w, r, err)
 		return
 	}
 
+	SetETag(w, newVersion)
 	NoContent(w, http.StatusNoContent)
 }
 
+// writeConflict answers a failed If-Match with the current version of the
+// post, so the client can merge its changes and retry.
+func (p *postHandler) writeConflict(w http.ResponseWriter, r *http.Request, userID string, postID string) {
+	current, err := p.ps.GetPostByID(r.Context(), userID, postID)
+	if err != nil {
+		slog.Error("get current post", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	if current == nil {
+		WriteError(w, r, models.ErrPostNotFound)
+		return
+	}
+
+	SetETag(w, current.Version)
+	writeProblem(w, http.StatusPreconditionFailed, models.PostConflictResponse{
+		Problem: problemFor(w, r, models.ErrPostVersionMismatch),
+		Current: current,
+	})
+}
+
 func (p *postHandler) LikePost(w http.ResponseWriter, r *http.Request) {
 	logger := slog.With(
 		slog.String("handler", "post"),
@@ -203,7 +266,7 @@ func (p *postHandler) LikePost(w http.ResponseWriter, r *http.Request) {
 	postID := r.PathValue("postId")
 	if postID == "" {
 		logger.Error("like post", "error", "post id not found in query params")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
 		return
 	}
 
@@ -211,19 +274,13 @@ func (p *postHandler) LikePost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("get user id from context", "error", "user id not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	if err := p.ps.LikePost(r.Context(), userID, postID); err != nil {
-		if err == models.ErrPostNotFound {
-			logger.Error("like post", "error", err)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("like post", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -239,7 +296,7 @@ func (p *postHandler) UnlikePost(w http.ResponseWriter, r *http.Request) {
 	postID := r.PathValue("postId")
 	if postID == "" {
 		logger.Error("unlike post", "error", "post id not found in query params")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
 		return
 	}
 
@@ -247,19 +304,13 @@ func (p *postHandler) UnlikePost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("get user id from context", "error", "user id not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	if err := p.ps.UnlikePost(r.Context(), userID, postID); err != nil {
-		if err == models.ErrPostNotFound {
-			logger.Error("unlike post", "error", err)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("unlike post", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -275,28 +326,16 @@ func (p *postHandler) GetPostsByUsername(w http.ResponseWriter, r *http.Request)
 	username := r.PathValue("username")
 	if username == "" {
 		logger.Error("get posts by username", "error", "username not found in query params")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
 		return
 	}
 
-	userID, ok := p.rc.GetUserID(r.Context())
-	if !ok {
-		logger.Error("get user id from context", "error", "user id not found in context")
-		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
-		return
-	}
+	userID, _ := p.rc.GetUserID(r.Context())
 
 	posts, err := p.ps.GetPostsByUsername(r.Context(), userID, username)
 	if err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Error("get posts by username", "error", err)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("get posts by username", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -307,6 +346,62 @@ func (p *postHandler) GetPostsByUsername(w http.ResponseWriter, r *http.Request)
 	JSON(w, http.StatusOK, posts)
 }
 
+func (p *postHandler) GetUserRSSFeed(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "post"),
+		slog.String("method", "GetUserRSSFeed"),
+	)
+
+	username := r.PathValue("username")
+	if username == "" {
+		logger.Error("get user rss feed", "error", "username not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
+		return
+	}
+
+	posts, err := p.ps.GetPostsByUsername(r.Context(), "", username)
+	if err != nil {
+		logger.Error("get posts by username", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	feed := newRSSFeed(username, posts)
+	if err := writeFeed(w, r, models.RSSContentType, feedUpdatedAt(posts), feed); err != nil {
+		logger.Error("write rss feed", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+}
+
+func (p *postHandler) GetUserAtomFeed(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "post"),
+		slog.String("method", "GetUserAtomFeed"),
+	)
+
+	username := r.PathValue("username")
+	if username == "" {
+		logger.Error("get user atom feed", "error", "username not found in path")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
+		return
+	}
+
+	posts, err := p.ps.GetPostsByUsername(r.Context(), "", username)
+	if err != nil {
+		logger.Error("get posts by username", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	feed := newAtomFeed(username, posts)
+	if err := writeFeed(w, r, models.AtomContentType, feedUpdatedAt(posts), feed); err != nil {
+		logger.Error("write atom feed", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+}
+
 func (p *postHandler) GetPostsByAuthorID(w http.ResponseWriter, r *http.Request) {
 	logger := slog.With(
 		slog.String("handler", "post"),
@@ -317,14 +412,14 @@ func (p *postHandler) GetPostsByAuthorID(w http.ResponseWriter, r *http.Request)
 	if !ok {
 		logger.Error("get user id from context", "error", "user id not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	posts, err := p.ps.GetPostsByAuthorID(r.Context(), authorID)
 	if err != nil {
 		logger.Error("get posts by author id", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -334,3 +429,90 @@ func (p *postHandler) GetPostsByAuthorID(w http.ResponseWriter, r *http.Request)
 
 	JSON(w, http.StatusOK, posts)
 }
+
+func (p *postHandler) GetBacklinks(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "post"),
+		slog.String("method", "GetBacklinks"),
+	)
+
+	postID := r.PathValue("postId")
+	if postID == "" {
+		logger.Error("get backlinks", "error", "post id not found in query params")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O ID do post é obrigatório.")
+		return
+	}
+
+	userID, _ := p.rc.GetUserID(r.Context())
+
+	backlinks, err := p.ps.GetBacklinks(r.Context(), userID, postID)
+	if err != nil {
+		logger.Error("get backlinks", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, backlinks)
+}
+
+func (p *postHandler) GetOEmbed(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "post"),
+		slog.String("method", "GetOEmbed"),
+	)
+
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" && format != "json" {
+		WriteProblem(w, r, http.StatusNotImplemented, CodeUnsupportedFormat, "Apenas o formato json é suportado.")
+		return
+	}
+
+	maxWidth, okWidth := parseEmbedSize(query.Get("maxwidth"))
+	maxHeight, okHeight := parseEmbedSize(query.Get("maxheight"))
+	if !okWidth || !okHeight {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "maxwidth e maxheight devem ser inteiros positivos.")
+		return
+	}
+
+	rawURL := query.Get("url")
+	if rawURL == "" {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O parâmetro url é obrigatório.")
+		return
+	}
+
+	postID, ok := embedPostID(rawURL)
+	if !ok {
+		WriteError(w, r, models.ErrPostNotFound)
+		return
+	}
+
+	post, err := p.ps.GetPostEmbed(r.Context(), postID)
+	if err != nil {
+		logger.Error("get post embed", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", models.EmbedCacheMaxAge))
+	JSON(w, http.StatusOK, newOEmbed(post, maxWidth, maxHeight))
+}
+
+func (p *postHandler) GetPostEmbed(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "post"),
+		slog.String("method", "GetPostEmbed"),
+	)
+
+	post, err := p.ps.GetPostEmbed(r.Context(), r.PathValue("postId"))
+	if err != nil {
+		logger.Error("get post embed", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	if err := writeEmbed(w, r, post); err != nil {
+		logger.Error("write post embed", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,454 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPostHandler_GetPostByID(t *testing.T) {
+	t.Run("should return post with ETag", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("user-1", true)
+		ps.On("GetPostByID", mock.Anything, "user-1", "post-1").Return(&models.PostResponse{ID: "post-1", Version: 3}, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/posts/post-1", nil)
+		req.SetPathValue("postId", "post-1")
+		rr := httptest.NewRecorder()
+
+		h.GetPostByID(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+	})
+
+	t.Run("should return post to anonymous visitors", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("", false)
+		ps.On("GetPostByID", mock.Anything, "", "post-1").Return(&models.PostResponse{ID: "post-1", Version: 1}, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/posts/post-1", nil)
+		req.SetPathValue("postId", "post-1")
+		rr := httptest.NewRecorder()
+
+		h.GetPostByID(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"liked_by_user":false`)
+		ps.AssertExpectations(t)
+	})
+}
+
+func TestPostHandler_UpdatePost(t *testing.T) {
+	payload := models.UpdatePostPayload{Title: "Título", Content: "Conteúdo"}
+
+	t.Run("should return 428 if If-Match is missing", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("user-1", true)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodPut, "/posts/post-1", toJSON(t, payload))
+		req.SetPathValue("postId", "post-1")
+		rr := httptest.NewRecorder()
+
+		h.UpdatePost(rr, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, rr.Code)
+		ps.AssertNotCalled(t, "UpdatePost", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 412 with current post on version mismatch", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("user-1", true)
+		ps.On("UpdatePost", mock.Anything, "user-1", "post-1", "Título", "Conteúdo", models.PostVisibility(""), 2).Return(0, models.ErrPostVersionMismatch)
+		ps.On("GetPostByID", mock.Anything, "user-1", "post-1").Return(&models.PostResponse{ID: "post-1", Title: "Outro", Version: 3}, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodPut, "/posts/post-1", toJSON(t, payload))
+		req.SetPathValue("postId", "post-1")
+		req.Header.Set("If-Match", `"2"`)
+		rr := httptest.NewRecorder()
+
+		h.UpdatePost(rr, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+
+		var body models.PostConflictResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, "Outro", body.Current.Title)
+		assert.Equal(t, 3, body.Current.Version)
+	})
+
+	t.Run("should return 204 with the new ETag", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("user-1", true)
+		ps.On("UpdatePost", mock.Anything, "user-1", "post-1", "Título", "Conteúdo", models.PostVisibility(""), 2).Return(3, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodPut, "/posts/post-1", toJSON(t, payload))
+		req.SetPathValue("postId", "post-1")
+		req.Header.Set("If-Match", `"2"`)
+		rr := httptest.NewRecorder()
+
+		h.UpdatePost(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+	})
+}
+
+func TestPostHandler_CreatePost(t *testing.T) {
+	t.Run("should return 422 if title exceeds the column limit", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		h := NewPostHandler(rc, ps)
+
+		payload := toJSON(t, models.CreatePostPayload{Title: strings.Repeat("á", models.MaxPostTitleLength+1), Content: "Conteúdo"})
+		req := httptest.NewRequest(http.MethodPost, "/posts", payload)
+		rr := httptest.NewRecorder()
+
+		h.CreatePost(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, []*models.FieldError{
+			{Field: "title", Code: "max", Message: "title deve ter no máximo 50 caracteres"},
+		}, body.Errors)
+		ps.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("should return 422 if the poll has too many options", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		h := NewPostHandler(rc, ps)
+
+		payload := toJSON(t, models.CreatePostPayload{
+			Title:   "Enquete",
+			Content: "Qual escolher?",
+			Poll: &models.CreatePollPayload{
+				Options:  []string{"A", "B", "C", "D", "E"},
+				ClosesAt: time.Now().Add(time.Hour),
+			},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/posts", payload)
+		rr := httptest.NewRecorder()
+
+		h.CreatePost(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, []*models.FieldError{
+			{Field: "poll.options", Code: "max", Message: "poll.options deve ter no máximo 4 itens"},
+		}, body.Errors)
+		ps.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPostHandler_PatchPost(t *testing.T) {
+	t.Run("should return 422 if title is null", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("user-1", true)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodPatch, "/posts/post-1", strings.NewReader(`{"title": null}`))
+		req.SetPathValue("postId", "post-1")
+		req.Header.Set("If-Match", `"1"`)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		rr := httptest.NewRecorder()
+
+		h.PatchPost(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("should pass only the present fields to the service", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		rc.On("GetUserID", mock.Anything).Return("user-1", true)
+		ps.On("PatchPost", mock.Anything, "user-1", "post-1", mock.MatchedBy(func(p *models.PatchPostPayload) bool {
+			return p.Title == nil && p.Content != nil && *p.Content == "Novo"
+		}), 1).Return(2, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodPatch, "/posts/post-1", strings.NewReader(`{"content": "Novo"}`))
+		req.SetPathValue("postId", "post-1")
+		req.Header.Set("If-Match", `"1"`)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		rr := httptest.NewRecorder()
+
+		h.PatchPost(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+		ps.AssertExpectations(t)
+	})
+}
+
+func TestPostHandler_GetUserRSSFeed(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	posts := []*models.PostResponse{
+		{ID: "post-1", Title: "Primeiro", ContentHTML: "<p>um</p>", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: "post-2", Title: "Segundo", ContentHTML: "<p>dois</p>", CreatedAt: createdAt.Add(time.Hour), UpdatedAt: createdAt.Add(2 * time.Hour)},
+	}
+
+	t.Run("should return an rss feed with the newest post first", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostsByUsername", mock.Anything, "", "joao").Return(posts, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/joao/feed.rss", nil)
+		req.SetPathValue("username", "joao")
+		rr := httptest.NewRecorder()
+
+		h.GetUserRSSFeed(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, models.RSSContentType, rr.Header().Get("Content-Type"))
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+		assert.Equal(t, createdAt.Add(2*time.Hour).Format(http.TimeFormat), rr.Header().Get("Last-Modified"))
+
+		var feed models.RSS
+		assert.NoError(t, xml.NewDecoder(rr.Body).Decode(&feed))
+		assert.Len(t, feed.Channel.Items, 2)
+		assert.Equal(t, "post-2", feed.Channel.Items[0].GUID.Value)
+		assert.False(t, feed.Channel.Items[0].GUID.IsPermaLink)
+	})
+
+	t.Run("should return 304 when the ETag matches", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostsByUsername", mock.Anything, "", "joao").Return(posts, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/joao/feed.rss", nil)
+		req.SetPathValue("username", "joao")
+		rr := httptest.NewRecorder()
+		h.GetUserRSSFeed(rr, req)
+
+		req = httptest.NewRequest(http.MethodGet, "/users/joao/feed.rss", nil)
+		req.SetPathValue("username", "joao")
+		req.Header.Set("If-None-Match", rr.Header().Get("ETag"))
+		rr = httptest.NewRecorder()
+		h.GetUserRSSFeed(rr, req)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("should return 404 if user not found", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostsByUsername", mock.Anything, "", "ninguem").Return(nil, models.ErrUserNotFound)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/ninguem/feed.rss", nil)
+		req.SetPathValue("username", "ninguem")
+		rr := httptest.NewRecorder()
+
+		h.GetUserRSSFeed(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestPostHandler_GetUserAtomFeed(t *testing.T) {
+	t.Run("should return an atom feed with entry ids from post ids", func(t *testing.T) {
+		createdAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostsByUsername", mock.Anything, "", "joao").Return([]*models.PostResponse{
+			{ID: "post-1", Title: "Primeiro", ContentHTML: "<p>um</p>", CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Hour)},
+		}, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/joao/feed.atom", nil)
+		req.SetPathValue("username", "joao")
+		rr := httptest.NewRecorder()
+
+		h.GetUserAtomFeed(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, models.AtomContentType, rr.Header().Get("Content-Type"))
+
+		var feed models.AtomFeed
+		assert.NoError(t, xml.NewDecoder(rr.Body).Decode(&feed))
+		assert.Len(t, feed.Entries, 1)
+		assert.Equal(t, "urn:uuid:post-1", feed.Entries[0].ID)
+		assert.Equal(t, createdAt.Add(time.Hour).Format(time.RFC3339), feed.Entries[0].Updated)
+		assert.Equal(t, "<p>um</p>", feed.Entries[0].Content.Value)
+	})
+}
+
+func TestPostHandler_GetOEmbed(t *testing.T) {
+	configs.Env.APIURL = "http://api.test"
+	configs.Env.RedirectURL = "http://app.test/"
+
+	embed := &models.PostEmbed{ID: "post-1", Title: "Olá", AuthorName: "João", AuthorUsername: "joao", Likes: 3}
+
+	t.Run("should return rich oembed for a profile post url", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostEmbed", mock.Anything, "post-1").Return(embed, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/oembed?url=http://app.test/joao%23post-1&maxwidth=400", nil)
+		rr := httptest.NewRecorder()
+
+		h.GetOEmbed(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var body models.OEmbedResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, "rich", body.Type)
+		assert.Equal(t, 400, body.Width)
+		assert.Equal(t, models.DefaultEmbedHeight, body.Height)
+		assert.Equal(t, "http://app.test/joao", body.AuthorURL)
+		assert.Contains(t, body.HTML, `src="http://api.test/posts/post-1/embed"`)
+	})
+
+	t.Run("should resolve api post urls", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostEmbed", mock.Anything, "post-1").Return(embed, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/oembed?url=http://api.test/posts/post-1", nil)
+		rr := httptest.NewRecorder()
+
+		h.GetOEmbed(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		ps.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 for private or foreign urls", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostEmbed", mock.Anything, "post-2").Return(nil, models.ErrPostNotFound)
+
+		h := NewPostHandler(rc, ps)
+
+		for _, target := range []string{"http://api.test/posts/post-2", "http://elsewhere.test/posts/post-1"} {
+			req := httptest.NewRequest(http.MethodGet, "/oembed?url="+target, nil)
+			rr := httptest.NewRecorder()
+
+			h.GetOEmbed(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("should return 501 for xml format", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/oembed?url=http://api.test/posts/post-1&format=xml", nil)
+		rr := httptest.NewRecorder()
+
+		h.GetOEmbed(rr, req)
+
+		assert.Equal(t, http.StatusNotImplemented, rr.Code)
+		ps.AssertNotCalled(t, "GetPostEmbed", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPostHandler_GetPostEmbed(t *testing.T) {
+	configs.Env.RedirectURL = "http://app.test/"
+
+	t.Run("should render an escaped card with strict headers", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		createdAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+		ps.On("GetPostEmbed", mock.Anything, "post-1").Return(&models.PostEmbed{
+			ID:             "post-1",
+			Title:          `<script>alert("x")</script>`,
+			AuthorName:     "João",
+			AuthorUsername: "joao",
+			Likes:          1,
+			CreatedAt:      createdAt,
+			UpdatedAt:      createdAt,
+		}, nil)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/posts/post-1/embed", nil)
+		req.SetPathValue("postId", "post-1")
+		rr := httptest.NewRecorder()
+
+		h.GetPostEmbed(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Security-Policy"), "default-src 'none'")
+		assert.Contains(t, rr.Header().Get("Cache-Control"), "public")
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+		assert.NotContains(t, rr.Body.String(), "<script>")
+		assert.Contains(t, rr.Body.String(), "1 curtida<")
+		assert.Contains(t, rr.Body.String(), `href="http://app.test/joao#post-1"`)
+	})
+
+	t.Run("should return 404 for private posts", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		rc := new(mocks.RequestContextMock)
+		ps.On("GetPostEmbed", mock.Anything, "post-2").Return(nil, models.ErrPostNotFound)
+
+		h := NewPostHandler(rc, ps)
+
+		req := httptest.NewRequest(http.MethodGet, "/posts/post-2/embed", nil)
+		req.SetPathValue("postId", "post-2")
+		rr := httptest.NewRecorder()
+
+		h.GetPostEmbed(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Security-Policy"))
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:tab-notes:problem:"
+
+	RequestIDHeader = "X-Request-ID"
+)
+
+// Stable codes for problems that do not come from a models sentinel error.
+const (
+	CodeInternalError        = "internal_error"
+	CodeUnauthorized         = "unauthorized"
+	CodeInvalidRequest       = "invalid_request"
+	CodeMalformedBody        = "malformed_body"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeValidationFailed     = "validation_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeUnsupportedFormat    = "unsupported_format"
+)
+
+type problemMapping struct {
+	err    error
+	status int
+	code   string
+	detail string
+}
+
+// problemMappings translates the sentinel errors in models into responses.
+// Matching uses errors.Is, so wrapped errors resolve to the same problem.
+// Codes are stable identifiers; details are shown to users and, like every
+// detail passed to WriteProblem, are written in Portuguese.
+var problemMappings = []problemMapping{
+	{models.ErrUserNotFound, http.StatusNotFound, "user_not_found", "Usuário não encontrado."},
+	{models.ErrUserAlreadyExists, http.StatusConflict, "user_already_exists", "Usuário já cadastrado."},
+	{models.ErrEmailAlreadyExists, http.StatusConflict, "email_already_exists", "Já existe uma conta com esse e-mail."},
+	{models.ErrUsernameAlreadyExists, http.StatusConflict, "username_already_exists", "Este nome de usuário já está em uso."},
+	{models.ErrCannotFollowSelf, http.StatusForbidden, "cannot_follow_self", "Você não pode seguir a si mesmo."},
+	{models.ErrCannotUnfollowSelf, http.StatusForbidden, "cannot_unfollow_self", "Você não pode deixar de seguir a si mesmo."},
+	{models.ErrUserBanned, http.StatusForbidden, "user_banned", "Esta conta foi suspensa."},
+	{models.ErrUserInactive, http.StatusForbidden, "user_inactive", "Esta conta está desativada."},
+	{models.ErrSessionNotFound, http.StatusNotFound, "session_not_found", "Sessão não encontrada."},
+	{models.ErrSessionExpired, http.StatusUnauthorized, "session_expired", "Sessão expirada."},
+	{models.ErrInvalidLoginCode, http.StatusUnauthorized, "invalid_login_code", "Código inválido ou expirado."},
+	{models.ErrPendingLoginNotFound, http.StatusNotFound, "pending_login_not_found", "Login pendente não encontrado."},
+	{models.ErrInvalidSessionRevokeToken, http.StatusNotFound, "invalid_session_revoke_token", "Link de encerramento de sessão inválido ou expirado."},
+	{models.ErrLoginCodeAttemptsExceeded, http.StatusTooManyRequests, "login_code_attempts_exceeded", "Muitas tentativas. Use o link do e-mail ou solicite um novo código."},
+	{models.ErrInvalidRefreshToken, http.StatusUnauthorized, "invalid_refresh_token", "Token de atualização inválido."},
+	{models.ErrRefreshTokenReused, http.StatusUnauthorized, "refresh_token_reused", "Token de atualização já utilizado. A sessão foi encerrada por segurança."},
+	{models.ErrSessionNotBelongToUser, http.StatusForbidden, "session_not_owned", "A sessão não pertence a este usuário."},
+	{models.ErrPersonalAccessTokenNotFound, http.StatusNotFound, "personal_access_token_not_found", "Token de acesso não encontrado."},
+	{models.ErrInsufficientScope, http.StatusForbidden, "insufficient_scope", "O token de acesso não tem permissão para esta operação."},
+	{models.ErrPostNotFound, http.StatusNotFound, "post_not_found", "Post não encontrado."},
+	{models.ErrPostNotBelongToUser, http.StatusForbidden, "post_not_owned", "O post não pertence a este usuário."},
+	{models.ErrPostVersionMismatch, http.StatusPreconditionFailed, "post_version_mismatch", "O post foi alterado por outra requisição."},
+	{models.ErrPollNotFound, http.StatusNotFound, "poll_not_found", "Este post não tem enquete."},
+	{models.ErrPollClosed, http.StatusConflict, "poll_closed", "A enquete já foi encerrada."},
+	{models.ErrPollOptionNotFound, http.StatusUnprocessableEntity, "poll_option_not_found", "Opção de enquete inválida."},
+	{models.ErrInvalidPollClosingTime, http.StatusUnprocessableEntity, "invalid_poll_closing_time", "A enquete deve encerrar no futuro, em até 30 dias."},
+	{models.ErrNotebookNotFound, http.StatusNotFound, "notebook_not_found", "Caderno não encontrado."},
+	{models.ErrInvalidNotebookOrder, http.StatusBadRequest, "invalid_notebook_order", "A lista de posts deve conter exatamente os posts do caderno."},
+	{models.ErrImportNotFound, http.StatusNotFound, "import_not_found", "Importação não encontrada."},
+	{models.ErrInvalidImportArchive, http.StatusBadRequest, "invalid_import_archive", "O arquivo enviado não é um ZIP válido."},
+	{models.ErrExportNotFound, http.StatusNotFound, "export_not_found", "Exportação não encontrada."},
+	{models.ErrExportNotReady, http.StatusConflict, "export_not_ready", "A exportação ainda não está pronta."},
+	{models.ErrExportExpired, http.StatusGone, "export_expired", "O link de exportação expirou."},
+	{models.ErrInvalidExportToken, http.StatusUnauthorized, "invalid_export_token", "Link de exportação inválido."},
+	{models.ErrShareLinkNotFound, http.StatusNotFound, "share_link_not_found", "Link de compartilhamento não encontrado."},
+	{models.ErrInvalidShareLink, http.StatusNotFound, "invalid_share_link", "Link de compartilhamento inválido ou expirado."},
+	{models.ErrInvalidSignature, http.StatusUnauthorized, "invalid_signature", "Assinatura HTTP inválida."},
+	{models.ErrInvalidActivity, http.StatusBadRequest, "invalid_activity", "Atividade inválida."},
+	{models.ErrRemoteActorUnavailable, http.StatusBadGateway, "remote_actor_unavailable", "Não foi possível obter o ator remoto."},
+}
+
+// WriteError answers with the problem mapped to err. Errors without a
+// mapping become a 500 that does not leak the underlying message.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := problemFor(w, r, err)
+	writeProblem(w, problem.Status, problem)
+}
+
+// WriteProblem answers with an application/problem+json body. The request ID
+// is the one the RequestID middleware put on the response headers.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, code string, detail string) {
+	writeProblem(w, status, newProblem(w, r, status, code, detail))
+}
+
+func Unauthorized(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, http.StatusUnauthorized, CodeUnauthorized, "Autenticação necessária.")
+}
+
+func ValidationError(w http.ResponseWriter, r *http.Request, fieldErrors []*models.FieldError) {
+	problem := newProblem(w, r, http.StatusUnprocessableEntity, CodeValidationFailed, "")
+	problem.Errors = fieldErrors
+	writeProblem(w, problem.Status, problem)
+}
+
+func problemFor(w http.ResponseWriter, r *http.Request, err error) models.Problem {
+	for _, m := range problemMappings {
+		if errors.Is(err, m.err) {
+			return newProblem(w, r, m.status, m.code, m.detail)
+		}
+	}
+
+	return newProblem(w, r, http.StatusInternalServerError, CodeInternalError, "")
+}
+
+func newProblem(w http.ResponseWriter, r *http.Request, status int, code string, detail string) models.Problem {
+	return models.Problem{
+		Type:      problemTypePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: w.Header().Get(RequestIDHeader),
+	}
+}
+
+func writeProblem(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	t.Run("should map a sentinel error to its status and code", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/posts/post-1", nil)
+		rr := httptest.NewRecorder()
+		rr.Header().Set(RequestIDHeader, "req-1")
+
+		WriteError(rr, req, models.ErrPostNotFound)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, "post_not_found", body.Code)
+		assert.Equal(t, "urn:tab-notes:problem:post_not_found", body.Type)
+		assert.Equal(t, http.StatusNotFound, body.Status)
+		assert.Equal(t, "/posts/post-1", body.Instance)
+		assert.Equal(t, "req-1", body.RequestID)
+	})
+
+	t.Run("should map wrapped sentinel errors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		rr := httptest.NewRecorder()
+
+		WriteError(rr, req, fmt.Errorf("get profile: %w", models.ErrUserNotFound))
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "user_not_found", body.Code)
+	})
+
+	t.Run("should return 500 without leaking unknown errors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		rr := httptest.NewRecorder()
+
+		WriteError(rr, req, errors.New("dial tcp: connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "connection refused")
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, CodeInternalError, body.Code)
+	})
+}
+
+func TestProblemMappings(t *testing.T) {
+	t.Run("should have a unique code for every sentinel error", func(t *testing.T) {
+		codes := make(map[string]bool, len(problemMappings))
+		for _, m := range problemMappings {
+			assert.False(t, codes[m.code], "duplicated code %s", m.code)
+			codes[m.code] = true
+		}
+	})
+}
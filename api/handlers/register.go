@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
@@ -30,27 +29,14 @@ func (rh *registerHandler) RegisterUser(w http.ResponseWriter, r *http.Request)
 	)
 
 	var payload models.RegisterPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		logger.Error("decode request body", "error", err)
-		NoContent(w, http.StatusBadRequest)
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
 		return
 	}
 
 	if err := rh.rs.RegisterUser(r.Context(), payload.Name, payload.Username, payload.Email); err != nil {
-		if err == models.ErrEmailAlreadyExists {
-			logger.Error("user already exists", "error", err)
-			ErrorJSON(w, http.StatusConflict, "Já existe uma conta com esse e-mail.")
-			return
-		}
-
-		if err == models.ErrUsernameAlreadyExists {
-			logger.Error("user already exists", "error", err)
-			ErrorJSON(w, http.StatusConflict, "Este nome de usuário já está em uso.")
-			return
-		}
-
 		logger.Error("register user", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -26,6 +27,50 @@ func TestRegisterHandler_RegisterUser(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 
+	t.Run("should return 422 with field errors if payload fails validation", func(t *testing.T) {
+		rs := new(mocks.RegisterServiceMock)
+		h := NewRegisterHandler(rs)
+
+		payload := toJSON(t, models.RegisterPayload{
+			Name:     "João",
+			Username: "  ",
+			Email:    "not-an-email",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/register", payload)
+		rr := httptest.NewRecorder()
+
+		h.RegisterUser(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.ElementsMatch(t, []*models.FieldError{
+			{Field: "email", Code: "email", Message: "email deve ser um e-mail válido"},
+			{Field: "username", Code: "notblank", Message: "username é obrigatório"},
+		}, body.Errors)
+		rs.AssertNotCalled(t, "RegisterUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 422 if payload has unknown fields", func(t *testing.T) {
+		rs := new(mocks.RegisterServiceMock)
+		h := NewRegisterHandler(rs)
+
+		payload := bytes.NewBufferString(`{"name":"João","username":"joao","email":"joao@example.com","admin":true}`)
+		req := httptest.NewRequest(http.MethodPost, "/register", payload)
+		rr := httptest.NewRecorder()
+
+		h.RegisterUser(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, []*models.FieldError{
+			{Field: "admin", Code: "unknown", Message: "admin não é um campo conhecido"},
+		}, body.Errors)
+	})
+
 	t.Run("should return 409 if email already exists", func(t *testing.T) {
 		rs := new(mocks.RegisterServiceMock)
 		h := NewRegisterHandler(rs)
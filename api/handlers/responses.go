@@ -1,14 +1,14 @@
 package handlers
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
-type ErrorResponse struct {
-	Message string `json:"message"`
-}
-
 func JSON(w http.ResponseWriter, statusCode int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -23,8 +23,11 @@ func NoContent(w http.ResponseWriter, statusCode int) {
 	w.WriteHeader(statusCode)
 }
 
-func ErrorJSON(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{Message: message})
+// serveCached writes body with an ETag over its bytes and lastModified, so
+// conditional requests get a 304 while nothing changed.
+func serveCached(w http.ResponseWriter, r *http.Request, contentType string, lastModified time.Time, body []byte) {
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(w, r, "", lastModified, bytes.NewReader(body))
 }
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
@@ -14,6 +13,8 @@ type SessionHandler interface {
 	GetUserSessions(w http.ResponseWriter, r *http.Request)
 	RevokeSession(w http.ResponseWriter, r *http.Request)
 	RevokeAllSessions(w http.ResponseWriter, r *http.Request)
+	RevokeSessionPage(w http.ResponseWriter, r *http.Request)
+	RevokeSessionFromLink(w http.ResponseWriter, r *http.Request)
 }
 
 type sessionHandler struct {
@@ -38,7 +39,7 @@ func (s *sessionHandler) GetUserSessions(w http.ResponseWriter, r *http.Request)
 	if !ok {
 		logger.Error("userID not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
@@ -46,20 +47,14 @@ func (s *sessionHandler) GetUserSessions(w http.ResponseWriter, r *http.Request)
 	if !ok {
 		logger.Error("sessionID not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	response, err := s.ss.GetUserSessions(r.Context(), userID, currentSessionID)
 	if err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Error("user not found", "userID", userID)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error getting user sessions", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -80,26 +75,20 @@ func (s *sessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("userID not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	sessionID := r.PathValue("sessionId")
 	if sessionID == "" {
 		logger.Error("session_id not found in query")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O parâmetro session_id é obrigatório.")
 		return
 	}
 
 	if err := s.ss.RevokeUserSession(r.Context(), userID, sessionID); err != nil {
-		if err == models.ErrSessionNotFound {
-			logger.Error("session not found", "sessionID", sessionID)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error revoking session", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -113,40 +102,90 @@ func (s *sessionHandler) RevokeAllSessions(w http.ResponseWriter, r *http.Reques
 	)
 
 	var payload models.RevokeAllSessionsPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		logger.Error("error decoding request body", "error", err)
-		NoContent(w, http.StatusBadRequest)
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
 		return
 	}
 
 	userID, ok := s.rc.GetUserID(r.Context())
 	if !ok {
 		logger.Error("userID not found in context")
-		NoContent(w, http.StatusUnauthorized)
 		DeleteTokenCookie(w)
+		Unauthorized(w, r)
 		return
 	}
 
 	currentSessionID, ok := s.rc.GetSessionID(r.Context())
 	if !ok {
 		logger.Error("sessionID not found in context")
-		NoContent(w, http.StatusUnauthorized)
 		DeleteTokenCookie(w)
+		Unauthorized(w, r)
 		return
 	}
 
 	err := s.ss.RevokeAllUserSessions(r.Context(), userID, currentSessionID, payload.RevokeCurrent)
 	if err != nil {
-		if err == models.ErrSessionNotFound {
-			logger.Error("session not found", "sessionID", userID)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error revoking all sessions", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
 	NoContent(w, http.StatusNoContent)
 }
+
+// RevokeSessionPage is opened from the link of a new sign-in alert. It asks
+// for a click before revoking, so mail scanners following the link do not
+// end the very session they were warning about.
+func (s *sessionHandler) RevokeSessionPage(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "session"),
+		slog.String("method", "RevokeSessionPage"),
+	)
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		logger.Error("missing token")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O token é obrigatório.")
+		return
+	}
+
+	if err := writeSessionRevoke(w, http.StatusOK, sessionRevokePage{Token: token}); err != nil {
+		logger.Error("write session revoke", "error", err)
+	}
+}
+
+// RevokeSessionFromLink is posted by the page of a new sign-in alert link.
+// The signed token in the form authorizes it, no sign-in needed.
+func (s *sessionHandler) RevokeSessionFromLink(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "session"),
+		slog.String("method", "RevokeSessionFromLink"),
+	)
+
+	token := r.PostFormValue("token")
+	if token == "" {
+		logger.Error("missing token")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O token é obrigatório.")
+		return
+	}
+
+	page := sessionRevokePage{Revoked: true}
+	status := http.StatusOK
+
+	if err := s.ss.RevokeSessionFromLink(r.Context(), token); err != nil {
+		switch err {
+		case models.ErrInvalidSessionRevokeToken, models.ErrSessionNotFound:
+			logger.Warn("invalid session revoke token", "error", err)
+			page = sessionRevokePage{Error: "Este link é inválido ou expirou. Encerre a sessão pelas configurações da conta."}
+			status = http.StatusNotFound
+		default:
+			logger.Error("revoke session from link", "error", err)
+			WriteError(w, r, err)
+			return
+		}
+	}
+
+	if err := writeSessionRevoke(w, status, page); err != nil {
+		logger.Error("write session revoke", "error", err)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+)
+
+var sessionRevokeTemplate = template.Must(template.New("session-revoke").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Encerrar sessão do TabNotes</title>
+<style>` + authPageCSS + `</style>
+</head>
+<body>
+<main>
+{{if .Revoked}}
+<h1>Sessão encerrada</h1>
+<p>O dispositivo que acessou sua conta foi desconectado. Se você não reconhece o acesso, confira suas outras sessões nas configurações da conta.</p>
+{{else if .Error}}
+<h1>Não foi possível encerrar a sessão</h1>
+<p class="error">{{.Error}}</p>
+{{else}}
+<h1>Encerrar sessão</h1>
+<p>Encerre a sessão do novo acesso à sua conta se não foi você quem entrou.</p>
+<form method="post" action="{{.RevokeURL}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">Encerrar sessão</button>
+</form>
+{{end}}
+</main>
+</body>
+</html>
+`))
+
+type sessionRevokePage struct {
+	Revoked   bool
+	Token     string
+	Error     string
+	RevokeURL string
+}
+
+func writeSessionRevoke(w http.ResponseWriter, status int, page sessionRevokePage) error {
+	page.RevokeURL = configs.Env.APIURL + "/sessions/revoke"
+
+	if err := writeAuthPage(w, status, sessionRevokeTemplate, page); err != nil {
+		return fmt.Errorf("render session revoke: %w", err)
+	}
+
+	return nil
+}
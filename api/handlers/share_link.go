@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+)
+
+type ShareLinkHandler interface {
+	CreateShareLink(w http.ResponseWriter, r *http.Request)
+	GetShareLinks(w http.ResponseWriter, r *http.Request)
+	RevokeShareLink(w http.ResponseWriter, r *http.Request)
+	GetSharedPost(w http.ResponseWriter, r *http.Request)
+}
+
+type shareLinkHandler struct {
+	rc  pkgs.RequestContext
+	sls services.ShareLinkService
+}
+
+func NewShareLinkHandler(
+	requestContext pkgs.RequestContext,
+	shareLinkService services.ShareLinkService) ShareLinkHandler {
+	return &shareLinkHandler{
+		rc:  requestContext,
+		sls: shareLinkService,
+	}
+}
+
+// CreateShareLink accepts an empty body for a link that never expires.
+func (s *shareLinkHandler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "share_link"),
+		slog.String("method", "CreateShareLink"),
+	)
+
+	var payload models.CreateShareLinkPayload
+	if r.ContentLength != 0 && !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	userID, ok := s.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	link, err := s.sls.CreateShareLink(r.Context(), userID, r.PathValue("postId"), &payload)
+	if err != nil {
+		logger.Error("create share link", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, link)
+}
+
+func (s *shareLinkHandler) GetShareLinks(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "share_link"),
+		slog.String("method", "GetShareLinks"),
+	)
+
+	userID, ok := s.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	links, err := s.sls.GetShareLinks(r.Context(), userID, r.PathValue("postId"))
+	if err != nil {
+		logger.Error("get share links", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, links)
+}
+
+func (s *shareLinkHandler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "share_link"),
+		slog.String("method", "RevokeShareLink"),
+	)
+
+	userID, ok := s.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("get user id from context", "error", "user id not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	if err := s.sls.RevokeShareLink(r.Context(), userID, r.PathValue("postId"), r.PathValue("linkId")); err != nil {
+		logger.Error("revoke share link", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
+
+func (s *shareLinkHandler) GetSharedPost(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "share_link"),
+		slog.String("method", "GetSharedPost"),
+	)
+
+	post, err := s.sls.GetSharedPost(r.Context(), r.PathValue("token"))
+	if err != nil {
+		logger.Error("get shared post", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	JSON(w, http.StatusOK, post)
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+// newRSSFeed builds an RSS 2.0 channel with the most recent posts first.
+// GUIDs are the post IDs, which are not links.
+func newRSSFeed(username string, posts []*models.PostResponse) *models.RSS {
+	posts = latestPosts(posts)
+	profileURL := profileURL(username)
+
+	feed := &models.RSS{
+		Version:   "2.0",
+		AtomXMLNS: "http://www.w3.org/2005/Atom",
+		Channel: models.RSSChannel{
+			Title:       "@" + username,
+			Link:        profileURL,
+			Description: fmt.Sprintf("Posts de @%s no Tab Notes", username),
+			AtomLink: models.RSSAtomLink{
+				Href: feedURL(username, "feed.rss"),
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			Items: make([]models.RSSItem, len(posts)),
+		},
+	}
+
+	if len(posts) > 0 {
+		feed.Channel.LastBuildDate = feedUpdatedAt(posts).Format(time.RFC1123Z)
+	}
+
+	for i, post := range posts {
+		feed.Channel.Items[i] = models.RSSItem{
+			Title:       post.Title,
+			Link:        postURL(username, post.ID),
+			Description: post.ContentHTML,
+			GUID:        models.RSSGUID{IsPermaLink: false, Value: post.ID},
+			PubDate:     post.CreatedAt.UTC().Format(time.RFC1123Z),
+		}
+	}
+
+	return feed
+}
+
+// newAtomFeed builds an Atom 1.0 feed with the most recent posts first.
+// Entry IDs are URNs built from the post IDs.
+func newAtomFeed(username string, posts []*models.PostResponse) *models.AtomFeed {
+	posts = latestPosts(posts)
+	profileURL := profileURL(username)
+
+	feed := &models.AtomFeed{
+		ID:      feedURL(username, "feed.atom"),
+		Title:   "@" + username,
+		Updated: feedUpdatedAt(posts).Format(time.RFC3339),
+		Links: []models.AtomLink{
+			{Href: feedURL(username, "feed.atom"), Rel: "self", Type: "application/atom+xml"},
+			{Href: profileURL, Rel: "alternate", Type: "text/html"},
+		},
+		Author:  models.AtomAuthor{Name: username, URI: profileURL},
+		Entries: make([]models.AtomEntry, len(posts)),
+	}
+
+	for i, post := range posts {
+		feed.Entries[i] = models.AtomEntry{
+			ID:        "urn:uuid:" + post.ID,
+			Title:     post.Title,
+			Updated:   post.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: post.CreatedAt.UTC().Format(time.RFC3339),
+			Link:      models.AtomLink{Href: postURL(username, post.ID), Rel: "alternate", Type: "text/html"},
+			Content:   models.AtomContent{Type: "html", Value: post.ContentHTML},
+		}
+	}
+
+	return feed
+}
+
+// writeFeed serves an XML feed with an ETag over its body and a
+// Last-Modified from its newest post, so readers polling with If-None-Match
+// or If-Modified-Since get a 304 while nothing changed.
+func writeFeed(w http.ResponseWriter, r *http.Request, contentType string, lastModified time.Time, feed any) error {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	if err := xml.NewEncoder(&body).Encode(feed); err != nil {
+		return fmt.Errorf("encode feed: %w", err)
+	}
+
+	serveCached(w, r, contentType, lastModified, body.Bytes())
+
+	return nil
+}
+
+func latestPosts(posts []*models.PostResponse) []*models.PostResponse {
+	sorted := make([]*models.PostResponse, len(posts))
+	copy(sorted, posts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	if len(sorted) > models.MaxSyndicationEntries {
+		sorted = sorted[:models.MaxSyndicationEntries]
+	}
+
+	return sorted
+}
+
+func feedUpdatedAt(posts []*models.PostResponse) time.Time {
+	var updatedAt time.Time
+	for _, post := range posts {
+		if post.UpdatedAt.After(updatedAt) {
+			updatedAt = post.UpdatedAt
+		}
+	}
+
+	return updatedAt.UTC()
+}
+
+func profileURL(username string) string {
+	return strings.TrimSuffix(configs.Env.RedirectURL, "/") + "/" + username
+}
+
+func postURL(username string, postID string) string {
+	return profileURL(username) + "#" + postID
+}
+
+func feedURL(username string, name string) string {
+	return fmt.Sprintf("%s/users/%s/%s", configs.Env.APIURL, username, name)
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -16,6 +15,7 @@ type UserHandler interface {
 	SearchUsers(w http.ResponseWriter, r *http.Request)
 	GetProfileByUsername(w http.ResponseWriter, r *http.Request)
 	UpdateUser(w http.ResponseWriter, r *http.Request)
+	PatchUser(w http.ResponseWriter, r *http.Request)
 }
 
 type userHandler struct {
@@ -39,21 +39,15 @@ func (u *userHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	userID, ok := u.rc.GetUserID(r.Context())
 	if !ok {
 		logger.Error("userID not found in context")
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		DeleteTokenCookie(w)
 		return
 	}
 
 	response, err := u.us.GetProfile(r.Context(), userID)
 	if err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Error("user not found", "userID", userID)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error getting user profile", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -68,15 +62,15 @@ func (u *userHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
 
 	query := strings.ToLower(r.URL.Query().Get("q"))
 	if query == "" {
-		logger.Error("query not found in query")
-		NoContent(w, http.StatusBadRequest)
+		logger.Error("O parâmetro query é obrigatório.")
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O parâmetro query é obrigatório.")
 		return
 	}
 
 	users, err := u.us.SearchUsers(r.Context(), query)
 	if err != nil {
 		logger.Error("error searching users", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -96,28 +90,16 @@ func (u *userHandler) GetProfileByUsername(w http.ResponseWriter, r *http.Reques
 	username := strings.ToLower(r.PathValue("username"))
 	if username == "" {
 		logger.Error("username not found in query")
-		NoContent(w, http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "O nome de usuário é obrigatório.")
 		return
 	}
 
-	viewerID, ok := u.rc.GetUserID(r.Context())
-	if !ok {
-		logger.Error("viewerID not found in context")
-		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
-		return
-	}
+	viewerID, _ := u.rc.GetUserID(r.Context())
 
 	response, err := u.us.GetProfileByUsername(r.Context(), username, viewerID)
 	if err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Error("user not found", "username", username)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
 		logger.Error("error getting user profile by username", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -131,9 +113,8 @@ func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	)
 
 	var payload models.UpdateUserPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		logger.Error("error decoding request body", "error", err)
-		NoContent(w, http.StatusBadRequest)
+	if !DecodeAndValidate(w, r, &payload) {
+		logger.Error("invalid request body")
 		return
 	}
 
@@ -141,27 +122,44 @@ func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		logger.Error("userID not found in context")
 		DeleteTokenCookie(w)
-		NoContent(w, http.StatusUnauthorized)
+		Unauthorized(w, r)
 		return
 	}
 
 	if err := u.us.UpdateUser(r.Context(), userID, payload.Name, payload.Username); err != nil {
-		if err == models.ErrUserNotFound {
-			logger.Error("user not found", "userID", userID)
-			NoContent(w, http.StatusNotFound)
-			return
-		}
-
-		if err == models.ErrUsernameAlreadyExists {
-			logger.Error("username already exists", "username", payload.Username)
-			NoContent(w, http.StatusConflict)
-			return
-		}
-
 		logger.Error("error updating user", "error", err)
-		NoContent(w, http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
 	NoContent(w, http.StatusOK)
 }
+
+func (u *userHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	logger := slog.With(
+		slog.String("handler", "user"),
+		slog.String("method", "PatchUser"),
+	)
+
+	userID, ok := u.rc.GetUserID(r.Context())
+	if !ok {
+		logger.Error("userID not found in context")
+		DeleteTokenCookie(w)
+		Unauthorized(w, r)
+		return
+	}
+
+	var payload models.PatchUserPayload
+	if !DecodeMergePatch(w, r, &payload) {
+		logger.Error("invalid request body")
+		return
+	}
+
+	if err := u.us.PatchUser(r.Context(), userID, &payload); err != nil {
+		logger.Error("error patching user", "error", err)
+		WriteError(w, r, err)
+		return
+	}
+
+	NoContent(w, http.StatusNoContent)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/g-villarinho/tab-notes-api/mocks"
@@ -101,3 +102,79 @@ func TestUserHandler_GetProfile(t *testing.T) {
 		us.AssertExpectations(t)
 	})
 }
+
+func TestUserHandler_PatchUser(t *testing.T) {
+	t.Run("should return 422 if a field is null or unknown", func(t *testing.T) {
+		us := new(mocks.UserServiceMock)
+		rc := new(mocks.RequestContextMock)
+		rc.On("GetUserID", mock.Anything).Return("user-123", true)
+
+		h := NewUserHandler(rc, us)
+
+		req := httptest.NewRequest(http.MethodPatch, "/me", bytes.NewBufferString(`{"name": null, "bio": "oi"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		rr := httptest.NewRecorder()
+
+		h.PatchUser(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var body models.Problem
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Len(t, body.Errors, 2)
+		assert.Equal(t, "bio", body.Errors[0].Field)
+		assert.Equal(t, "name", body.Errors[1].Field)
+		us.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 422 if username is too long", func(t *testing.T) {
+		us := new(mocks.UserServiceMock)
+		rc := new(mocks.RequestContextMock)
+		rc.On("GetUserID", mock.Anything).Return("user-123", true)
+
+		h := NewUserHandler(rc, us)
+
+		req := httptest.NewRequest(http.MethodPatch, "/me", toJSON(t, map[string]string{"username": strings.Repeat("a", 101)}))
+		rr := httptest.NewRecorder()
+
+		h.PatchUser(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("should patch only the fields present", func(t *testing.T) {
+		us := new(mocks.UserServiceMock)
+		rc := new(mocks.RequestContextMock)
+		rc.On("GetUserID", mock.Anything).Return("user-123", true)
+		us.On("PatchUser", mock.Anything, "user-123", mock.MatchedBy(func(p *models.PatchUserPayload) bool {
+			return p.Username != nil && *p.Username == "joao" && p.Name == nil
+		})).Return(nil)
+
+		h := NewUserHandler(rc, us)
+
+		req := httptest.NewRequest(http.MethodPatch, "/me", toJSON(t, map[string]string{"username": "joao"}))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		rr := httptest.NewRecorder()
+
+		h.PatchUser(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		us.AssertExpectations(t)
+	})
+
+	t.Run("should return 415 for other content types", func(t *testing.T) {
+		us := new(mocks.UserServiceMock)
+		rc := new(mocks.RequestContextMock)
+		rc.On("GetUserID", mock.Anything).Return("user-123", true)
+
+		h := NewUserHandler(rc, us)
+
+		req := httptest.NewRequest(http.MethodPatch, "/me", bytes.NewBufferString(`name=joao`))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		h.PatchUser(rr, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+	})
+}
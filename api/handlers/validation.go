@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(validateFieldName)
+
+	if err := v.RegisterValidation("notblank", validators.NotBlank); err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func validateFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// DecodeAndValidate decodes the JSON body into dst and enforces its validate
+// tags. Unknown fields are rejected. When it returns false a problem response
+// has already been written: 400 for a malformed body, 413 for an oversized
+// one and 422 listing the failing fields.
+func DecodeAndValidate(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		if fieldError := decodeFieldError(err); fieldError != nil {
+			ValidationError(w, r, []*models.FieldError{fieldError})
+			return false
+		}
+
+		writeDecodeError(w, r, err)
+		return false
+	}
+
+	if decoder.More() {
+		WriteProblem(w, r, http.StatusBadRequest, CodeMalformedBody, "O corpo da requisição deve conter um único valor JSON.")
+		return false
+	}
+
+	return validatePayload(w, r, dst)
+}
+
+func validatePayload(w http.ResponseWriter, r *http.Request, dst any) bool {
+	fieldErrors := validateStruct(dst)
+	if len(fieldErrors) > 0 {
+		ValidationError(w, r, fieldErrors)
+		return false
+	}
+
+	return true
+}
+
+func validateStruct(dst any) []*models.FieldError {
+	err := validate.Struct(dst)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []*models.FieldError{{Field: "", Code: "invalid", Message: err.Error()}}
+	}
+
+	fieldErrors := make([]*models.FieldError, len(validationErrors))
+	for i, fe := range validationErrors {
+		fieldErrors[i] = &models.FieldError{
+			Field:   fieldPath(fe),
+			Code:    fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+
+	return fieldErrors
+}
+
+// fieldPath drops the struct name from the namespace, so "PostPayload.title"
+// becomes "title" and nested fields keep their JSON path.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	field := fieldPath(fe)
+
+	switch fe.Tag() {
+	case "required", "notblank":
+		return fmt.Sprintf("%s é obrigatório", field)
+	case "email":
+		return fmt.Sprintf("%s deve ser um e-mail válido", field)
+	case "oneof":
+		return fmt.Sprintf("%s deve ser um destes valores: %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "max":
+		switch fe.Kind() {
+		case reflect.Slice:
+			return fmt.Sprintf("%s deve ter no máximo %s itens", field, fe.Param())
+		case reflect.Int:
+			return fmt.Sprintf("%s deve ser no máximo %s", field, fe.Param())
+		}
+		return fmt.Sprintf("%s deve ter no máximo %s caracteres", field, fe.Param())
+	case "min":
+		switch fe.Kind() {
+		case reflect.Slice:
+			return fmt.Sprintf("%s deve ter no mínimo %s itens", field, fe.Param())
+		case reflect.Int:
+			return fmt.Sprintf("%s deve ser no mínimo %s", field, fe.Param())
+		}
+		return fmt.Sprintf("%s deve ter no mínimo %s caracteres", field, fe.Param())
+	default:
+		return fmt.Sprintf("%s não atende à regra %s", field, fe.Tag())
+	}
+}
+
+func decodeFieldError(err error) *models.FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &models.FieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("%s deve ser do tipo %s", typeErr.Field, typeErr.Type.String()),
+		}
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		field = strings.Trim(field, `"`)
+		return &models.FieldError{
+			Field:   field,
+			Code:    "unknown",
+			Message: fmt.Sprintf("%s não é um campo conhecido", field),
+		}
+	}
+
+	return nil
+}
+
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		WriteProblem(w, r, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "")
+		return
+	}
+
+	WriteProblem(w, r, http.StatusBadRequest, CodeMalformedBody, "O corpo da requisição deve ser um JSON válido.")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/g-villarinho/tab-notes-api/app"
 	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/middlewares"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/g-villarinho/tab-notes-api/routes"
 	"github.com/g-villarinho/tab-notes-api/storages"
 )
@@ -28,12 +29,23 @@ func main() {
 
 	app := app.NewApp(configs.Env.APIPort)
 
+	app.Use(middlewares.RequestID)
 	app.Use(middlewares.CORS)
 	app.Use(middlewares.Logging)
 	app.Use(middlewares.Recovery)
 	app.Use(middlewares.BodySizeLimit)
 
-	router := routes.SetupRoutes(db)
+	keyring, err := pkgs.NewKeyring(pkgs.NewEcdsaKeyPair(), configs.Env.Key)
+	if err != nil {
+		log.Fatalf("loading signing keys: %v", err)
+	}
+
+	geoIP, err := pkgs.NewGeoIP(configs.Env.GeoIPPath)
+	if err != nil {
+		log.Fatalf("loading geoip database: %v", err)
+	}
+
+	router := routes.SetupRoutes(db, keyring, geoIP)
 
 	app.RegisterRoutes(router)
 
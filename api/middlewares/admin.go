@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/handlers"
+)
+
+// AdminKeyHeader carries the key that authorizes operators on /admin routes.
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminOnly lets through requests carrying the configured admin API key.
+// Without a configured key every request is refused.
+func AdminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(AdminKeyHeader)
+		if configs.Env.AdminAPIKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(configs.Env.AdminAPIKey)) != 1 {
+			handlers.Unauthorized(w, r)
+			return
+		}
+
+		next(w, r)
+	}
+}
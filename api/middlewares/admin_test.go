@@ -0,0 +1,134 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/handlers"
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminOnly(t *testing.T) {
+	newRequest := func(key string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/user-1/ban", nil)
+		if key != "" {
+			req.Header.Set(AdminKeyHeader, key)
+		}
+		return req
+	}
+
+	t.Run("should call next with the admin key", func(t *testing.T) {
+		configs.Env.AdminAPIKey = "admin-key"
+
+		called := false
+		rr := httptest.NewRecorder()
+		AdminOnly(func(w http.ResponseWriter, r *http.Request) { called = true })(rr, newRequest("admin-key"))
+
+		assert.True(t, called)
+	})
+
+	t.Run("should return 401 with a wrong or missing key", func(t *testing.T) {
+		configs.Env.AdminAPIKey = "admin-key"
+
+		for _, key := range []string{"", "other-key"} {
+			rr := httptest.NewRecorder()
+			AdminOnly(func(w http.ResponseWriter, r *http.Request) { t.Fail() })(rr, newRequest(key))
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		}
+	})
+
+	t.Run("should return 401 when no admin key is configured", func(t *testing.T) {
+		configs.Env.AdminAPIKey = ""
+
+		rr := httptest.NewRecorder()
+		AdminOnly(func(w http.ResponseWriter, r *http.Request) { t.Fail() })(rr, newRequest(""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestAdminBanUser(t *testing.T) {
+	const patStr = models.PersonalAccessTokenPrefix + "secret"
+
+	t.Run("should stop refresh tokens and personal access tokens of the banned user", func(t *testing.T) {
+		configs.Env.AdminAPIKey = "admin-key"
+
+		ur := new(mocks.UserRepositoryMock)
+		sr := new(mocks.SessionRepositoryMock)
+		pats := new(mocks.PersonalAccessTokenServiceMock)
+		rc := pkgs.NewRequestContext()
+
+		sessionService := services.NewSessionService(new(mocks.TokenServiceMock), sr)
+		userService := services.NewUserService(nil, ur)
+		authService := services.NewAuthService(sessionService, userService, nil)
+		adminHandler := handlers.NewAdminHandler(authService)
+		authHandler := handlers.NewAuthHandler(authService, rc, nil)
+		mw := NewAuthMiddleware(new(mocks.KeyringMock), rc, sessionService, pats)
+
+		status := models.UserStatusActive
+		session := &models.Session{
+			ID:         "session-1",
+			UserID:     "user-1",
+			VerifiedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			ExpiresAt:  time.Now().UTC().Add(time.Hour),
+		}
+
+		ur.On("GetUserByID", mock.Anything, "user-1").Return(&models.User{ID: "user-1"}, nil)
+		ur.On("BanUser", mock.Anything, "user-1", mock.AnythingOfType("time.Time")).
+			Run(func(mock.Arguments) { status = models.UserStatusBanned }).
+			Return(nil)
+		sr.On("RevokeAllSessionsByUserID", mock.Anything, "user-1", mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) {
+				session.RevokedAt = sql.NullTime{Time: args.Get(2).(time.Time), Valid: true}
+			}).
+			Return(nil)
+		sr.On("GetUserStatus", mock.Anything, "user-1").
+			Return(func(context.Context, string) models.UserStatus { return status }, nil)
+		sr.On("GetRefreshTokenByHash", mock.Anything, mock.AnythingOfType("string")).
+			Return(&models.RefreshToken{SessionID: "session-1"}, nil)
+		sr.On("GetSessionById", mock.Anything, "session-1").Return(session, nil)
+		pats.On("Authenticate", mock.Anything, patStr).
+			Return(&models.PersonalAccessToken{ID: "pat-1", UserID: "user-1", Scopes: []models.TokenScope{models.ScopePostsRead}}, nil)
+
+		usePersonalAccessToken := func() int {
+			req := httptest.NewRequest(http.MethodGet, "/posts/post-1", nil)
+			req.Header.Set("Authorization", "Bearer "+patStr)
+			rr := httptest.NewRecorder()
+			mw.Scoped(models.ScopePostsRead, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})(rr, req)
+			return rr.Code
+		}
+
+		assert.Equal(t, http.StatusOK, usePersonalAccessToken())
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/user-1/ban", nil)
+		req.SetPathValue("userId", "user-1")
+		req.Header.Set(AdminKeyHeader, "admin-key")
+		rr := httptest.NewRecorder()
+		AdminOnly(adminHandler.BanUser)(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, http.StatusForbidden, usePersonalAccessToken())
+
+		req = httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refresh_token":"refresh-token"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rr = httptest.NewRecorder()
+		authHandler.RefreshToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "invalid_refresh_token")
+	})
+}
@@ -1,9 +1,14 @@
 package middlewares
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 
-	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/handlers"
 	"github.com/g-villarinho/tab-notes-api/models"
 	"github.com/g-villarinho/tab-notes-api/pkgs"
@@ -11,76 +16,236 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AuthMiddleware authenticates requests by session token or personal access
+// token. Authenticated and OptionalAuth only accept session tokens; routes
+// that personal access tokens may reach use Scoped or OptionalScoped with the
+// scope the token must carry.
 type AuthMiddleware interface {
 	Authenticated(next http.HandlerFunc) http.HandlerFunc
+	OptionalAuth(next http.HandlerFunc) http.HandlerFunc
+	Scoped(scope models.TokenScope, next http.HandlerFunc) http.HandlerFunc
+	OptionalScoped(scope models.TokenScope, next http.HandlerFunc) http.HandlerFunc
 }
 
 type authMiddleware struct {
-	kp pkgs.EcdsaKeyPair
-	rc pkgs.RequestContext
-	ss services.SessionService
+	kr   pkgs.Keyring
+	rc   pkgs.RequestContext
+	ss   services.SessionService
+	pats services.PersonalAccessTokenService
 }
 
 func NewAuthMiddleware(
-	ecdsaKeyPair pkgs.EcdsaKeyPair,
+	keyring pkgs.Keyring,
 	requestContext pkgs.RequestContext,
-	sessionService services.SessionService) AuthMiddleware {
+	sessionService services.SessionService,
+	personalAccessTokenService services.PersonalAccessTokenService) AuthMiddleware {
 	return &authMiddleware{
-		kp: ecdsaKeyPair,
-		rc: requestContext,
-		ss: sessionService,
+		kr:   keyring,
+		rc:   requestContext,
+		ss:   sessionService,
+		pats: personalAccessTokenService,
 	}
 }
 
+var (
+	errMissingToken = errors.New("missing token")
+	errInvalidToken = errors.New("invalid token")
+)
+
+const bearerPrefix = "Bearer "
+
 func (a *authMiddleware) Authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return a.Scoped("", next)
+}
+
+// OptionalAuth populates the request context like Authenticated when the
+// request carries a token of an active session, and otherwise lets the
+// request through anonymously, so handlers see no user ID.
+func (a *authMiddleware) OptionalAuth(next http.HandlerFunc) http.HandlerFunc {
+	return a.OptionalScoped("", next)
+}
+
+// Scoped is Authenticated for routes that also accept personal access tokens
+// carrying scope.
+func (a *authMiddleware) Scoped(scope models.TokenScope, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		tokenStr, err := handlers.GetTokenCookie(r)
+		ctx, err := a.authenticate(r, scope)
 		if err != nil {
-			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
+			switch {
+			case errors.Is(err, errMissingToken):
+				handlers.Unauthorized(w, r)
+			case errors.Is(err, errInvalidToken):
+				clearInvalidToken(w, r)
+				handlers.Unauthorized(w, r)
+			default:
+				writeAuthError(w, r, scope, err)
+			}
 			return
 		}
 
-		if tokenStr == "" {
-			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
-			return
-		}
+		next(w, r.WithContext(ctx))
+	}
+}
 
-		publicKey, err := a.kp.ParseECDSAPublicKey(configs.Env.Key.PublicKey)
+// OptionalScoped is OptionalAuth for routes that also accept personal access
+// tokens carrying scope. A personal access token without it is refused rather
+// than treated as anonymous, since its owner asked to act as themselves.
+func (a *authMiddleware) OptionalScoped(scope models.TokenScope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := a.authenticate(r, scope)
 		if err != nil {
-			http.Error(w, "UNAUTHORIZED", http.StatusInternalServerError)
-			handlers.DeleteTokenCookie(w)
+			switch {
+			case errors.Is(err, models.ErrInsufficientScope):
+				writeAuthError(w, r, scope, err)
+				return
+			case errors.Is(err, errInvalidToken):
+				clearInvalidToken(w, r)
+			case !errors.Is(err, errMissingToken):
+				slog.Warn("optional auth: continuing anonymously", "error", err)
+			}
+
+			next(w, r)
 			return
 		}
 
-		var claims models.AuthTokenClaims
-		token, err := jwt.ParseWithClaims(tokenStr, &claims, func(t *jwt.Token) (any, error) {
-			return publicKey, nil
-		})
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// authenticate validates the request token against an active session and
+// returns the request context carrying its token, session and user IDs.
+// Personal access tokens are checked for scope instead and carry no session;
+// an empty scope refuses them.
+func (a *authMiddleware) authenticate(r *http.Request, scope models.TokenScope) (context.Context, error) {
+	tokenStr, err := requestToken(r)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil || !token.Valid {
-			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
-			handlers.DeleteTokenCookie(w)
-			return
+	if strings.HasPrefix(tokenStr, models.PersonalAccessTokenPrefix) {
+		return a.authenticatePersonalAccessToken(r, tokenStr, scope)
+	}
+
+	var claims models.AuthTokenClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, a.kr.Keyfunc)
+
+	if err != nil || !token.Valid {
+		return nil, errInvalidToken
+	}
+
+	revoked, err := a.ss.IsSessionRevoked(r.Context(), claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("check session revoked: %w", err)
+	}
+
+	if revoked {
+		return nil, errInvalidToken
+	}
+
+	if err := a.ensureUserActive(r.Context(), claims.Subject); err != nil {
+		return nil, err
+	}
+
+	// A failed touch only leaves last_seen_at stale; it does not refuse the
+	// request.
+	if err := a.ss.TouchSession(r.Context(), claims.SessionID); err != nil {
+		slog.Warn("touch session", "session_id", claims.SessionID, "error", err)
+	}
+
+	ctx := a.rc.SetToken(r.Context(), tokenStr)
+	ctx = a.rc.SetSessionID(ctx, claims.SessionID)
+	ctx = a.rc.SetUserID(ctx, claims.Subject)
+
+	return ctx, nil
+}
+
+func (a *authMiddleware) authenticatePersonalAccessToken(r *http.Request, tokenStr string, scope models.TokenScope) (context.Context, error) {
+	pat, err := a.pats.Authenticate(r.Context(), tokenStr)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidPersonalAccessToken) {
+			return nil, errInvalidToken
 		}
+		return nil, fmt.Errorf("authenticate personal access token: %w", err)
+	}
 
-		revoked, err := a.ss.IsSessionRevoked(r.Context(), claims.SessionID)
-		if err != nil {
-			http.Error(w, "UNAUTHORIZED", http.StatusInternalServerError)
-			return
+	if scope == "" || !slices.Contains(pat.Scopes, scope) {
+		return nil, models.ErrInsufficientScope
+	}
+
+	if err := a.ensureUserActive(r.Context(), pat.UserID); err != nil {
+		return nil, err
+	}
+
+	ctx := a.rc.SetToken(r.Context(), tokenStr)
+	ctx = a.rc.SetUserID(ctx, pat.UserID)
+
+	return ctx, nil
+}
+
+// ensureUserActive refuses tokens of banned and inactive users with their
+// own errors. A token whose user is gone is just invalid.
+func (a *authMiddleware) ensureUserActive(ctx context.Context, userID string) error {
+	err := a.ss.EnsureUserActive(ctx, userID)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, models.ErrUserNotFound):
+		return errInvalidToken
+	case errors.Is(err, models.ErrUserBanned), errors.Is(err, models.ErrUserInactive):
+		return err
+	default:
+		return fmt.Errorf("ensure user active: %w", err)
+	}
+}
+
+// requestToken reads the token from the Authorization header, falling back
+// to the cookie only when the header is absent. A request sending both is
+// authenticated by the header alone, and a header that is not a Bearer
+// token is rejected rather than ignored.
+func requestToken(r *http.Request) (string, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			return "", errInvalidToken
 		}
 
-		if revoked {
-			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
-			handlers.DeleteTokenCookie(w)
-			return
+		tokenStr := strings.TrimSpace(header[len(bearerPrefix):])
+		if tokenStr == "" {
+			return "", errInvalidToken
 		}
 
-		ctx := a.rc.SetToken(r.Context(), tokenStr)
-		ctx = a.rc.SetSessionID(ctx, claims.SessionID)
-		ctx = a.rc.SetUserID(ctx, claims.Subject)
+		return tokenStr, nil
+	}
 
-		r = r.WithContext(ctx)
+	tokenStr, err := handlers.GetTokenCookie(r)
+	if err != nil || tokenStr == "" {
+		return "", errMissingToken
+	}
 
-		next(w, r)
+	return tokenStr, nil
+}
+
+// clearInvalidToken tells the client to drop a token that failed: bearer
+// clients get an RFC 6750 challenge and the cookie is only deleted when it
+// was the one presented.
+func clearInvalidToken(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "" {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		return
 	}
+
+	handlers.DeleteTokenCookie(w)
+}
+
+// writeAuthError answers a refused personal access token with an RFC 6750
+// insufficient_scope challenge naming the scope the route needs.
+func writeAuthError(w http.ResponseWriter, r *http.Request, scope models.TokenScope, err error) {
+	if errors.Is(err, models.ErrInsufficientScope) {
+		challenge := `Bearer error="insufficient_scope"`
+		if scope != "" {
+			challenge += fmt.Sprintf(`, scope="%s"`, scope)
+		}
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+
+	handlers.WriteError(w, r, err)
 }
@@ -10,7 +10,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/mocks"
 	"github.com/g-villarinho/tab-notes-api/models"
 	"github.com/g-villarinho/tab-notes-api/pkgs"
@@ -50,10 +49,10 @@ func generateValidJWT(t *testing.T, key *ecdsa.PrivateKey, sub string, sid strin
 
 func TestAuthMiddleware_Authenticated(t *testing.T) {
 	t.Run("should return 401 if token is missing", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
+		kr := new(mocks.KeyringMock)
 		rc := pkgs.NewRequestContext()
 		ss := new(mocks.SessionServiceMock)
-		mw := NewAuthMiddleware(kp, rc, ss)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		rr := httptest.NewRecorder()
@@ -68,17 +67,16 @@ func TestAuthMiddleware_Authenticated(t *testing.T) {
 	})
 
 	t.Run("should return 401 if token is invalid JWT", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
+		kr := new(mocks.KeyringMock)
 		rc := pkgs.NewRequestContext()
 		ss := new(mocks.SessionServiceMock)
-		mw := NewAuthMiddleware(kp, rc, ss)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.AddCookie(&http.Cookie{Name: "tabnews_id", Value: "invalid-token"})
 		rr := httptest.NewRecorder()
 
-		kp.On("ParseECDSAPublicKey", configs.Env.Key.PublicKey).
-			Return(&testPrivateKey.PublicKey, nil)
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
 
 		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			t.Fail()
@@ -90,10 +88,10 @@ func TestAuthMiddleware_Authenticated(t *testing.T) {
 	})
 
 	t.Run("should return 401 if session is revoked", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
+		kr := new(mocks.KeyringMock)
 		rc := pkgs.NewRequestContext()
 		ss := new(mocks.SessionServiceMock)
-		mw := NewAuthMiddleware(kp, rc, ss)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
 
 		tokenStr := generateValidJWT(t, testPrivateKey, "user-1", "sess-1")
 
@@ -101,8 +99,7 @@ func TestAuthMiddleware_Authenticated(t *testing.T) {
 		req.AddCookie(&http.Cookie{Name: "tabnews_id", Value: tokenStr})
 		rr := httptest.NewRecorder()
 
-		kp.On("ParseECDSAPublicKey", configs.Env.Key.PublicKey).
-			Return(&testPrivateKey.PublicKey, nil)
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
 
 		ss.On("IsSessionRevoked", mock.Anything, "sess-1").
 			Return(true, nil)
@@ -116,10 +113,10 @@ func TestAuthMiddleware_Authenticated(t *testing.T) {
 	})
 
 	t.Run("should call next if token is valid and session is active", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
+		kr := new(mocks.KeyringMock)
 		rc := pkgs.NewRequestContext()
 		ss := new(mocks.SessionServiceMock)
-		mw := NewAuthMiddleware(kp, rc, ss)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
 
 		tokenStr := generateValidJWT(t, testPrivateKey, "user-1", "sess-1")
 
@@ -127,11 +124,12 @@ func TestAuthMiddleware_Authenticated(t *testing.T) {
 		req.AddCookie(&http.Cookie{Name: "tabnews_id", Value: tokenStr})
 		rr := httptest.NewRecorder()
 
-		kp.On("ParseECDSAPublicKey", configs.Env.Key.PublicKey).
-			Return(&testPrivateKey.PublicKey, nil)
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
 
 		ss.On("IsSessionRevoked", mock.Anything, "sess-1").
 			Return(false, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(nil)
+		ss.On("TouchSession", mock.Anything, "sess-1").Return(nil)
 
 		called := false
 		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -148,3 +146,315 @@ func TestAuthMiddleware_Authenticated(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 	})
 }
+
+func TestAuthMiddleware_BearerToken(t *testing.T) {
+	t.Run("should authenticate a bearer token", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		tokenStr := generateValidJWT(t, testPrivateKey, "user-1", "sess-1")
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenStr)
+		rr := httptest.NewRecorder()
+
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
+		ss.On("IsSessionRevoked", mock.Anything, "sess-1").Return(false, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(nil)
+		ss.On("TouchSession", mock.Anything, "sess-1").Return(nil)
+
+		called := false
+		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			token, _ := rc.GetToken(r.Context())
+			assert.Equal(t, tokenStr, token)
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		assert.True(t, called)
+	})
+
+	t.Run("should return 403 if the user is banned", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+generateValidJWT(t, testPrivateKey, "user-1", "sess-1"))
+		rr := httptest.NewRecorder()
+
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
+		ss.On("IsSessionRevoked", mock.Anything, "sess-1").Return(false, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(models.ErrUserBanned)
+
+		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fail()
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "user_banned")
+		ss.AssertNotCalled(t, "TouchSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should prefer the header over the cookie", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		headerToken := generateValidJWT(t, testPrivateKey, "user-1", "sess-1")
+		cookieToken := generateValidJWT(t, testPrivateKey, "user-2", "sess-2")
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+headerToken)
+		req.AddCookie(&http.Cookie{Name: "tabnotes_id", Value: cookieToken})
+		rr := httptest.NewRecorder()
+
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
+		ss.On("IsSessionRevoked", mock.Anything, "sess-1").Return(false, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(nil)
+		ss.On("TouchSession", mock.Anything, "sess-1").Return(nil)
+
+		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := rc.GetUserID(r.Context())
+			assert.Equal(t, "user-1", userID)
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		ss.AssertNotCalled(t, "IsSessionRevoked", mock.Anything, "sess-2")
+	})
+
+	t.Run("should reject a non bearer header without falling back to the cookie", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		req.AddCookie(&http.Cookie{Name: "tabnotes_id", Value: generateValidJWT(t, testPrivateKey, "user-1", "sess-1")})
+		rr := httptest.NewRecorder()
+
+		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fail()
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, `Bearer error="invalid_token"`, rr.Header().Get("WWW-Authenticate"))
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+		kr.AssertNotCalled(t, "Keyfunc", mock.Anything)
+	})
+}
+
+func TestAuthMiddleware_OptionalAuth(t *testing.T) {
+	t.Run("should continue anonymously if token is missing", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+
+		called := false
+		handler := mw.OptionalAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			_, ok := rc.GetUserID(r.Context())
+			assert.False(t, ok)
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		assert.True(t, called)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		kr.AssertNotCalled(t, "Keyfunc", mock.Anything)
+	})
+
+	t.Run("should continue anonymously and clear the cookie if session is revoked", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		tokenStr := generateValidJWT(t, testPrivateKey, "user-1", "sess-1")
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "tabnotes_id", Value: tokenStr})
+		rr := httptest.NewRecorder()
+
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
+
+		ss.On("IsSessionRevoked", mock.Anything, "sess-1").
+			Return(true, nil)
+
+		called := false
+		handler := mw.OptionalAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			_, ok := rc.GetUserID(r.Context())
+			assert.False(t, ok)
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		assert.True(t, called)
+		assert.Contains(t, rr.Header().Get("Set-Cookie"), "tabnotes_id=")
+	})
+
+	t.Run("should populate the context if session is active", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, nil)
+
+		tokenStr := generateValidJWT(t, testPrivateKey, "user-1", "sess-1")
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "tabnotes_id", Value: tokenStr})
+		rr := httptest.NewRecorder()
+
+		kr.On("Keyfunc", mock.Anything).Return(&testPrivateKey.PublicKey, nil)
+
+		ss.On("IsSessionRevoked", mock.Anything, "sess-1").
+			Return(false, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(nil)
+		ss.On("TouchSession", mock.Anything, "sess-1").Return(nil)
+
+		called := false
+		handler := mw.OptionalAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			userID, ok := rc.GetUserID(r.Context())
+			assert.True(t, ok)
+			assert.Equal(t, "user-1", userID)
+		}))
+
+		handler.ServeHTTP(rr, req)
+
+		assert.True(t, called)
+	})
+}
+
+func TestAuthMiddleware_PersonalAccessToken(t *testing.T) {
+	const patStr = models.PersonalAccessTokenPrefix + "secret"
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+patStr)
+		return req
+	}
+
+	pat := &models.PersonalAccessToken{
+		ID:     "pat-1",
+		UserID: "user-1",
+		Scopes: []models.TokenScope{models.ScopePostsRead},
+	}
+
+	t.Run("should populate the user without a session if the token has the scope", func(t *testing.T) {
+		kr := new(mocks.KeyringMock)
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		pats := new(mocks.PersonalAccessTokenServiceMock)
+		mw := NewAuthMiddleware(kr, rc, ss, pats)
+
+		pats.On("Authenticate", mock.Anything, patStr).Return(pat, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(nil)
+
+		called := false
+		handler := mw.Scoped(models.ScopePostsRead, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			userID, ok := rc.GetUserID(r.Context())
+			assert.True(t, ok)
+			assert.Equal(t, "user-1", userID)
+			_, ok = rc.GetSessionID(r.Context())
+			assert.False(t, ok)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest())
+
+		assert.True(t, called)
+		kr.AssertNotCalled(t, "Keyfunc", mock.Anything)
+		ss.AssertNotCalled(t, "IsSessionRevoked", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 403 with a challenge if the token lacks the scope", func(t *testing.T) {
+		rc := pkgs.NewRequestContext()
+		pats := new(mocks.PersonalAccessTokenServiceMock)
+		mw := NewAuthMiddleware(new(mocks.KeyringMock), rc, new(mocks.SessionServiceMock), pats)
+
+		pats.On("Authenticate", mock.Anything, patStr).Return(pat, nil)
+
+		rr := httptest.NewRecorder()
+		handler := mw.OptionalScoped(models.ScopePostsWrite, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fail()
+		}))
+
+		handler.ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, `Bearer error="insufficient_scope", scope="posts:write"`, rr.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("should return 403 on session only routes", func(t *testing.T) {
+		rc := pkgs.NewRequestContext()
+		pats := new(mocks.PersonalAccessTokenServiceMock)
+		mw := NewAuthMiddleware(new(mocks.KeyringMock), rc, new(mocks.SessionServiceMock), pats)
+
+		pats.On("Authenticate", mock.Anything, patStr).Return(pat, nil)
+
+		rr := httptest.NewRecorder()
+		handler := mw.Authenticated(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fail()
+		}))
+
+		handler.ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return 403 if the token owner is banned", func(t *testing.T) {
+		rc := pkgs.NewRequestContext()
+		ss := new(mocks.SessionServiceMock)
+		pats := new(mocks.PersonalAccessTokenServiceMock)
+		mw := NewAuthMiddleware(new(mocks.KeyringMock), rc, ss, pats)
+
+		pats.On("Authenticate", mock.Anything, patStr).Return(pat, nil)
+		ss.On("EnsureUserActive", mock.Anything, "user-1").Return(models.ErrUserBanned)
+
+		rr := httptest.NewRecorder()
+		handler := mw.Scoped(models.ScopePostsRead, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fail()
+		}))
+
+		handler.ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "user_banned")
+	})
+
+	t.Run("should return 401 if the token is revoked or expired", func(t *testing.T) {
+		rc := pkgs.NewRequestContext()
+		pats := new(mocks.PersonalAccessTokenServiceMock)
+		mw := NewAuthMiddleware(new(mocks.KeyringMock), rc, new(mocks.SessionServiceMock), pats)
+
+		pats.On("Authenticate", mock.Anything, patStr).Return(nil, models.ErrInvalidPersonalAccessToken)
+
+		rr := httptest.NewRecorder()
+		handler := mw.Scoped(models.ScopePostsRead, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fail()
+		}))
+
+		handler.ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, `Bearer error="invalid_token"`, rr.Header().Get("WWW-Authenticate"))
+	})
+}
@@ -3,6 +3,8 @@ package middlewares
 import (
 	"log"
 	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/handlers"
 )
 
 func Recovery(next http.Handler) http.Handler {
@@ -10,7 +12,7 @@ func Recovery(next http.Handler) http.Handler {
 		defer func() {
 			if rec := recover(); rec != nil {
 				log.Printf("[Recovery] panic: %v\n", rec)
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				handlers.WriteProblem(w, r, http.StatusInternalServerError, handlers.CodeInternalError, "")
 			}
 		}()
 
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/g-villarinho/tab-notes-api/handlers"
+	"github.com/google/uuid"
+)
+
+const maxRequestIDLength = 128
+
+// RequestID echoes the caller's X-Request-ID, or generates one, on the
+// response headers so logs and problem responses can be correlated.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(handlers.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+			r.Header.Set(handlers.RequestIDHeader, requestID)
+		}
+
+		w.Header().Set(handlers.RequestIDHeader, requestID)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("should echo the caller request id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", "abc-123")
+		rr := httptest.NewRecorder()
+
+		RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rr, req)
+
+		assert.Equal(t, "abc-123", rr.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("should generate a request id when missing or invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", "bad id\n")
+		rr := httptest.NewRecorder()
+
+		RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rr, req)
+
+		requestID := rr.Header().Get("X-Request-ID")
+		assert.NotEmpty(t, requestID)
+		assert.NotEqual(t, "bad id\n", requestID)
+	})
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ActivityPubClientMock is an autogenerated mock type for the ActivityPubClient type
+type ActivityPubClientMock struct {
+	mock.Mock
+}
+
+type ActivityPubClientMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ActivityPubClientMock) EXPECT() *ActivityPubClientMock_Expecter {
+	return &ActivityPubClientMock_Expecter{mock: &_m.Mock}
+}
+
+// Deliver provides a mock function with given fields: ctx, delivery
+func (_m *ActivityPubClientMock) Deliver(ctx context.Context, delivery *models.Delivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deliver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Delivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ActivityPubClientMock_Deliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deliver'
+type ActivityPubClientMock_Deliver_Call struct {
+	*mock.Call
+}
+
+// Deliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *models.Delivery
+func (_e *ActivityPubClientMock_Expecter) Deliver(ctx interface{}, delivery interface{}) *ActivityPubClientMock_Deliver_Call {
+	return &ActivityPubClientMock_Deliver_Call{Call: _e.mock.On("Deliver", ctx, delivery)}
+}
+
+func (_c *ActivityPubClientMock_Deliver_Call) Run(run func(ctx context.Context, delivery *models.Delivery)) *ActivityPubClientMock_Deliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Delivery))
+	})
+	return _c
+}
+
+func (_c *ActivityPubClientMock_Deliver_Call) Return(_a0 error) *ActivityPubClientMock_Deliver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ActivityPubClientMock_Deliver_Call) RunAndReturn(run func(context.Context, *models.Delivery) error) *ActivityPubClientMock_Deliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchActor provides a mock function with given fields: ctx, actorURI
+func (_m *ActivityPubClientMock) FetchActor(ctx context.Context, actorURI string) (*models.Actor, error) {
+	ret := _m.Called(ctx, actorURI)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchActor")
+	}
+
+	var r0 *models.Actor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Actor, error)); ok {
+		return rf(ctx, actorURI)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Actor); ok {
+		r0 = rf(ctx, actorURI)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Actor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, actorURI)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActivityPubClientMock_FetchActor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchActor'
+type ActivityPubClientMock_FetchActor_Call struct {
+	*mock.Call
+}
+
+// FetchActor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorURI string
+func (_e *ActivityPubClientMock_Expecter) FetchActor(ctx interface{}, actorURI interface{}) *ActivityPubClientMock_FetchActor_Call {
+	return &ActivityPubClientMock_FetchActor_Call{Call: _e.mock.On("FetchActor", ctx, actorURI)}
+}
+
+func (_c *ActivityPubClientMock_FetchActor_Call) Run(run func(ctx context.Context, actorURI string)) *ActivityPubClientMock_FetchActor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ActivityPubClientMock_FetchActor_Call) Return(_a0 *models.Actor, _a1 error) *ActivityPubClientMock_FetchActor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ActivityPubClientMock_FetchActor_Call) RunAndReturn(run func(context.Context, string) (*models.Actor, error)) *ActivityPubClientMock_FetchActor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewActivityPubClientMock creates a new instance of ActivityPubClientMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewActivityPubClientMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ActivityPubClientMock {
+	mock := &ActivityPubClientMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,206 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ActivityPubHandlerMock is an autogenerated mock type for the ActivityPubHandler type
+type ActivityPubHandlerMock struct {
+	mock.Mock
+}
+
+type ActivityPubHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ActivityPubHandlerMock) EXPECT() *ActivityPubHandlerMock_Expecter {
+	return &ActivityPubHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// GetActor provides a mock function with given fields: w, r
+func (_m *ActivityPubHandlerMock) GetActor(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ActivityPubHandlerMock_GetActor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActor'
+type ActivityPubHandlerMock_GetActor_Call struct {
+	*mock.Call
+}
+
+// GetActor is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ActivityPubHandlerMock_Expecter) GetActor(w interface{}, r interface{}) *ActivityPubHandlerMock_GetActor_Call {
+	return &ActivityPubHandlerMock_GetActor_Call{Call: _e.mock.On("GetActor", w, r)}
+}
+
+func (_c *ActivityPubHandlerMock_GetActor_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ActivityPubHandlerMock_GetActor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_GetActor_Call) Return() *ActivityPubHandlerMock_GetActor_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_GetActor_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ActivityPubHandlerMock_GetActor_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetNote provides a mock function with given fields: w, r
+func (_m *ActivityPubHandlerMock) GetNote(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ActivityPubHandlerMock_GetNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNote'
+type ActivityPubHandlerMock_GetNote_Call struct {
+	*mock.Call
+}
+
+// GetNote is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ActivityPubHandlerMock_Expecter) GetNote(w interface{}, r interface{}) *ActivityPubHandlerMock_GetNote_Call {
+	return &ActivityPubHandlerMock_GetNote_Call{Call: _e.mock.On("GetNote", w, r)}
+}
+
+func (_c *ActivityPubHandlerMock_GetNote_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ActivityPubHandlerMock_GetNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_GetNote_Call) Return() *ActivityPubHandlerMock_GetNote_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_GetNote_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ActivityPubHandlerMock_GetNote_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetOutbox provides a mock function with given fields: w, r
+func (_m *ActivityPubHandlerMock) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ActivityPubHandlerMock_GetOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOutbox'
+type ActivityPubHandlerMock_GetOutbox_Call struct {
+	*mock.Call
+}
+
+// GetOutbox is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ActivityPubHandlerMock_Expecter) GetOutbox(w interface{}, r interface{}) *ActivityPubHandlerMock_GetOutbox_Call {
+	return &ActivityPubHandlerMock_GetOutbox_Call{Call: _e.mock.On("GetOutbox", w, r)}
+}
+
+func (_c *ActivityPubHandlerMock_GetOutbox_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ActivityPubHandlerMock_GetOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_GetOutbox_Call) Return() *ActivityPubHandlerMock_GetOutbox_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_GetOutbox_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ActivityPubHandlerMock_GetOutbox_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostInbox provides a mock function with given fields: w, r
+func (_m *ActivityPubHandlerMock) PostInbox(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ActivityPubHandlerMock_PostInbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostInbox'
+type ActivityPubHandlerMock_PostInbox_Call struct {
+	*mock.Call
+}
+
+// PostInbox is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ActivityPubHandlerMock_Expecter) PostInbox(w interface{}, r interface{}) *ActivityPubHandlerMock_PostInbox_Call {
+	return &ActivityPubHandlerMock_PostInbox_Call{Call: _e.mock.On("PostInbox", w, r)}
+}
+
+func (_c *ActivityPubHandlerMock_PostInbox_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ActivityPubHandlerMock_PostInbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_PostInbox_Call) Return() *ActivityPubHandlerMock_PostInbox_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_PostInbox_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ActivityPubHandlerMock_PostInbox_Call {
+	_c.Run(run)
+	return _c
+}
+
+// WebFinger provides a mock function with given fields: w, r
+func (_m *ActivityPubHandlerMock) WebFinger(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ActivityPubHandlerMock_WebFinger_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebFinger'
+type ActivityPubHandlerMock_WebFinger_Call struct {
+	*mock.Call
+}
+
+// WebFinger is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ActivityPubHandlerMock_Expecter) WebFinger(w interface{}, r interface{}) *ActivityPubHandlerMock_WebFinger_Call {
+	return &ActivityPubHandlerMock_WebFinger_Call{Call: _e.mock.On("WebFinger", w, r)}
+}
+
+func (_c *ActivityPubHandlerMock_WebFinger_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ActivityPubHandlerMock_WebFinger_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_WebFinger_Call) Return() *ActivityPubHandlerMock_WebFinger_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ActivityPubHandlerMock_WebFinger_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ActivityPubHandlerMock_WebFinger_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewActivityPubHandlerMock creates a new instance of ActivityPubHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewActivityPubHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ActivityPubHandlerMock {
+	mock := &ActivityPubHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
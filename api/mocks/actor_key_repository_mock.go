@@ -0,0 +1,143 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ActorKeyRepositoryMock is an autogenerated mock type for the ActorKeyRepository type
+type ActorKeyRepositoryMock struct {
+	mock.Mock
+}
+
+type ActorKeyRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ActorKeyRepositoryMock) EXPECT() *ActorKeyRepositoryMock_Expecter {
+	return &ActorKeyRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateActorKey provides a mock function with given fields: ctx, key
+func (_m *ActorKeyRepositoryMock) CreateActorKey(ctx context.Context, key *models.ActorKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateActorKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ActorKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ActorKeyRepositoryMock_CreateActorKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateActorKey'
+type ActorKeyRepositoryMock_CreateActorKey_Call struct {
+	*mock.Call
+}
+
+// CreateActorKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *models.ActorKey
+func (_e *ActorKeyRepositoryMock_Expecter) CreateActorKey(ctx interface{}, key interface{}) *ActorKeyRepositoryMock_CreateActorKey_Call {
+	return &ActorKeyRepositoryMock_CreateActorKey_Call{Call: _e.mock.On("CreateActorKey", ctx, key)}
+}
+
+func (_c *ActorKeyRepositoryMock_CreateActorKey_Call) Run(run func(ctx context.Context, key *models.ActorKey)) *ActorKeyRepositoryMock_CreateActorKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ActorKey))
+	})
+	return _c
+}
+
+func (_c *ActorKeyRepositoryMock_CreateActorKey_Call) Return(_a0 error) *ActorKeyRepositoryMock_CreateActorKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ActorKeyRepositoryMock_CreateActorKey_Call) RunAndReturn(run func(context.Context, *models.ActorKey) error) *ActorKeyRepositoryMock_CreateActorKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActorKeyByUserID provides a mock function with given fields: ctx, userID
+func (_m *ActorKeyRepositoryMock) GetActorKeyByUserID(ctx context.Context, userID string) (*models.ActorKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActorKeyByUserID")
+	}
+
+	var r0 *models.ActorKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ActorKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ActorKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ActorKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActorKeyRepositoryMock_GetActorKeyByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActorKeyByUserID'
+type ActorKeyRepositoryMock_GetActorKeyByUserID_Call struct {
+	*mock.Call
+}
+
+// GetActorKeyByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ActorKeyRepositoryMock_Expecter) GetActorKeyByUserID(ctx interface{}, userID interface{}) *ActorKeyRepositoryMock_GetActorKeyByUserID_Call {
+	return &ActorKeyRepositoryMock_GetActorKeyByUserID_Call{Call: _e.mock.On("GetActorKeyByUserID", ctx, userID)}
+}
+
+func (_c *ActorKeyRepositoryMock_GetActorKeyByUserID_Call) Run(run func(ctx context.Context, userID string)) *ActorKeyRepositoryMock_GetActorKeyByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ActorKeyRepositoryMock_GetActorKeyByUserID_Call) Return(_a0 *models.ActorKey, _a1 error) *ActorKeyRepositoryMock_GetActorKeyByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ActorKeyRepositoryMock_GetActorKeyByUserID_Call) RunAndReturn(run func(context.Context, string) (*models.ActorKey, error)) *ActorKeyRepositoryMock_GetActorKeyByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewActorKeyRepositoryMock creates a new instance of ActorKeyRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewActorKeyRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ActorKeyRepositoryMock {
+	mock := &ActorKeyRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
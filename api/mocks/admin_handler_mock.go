@@ -0,0 +1,70 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminHandlerMock is an autogenerated mock type for the AdminHandler type
+type AdminHandlerMock struct {
+	mock.Mock
+}
+
+type AdminHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminHandlerMock) EXPECT() *AdminHandlerMock_Expecter {
+	return &AdminHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// BanUser provides a mock function with given fields: w, r
+func (_m *AdminHandlerMock) BanUser(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// AdminHandlerMock_BanUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanUser'
+type AdminHandlerMock_BanUser_Call struct {
+	*mock.Call
+}
+
+// BanUser is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *AdminHandlerMock_Expecter) BanUser(w interface{}, r interface{}) *AdminHandlerMock_BanUser_Call {
+	return &AdminHandlerMock_BanUser_Call{Call: _e.mock.On("BanUser", w, r)}
+}
+
+func (_c *AdminHandlerMock_BanUser_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *AdminHandlerMock_BanUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *AdminHandlerMock_BanUser_Call) Return() *AdminHandlerMock_BanUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AdminHandlerMock_BanUser_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *AdminHandlerMock_BanUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewAdminHandlerMock creates a new instance of AdminHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminHandlerMock {
+	mock := &AdminHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
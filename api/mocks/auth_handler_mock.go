@@ -21,6 +21,40 @@ func (_m *AuthHandlerMock) EXPECT() *AuthHandlerMock_Expecter {
 	return &AuthHandlerMock_Expecter{mock: &_m.Mock}
 }
 
+// ApproveLogin provides a mock function with given fields: w, r
+func (_m *AuthHandlerMock) ApproveLogin(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// AuthHandlerMock_ApproveLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveLogin'
+type AuthHandlerMock_ApproveLogin_Call struct {
+	*mock.Call
+}
+
+// ApproveLogin is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *AuthHandlerMock_Expecter) ApproveLogin(w interface{}, r interface{}) *AuthHandlerMock_ApproveLogin_Call {
+	return &AuthHandlerMock_ApproveLogin_Call{Call: _e.mock.On("ApproveLogin", w, r)}
+}
+
+func (_c *AuthHandlerMock_ApproveLogin_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *AuthHandlerMock_ApproveLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *AuthHandlerMock_ApproveLogin_Call) Return() *AuthHandlerMock_ApproveLogin_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AuthHandlerMock_ApproveLogin_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *AuthHandlerMock_ApproveLogin_Call {
+	_c.Run(run)
+	return _c
+}
+
 // AuthenticateFromLink provides a mock function with given fields: w, r
 func (_m *AuthHandlerMock) AuthenticateFromLink(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -55,6 +89,74 @@ func (_c *AuthHandlerMock_AuthenticateFromLink_Call) RunAndReturn(run func(http.
 	return _c
 }
 
+// AuthenticateWithCode provides a mock function with given fields: w, r
+func (_m *AuthHandlerMock) AuthenticateWithCode(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// AuthHandlerMock_AuthenticateWithCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateWithCode'
+type AuthHandlerMock_AuthenticateWithCode_Call struct {
+	*mock.Call
+}
+
+// AuthenticateWithCode is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *AuthHandlerMock_Expecter) AuthenticateWithCode(w interface{}, r interface{}) *AuthHandlerMock_AuthenticateWithCode_Call {
+	return &AuthHandlerMock_AuthenticateWithCode_Call{Call: _e.mock.On("AuthenticateWithCode", w, r)}
+}
+
+func (_c *AuthHandlerMock_AuthenticateWithCode_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *AuthHandlerMock_AuthenticateWithCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *AuthHandlerMock_AuthenticateWithCode_Call) Return() *AuthHandlerMock_AuthenticateWithCode_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AuthHandlerMock_AuthenticateWithCode_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *AuthHandlerMock_AuthenticateWithCode_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ExchangeLinkToken provides a mock function with given fields: w, r
+func (_m *AuthHandlerMock) ExchangeLinkToken(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// AuthHandlerMock_ExchangeLinkToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExchangeLinkToken'
+type AuthHandlerMock_ExchangeLinkToken_Call struct {
+	*mock.Call
+}
+
+// ExchangeLinkToken is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *AuthHandlerMock_Expecter) ExchangeLinkToken(w interface{}, r interface{}) *AuthHandlerMock_ExchangeLinkToken_Call {
+	return &AuthHandlerMock_ExchangeLinkToken_Call{Call: _e.mock.On("ExchangeLinkToken", w, r)}
+}
+
+func (_c *AuthHandlerMock_ExchangeLinkToken_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *AuthHandlerMock_ExchangeLinkToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *AuthHandlerMock_ExchangeLinkToken_Call) Return() *AuthHandlerMock_ExchangeLinkToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AuthHandlerMock_ExchangeLinkToken_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *AuthHandlerMock_ExchangeLinkToken_Call {
+	_c.Run(run)
+	return _c
+}
+
 // Logout provides a mock function with given fields: w, r
 func (_m *AuthHandlerMock) Logout(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -89,6 +191,74 @@ func (_c *AuthHandlerMock_Logout_Call) RunAndReturn(run func(http.ResponseWriter
 	return _c
 }
 
+// PollPendingLogin provides a mock function with given fields: w, r
+func (_m *AuthHandlerMock) PollPendingLogin(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// AuthHandlerMock_PollPendingLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PollPendingLogin'
+type AuthHandlerMock_PollPendingLogin_Call struct {
+	*mock.Call
+}
+
+// PollPendingLogin is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *AuthHandlerMock_Expecter) PollPendingLogin(w interface{}, r interface{}) *AuthHandlerMock_PollPendingLogin_Call {
+	return &AuthHandlerMock_PollPendingLogin_Call{Call: _e.mock.On("PollPendingLogin", w, r)}
+}
+
+func (_c *AuthHandlerMock_PollPendingLogin_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *AuthHandlerMock_PollPendingLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *AuthHandlerMock_PollPendingLogin_Call) Return() *AuthHandlerMock_PollPendingLogin_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AuthHandlerMock_PollPendingLogin_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *AuthHandlerMock_PollPendingLogin_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RefreshToken provides a mock function with given fields: w, r
+func (_m *AuthHandlerMock) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// AuthHandlerMock_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type AuthHandlerMock_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *AuthHandlerMock_Expecter) RefreshToken(w interface{}, r interface{}) *AuthHandlerMock_RefreshToken_Call {
+	return &AuthHandlerMock_RefreshToken_Call{Call: _e.mock.On("RefreshToken", w, r)}
+}
+
+func (_c *AuthHandlerMock_RefreshToken_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *AuthHandlerMock_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *AuthHandlerMock_RefreshToken_Call) Return() *AuthHandlerMock_RefreshToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AuthHandlerMock_RefreshToken_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *AuthHandlerMock_RefreshToken_Call {
+	_c.Run(run)
+	return _c
+}
+
 // SendAuthenticationLink provides a mock function with given fields: w, r
 func (_m *AuthHandlerMock) SendAuthenticationLink(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -6,6 +6,8 @@ import (
 	http "net/http"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
 )
 
 // AuthMiddlewareMock is an autogenerated mock type for the AuthMiddleware type
@@ -69,6 +71,152 @@ func (_c *AuthMiddlewareMock_Authenticated_Call) RunAndReturn(run func(http.Hand
 	return _c
 }
 
+// OptionalAuth provides a mock function with given fields: next
+func (_m *AuthMiddlewareMock) OptionalAuth(next http.HandlerFunc) http.HandlerFunc {
+	ret := _m.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OptionalAuth")
+	}
+
+	var r0 http.HandlerFunc
+	if rf, ok := ret.Get(0).(func(http.HandlerFunc) http.HandlerFunc); ok {
+		r0 = rf(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// AuthMiddlewareMock_OptionalAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OptionalAuth'
+type AuthMiddlewareMock_OptionalAuth_Call struct {
+	*mock.Call
+}
+
+// OptionalAuth is a helper method to define mock.On call
+//   - next http.HandlerFunc
+func (_e *AuthMiddlewareMock_Expecter) OptionalAuth(next interface{}) *AuthMiddlewareMock_OptionalAuth_Call {
+	return &AuthMiddlewareMock_OptionalAuth_Call{Call: _e.mock.On("OptionalAuth", next)}
+}
+
+func (_c *AuthMiddlewareMock_OptionalAuth_Call) Run(run func(next http.HandlerFunc)) *AuthMiddlewareMock_OptionalAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *AuthMiddlewareMock_OptionalAuth_Call) Return(_a0 http.HandlerFunc) *AuthMiddlewareMock_OptionalAuth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthMiddlewareMock_OptionalAuth_Call) RunAndReturn(run func(http.HandlerFunc) http.HandlerFunc) *AuthMiddlewareMock_OptionalAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OptionalScoped provides a mock function with given fields: scope, next
+func (_m *AuthMiddlewareMock) OptionalScoped(scope models.TokenScope, next http.HandlerFunc) http.HandlerFunc {
+	ret := _m.Called(scope, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OptionalScoped")
+	}
+
+	var r0 http.HandlerFunc
+	if rf, ok := ret.Get(0).(func(models.TokenScope, http.HandlerFunc) http.HandlerFunc); ok {
+		r0 = rf(scope, next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// AuthMiddlewareMock_OptionalScoped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OptionalScoped'
+type AuthMiddlewareMock_OptionalScoped_Call struct {
+	*mock.Call
+}
+
+// OptionalScoped is a helper method to define mock.On call
+//   - scope models.TokenScope
+//   - next http.HandlerFunc
+func (_e *AuthMiddlewareMock_Expecter) OptionalScoped(scope interface{}, next interface{}) *AuthMiddlewareMock_OptionalScoped_Call {
+	return &AuthMiddlewareMock_OptionalScoped_Call{Call: _e.mock.On("OptionalScoped", scope, next)}
+}
+
+func (_c *AuthMiddlewareMock_OptionalScoped_Call) Run(run func(scope models.TokenScope, next http.HandlerFunc)) *AuthMiddlewareMock_OptionalScoped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.TokenScope), args[1].(http.HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *AuthMiddlewareMock_OptionalScoped_Call) Return(_a0 http.HandlerFunc) *AuthMiddlewareMock_OptionalScoped_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthMiddlewareMock_OptionalScoped_Call) RunAndReturn(run func(models.TokenScope, http.HandlerFunc) http.HandlerFunc) *AuthMiddlewareMock_OptionalScoped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Scoped provides a mock function with given fields: scope, next
+func (_m *AuthMiddlewareMock) Scoped(scope models.TokenScope, next http.HandlerFunc) http.HandlerFunc {
+	ret := _m.Called(scope, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scoped")
+	}
+
+	var r0 http.HandlerFunc
+	if rf, ok := ret.Get(0).(func(models.TokenScope, http.HandlerFunc) http.HandlerFunc); ok {
+		r0 = rf(scope, next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// AuthMiddlewareMock_Scoped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scoped'
+type AuthMiddlewareMock_Scoped_Call struct {
+	*mock.Call
+}
+
+// Scoped is a helper method to define mock.On call
+//   - scope models.TokenScope
+//   - next http.HandlerFunc
+func (_e *AuthMiddlewareMock_Expecter) Scoped(scope interface{}, next interface{}) *AuthMiddlewareMock_Scoped_Call {
+	return &AuthMiddlewareMock_Scoped_Call{Call: _e.mock.On("Scoped", scope, next)}
+}
+
+func (_c *AuthMiddlewareMock_Scoped_Call) Run(run func(scope models.TokenScope, next http.HandlerFunc)) *AuthMiddlewareMock_Scoped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.TokenScope), args[1].(http.HandlerFunc))
+	})
+	return _c
+}
+
+func (_c *AuthMiddlewareMock_Scoped_Call) Return(_a0 http.HandlerFunc) *AuthMiddlewareMock_Scoped_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthMiddlewareMock_Scoped_Call) RunAndReturn(run func(models.TokenScope, http.HandlerFunc) http.HandlerFunc) *AuthMiddlewareMock_Scoped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAuthMiddlewareMock creates a new instance of AuthMiddlewareMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthMiddlewareMock(t interface {
@@ -22,9 +22,57 @@ func (_m *AuthServiceMock) EXPECT() *AuthServiceMock_Expecter {
 	return &AuthServiceMock_Expecter{mock: &_m.Mock}
 }
 
-// AuthenticateFromLink provides a mock function with given fields: ctx, token
-func (_m *AuthServiceMock) AuthenticateFromLink(ctx context.Context, token string) (*models.AuthResponse, error) {
-	ret := _m.Called(ctx, token)
+// ApproveLogin provides a mock function with given fields: ctx, token, verificationCode
+func (_m *AuthServiceMock) ApproveLogin(ctx context.Context, token string, verificationCode string) error {
+	ret := _m.Called(ctx, token, verificationCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, verificationCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthServiceMock_ApproveLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveLogin'
+type AuthServiceMock_ApproveLogin_Call struct {
+	*mock.Call
+}
+
+// ApproveLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - verificationCode string
+func (_e *AuthServiceMock_Expecter) ApproveLogin(ctx interface{}, token interface{}, verificationCode interface{}) *AuthServiceMock_ApproveLogin_Call {
+	return &AuthServiceMock_ApproveLogin_Call{Call: _e.mock.On("ApproveLogin", ctx, token, verificationCode)}
+}
+
+func (_c *AuthServiceMock_ApproveLogin_Call) Run(run func(ctx context.Context, token string, verificationCode string)) *AuthServiceMock_ApproveLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthServiceMock_ApproveLogin_Call) Return(_a0 error) *AuthServiceMock_ApproveLogin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthServiceMock_ApproveLogin_Call) RunAndReturn(run func(context.Context, string, string) error) *AuthServiceMock_ApproveLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuthenticateFromLink provides a mock function with given fields: ctx, token, pollSecret, here, client
+func (_m *AuthServiceMock) AuthenticateFromLink(ctx context.Context, token string, pollSecret string, here bool, client *models.ClientInfo) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, token, pollSecret, here, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AuthenticateFromLink")
@@ -32,19 +80,19 @@ func (_m *AuthServiceMock) AuthenticateFromLink(ctx context.Context, token strin
 
 	var r0 *models.AuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AuthResponse, error)); ok {
-		return rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, *models.ClientInfo) (*models.AuthResponse, error)); ok {
+		return rf(ctx, token, pollSecret, here, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AuthResponse); ok {
-		r0 = rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, *models.ClientInfo) *models.AuthResponse); ok {
+		r0 = rf(ctx, token, pollSecret, here, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.AuthResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, *models.ClientInfo) error); ok {
+		r1 = rf(ctx, token, pollSecret, here, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,13 +108,16 @@ type AuthServiceMock_AuthenticateFromLink_Call struct {
 // AuthenticateFromLink is a helper method to define mock.On call
 //   - ctx context.Context
 //   - token string
-func (_e *AuthServiceMock_Expecter) AuthenticateFromLink(ctx interface{}, token interface{}) *AuthServiceMock_AuthenticateFromLink_Call {
-	return &AuthServiceMock_AuthenticateFromLink_Call{Call: _e.mock.On("AuthenticateFromLink", ctx, token)}
+//   - pollSecret string
+//   - here bool
+//   - client *models.ClientInfo
+func (_e *AuthServiceMock_Expecter) AuthenticateFromLink(ctx interface{}, token interface{}, pollSecret interface{}, here interface{}, client interface{}) *AuthServiceMock_AuthenticateFromLink_Call {
+	return &AuthServiceMock_AuthenticateFromLink_Call{Call: _e.mock.On("AuthenticateFromLink", ctx, token, pollSecret, here, client)}
 }
 
-func (_c *AuthServiceMock_AuthenticateFromLink_Call) Run(run func(ctx context.Context, token string)) *AuthServiceMock_AuthenticateFromLink_Call {
+func (_c *AuthServiceMock_AuthenticateFromLink_Call) Run(run func(ctx context.Context, token string, pollSecret string, here bool, client *models.ClientInfo)) *AuthServiceMock_AuthenticateFromLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool), args[4].(*models.ClientInfo))
 	})
 	return _c
 }
@@ -76,7 +127,115 @@ func (_c *AuthServiceMock_AuthenticateFromLink_Call) Return(_a0 *models.AuthResp
 	return _c
 }
 
-func (_c *AuthServiceMock_AuthenticateFromLink_Call) RunAndReturn(run func(context.Context, string) (*models.AuthResponse, error)) *AuthServiceMock_AuthenticateFromLink_Call {
+func (_c *AuthServiceMock_AuthenticateFromLink_Call) RunAndReturn(run func(context.Context, string, string, bool, *models.ClientInfo) (*models.AuthResponse, error)) *AuthServiceMock_AuthenticateFromLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuthenticateWithCode provides a mock function with given fields: ctx, email, code, client
+func (_m *AuthServiceMock) AuthenticateWithCode(ctx context.Context, email string, code string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, email, code, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateWithCode")
+	}
+
+	var r0 *models.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)); ok {
+		return rf(ctx, email, code, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) *models.AuthResponse); ok {
+		r0 = rf(ctx, email, code, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.ClientInfo) error); ok {
+		r1 = rf(ctx, email, code, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthServiceMock_AuthenticateWithCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateWithCode'
+type AuthServiceMock_AuthenticateWithCode_Call struct {
+	*mock.Call
+}
+
+// AuthenticateWithCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - code string
+//   - client *models.ClientInfo
+func (_e *AuthServiceMock_Expecter) AuthenticateWithCode(ctx interface{}, email interface{}, code interface{}, client interface{}) *AuthServiceMock_AuthenticateWithCode_Call {
+	return &AuthServiceMock_AuthenticateWithCode_Call{Call: _e.mock.On("AuthenticateWithCode", ctx, email, code, client)}
+}
+
+func (_c *AuthServiceMock_AuthenticateWithCode_Call) Run(run func(ctx context.Context, email string, code string, client *models.ClientInfo)) *AuthServiceMock_AuthenticateWithCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.ClientInfo))
+	})
+	return _c
+}
+
+func (_c *AuthServiceMock_AuthenticateWithCode_Call) Return(_a0 *models.AuthResponse, _a1 error) *AuthServiceMock_AuthenticateWithCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthServiceMock_AuthenticateWithCode_Call) RunAndReturn(run func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)) *AuthServiceMock_AuthenticateWithCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BanUser provides a mock function with given fields: ctx, userID
+func (_m *AuthServiceMock) BanUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BanUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthServiceMock_BanUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanUser'
+type AuthServiceMock_BanUser_Call struct {
+	*mock.Call
+}
+
+// BanUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *AuthServiceMock_Expecter) BanUser(ctx interface{}, userID interface{}) *AuthServiceMock_BanUser_Call {
+	return &AuthServiceMock_BanUser_Call{Call: _e.mock.On("BanUser", ctx, userID)}
+}
+
+func (_c *AuthServiceMock_BanUser_Call) Run(run func(ctx context.Context, userID string)) *AuthServiceMock_BanUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthServiceMock_BanUser_Call) Return(_a0 error) *AuthServiceMock_BanUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthServiceMock_BanUser_Call) RunAndReturn(run func(context.Context, string) error) *AuthServiceMock_BanUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -128,22 +287,154 @@ func (_c *AuthServiceMock_Logout_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
-// SendAuthenticationLink provides a mock function with given fields: ctx, email
-func (_m *AuthServiceMock) SendAuthenticationLink(ctx context.Context, email string) error {
-	ret := _m.Called(ctx, email)
+// PollLogin provides a mock function with given fields: ctx, pendingLoginID, pollSecret, client
+func (_m *AuthServiceMock) PollLogin(ctx context.Context, pendingLoginID string, pollSecret string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, pendingLoginID, pollSecret, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PollLogin")
+	}
+
+	var r0 *models.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)); ok {
+		return rf(ctx, pendingLoginID, pollSecret, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) *models.AuthResponse); ok {
+		r0 = rf(ctx, pendingLoginID, pollSecret, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.ClientInfo) error); ok {
+		r1 = rf(ctx, pendingLoginID, pollSecret, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthServiceMock_PollLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PollLogin'
+type AuthServiceMock_PollLogin_Call struct {
+	*mock.Call
+}
+
+// PollLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pendingLoginID string
+//   - pollSecret string
+//   - client *models.ClientInfo
+func (_e *AuthServiceMock_Expecter) PollLogin(ctx interface{}, pendingLoginID interface{}, pollSecret interface{}, client interface{}) *AuthServiceMock_PollLogin_Call {
+	return &AuthServiceMock_PollLogin_Call{Call: _e.mock.On("PollLogin", ctx, pendingLoginID, pollSecret, client)}
+}
+
+func (_c *AuthServiceMock_PollLogin_Call) Run(run func(ctx context.Context, pendingLoginID string, pollSecret string, client *models.ClientInfo)) *AuthServiceMock_PollLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.ClientInfo))
+	})
+	return _c
+}
+
+func (_c *AuthServiceMock_PollLogin_Call) Return(_a0 *models.AuthResponse, _a1 error) *AuthServiceMock_PollLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthServiceMock_PollLogin_Call) RunAndReturn(run func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)) *AuthServiceMock_PollLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshSession provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthServiceMock) RefreshSession(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSession")
+	}
+
+	var r0 *models.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AuthResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AuthResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthServiceMock_RefreshSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshSession'
+type AuthServiceMock_RefreshSession_Call struct {
+	*mock.Call
+}
+
+// RefreshSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *AuthServiceMock_Expecter) RefreshSession(ctx interface{}, refreshToken interface{}) *AuthServiceMock_RefreshSession_Call {
+	return &AuthServiceMock_RefreshSession_Call{Call: _e.mock.On("RefreshSession", ctx, refreshToken)}
+}
+
+func (_c *AuthServiceMock_RefreshSession_Call) Run(run func(ctx context.Context, refreshToken string)) *AuthServiceMock_RefreshSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthServiceMock_RefreshSession_Call) Return(_a0 *models.AuthResponse, _a1 error) *AuthServiceMock_RefreshSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthServiceMock_RefreshSession_Call) RunAndReturn(run func(context.Context, string) (*models.AuthResponse, error)) *AuthServiceMock_RefreshSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendAuthenticationLink provides a mock function with given fields: ctx, email, withCode
+func (_m *AuthServiceMock) SendAuthenticationLink(ctx context.Context, email string, withCode bool) (*models.LoginSession, error) {
+	ret := _m.Called(ctx, email, withCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendAuthenticationLink")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, email)
+	var r0 *models.LoginSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*models.LoginSession, error)); ok {
+		return rf(ctx, email, withCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *models.LoginSession); ok {
+		r0 = rf(ctx, email, withCode)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginSession)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, email, withCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // AuthServiceMock_SendAuthenticationLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAuthenticationLink'
@@ -154,23 +445,24 @@ type AuthServiceMock_SendAuthenticationLink_Call struct {
 // SendAuthenticationLink is a helper method to define mock.On call
 //   - ctx context.Context
 //   - email string
-func (_e *AuthServiceMock_Expecter) SendAuthenticationLink(ctx interface{}, email interface{}) *AuthServiceMock_SendAuthenticationLink_Call {
-	return &AuthServiceMock_SendAuthenticationLink_Call{Call: _e.mock.On("SendAuthenticationLink", ctx, email)}
+//   - withCode bool
+func (_e *AuthServiceMock_Expecter) SendAuthenticationLink(ctx interface{}, email interface{}, withCode interface{}) *AuthServiceMock_SendAuthenticationLink_Call {
+	return &AuthServiceMock_SendAuthenticationLink_Call{Call: _e.mock.On("SendAuthenticationLink", ctx, email, withCode)}
 }
 
-func (_c *AuthServiceMock_SendAuthenticationLink_Call) Run(run func(ctx context.Context, email string)) *AuthServiceMock_SendAuthenticationLink_Call {
+func (_c *AuthServiceMock_SendAuthenticationLink_Call) Run(run func(ctx context.Context, email string, withCode bool)) *AuthServiceMock_SendAuthenticationLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *AuthServiceMock_SendAuthenticationLink_Call) Return(_a0 error) *AuthServiceMock_SendAuthenticationLink_Call {
-	_c.Call.Return(_a0)
+func (_c *AuthServiceMock_SendAuthenticationLink_Call) Return(_a0 *models.LoginSession, _a1 error) *AuthServiceMock_SendAuthenticationLink_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AuthServiceMock_SendAuthenticationLink_Call) RunAndReturn(run func(context.Context, string) error) *AuthServiceMock_SendAuthenticationLink_Call {
+func (_c *AuthServiceMock_SendAuthenticationLink_Call) RunAndReturn(run func(context.Context, string, bool) (*models.LoginSession, error)) *AuthServiceMock_SendAuthenticationLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,68 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeliveryServiceMock is an autogenerated mock type for the DeliveryService type
+type DeliveryServiceMock struct {
+	mock.Mock
+}
+
+type DeliveryServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeliveryServiceMock) EXPECT() *DeliveryServiceMock_Expecter {
+	return &DeliveryServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: delivery
+func (_m *DeliveryServiceMock) Enqueue(delivery *models.Delivery) {
+	_m.Called(delivery)
+}
+
+// DeliveryServiceMock_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type DeliveryServiceMock_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - delivery *models.Delivery
+func (_e *DeliveryServiceMock_Expecter) Enqueue(delivery interface{}) *DeliveryServiceMock_Enqueue_Call {
+	return &DeliveryServiceMock_Enqueue_Call{Call: _e.mock.On("Enqueue", delivery)}
+}
+
+func (_c *DeliveryServiceMock_Enqueue_Call) Run(run func(delivery *models.Delivery)) *DeliveryServiceMock_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Delivery))
+	})
+	return _c
+}
+
+func (_c *DeliveryServiceMock_Enqueue_Call) Return() *DeliveryServiceMock_Enqueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeliveryServiceMock_Enqueue_Call) RunAndReturn(run func(*models.Delivery)) *DeliveryServiceMock_Enqueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewDeliveryServiceMock creates a new instance of DeliveryServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryServiceMock {
+	mock := &DeliveryServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/g-villarinho/tab-notes-api/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,17 +22,66 @@ func (_m *EmailNotificationMock) EXPECT() *EmailNotificationMock_Expecter {
 	return &EmailNotificationMock_Expecter{mock: &_m.Mock}
 }
 
-// SendMagicLink provides a mock function with given fields: ctx, name, email, magicLink
-func (_m *EmailNotificationMock) SendMagicLink(ctx context.Context, name string, email string, magicLink string) error {
-	ret := _m.Called(ctx, name, email, magicLink)
+// SendExportReady provides a mock function with given fields: ctx, name, email, downloadLink
+func (_m *EmailNotificationMock) SendExportReady(ctx context.Context, name string, email string, downloadLink string) error {
+	ret := _m.Called(ctx, name, email, downloadLink)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendMagicLink")
+		panic("no return value specified for SendExportReady")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, name, email, magicLink)
+		r0 = rf(ctx, name, email, downloadLink)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmailNotificationMock_SendExportReady_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendExportReady'
+type EmailNotificationMock_SendExportReady_Call struct {
+	*mock.Call
+}
+
+// SendExportReady is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - email string
+//   - downloadLink string
+func (_e *EmailNotificationMock_Expecter) SendExportReady(ctx interface{}, name interface{}, email interface{}, downloadLink interface{}) *EmailNotificationMock_SendExportReady_Call {
+	return &EmailNotificationMock_SendExportReady_Call{Call: _e.mock.On("SendExportReady", ctx, name, email, downloadLink)}
+}
+
+func (_c *EmailNotificationMock_SendExportReady_Call) Run(run func(ctx context.Context, name string, email string, downloadLink string)) *EmailNotificationMock_SendExportReady_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *EmailNotificationMock_SendExportReady_Call) Return(_a0 error) *EmailNotificationMock_SendExportReady_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EmailNotificationMock_SendExportReady_Call) RunAndReturn(run func(context.Context, string, string, string) error) *EmailNotificationMock_SendExportReady_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMagicLink provides a mock function with given fields: ctx, name, email, magicLink, code
+func (_m *EmailNotificationMock) SendMagicLink(ctx context.Context, name string, email string, magicLink string, code string) error {
+	ret := _m.Called(ctx, name, email, magicLink, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMagicLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, name, email, magicLink, code)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,13 +99,14 @@ type EmailNotificationMock_SendMagicLink_Call struct {
 //   - name string
 //   - email string
 //   - magicLink string
-func (_e *EmailNotificationMock_Expecter) SendMagicLink(ctx interface{}, name interface{}, email interface{}, magicLink interface{}) *EmailNotificationMock_SendMagicLink_Call {
-	return &EmailNotificationMock_SendMagicLink_Call{Call: _e.mock.On("SendMagicLink", ctx, name, email, magicLink)}
+//   - code string
+func (_e *EmailNotificationMock_Expecter) SendMagicLink(ctx interface{}, name interface{}, email interface{}, magicLink interface{}, code interface{}) *EmailNotificationMock_SendMagicLink_Call {
+	return &EmailNotificationMock_SendMagicLink_Call{Call: _e.mock.On("SendMagicLink", ctx, name, email, magicLink, code)}
 }
 
-func (_c *EmailNotificationMock_SendMagicLink_Call) Run(run func(ctx context.Context, name string, email string, magicLink string)) *EmailNotificationMock_SendMagicLink_Call {
+func (_c *EmailNotificationMock_SendMagicLink_Call) Run(run func(ctx context.Context, name string, email string, magicLink string, code string)) *EmailNotificationMock_SendMagicLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -65,7 +116,57 @@ func (_c *EmailNotificationMock_SendMagicLink_Call) Return(_a0 error) *EmailNoti
 	return _c
 }
 
-func (_c *EmailNotificationMock_SendMagicLink_Call) RunAndReturn(run func(context.Context, string, string, string) error) *EmailNotificationMock_SendMagicLink_Call {
+func (_c *EmailNotificationMock_SendMagicLink_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *EmailNotificationMock_SendMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendNewSignInAlert provides a mock function with given fields: ctx, name, email, signIn, revokeLink
+func (_m *EmailNotificationMock) SendNewSignInAlert(ctx context.Context, name string, email string, signIn *models.NewSignIn, revokeLink string) error {
+	ret := _m.Called(ctx, name, email, signIn, revokeLink)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendNewSignInAlert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.NewSignIn, string) error); ok {
+		r0 = rf(ctx, name, email, signIn, revokeLink)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmailNotificationMock_SendNewSignInAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendNewSignInAlert'
+type EmailNotificationMock_SendNewSignInAlert_Call struct {
+	*mock.Call
+}
+
+// SendNewSignInAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - email string
+//   - signIn *models.NewSignIn
+//   - revokeLink string
+func (_e *EmailNotificationMock_Expecter) SendNewSignInAlert(ctx interface{}, name interface{}, email interface{}, signIn interface{}, revokeLink interface{}) *EmailNotificationMock_SendNewSignInAlert_Call {
+	return &EmailNotificationMock_SendNewSignInAlert_Call{Call: _e.mock.On("SendNewSignInAlert", ctx, name, email, signIn, revokeLink)}
+}
+
+func (_c *EmailNotificationMock_SendNewSignInAlert_Call) Run(run func(ctx context.Context, name string, email string, signIn *models.NewSignIn, revokeLink string)) *EmailNotificationMock_SendNewSignInAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.NewSignIn), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *EmailNotificationMock_SendNewSignInAlert_Call) Return(_a0 error) *EmailNotificationMock_SendNewSignInAlert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EmailNotificationMock_SendNewSignInAlert_Call) RunAndReturn(run func(context.Context, string, string, *models.NewSignIn, string) error) *EmailNotificationMock_SendNewSignInAlert_Call {
 	_c.Call.Return(run)
 	return _c
 }
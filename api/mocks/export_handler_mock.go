@@ -0,0 +1,104 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportHandlerMock is an autogenerated mock type for the ExportHandler type
+type ExportHandlerMock struct {
+	mock.Mock
+}
+
+type ExportHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportHandlerMock) EXPECT() *ExportHandlerMock_Expecter {
+	return &ExportHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// DownloadExport provides a mock function with given fields: w, r
+func (_m *ExportHandlerMock) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ExportHandlerMock_DownloadExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadExport'
+type ExportHandlerMock_DownloadExport_Call struct {
+	*mock.Call
+}
+
+// DownloadExport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ExportHandlerMock_Expecter) DownloadExport(w interface{}, r interface{}) *ExportHandlerMock_DownloadExport_Call {
+	return &ExportHandlerMock_DownloadExport_Call{Call: _e.mock.On("DownloadExport", w, r)}
+}
+
+func (_c *ExportHandlerMock_DownloadExport_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ExportHandlerMock_DownloadExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ExportHandlerMock_DownloadExport_Call) Return() *ExportHandlerMock_DownloadExport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ExportHandlerMock_DownloadExport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ExportHandlerMock_DownloadExport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RequestExport provides a mock function with given fields: w, r
+func (_m *ExportHandlerMock) RequestExport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ExportHandlerMock_RequestExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestExport'
+type ExportHandlerMock_RequestExport_Call struct {
+	*mock.Call
+}
+
+// RequestExport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ExportHandlerMock_Expecter) RequestExport(w interface{}, r interface{}) *ExportHandlerMock_RequestExport_Call {
+	return &ExportHandlerMock_RequestExport_Call{Call: _e.mock.On("RequestExport", w, r)}
+}
+
+func (_c *ExportHandlerMock_RequestExport_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ExportHandlerMock_RequestExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ExportHandlerMock_RequestExport_Call) Return() *ExportHandlerMock_RequestExport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ExportHandlerMock_RequestExport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ExportHandlerMock_RequestExport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewExportHandlerMock creates a new instance of ExportHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportHandlerMock {
+	mock := &ExportHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,311 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ExportRepositoryMock is an autogenerated mock type for the ExportRepository type
+type ExportRepositoryMock struct {
+	mock.Mock
+}
+
+type ExportRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportRepositoryMock) EXPECT() *ExportRepositoryMock_Expecter {
+	return &ExportRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateExport provides a mock function with given fields: ctx, export
+func (_m *ExportRepositoryMock) CreateExport(ctx context.Context, export *models.Export) error {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Export) error); ok {
+		r0 = rf(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportRepositoryMock_CreateExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateExport'
+type ExportRepositoryMock_CreateExport_Call struct {
+	*mock.Call
+}
+
+// CreateExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - export *models.Export
+func (_e *ExportRepositoryMock_Expecter) CreateExport(ctx interface{}, export interface{}) *ExportRepositoryMock_CreateExport_Call {
+	return &ExportRepositoryMock_CreateExport_Call{Call: _e.mock.On("CreateExport", ctx, export)}
+}
+
+func (_c *ExportRepositoryMock_CreateExport_Call) Run(run func(ctx context.Context, export *models.Export)) *ExportRepositoryMock_CreateExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Export))
+	})
+	return _c
+}
+
+func (_c *ExportRepositoryMock_CreateExport_Call) Return(_a0 error) *ExportRepositoryMock_CreateExport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExportRepositoryMock_CreateExport_Call) RunAndReturn(run func(context.Context, *models.Export) error) *ExportRepositoryMock_CreateExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiredExports provides a mock function with given fields: ctx, now
+func (_m *ExportRepositoryMock) GetExpiredExports(ctx context.Context, now time.Time) ([]*models.Export, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredExports")
+	}
+
+	var r0 []*models.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Export, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Export); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportRepositoryMock_GetExpiredExports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredExports'
+type ExportRepositoryMock_GetExpiredExports_Call struct {
+	*mock.Call
+}
+
+// GetExpiredExports is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *ExportRepositoryMock_Expecter) GetExpiredExports(ctx interface{}, now interface{}) *ExportRepositoryMock_GetExpiredExports_Call {
+	return &ExportRepositoryMock_GetExpiredExports_Call{Call: _e.mock.On("GetExpiredExports", ctx, now)}
+}
+
+func (_c *ExportRepositoryMock_GetExpiredExports_Call) Run(run func(ctx context.Context, now time.Time)) *ExportRepositoryMock_GetExpiredExports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ExportRepositoryMock_GetExpiredExports_Call) Return(_a0 []*models.Export, _a1 error) *ExportRepositoryMock_GetExpiredExports_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportRepositoryMock_GetExpiredExports_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.Export, error)) *ExportRepositoryMock_GetExpiredExports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExportByID provides a mock function with given fields: ctx, id
+func (_m *ExportRepositoryMock) GetExportByID(ctx context.Context, id string) (*models.Export, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportByID")
+	}
+
+	var r0 *models.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Export, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Export); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportRepositoryMock_GetExportByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExportByID'
+type ExportRepositoryMock_GetExportByID_Call struct {
+	*mock.Call
+}
+
+// GetExportByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ExportRepositoryMock_Expecter) GetExportByID(ctx interface{}, id interface{}) *ExportRepositoryMock_GetExportByID_Call {
+	return &ExportRepositoryMock_GetExportByID_Call{Call: _e.mock.On("GetExportByID", ctx, id)}
+}
+
+func (_c *ExportRepositoryMock_GetExportByID_Call) Run(run func(ctx context.Context, id string)) *ExportRepositoryMock_GetExportByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExportRepositoryMock_GetExportByID_Call) Return(_a0 *models.Export, _a1 error) *ExportRepositoryMock_GetExportByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportRepositoryMock_GetExportByID_Call) RunAndReturn(run func(context.Context, string) (*models.Export, error)) *ExportRepositoryMock_GetExportByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingExportByUserID provides a mock function with given fields: ctx, userID, createdAfter
+func (_m *ExportRepositoryMock) GetPendingExportByUserID(ctx context.Context, userID string, createdAfter time.Time) (*models.Export, error) {
+	ret := _m.Called(ctx, userID, createdAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingExportByUserID")
+	}
+
+	var r0 *models.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.Export, error)); ok {
+		return rf(ctx, userID, createdAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.Export); ok {
+		r0 = rf(ctx, userID, createdAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, createdAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportRepositoryMock_GetPendingExportByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingExportByUserID'
+type ExportRepositoryMock_GetPendingExportByUserID_Call struct {
+	*mock.Call
+}
+
+// GetPendingExportByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - createdAfter time.Time
+func (_e *ExportRepositoryMock_Expecter) GetPendingExportByUserID(ctx interface{}, userID interface{}, createdAfter interface{}) *ExportRepositoryMock_GetPendingExportByUserID_Call {
+	return &ExportRepositoryMock_GetPendingExportByUserID_Call{Call: _e.mock.On("GetPendingExportByUserID", ctx, userID, createdAfter)}
+}
+
+func (_c *ExportRepositoryMock_GetPendingExportByUserID_Call) Run(run func(ctx context.Context, userID string, createdAfter time.Time)) *ExportRepositoryMock_GetPendingExportByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ExportRepositoryMock_GetPendingExportByUserID_Call) Return(_a0 *models.Export, _a1 error) *ExportRepositoryMock_GetPendingExportByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportRepositoryMock_GetPendingExportByUserID_Call) RunAndReturn(run func(context.Context, string, time.Time) (*models.Export, error)) *ExportRepositoryMock_GetPendingExportByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateExport provides a mock function with given fields: ctx, export
+func (_m *ExportRepositoryMock) UpdateExport(ctx context.Context, export *models.Export) error {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Export) error); ok {
+		r0 = rf(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportRepositoryMock_UpdateExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateExport'
+type ExportRepositoryMock_UpdateExport_Call struct {
+	*mock.Call
+}
+
+// UpdateExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - export *models.Export
+func (_e *ExportRepositoryMock_Expecter) UpdateExport(ctx interface{}, export interface{}) *ExportRepositoryMock_UpdateExport_Call {
+	return &ExportRepositoryMock_UpdateExport_Call{Call: _e.mock.On("UpdateExport", ctx, export)}
+}
+
+func (_c *ExportRepositoryMock_UpdateExport_Call) Run(run func(ctx context.Context, export *models.Export)) *ExportRepositoryMock_UpdateExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Export))
+	})
+	return _c
+}
+
+func (_c *ExportRepositoryMock_UpdateExport_Call) Return(_a0 error) *ExportRepositoryMock_UpdateExport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExportRepositoryMock_UpdateExport_Call) RunAndReturn(run func(context.Context, *models.Export) error) *ExportRepositoryMock_UpdateExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportRepositoryMock creates a new instance of ExportRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportRepositoryMock {
+	mock := &ExportRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
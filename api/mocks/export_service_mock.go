@@ -0,0 +1,200 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportServiceMock is an autogenerated mock type for the ExportService type
+type ExportServiceMock struct {
+	mock.Mock
+}
+
+type ExportServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportServiceMock) EXPECT() *ExportServiceMock_Expecter {
+	return &ExportServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// BuildExport provides a mock function with given fields: ctx, exportID
+func (_m *ExportServiceMock) BuildExport(ctx context.Context, exportID string) error {
+	ret := _m.Called(ctx, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildExport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, exportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportServiceMock_BuildExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildExport'
+type ExportServiceMock_BuildExport_Call struct {
+	*mock.Call
+}
+
+// BuildExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - exportID string
+func (_e *ExportServiceMock_Expecter) BuildExport(ctx interface{}, exportID interface{}) *ExportServiceMock_BuildExport_Call {
+	return &ExportServiceMock_BuildExport_Call{Call: _e.mock.On("BuildExport", ctx, exportID)}
+}
+
+func (_c *ExportServiceMock_BuildExport_Call) Run(run func(ctx context.Context, exportID string)) *ExportServiceMock_BuildExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExportServiceMock_BuildExport_Call) Return(_a0 error) *ExportServiceMock_BuildExport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExportServiceMock_BuildExport_Call) RunAndReturn(run func(context.Context, string) error) *ExportServiceMock_BuildExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExportFile provides a mock function with given fields: ctx, token
+func (_m *ExportServiceMock) GetExportFile(ctx context.Context, token string) (string, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportFile")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportServiceMock_GetExportFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExportFile'
+type ExportServiceMock_GetExportFile_Call struct {
+	*mock.Call
+}
+
+// GetExportFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *ExportServiceMock_Expecter) GetExportFile(ctx interface{}, token interface{}) *ExportServiceMock_GetExportFile_Call {
+	return &ExportServiceMock_GetExportFile_Call{Call: _e.mock.On("GetExportFile", ctx, token)}
+}
+
+func (_c *ExportServiceMock_GetExportFile_Call) Run(run func(ctx context.Context, token string)) *ExportServiceMock_GetExportFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExportServiceMock_GetExportFile_Call) Return(_a0 string, _a1 error) *ExportServiceMock_GetExportFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportServiceMock_GetExportFile_Call) RunAndReturn(run func(context.Context, string) (string, error)) *ExportServiceMock_GetExportFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestExport provides a mock function with given fields: ctx, userID
+func (_m *ExportServiceMock) RequestExport(ctx context.Context, userID string) (*models.ExportResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestExport")
+	}
+
+	var r0 *models.ExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ExportResponse, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ExportResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportServiceMock_RequestExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestExport'
+type ExportServiceMock_RequestExport_Call struct {
+	*mock.Call
+}
+
+// RequestExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ExportServiceMock_Expecter) RequestExport(ctx interface{}, userID interface{}) *ExportServiceMock_RequestExport_Call {
+	return &ExportServiceMock_RequestExport_Call{Call: _e.mock.On("RequestExport", ctx, userID)}
+}
+
+func (_c *ExportServiceMock_RequestExport_Call) Run(run func(ctx context.Context, userID string)) *ExportServiceMock_RequestExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExportServiceMock_RequestExport_Call) Return(_a0 *models.ExportResponse, _a1 error) *ExportServiceMock_RequestExport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportServiceMock_RequestExport_Call) RunAndReturn(run func(context.Context, string) (*models.ExportResponse, error)) *ExportServiceMock_RequestExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportServiceMock creates a new instance of ExportServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportServiceMock {
+	mock := &ExportServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
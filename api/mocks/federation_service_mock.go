@@ -0,0 +1,371 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+)
+
+// FederationServiceMock is an autogenerated mock type for the FederationService type
+type FederationServiceMock struct {
+	mock.Mock
+}
+
+type FederationServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FederationServiceMock) EXPECT() *FederationServiceMock_Expecter {
+	return &FederationServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// GetActor provides a mock function with given fields: ctx, username
+func (_m *FederationServiceMock) GetActor(ctx context.Context, username string) (*models.Actor, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActor")
+	}
+
+	var r0 *models.Actor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Actor, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Actor); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Actor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FederationServiceMock_GetActor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActor'
+type FederationServiceMock_GetActor_Call struct {
+	*mock.Call
+}
+
+// GetActor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *FederationServiceMock_Expecter) GetActor(ctx interface{}, username interface{}) *FederationServiceMock_GetActor_Call {
+	return &FederationServiceMock_GetActor_Call{Call: _e.mock.On("GetActor", ctx, username)}
+}
+
+func (_c *FederationServiceMock_GetActor_Call) Run(run func(ctx context.Context, username string)) *FederationServiceMock_GetActor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FederationServiceMock_GetActor_Call) Return(_a0 *models.Actor, _a1 error) *FederationServiceMock_GetActor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FederationServiceMock_GetActor_Call) RunAndReturn(run func(context.Context, string) (*models.Actor, error)) *FederationServiceMock_GetActor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNote provides a mock function with given fields: ctx, postID
+func (_m *FederationServiceMock) GetNote(ctx context.Context, postID string) (*models.Note, error) {
+	ret := _m.Called(ctx, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNote")
+	}
+
+	var r0 *models.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Note, error)); ok {
+		return rf(ctx, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Note); ok {
+		r0 = rf(ctx, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FederationServiceMock_GetNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNote'
+type FederationServiceMock_GetNote_Call struct {
+	*mock.Call
+}
+
+// GetNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+func (_e *FederationServiceMock_Expecter) GetNote(ctx interface{}, postID interface{}) *FederationServiceMock_GetNote_Call {
+	return &FederationServiceMock_GetNote_Call{Call: _e.mock.On("GetNote", ctx, postID)}
+}
+
+func (_c *FederationServiceMock_GetNote_Call) Run(run func(ctx context.Context, postID string)) *FederationServiceMock_GetNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FederationServiceMock_GetNote_Call) Return(_a0 *models.Note, _a1 error) *FederationServiceMock_GetNote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FederationServiceMock_GetNote_Call) RunAndReturn(run func(context.Context, string) (*models.Note, error)) *FederationServiceMock_GetNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOutbox provides a mock function with given fields: ctx, username
+func (_m *FederationServiceMock) GetOutbox(ctx context.Context, username string) (*models.OrderedCollection, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutbox")
+	}
+
+	var r0 *models.OrderedCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.OrderedCollection, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.OrderedCollection); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderedCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FederationServiceMock_GetOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOutbox'
+type FederationServiceMock_GetOutbox_Call struct {
+	*mock.Call
+}
+
+// GetOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *FederationServiceMock_Expecter) GetOutbox(ctx interface{}, username interface{}) *FederationServiceMock_GetOutbox_Call {
+	return &FederationServiceMock_GetOutbox_Call{Call: _e.mock.On("GetOutbox", ctx, username)}
+}
+
+func (_c *FederationServiceMock_GetOutbox_Call) Run(run func(ctx context.Context, username string)) *FederationServiceMock_GetOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FederationServiceMock_GetOutbox_Call) Return(_a0 *models.OrderedCollection, _a1 error) *FederationServiceMock_GetOutbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FederationServiceMock_GetOutbox_Call) RunAndReturn(run func(context.Context, string) (*models.OrderedCollection, error)) *FederationServiceMock_GetOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebFinger provides a mock function with given fields: ctx, resource
+func (_m *FederationServiceMock) GetWebFinger(ctx context.Context, resource string) (*models.WebFinger, error) {
+	ret := _m.Called(ctx, resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebFinger")
+	}
+
+	var r0 *models.WebFinger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.WebFinger, error)); ok {
+		return rf(ctx, resource)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.WebFinger); ok {
+		r0 = rf(ctx, resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebFinger)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FederationServiceMock_GetWebFinger_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebFinger'
+type FederationServiceMock_GetWebFinger_Call struct {
+	*mock.Call
+}
+
+// GetWebFinger is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resource string
+func (_e *FederationServiceMock_Expecter) GetWebFinger(ctx interface{}, resource interface{}) *FederationServiceMock_GetWebFinger_Call {
+	return &FederationServiceMock_GetWebFinger_Call{Call: _e.mock.On("GetWebFinger", ctx, resource)}
+}
+
+func (_c *FederationServiceMock_GetWebFinger_Call) Run(run func(ctx context.Context, resource string)) *FederationServiceMock_GetWebFinger_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FederationServiceMock_GetWebFinger_Call) Return(_a0 *models.WebFinger, _a1 error) *FederationServiceMock_GetWebFinger_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FederationServiceMock_GetWebFinger_Call) RunAndReturn(run func(context.Context, string) (*models.WebFinger, error)) *FederationServiceMock_GetWebFinger_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleInbox provides a mock function with given fields: ctx, username, r, body
+func (_m *FederationServiceMock) HandleInbox(ctx context.Context, username string, r *http.Request, body []byte) error {
+	ret := _m.Called(ctx, username, r, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleInbox")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *http.Request, []byte) error); ok {
+		r0 = rf(ctx, username, r, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FederationServiceMock_HandleInbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleInbox'
+type FederationServiceMock_HandleInbox_Call struct {
+	*mock.Call
+}
+
+// HandleInbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - r *http.Request
+//   - body []byte
+func (_e *FederationServiceMock_Expecter) HandleInbox(ctx interface{}, username interface{}, r interface{}, body interface{}) *FederationServiceMock_HandleInbox_Call {
+	return &FederationServiceMock_HandleInbox_Call{Call: _e.mock.On("HandleInbox", ctx, username, r, body)}
+}
+
+func (_c *FederationServiceMock_HandleInbox_Call) Run(run func(ctx context.Context, username string, r *http.Request, body []byte)) *FederationServiceMock_HandleInbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*http.Request), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *FederationServiceMock_HandleInbox_Call) Return(_a0 error) *FederationServiceMock_HandleInbox_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FederationServiceMock_HandleInbox_Call) RunAndReturn(run func(context.Context, string, *http.Request, []byte) error) *FederationServiceMock_HandleInbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishPost provides a mock function with given fields: ctx, post
+func (_m *FederationServiceMock) PublishPost(ctx context.Context, post *models.Post) error {
+	ret := _m.Called(ctx, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Post) error); ok {
+		r0 = rf(ctx, post)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FederationServiceMock_PublishPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishPost'
+type FederationServiceMock_PublishPost_Call struct {
+	*mock.Call
+}
+
+// PublishPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - post *models.Post
+func (_e *FederationServiceMock_Expecter) PublishPost(ctx interface{}, post interface{}) *FederationServiceMock_PublishPost_Call {
+	return &FederationServiceMock_PublishPost_Call{Call: _e.mock.On("PublishPost", ctx, post)}
+}
+
+func (_c *FederationServiceMock_PublishPost_Call) Run(run func(ctx context.Context, post *models.Post)) *FederationServiceMock_PublishPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Post))
+	})
+	return _c
+}
+
+func (_c *FederationServiceMock_PublishPost_Call) Return(_a0 error) *FederationServiceMock_PublishPost_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FederationServiceMock_PublishPost_Call) RunAndReturn(run func(context.Context, *models.Post) error) *FederationServiceMock_PublishPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFederationServiceMock creates a new instance of FederationServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFederationServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FederationServiceMock {
+	mock := &FederationServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
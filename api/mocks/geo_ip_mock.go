@@ -0,0 +1,82 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	netip "net/netip"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GeoIPMock is an autogenerated mock type for the GeoIP type
+type GeoIPMock struct {
+	mock.Mock
+}
+
+type GeoIPMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GeoIPMock) EXPECT() *GeoIPMock_Expecter {
+	return &GeoIPMock_Expecter{mock: &_m.Mock}
+}
+
+// Lookup provides a mock function with given fields: ip
+func (_m *GeoIPMock) Lookup(ip netip.Addr) string {
+	ret := _m.Called(ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lookup")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(netip.Addr) string); ok {
+		r0 = rf(ip)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GeoIPMock_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type GeoIPMock_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - ip netip.Addr
+func (_e *GeoIPMock_Expecter) Lookup(ip interface{}) *GeoIPMock_Lookup_Call {
+	return &GeoIPMock_Lookup_Call{Call: _e.mock.On("Lookup", ip)}
+}
+
+func (_c *GeoIPMock_Lookup_Call) Run(run func(ip netip.Addr)) *GeoIPMock_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(netip.Addr))
+	})
+	return _c
+}
+
+func (_c *GeoIPMock_Lookup_Call) Return(_a0 string) *GeoIPMock_Lookup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GeoIPMock_Lookup_Call) RunAndReturn(run func(netip.Addr) string) *GeoIPMock_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGeoIPMock creates a new instance of GeoIPMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGeoIPMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GeoIPMock {
+	mock := &GeoIPMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HTTPSignerMock is an autogenerated mock type for the HTTPSigner type
+type HTTPSignerMock struct {
+	mock.Mock
+}
+
+type HTTPSignerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HTTPSignerMock) EXPECT() *HTTPSignerMock_Expecter {
+	return &HTTPSignerMock_Expecter{mock: &_m.Mock}
+}
+
+// GenerateKeyPair provides a mock function with no fields
+func (_m *HTTPSignerMock) GenerateKeyPair() (string, string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateKeyPair")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (string, string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() string); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// HTTPSignerMock_GenerateKeyPair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateKeyPair'
+type HTTPSignerMock_GenerateKeyPair_Call struct {
+	*mock.Call
+}
+
+// GenerateKeyPair is a helper method to define mock.On call
+func (_e *HTTPSignerMock_Expecter) GenerateKeyPair() *HTTPSignerMock_GenerateKeyPair_Call {
+	return &HTTPSignerMock_GenerateKeyPair_Call{Call: _e.mock.On("GenerateKeyPair")}
+}
+
+func (_c *HTTPSignerMock_GenerateKeyPair_Call) Run(run func()) *HTTPSignerMock_GenerateKeyPair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HTTPSignerMock_GenerateKeyPair_Call) Return(publicKeyPEM string, privateKeyPEM string, err error) *HTTPSignerMock_GenerateKeyPair_Call {
+	_c.Call.Return(publicKeyPEM, privateKeyPEM, err)
+	return _c
+}
+
+func (_c *HTTPSignerMock_GenerateKeyPair_Call) RunAndReturn(run func() (string, string, error)) *HTTPSignerMock_GenerateKeyPair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// KeyID provides a mock function with given fields: r
+func (_m *HTTPSignerMock) KeyID(r *http.Request) (string, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KeyID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (string, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) string); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HTTPSignerMock_KeyID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KeyID'
+type HTTPSignerMock_KeyID_Call struct {
+	*mock.Call
+}
+
+// KeyID is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *HTTPSignerMock_Expecter) KeyID(r interface{}) *HTTPSignerMock_KeyID_Call {
+	return &HTTPSignerMock_KeyID_Call{Call: _e.mock.On("KeyID", r)}
+}
+
+func (_c *HTTPSignerMock_KeyID_Call) Run(run func(r *http.Request)) *HTTPSignerMock_KeyID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *HTTPSignerMock_KeyID_Call) Return(_a0 string, _a1 error) *HTTPSignerMock_KeyID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HTTPSignerMock_KeyID_Call) RunAndReturn(run func(*http.Request) (string, error)) *HTTPSignerMock_KeyID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sign provides a mock function with given fields: r, keyID, privateKeyPEM, body
+func (_m *HTTPSignerMock) Sign(r *http.Request, keyID string, privateKeyPEM string, body []byte) error {
+	ret := _m.Called(r, keyID, privateKeyPEM, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*http.Request, string, string, []byte) error); ok {
+		r0 = rf(r, keyID, privateKeyPEM, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HTTPSignerMock_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type HTTPSignerMock_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - r *http.Request
+//   - keyID string
+//   - privateKeyPEM string
+//   - body []byte
+func (_e *HTTPSignerMock_Expecter) Sign(r interface{}, keyID interface{}, privateKeyPEM interface{}, body interface{}) *HTTPSignerMock_Sign_Call {
+	return &HTTPSignerMock_Sign_Call{Call: _e.mock.On("Sign", r, keyID, privateKeyPEM, body)}
+}
+
+func (_c *HTTPSignerMock_Sign_Call) Run(run func(r *http.Request, keyID string, privateKeyPEM string, body []byte)) *HTTPSignerMock_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request), args[1].(string), args[2].(string), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *HTTPSignerMock_Sign_Call) Return(_a0 error) *HTTPSignerMock_Sign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HTTPSignerMock_Sign_Call) RunAndReturn(run func(*http.Request, string, string, []byte) error) *HTTPSignerMock_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: r, publicKeyPEM, body
+func (_m *HTTPSignerMock) Verify(r *http.Request, publicKeyPEM string, body []byte) error {
+	ret := _m.Called(r, publicKeyPEM, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*http.Request, string, []byte) error); ok {
+		r0 = rf(r, publicKeyPEM, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HTTPSignerMock_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type HTTPSignerMock_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - r *http.Request
+//   - publicKeyPEM string
+//   - body []byte
+func (_e *HTTPSignerMock_Expecter) Verify(r interface{}, publicKeyPEM interface{}, body interface{}) *HTTPSignerMock_Verify_Call {
+	return &HTTPSignerMock_Verify_Call{Call: _e.mock.On("Verify", r, publicKeyPEM, body)}
+}
+
+func (_c *HTTPSignerMock_Verify_Call) Run(run func(r *http.Request, publicKeyPEM string, body []byte)) *HTTPSignerMock_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *HTTPSignerMock_Verify_Call) Return(_a0 error) *HTTPSignerMock_Verify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HTTPSignerMock_Verify_Call) RunAndReturn(run func(*http.Request, string, []byte) error) *HTTPSignerMock_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHTTPSignerMock creates a new instance of HTTPSignerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHTTPSignerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HTTPSignerMock {
+	mock := &HTTPSignerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportHandlerMock is an autogenerated mock type for the ImportHandler type
+type ImportHandlerMock struct {
+	mock.Mock
+}
+
+type ImportHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportHandlerMock) EXPECT() *ImportHandlerMock_Expecter {
+	return &ImportHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// GetImport provides a mock function with given fields: w, r
+func (_m *ImportHandlerMock) GetImport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ImportHandlerMock_GetImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImport'
+type ImportHandlerMock_GetImport_Call struct {
+	*mock.Call
+}
+
+// GetImport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ImportHandlerMock_Expecter) GetImport(w interface{}, r interface{}) *ImportHandlerMock_GetImport_Call {
+	return &ImportHandlerMock_GetImport_Call{Call: _e.mock.On("GetImport", w, r)}
+}
+
+func (_c *ImportHandlerMock_GetImport_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ImportHandlerMock_GetImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ImportHandlerMock_GetImport_Call) Return() *ImportHandlerMock_GetImport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ImportHandlerMock_GetImport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ImportHandlerMock_GetImport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RequestImport provides a mock function with given fields: w, r
+func (_m *ImportHandlerMock) RequestImport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ImportHandlerMock_RequestImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestImport'
+type ImportHandlerMock_RequestImport_Call struct {
+	*mock.Call
+}
+
+// RequestImport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ImportHandlerMock_Expecter) RequestImport(w interface{}, r interface{}) *ImportHandlerMock_RequestImport_Call {
+	return &ImportHandlerMock_RequestImport_Call{Call: _e.mock.On("RequestImport", w, r)}
+}
+
+func (_c *ImportHandlerMock_RequestImport_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ImportHandlerMock_RequestImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ImportHandlerMock_RequestImport_Call) Return() *ImportHandlerMock_RequestImport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ImportHandlerMock_RequestImport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ImportHandlerMock_RequestImport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewImportHandlerMock creates a new instance of ImportHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportHandlerMock {
+	mock := &ImportHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,190 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportRepositoryMock is an autogenerated mock type for the ImportRepository type
+type ImportRepositoryMock struct {
+	mock.Mock
+}
+
+type ImportRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportRepositoryMock) EXPECT() *ImportRepositoryMock_Expecter {
+	return &ImportRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateImport provides a mock function with given fields: ctx, imp
+func (_m *ImportRepositoryMock) CreateImport(ctx context.Context, imp *models.Import) error {
+	ret := _m.Called(ctx, imp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Import) error); ok {
+		r0 = rf(ctx, imp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportRepositoryMock_CreateImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImport'
+type ImportRepositoryMock_CreateImport_Call struct {
+	*mock.Call
+}
+
+// CreateImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imp *models.Import
+func (_e *ImportRepositoryMock_Expecter) CreateImport(ctx interface{}, imp interface{}) *ImportRepositoryMock_CreateImport_Call {
+	return &ImportRepositoryMock_CreateImport_Call{Call: _e.mock.On("CreateImport", ctx, imp)}
+}
+
+func (_c *ImportRepositoryMock_CreateImport_Call) Run(run func(ctx context.Context, imp *models.Import)) *ImportRepositoryMock_CreateImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Import))
+	})
+	return _c
+}
+
+func (_c *ImportRepositoryMock_CreateImport_Call) Return(_a0 error) *ImportRepositoryMock_CreateImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportRepositoryMock_CreateImport_Call) RunAndReturn(run func(context.Context, *models.Import) error) *ImportRepositoryMock_CreateImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImportByID provides a mock function with given fields: ctx, id
+func (_m *ImportRepositoryMock) GetImportByID(ctx context.Context, id string) (*models.Import, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportByID")
+	}
+
+	var r0 *models.Import
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Import, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Import); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Import)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportRepositoryMock_GetImportByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportByID'
+type ImportRepositoryMock_GetImportByID_Call struct {
+	*mock.Call
+}
+
+// GetImportByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ImportRepositoryMock_Expecter) GetImportByID(ctx interface{}, id interface{}) *ImportRepositoryMock_GetImportByID_Call {
+	return &ImportRepositoryMock_GetImportByID_Call{Call: _e.mock.On("GetImportByID", ctx, id)}
+}
+
+func (_c *ImportRepositoryMock_GetImportByID_Call) Run(run func(ctx context.Context, id string)) *ImportRepositoryMock_GetImportByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ImportRepositoryMock_GetImportByID_Call) Return(_a0 *models.Import, _a1 error) *ImportRepositoryMock_GetImportByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportRepositoryMock_GetImportByID_Call) RunAndReturn(run func(context.Context, string) (*models.Import, error)) *ImportRepositoryMock_GetImportByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateImport provides a mock function with given fields: ctx, imp
+func (_m *ImportRepositoryMock) UpdateImport(ctx context.Context, imp *models.Import) error {
+	ret := _m.Called(ctx, imp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Import) error); ok {
+		r0 = rf(ctx, imp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportRepositoryMock_UpdateImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateImport'
+type ImportRepositoryMock_UpdateImport_Call struct {
+	*mock.Call
+}
+
+// UpdateImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imp *models.Import
+func (_e *ImportRepositoryMock_Expecter) UpdateImport(ctx interface{}, imp interface{}) *ImportRepositoryMock_UpdateImport_Call {
+	return &ImportRepositoryMock_UpdateImport_Call{Call: _e.mock.On("UpdateImport", ctx, imp)}
+}
+
+func (_c *ImportRepositoryMock_UpdateImport_Call) Run(run func(ctx context.Context, imp *models.Import)) *ImportRepositoryMock_UpdateImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Import))
+	})
+	return _c
+}
+
+func (_c *ImportRepositoryMock_UpdateImport_Call) Return(_a0 error) *ImportRepositoryMock_UpdateImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportRepositoryMock_UpdateImport_Call) RunAndReturn(run func(context.Context, *models.Import) error) *ImportRepositoryMock_UpdateImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewImportRepositoryMock creates a new instance of ImportRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportRepositoryMock {
+	mock := &ImportRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,157 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportServiceMock is an autogenerated mock type for the ImportService type
+type ImportServiceMock struct {
+	mock.Mock
+}
+
+type ImportServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportServiceMock) EXPECT() *ImportServiceMock_Expecter {
+	return &ImportServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// GetImport provides a mock function with given fields: ctx, userID, importID
+func (_m *ImportServiceMock) GetImport(ctx context.Context, userID string, importID string) (*models.ImportResponse, error) {
+	ret := _m.Called(ctx, userID, importID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImport")
+	}
+
+	var r0 *models.ImportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ImportResponse, error)); ok {
+		return rf(ctx, userID, importID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ImportResponse); ok {
+		r0 = rf(ctx, userID, importID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, importID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportServiceMock_GetImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImport'
+type ImportServiceMock_GetImport_Call struct {
+	*mock.Call
+}
+
+// GetImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - importID string
+func (_e *ImportServiceMock_Expecter) GetImport(ctx interface{}, userID interface{}, importID interface{}) *ImportServiceMock_GetImport_Call {
+	return &ImportServiceMock_GetImport_Call{Call: _e.mock.On("GetImport", ctx, userID, importID)}
+}
+
+func (_c *ImportServiceMock_GetImport_Call) Run(run func(ctx context.Context, userID string, importID string)) *ImportServiceMock_GetImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ImportServiceMock_GetImport_Call) Return(_a0 *models.ImportResponse, _a1 error) *ImportServiceMock_GetImport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportServiceMock_GetImport_Call) RunAndReturn(run func(context.Context, string, string) (*models.ImportResponse, error)) *ImportServiceMock_GetImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestImport provides a mock function with given fields: ctx, userID, archive
+func (_m *ImportServiceMock) RequestImport(ctx context.Context, userID string, archive []byte) (*models.ImportResponse, error) {
+	ret := _m.Called(ctx, userID, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestImport")
+	}
+
+	var r0 *models.ImportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (*models.ImportResponse, error)); ok {
+		return rf(ctx, userID, archive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) *models.ImportResponse); ok {
+		r0 = rf(ctx, userID, archive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, userID, archive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportServiceMock_RequestImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestImport'
+type ImportServiceMock_RequestImport_Call struct {
+	*mock.Call
+}
+
+// RequestImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - archive []byte
+func (_e *ImportServiceMock_Expecter) RequestImport(ctx interface{}, userID interface{}, archive interface{}) *ImportServiceMock_RequestImport_Call {
+	return &ImportServiceMock_RequestImport_Call{Call: _e.mock.On("RequestImport", ctx, userID, archive)}
+}
+
+func (_c *ImportServiceMock_RequestImport_Call) Run(run func(ctx context.Context, userID string, archive []byte)) *ImportServiceMock_RequestImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *ImportServiceMock_RequestImport_Call) Return(_a0 *models.ImportResponse, _a1 error) *ImportServiceMock_RequestImport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportServiceMock_RequestImport_Call) RunAndReturn(run func(context.Context, string, []byte) (*models.ImportResponse, error)) *ImportServiceMock_RequestImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewImportServiceMock creates a new instance of ImportServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportServiceMock {
+	mock := &ImportServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JWKSHandlerMock is an autogenerated mock type for the JWKSHandler type
+type JWKSHandlerMock struct {
+	mock.Mock
+}
+
+type JWKSHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *JWKSHandlerMock) EXPECT() *JWKSHandlerMock_Expecter {
+	return &JWKSHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// GetJWKS provides a mock function with given fields: w, r
+func (_m *JWKSHandlerMock) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// JWKSHandlerMock_GetJWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJWKS'
+type JWKSHandlerMock_GetJWKS_Call struct {
+	*mock.Call
+}
+
+// GetJWKS is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *JWKSHandlerMock_Expecter) GetJWKS(w interface{}, r interface{}) *JWKSHandlerMock_GetJWKS_Call {
+	return &JWKSHandlerMock_GetJWKS_Call{Call: _e.mock.On("GetJWKS", w, r)}
+}
+
+func (_c *JWKSHandlerMock_GetJWKS_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *JWKSHandlerMock_GetJWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *JWKSHandlerMock_GetJWKS_Call) Return() *JWKSHandlerMock_GetJWKS_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *JWKSHandlerMock_GetJWKS_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *JWKSHandlerMock_GetJWKS_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewJWKSHandlerMock creates a new instance of JWKSHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJWKSHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JWKSHandlerMock {
+	mock := &JWKSHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
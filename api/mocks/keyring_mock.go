@@ -0,0 +1,201 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	ecdsa "crypto/ecdsa"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+)
+
+// KeyringMock is an autogenerated mock type for the Keyring type
+type KeyringMock struct {
+	mock.Mock
+}
+
+type KeyringMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KeyringMock) EXPECT() *KeyringMock_Expecter {
+	return &KeyringMock_Expecter{mock: &_m.Mock}
+}
+
+// JWKS provides a mock function with no fields
+func (_m *KeyringMock) JWKS() *models.JWKSet {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 *models.JWKSet
+	if rf, ok := ret.Get(0).(func() *models.JWKSet); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JWKSet)
+		}
+	}
+
+	return r0
+}
+
+// KeyringMock_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type KeyringMock_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+func (_e *KeyringMock_Expecter) JWKS() *KeyringMock_JWKS_Call {
+	return &KeyringMock_JWKS_Call{Call: _e.mock.On("JWKS")}
+}
+
+func (_c *KeyringMock_JWKS_Call) Run(run func()) *KeyringMock_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KeyringMock_JWKS_Call) Return(_a0 *models.JWKSet) *KeyringMock_JWKS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KeyringMock_JWKS_Call) RunAndReturn(run func() *models.JWKSet) *KeyringMock_JWKS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keyfunc provides a mock function with given fields: token
+func (_m *KeyringMock) Keyfunc(token *jwt.Token) (interface{}, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keyfunc")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwt.Token) (interface{}, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(*jwt.Token) interface{}); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwt.Token) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeyringMock_Keyfunc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keyfunc'
+type KeyringMock_Keyfunc_Call struct {
+	*mock.Call
+}
+
+// Keyfunc is a helper method to define mock.On call
+//   - token *jwt.Token
+func (_e *KeyringMock_Expecter) Keyfunc(token interface{}) *KeyringMock_Keyfunc_Call {
+	return &KeyringMock_Keyfunc_Call{Call: _e.mock.On("Keyfunc", token)}
+}
+
+func (_c *KeyringMock_Keyfunc_Call) Run(run func(token *jwt.Token)) *KeyringMock_Keyfunc_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*jwt.Token))
+	})
+	return _c
+}
+
+func (_c *KeyringMock_Keyfunc_Call) Return(_a0 interface{}, _a1 error) *KeyringMock_Keyfunc_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KeyringMock_Keyfunc_Call) RunAndReturn(run func(*jwt.Token) (interface{}, error)) *KeyringMock_Keyfunc_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SigningKey provides a mock function with no fields
+func (_m *KeyringMock) SigningKey() (string, *ecdsa.PrivateKey) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SigningKey")
+	}
+
+	var r0 string
+	var r1 *ecdsa.PrivateKey
+	if rf, ok := ret.Get(0).(func() (string, *ecdsa.PrivateKey)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() *ecdsa.PrivateKey); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ecdsa.PrivateKey)
+		}
+	}
+
+	return r0, r1
+}
+
+// KeyringMock_SigningKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SigningKey'
+type KeyringMock_SigningKey_Call struct {
+	*mock.Call
+}
+
+// SigningKey is a helper method to define mock.On call
+func (_e *KeyringMock_Expecter) SigningKey() *KeyringMock_SigningKey_Call {
+	return &KeyringMock_SigningKey_Call{Call: _e.mock.On("SigningKey")}
+}
+
+func (_c *KeyringMock_SigningKey_Call) Run(run func()) *KeyringMock_SigningKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KeyringMock_SigningKey_Call) Return(_a0 string, _a1 *ecdsa.PrivateKey) *KeyringMock_SigningKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KeyringMock_SigningKey_Call) RunAndReturn(run func() (string, *ecdsa.PrivateKey)) *KeyringMock_SigningKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewKeyringMock creates a new instance of KeyringMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKeyringMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KeyringMock {
+	mock := &KeyringMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -234,6 +234,65 @@ func (_c *LikeRepositoryMock_GetLikedPostIDs_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetLikesByUserID provides a mock function with given fields: ctx, userID
+func (_m *LikeRepositoryMock) GetLikesByUserID(ctx context.Context, userID string) ([]*models.Like, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLikesByUserID")
+	}
+
+	var r0 []*models.Like
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Like, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Like); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Like)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LikeRepositoryMock_GetLikesByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikesByUserID'
+type LikeRepositoryMock_GetLikesByUserID_Call struct {
+	*mock.Call
+}
+
+// GetLikesByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *LikeRepositoryMock_Expecter) GetLikesByUserID(ctx interface{}, userID interface{}) *LikeRepositoryMock_GetLikesByUserID_Call {
+	return &LikeRepositoryMock_GetLikesByUserID_Call{Call: _e.mock.On("GetLikesByUserID", ctx, userID)}
+}
+
+func (_c *LikeRepositoryMock_GetLikesByUserID_Call) Run(run func(ctx context.Context, userID string)) *LikeRepositoryMock_GetLikesByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LikeRepositoryMock_GetLikesByUserID_Call) Return(_a0 []*models.Like, _a1 error) *LikeRepositoryMock_GetLikesByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LikeRepositoryMock_GetLikesByUserID_Call) RunAndReturn(run func(context.Context, string) ([]*models.Like, error)) *LikeRepositoryMock_GetLikesByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewLikeRepositoryMock creates a new instance of LikeRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLikeRepositoryMock(t interface {
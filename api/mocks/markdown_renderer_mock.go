@@ -0,0 +1,88 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MarkdownRendererMock is an autogenerated mock type for the MarkdownRenderer type
+type MarkdownRendererMock struct {
+	mock.Mock
+}
+
+type MarkdownRendererMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MarkdownRendererMock) EXPECT() *MarkdownRendererMock_Expecter {
+	return &MarkdownRendererMock_Expecter{mock: &_m.Mock}
+}
+
+// Render provides a mock function with given fields: source
+func (_m *MarkdownRendererMock) Render(source string) (string, error) {
+	ret := _m.Called(source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(source)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(source)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkdownRendererMock_Render_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Render'
+type MarkdownRendererMock_Render_Call struct {
+	*mock.Call
+}
+
+// Render is a helper method to define mock.On call
+//   - source string
+func (_e *MarkdownRendererMock_Expecter) Render(source interface{}) *MarkdownRendererMock_Render_Call {
+	return &MarkdownRendererMock_Render_Call{Call: _e.mock.On("Render", source)}
+}
+
+func (_c *MarkdownRendererMock_Render_Call) Run(run func(source string)) *MarkdownRendererMock_Render_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MarkdownRendererMock_Render_Call) Return(_a0 string, _a1 error) *MarkdownRendererMock_Render_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MarkdownRendererMock_Render_Call) RunAndReturn(run func(string) (string, error)) *MarkdownRendererMock_Render_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMarkdownRendererMock creates a new instance of MarkdownRendererMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMarkdownRendererMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MarkdownRendererMock {
+	mock := &MarkdownRendererMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,342 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotebookHandlerMock is an autogenerated mock type for the NotebookHandler type
+type NotebookHandlerMock struct {
+	mock.Mock
+}
+
+type NotebookHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotebookHandlerMock) EXPECT() *NotebookHandlerMock_Expecter {
+	return &NotebookHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// AddPost provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) AddPost(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_AddPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPost'
+type NotebookHandlerMock_AddPost_Call struct {
+	*mock.Call
+}
+
+// AddPost is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) AddPost(w interface{}, r interface{}) *NotebookHandlerMock_AddPost_Call {
+	return &NotebookHandlerMock_AddPost_Call{Call: _e.mock.On("AddPost", w, r)}
+}
+
+func (_c *NotebookHandlerMock_AddPost_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_AddPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_AddPost_Call) Return() *NotebookHandlerMock_AddPost_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_AddPost_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_AddPost_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CreateNotebook provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) CreateNotebook(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_CreateNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotebook'
+type NotebookHandlerMock_CreateNotebook_Call struct {
+	*mock.Call
+}
+
+// CreateNotebook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) CreateNotebook(w interface{}, r interface{}) *NotebookHandlerMock_CreateNotebook_Call {
+	return &NotebookHandlerMock_CreateNotebook_Call{Call: _e.mock.On("CreateNotebook", w, r)}
+}
+
+func (_c *NotebookHandlerMock_CreateNotebook_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_CreateNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_CreateNotebook_Call) Return() *NotebookHandlerMock_CreateNotebook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_CreateNotebook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_CreateNotebook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteNotebook provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) DeleteNotebook(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_DeleteNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotebook'
+type NotebookHandlerMock_DeleteNotebook_Call struct {
+	*mock.Call
+}
+
+// DeleteNotebook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) DeleteNotebook(w interface{}, r interface{}) *NotebookHandlerMock_DeleteNotebook_Call {
+	return &NotebookHandlerMock_DeleteNotebook_Call{Call: _e.mock.On("DeleteNotebook", w, r)}
+}
+
+func (_c *NotebookHandlerMock_DeleteNotebook_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_DeleteNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_DeleteNotebook_Call) Return() *NotebookHandlerMock_DeleteNotebook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_DeleteNotebook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_DeleteNotebook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetMyNotebook provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) GetMyNotebook(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_GetMyNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMyNotebook'
+type NotebookHandlerMock_GetMyNotebook_Call struct {
+	*mock.Call
+}
+
+// GetMyNotebook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) GetMyNotebook(w interface{}, r interface{}) *NotebookHandlerMock_GetMyNotebook_Call {
+	return &NotebookHandlerMock_GetMyNotebook_Call{Call: _e.mock.On("GetMyNotebook", w, r)}
+}
+
+func (_c *NotebookHandlerMock_GetMyNotebook_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_GetMyNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_GetMyNotebook_Call) Return() *NotebookHandlerMock_GetMyNotebook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_GetMyNotebook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_GetMyNotebook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetMyNotebooks provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) GetMyNotebooks(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_GetMyNotebooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMyNotebooks'
+type NotebookHandlerMock_GetMyNotebooks_Call struct {
+	*mock.Call
+}
+
+// GetMyNotebooks is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) GetMyNotebooks(w interface{}, r interface{}) *NotebookHandlerMock_GetMyNotebooks_Call {
+	return &NotebookHandlerMock_GetMyNotebooks_Call{Call: _e.mock.On("GetMyNotebooks", w, r)}
+}
+
+func (_c *NotebookHandlerMock_GetMyNotebooks_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_GetMyNotebooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_GetMyNotebooks_Call) Return() *NotebookHandlerMock_GetMyNotebooks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_GetMyNotebooks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_GetMyNotebooks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetNotebookBySlug provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) GetNotebookBySlug(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_GetNotebookBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotebookBySlug'
+type NotebookHandlerMock_GetNotebookBySlug_Call struct {
+	*mock.Call
+}
+
+// GetNotebookBySlug is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) GetNotebookBySlug(w interface{}, r interface{}) *NotebookHandlerMock_GetNotebookBySlug_Call {
+	return &NotebookHandlerMock_GetNotebookBySlug_Call{Call: _e.mock.On("GetNotebookBySlug", w, r)}
+}
+
+func (_c *NotebookHandlerMock_GetNotebookBySlug_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_GetNotebookBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_GetNotebookBySlug_Call) Return() *NotebookHandlerMock_GetNotebookBySlug_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_GetNotebookBySlug_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_GetNotebookBySlug_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RemovePost provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) RemovePost(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_RemovePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePost'
+type NotebookHandlerMock_RemovePost_Call struct {
+	*mock.Call
+}
+
+// RemovePost is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) RemovePost(w interface{}, r interface{}) *NotebookHandlerMock_RemovePost_Call {
+	return &NotebookHandlerMock_RemovePost_Call{Call: _e.mock.On("RemovePost", w, r)}
+}
+
+func (_c *NotebookHandlerMock_RemovePost_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_RemovePost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_RemovePost_Call) Return() *NotebookHandlerMock_RemovePost_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_RemovePost_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_RemovePost_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ReorderPosts provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) ReorderPosts(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_ReorderPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderPosts'
+type NotebookHandlerMock_ReorderPosts_Call struct {
+	*mock.Call
+}
+
+// ReorderPosts is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) ReorderPosts(w interface{}, r interface{}) *NotebookHandlerMock_ReorderPosts_Call {
+	return &NotebookHandlerMock_ReorderPosts_Call{Call: _e.mock.On("ReorderPosts", w, r)}
+}
+
+func (_c *NotebookHandlerMock_ReorderPosts_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_ReorderPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_ReorderPosts_Call) Return() *NotebookHandlerMock_ReorderPosts_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_ReorderPosts_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_ReorderPosts_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateNotebook provides a mock function with given fields: w, r
+func (_m *NotebookHandlerMock) UpdateNotebook(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// NotebookHandlerMock_UpdateNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotebook'
+type NotebookHandlerMock_UpdateNotebook_Call struct {
+	*mock.Call
+}
+
+// UpdateNotebook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *NotebookHandlerMock_Expecter) UpdateNotebook(w interface{}, r interface{}) *NotebookHandlerMock_UpdateNotebook_Call {
+	return &NotebookHandlerMock_UpdateNotebook_Call{Call: _e.mock.On("UpdateNotebook", w, r)}
+}
+
+func (_c *NotebookHandlerMock_UpdateNotebook_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *NotebookHandlerMock_UpdateNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *NotebookHandlerMock_UpdateNotebook_Call) Return() *NotebookHandlerMock_UpdateNotebook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NotebookHandlerMock_UpdateNotebook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *NotebookHandlerMock_UpdateNotebook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewNotebookHandlerMock creates a new instance of NotebookHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotebookHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotebookHandlerMock {
+	mock := &NotebookHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
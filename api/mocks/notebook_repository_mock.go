@@ -0,0 +1,618 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotebookRepositoryMock is an autogenerated mock type for the NotebookRepository type
+type NotebookRepositoryMock struct {
+	mock.Mock
+}
+
+type NotebookRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotebookRepositoryMock) EXPECT() *NotebookRepositoryMock_Expecter {
+	return &NotebookRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// AddPost provides a mock function with given fields: ctx, notebookID, postID
+func (_m *NotebookRepositoryMock) AddPost(ctx context.Context, notebookID string, postID string) error {
+	ret := _m.Called(ctx, notebookID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, notebookID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookRepositoryMock_AddPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPost'
+type NotebookRepositoryMock_AddPost_Call struct {
+	*mock.Call
+}
+
+// AddPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebookID string
+//   - postID string
+func (_e *NotebookRepositoryMock_Expecter) AddPost(ctx interface{}, notebookID interface{}, postID interface{}) *NotebookRepositoryMock_AddPost_Call {
+	return &NotebookRepositoryMock_AddPost_Call{Call: _e.mock.On("AddPost", ctx, notebookID, postID)}
+}
+
+func (_c *NotebookRepositoryMock_AddPost_Call) Run(run func(ctx context.Context, notebookID string, postID string)) *NotebookRepositoryMock_AddPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_AddPost_Call) Return(_a0 error) *NotebookRepositoryMock_AddPost_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_AddPost_Call) RunAndReturn(run func(context.Context, string, string) error) *NotebookRepositoryMock_AddPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotebook provides a mock function with given fields: ctx, notebook
+func (_m *NotebookRepositoryMock) CreateNotebook(ctx context.Context, notebook *models.Notebook) error {
+	ret := _m.Called(ctx, notebook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotebook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Notebook) error); ok {
+		r0 = rf(ctx, notebook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookRepositoryMock_CreateNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotebook'
+type NotebookRepositoryMock_CreateNotebook_Call struct {
+	*mock.Call
+}
+
+// CreateNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebook *models.Notebook
+func (_e *NotebookRepositoryMock_Expecter) CreateNotebook(ctx interface{}, notebook interface{}) *NotebookRepositoryMock_CreateNotebook_Call {
+	return &NotebookRepositoryMock_CreateNotebook_Call{Call: _e.mock.On("CreateNotebook", ctx, notebook)}
+}
+
+func (_c *NotebookRepositoryMock_CreateNotebook_Call) Run(run func(ctx context.Context, notebook *models.Notebook)) *NotebookRepositoryMock_CreateNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Notebook))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_CreateNotebook_Call) Return(_a0 error) *NotebookRepositoryMock_CreateNotebook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_CreateNotebook_Call) RunAndReturn(run func(context.Context, *models.Notebook) error) *NotebookRepositoryMock_CreateNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNotebook provides a mock function with given fields: ctx, id
+func (_m *NotebookRepositoryMock) DeleteNotebook(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotebook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookRepositoryMock_DeleteNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotebook'
+type NotebookRepositoryMock_DeleteNotebook_Call struct {
+	*mock.Call
+}
+
+// DeleteNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *NotebookRepositoryMock_Expecter) DeleteNotebook(ctx interface{}, id interface{}) *NotebookRepositoryMock_DeleteNotebook_Call {
+	return &NotebookRepositoryMock_DeleteNotebook_Call{Call: _e.mock.On("DeleteNotebook", ctx, id)}
+}
+
+func (_c *NotebookRepositoryMock_DeleteNotebook_Call) Run(run func(ctx context.Context, id string)) *NotebookRepositoryMock_DeleteNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_DeleteNotebook_Call) Return(_a0 error) *NotebookRepositoryMock_DeleteNotebook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_DeleteNotebook_Call) RunAndReturn(run func(context.Context, string) error) *NotebookRepositoryMock_DeleteNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotebookByID provides a mock function with given fields: ctx, id
+func (_m *NotebookRepositoryMock) GetNotebookByID(ctx context.Context, id string) (*models.Notebook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotebookByID")
+	}
+
+	var r0 *models.Notebook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Notebook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Notebook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notebook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookRepositoryMock_GetNotebookByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotebookByID'
+type NotebookRepositoryMock_GetNotebookByID_Call struct {
+	*mock.Call
+}
+
+// GetNotebookByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *NotebookRepositoryMock_Expecter) GetNotebookByID(ctx interface{}, id interface{}) *NotebookRepositoryMock_GetNotebookByID_Call {
+	return &NotebookRepositoryMock_GetNotebookByID_Call{Call: _e.mock.On("GetNotebookByID", ctx, id)}
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookByID_Call) Run(run func(ctx context.Context, id string)) *NotebookRepositoryMock_GetNotebookByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookByID_Call) Return(_a0 *models.Notebook, _a1 error) *NotebookRepositoryMock_GetNotebookByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookByID_Call) RunAndReturn(run func(context.Context, string) (*models.Notebook, error)) *NotebookRepositoryMock_GetNotebookByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotebookBySlug provides a mock function with given fields: ctx, ownerID, slug
+func (_m *NotebookRepositoryMock) GetNotebookBySlug(ctx context.Context, ownerID string, slug string) (*models.Notebook, error) {
+	ret := _m.Called(ctx, ownerID, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotebookBySlug")
+	}
+
+	var r0 *models.Notebook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Notebook, error)); ok {
+		return rf(ctx, ownerID, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Notebook); ok {
+		r0 = rf(ctx, ownerID, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notebook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ownerID, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookRepositoryMock_GetNotebookBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotebookBySlug'
+type NotebookRepositoryMock_GetNotebookBySlug_Call struct {
+	*mock.Call
+}
+
+// GetNotebookBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - slug string
+func (_e *NotebookRepositoryMock_Expecter) GetNotebookBySlug(ctx interface{}, ownerID interface{}, slug interface{}) *NotebookRepositoryMock_GetNotebookBySlug_Call {
+	return &NotebookRepositoryMock_GetNotebookBySlug_Call{Call: _e.mock.On("GetNotebookBySlug", ctx, ownerID, slug)}
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookBySlug_Call) Run(run func(ctx context.Context, ownerID string, slug string)) *NotebookRepositoryMock_GetNotebookBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookBySlug_Call) Return(_a0 *models.Notebook, _a1 error) *NotebookRepositoryMock_GetNotebookBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookBySlug_Call) RunAndReturn(run func(context.Context, string, string) (*models.Notebook, error)) *NotebookRepositoryMock_GetNotebookBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotebookPosts provides a mock function with given fields: ctx, notebookID
+func (_m *NotebookRepositoryMock) GetNotebookPosts(ctx context.Context, notebookID string) ([]*models.NotebookPostResponse, error) {
+	ret := _m.Called(ctx, notebookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotebookPosts")
+	}
+
+	var r0 []*models.NotebookPostResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.NotebookPostResponse, error)); ok {
+		return rf(ctx, notebookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.NotebookPostResponse); ok {
+		r0 = rf(ctx, notebookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.NotebookPostResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notebookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookRepositoryMock_GetNotebookPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotebookPosts'
+type NotebookRepositoryMock_GetNotebookPosts_Call struct {
+	*mock.Call
+}
+
+// GetNotebookPosts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebookID string
+func (_e *NotebookRepositoryMock_Expecter) GetNotebookPosts(ctx interface{}, notebookID interface{}) *NotebookRepositoryMock_GetNotebookPosts_Call {
+	return &NotebookRepositoryMock_GetNotebookPosts_Call{Call: _e.mock.On("GetNotebookPosts", ctx, notebookID)}
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookPosts_Call) Run(run func(ctx context.Context, notebookID string)) *NotebookRepositoryMock_GetNotebookPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookPosts_Call) Return(_a0 []*models.NotebookPostResponse, _a1 error) *NotebookRepositoryMock_GetNotebookPosts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebookPosts_Call) RunAndReturn(run func(context.Context, string) ([]*models.NotebookPostResponse, error)) *NotebookRepositoryMock_GetNotebookPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotebooksByOwnerID provides a mock function with given fields: ctx, ownerID
+func (_m *NotebookRepositoryMock) GetNotebooksByOwnerID(ctx context.Context, ownerID string) ([]*models.Notebook, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotebooksByOwnerID")
+	}
+
+	var r0 []*models.Notebook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Notebook, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Notebook); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notebook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookRepositoryMock_GetNotebooksByOwnerID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotebooksByOwnerID'
+type NotebookRepositoryMock_GetNotebooksByOwnerID_Call struct {
+	*mock.Call
+}
+
+// GetNotebooksByOwnerID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *NotebookRepositoryMock_Expecter) GetNotebooksByOwnerID(ctx interface{}, ownerID interface{}) *NotebookRepositoryMock_GetNotebooksByOwnerID_Call {
+	return &NotebookRepositoryMock_GetNotebooksByOwnerID_Call{Call: _e.mock.On("GetNotebooksByOwnerID", ctx, ownerID)}
+}
+
+func (_c *NotebookRepositoryMock_GetNotebooksByOwnerID_Call) Run(run func(ctx context.Context, ownerID string)) *NotebookRepositoryMock_GetNotebooksByOwnerID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebooksByOwnerID_Call) Return(_a0 []*models.Notebook, _a1 error) *NotebookRepositoryMock_GetNotebooksByOwnerID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetNotebooksByOwnerID_Call) RunAndReturn(run func(context.Context, string) ([]*models.Notebook, error)) *NotebookRepositoryMock_GetNotebooksByOwnerID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPostIDs provides a mock function with given fields: ctx, notebookID
+func (_m *NotebookRepositoryMock) GetPostIDs(ctx context.Context, notebookID string) ([]string, error) {
+	ret := _m.Called(ctx, notebookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, notebookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, notebookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notebookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookRepositoryMock_GetPostIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostIDs'
+type NotebookRepositoryMock_GetPostIDs_Call struct {
+	*mock.Call
+}
+
+// GetPostIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebookID string
+func (_e *NotebookRepositoryMock_Expecter) GetPostIDs(ctx interface{}, notebookID interface{}) *NotebookRepositoryMock_GetPostIDs_Call {
+	return &NotebookRepositoryMock_GetPostIDs_Call{Call: _e.mock.On("GetPostIDs", ctx, notebookID)}
+}
+
+func (_c *NotebookRepositoryMock_GetPostIDs_Call) Run(run func(ctx context.Context, notebookID string)) *NotebookRepositoryMock_GetPostIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetPostIDs_Call) Return(_a0 []string, _a1 error) *NotebookRepositoryMock_GetPostIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_GetPostIDs_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *NotebookRepositoryMock_GetPostIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemovePost provides a mock function with given fields: ctx, notebookID, postID
+func (_m *NotebookRepositoryMock) RemovePost(ctx context.Context, notebookID string, postID string) error {
+	ret := _m.Called(ctx, notebookID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, notebookID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookRepositoryMock_RemovePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePost'
+type NotebookRepositoryMock_RemovePost_Call struct {
+	*mock.Call
+}
+
+// RemovePost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebookID string
+//   - postID string
+func (_e *NotebookRepositoryMock_Expecter) RemovePost(ctx interface{}, notebookID interface{}, postID interface{}) *NotebookRepositoryMock_RemovePost_Call {
+	return &NotebookRepositoryMock_RemovePost_Call{Call: _e.mock.On("RemovePost", ctx, notebookID, postID)}
+}
+
+func (_c *NotebookRepositoryMock_RemovePost_Call) Run(run func(ctx context.Context, notebookID string, postID string)) *NotebookRepositoryMock_RemovePost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_RemovePost_Call) Return(_a0 error) *NotebookRepositoryMock_RemovePost_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_RemovePost_Call) RunAndReturn(run func(context.Context, string, string) error) *NotebookRepositoryMock_RemovePost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderPosts provides a mock function with given fields: ctx, notebookID, postIDs
+func (_m *NotebookRepositoryMock) ReorderPosts(ctx context.Context, notebookID string, postIDs []string) error {
+	ret := _m.Called(ctx, notebookID, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderPosts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, notebookID, postIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookRepositoryMock_ReorderPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderPosts'
+type NotebookRepositoryMock_ReorderPosts_Call struct {
+	*mock.Call
+}
+
+// ReorderPosts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebookID string
+//   - postIDs []string
+func (_e *NotebookRepositoryMock_Expecter) ReorderPosts(ctx interface{}, notebookID interface{}, postIDs interface{}) *NotebookRepositoryMock_ReorderPosts_Call {
+	return &NotebookRepositoryMock_ReorderPosts_Call{Call: _e.mock.On("ReorderPosts", ctx, notebookID, postIDs)}
+}
+
+func (_c *NotebookRepositoryMock_ReorderPosts_Call) Run(run func(ctx context.Context, notebookID string, postIDs []string)) *NotebookRepositoryMock_ReorderPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_ReorderPosts_Call) Return(_a0 error) *NotebookRepositoryMock_ReorderPosts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_ReorderPosts_Call) RunAndReturn(run func(context.Context, string, []string) error) *NotebookRepositoryMock_ReorderPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotebook provides a mock function with given fields: ctx, notebook
+func (_m *NotebookRepositoryMock) UpdateNotebook(ctx context.Context, notebook *models.Notebook) error {
+	ret := _m.Called(ctx, notebook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotebook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Notebook) error); ok {
+		r0 = rf(ctx, notebook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookRepositoryMock_UpdateNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotebook'
+type NotebookRepositoryMock_UpdateNotebook_Call struct {
+	*mock.Call
+}
+
+// UpdateNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notebook *models.Notebook
+func (_e *NotebookRepositoryMock_Expecter) UpdateNotebook(ctx interface{}, notebook interface{}) *NotebookRepositoryMock_UpdateNotebook_Call {
+	return &NotebookRepositoryMock_UpdateNotebook_Call{Call: _e.mock.On("UpdateNotebook", ctx, notebook)}
+}
+
+func (_c *NotebookRepositoryMock_UpdateNotebook_Call) Run(run func(ctx context.Context, notebook *models.Notebook)) *NotebookRepositoryMock_UpdateNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Notebook))
+	})
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_UpdateNotebook_Call) Return(_a0 error) *NotebookRepositoryMock_UpdateNotebook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookRepositoryMock_UpdateNotebook_Call) RunAndReturn(run func(context.Context, *models.Notebook) error) *NotebookRepositoryMock_UpdateNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotebookRepositoryMock creates a new instance of NotebookRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotebookRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotebookRepositoryMock {
+	mock := &NotebookRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,523 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotebookServiceMock is an autogenerated mock type for the NotebookService type
+type NotebookServiceMock struct {
+	mock.Mock
+}
+
+type NotebookServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotebookServiceMock) EXPECT() *NotebookServiceMock_Expecter {
+	return &NotebookServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// AddPost provides a mock function with given fields: ctx, userID, notebookID, postID
+func (_m *NotebookServiceMock) AddPost(ctx context.Context, userID string, notebookID string, postID string) error {
+	ret := _m.Called(ctx, userID, notebookID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, notebookID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookServiceMock_AddPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPost'
+type NotebookServiceMock_AddPost_Call struct {
+	*mock.Call
+}
+
+// AddPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - notebookID string
+//   - postID string
+func (_e *NotebookServiceMock_Expecter) AddPost(ctx interface{}, userID interface{}, notebookID interface{}, postID interface{}) *NotebookServiceMock_AddPost_Call {
+	return &NotebookServiceMock_AddPost_Call{Call: _e.mock.On("AddPost", ctx, userID, notebookID, postID)}
+}
+
+func (_c *NotebookServiceMock_AddPost_Call) Run(run func(ctx context.Context, userID string, notebookID string, postID string)) *NotebookServiceMock_AddPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_AddPost_Call) Return(_a0 error) *NotebookServiceMock_AddPost_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookServiceMock_AddPost_Call) RunAndReturn(run func(context.Context, string, string, string) error) *NotebookServiceMock_AddPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotebook provides a mock function with given fields: ctx, userID, title, description
+func (_m *NotebookServiceMock) CreateNotebook(ctx context.Context, userID string, title string, description string) (*models.NotebookResponse, error) {
+	ret := _m.Called(ctx, userID, title, description)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotebook")
+	}
+
+	var r0 *models.NotebookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.NotebookResponse, error)); ok {
+		return rf(ctx, userID, title, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.NotebookResponse); ok {
+		r0 = rf(ctx, userID, title, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotebookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, title, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookServiceMock_CreateNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotebook'
+type NotebookServiceMock_CreateNotebook_Call struct {
+	*mock.Call
+}
+
+// CreateNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - title string
+//   - description string
+func (_e *NotebookServiceMock_Expecter) CreateNotebook(ctx interface{}, userID interface{}, title interface{}, description interface{}) *NotebookServiceMock_CreateNotebook_Call {
+	return &NotebookServiceMock_CreateNotebook_Call{Call: _e.mock.On("CreateNotebook", ctx, userID, title, description)}
+}
+
+func (_c *NotebookServiceMock_CreateNotebook_Call) Run(run func(ctx context.Context, userID string, title string, description string)) *NotebookServiceMock_CreateNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_CreateNotebook_Call) Return(_a0 *models.NotebookResponse, _a1 error) *NotebookServiceMock_CreateNotebook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookServiceMock_CreateNotebook_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.NotebookResponse, error)) *NotebookServiceMock_CreateNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNotebook provides a mock function with given fields: ctx, userID, notebookID
+func (_m *NotebookServiceMock) DeleteNotebook(ctx context.Context, userID string, notebookID string) error {
+	ret := _m.Called(ctx, userID, notebookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotebook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, notebookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookServiceMock_DeleteNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotebook'
+type NotebookServiceMock_DeleteNotebook_Call struct {
+	*mock.Call
+}
+
+// DeleteNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - notebookID string
+func (_e *NotebookServiceMock_Expecter) DeleteNotebook(ctx interface{}, userID interface{}, notebookID interface{}) *NotebookServiceMock_DeleteNotebook_Call {
+	return &NotebookServiceMock_DeleteNotebook_Call{Call: _e.mock.On("DeleteNotebook", ctx, userID, notebookID)}
+}
+
+func (_c *NotebookServiceMock_DeleteNotebook_Call) Run(run func(ctx context.Context, userID string, notebookID string)) *NotebookServiceMock_DeleteNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_DeleteNotebook_Call) Return(_a0 error) *NotebookServiceMock_DeleteNotebook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookServiceMock_DeleteNotebook_Call) RunAndReturn(run func(context.Context, string, string) error) *NotebookServiceMock_DeleteNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMyNotebook provides a mock function with given fields: ctx, userID, notebookID
+func (_m *NotebookServiceMock) GetMyNotebook(ctx context.Context, userID string, notebookID string) (*models.NotebookDetailResponse, error) {
+	ret := _m.Called(ctx, userID, notebookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMyNotebook")
+	}
+
+	var r0 *models.NotebookDetailResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.NotebookDetailResponse, error)); ok {
+		return rf(ctx, userID, notebookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.NotebookDetailResponse); ok {
+		r0 = rf(ctx, userID, notebookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotebookDetailResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, notebookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookServiceMock_GetMyNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMyNotebook'
+type NotebookServiceMock_GetMyNotebook_Call struct {
+	*mock.Call
+}
+
+// GetMyNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - notebookID string
+func (_e *NotebookServiceMock_Expecter) GetMyNotebook(ctx interface{}, userID interface{}, notebookID interface{}) *NotebookServiceMock_GetMyNotebook_Call {
+	return &NotebookServiceMock_GetMyNotebook_Call{Call: _e.mock.On("GetMyNotebook", ctx, userID, notebookID)}
+}
+
+func (_c *NotebookServiceMock_GetMyNotebook_Call) Run(run func(ctx context.Context, userID string, notebookID string)) *NotebookServiceMock_GetMyNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_GetMyNotebook_Call) Return(_a0 *models.NotebookDetailResponse, _a1 error) *NotebookServiceMock_GetMyNotebook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookServiceMock_GetMyNotebook_Call) RunAndReturn(run func(context.Context, string, string) (*models.NotebookDetailResponse, error)) *NotebookServiceMock_GetMyNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMyNotebooks provides a mock function with given fields: ctx, userID
+func (_m *NotebookServiceMock) GetMyNotebooks(ctx context.Context, userID string) ([]*models.NotebookResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMyNotebooks")
+	}
+
+	var r0 []*models.NotebookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.NotebookResponse, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.NotebookResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.NotebookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookServiceMock_GetMyNotebooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMyNotebooks'
+type NotebookServiceMock_GetMyNotebooks_Call struct {
+	*mock.Call
+}
+
+// GetMyNotebooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *NotebookServiceMock_Expecter) GetMyNotebooks(ctx interface{}, userID interface{}) *NotebookServiceMock_GetMyNotebooks_Call {
+	return &NotebookServiceMock_GetMyNotebooks_Call{Call: _e.mock.On("GetMyNotebooks", ctx, userID)}
+}
+
+func (_c *NotebookServiceMock_GetMyNotebooks_Call) Run(run func(ctx context.Context, userID string)) *NotebookServiceMock_GetMyNotebooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_GetMyNotebooks_Call) Return(_a0 []*models.NotebookResponse, _a1 error) *NotebookServiceMock_GetMyNotebooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookServiceMock_GetMyNotebooks_Call) RunAndReturn(run func(context.Context, string) ([]*models.NotebookResponse, error)) *NotebookServiceMock_GetMyNotebooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotebookBySlug provides a mock function with given fields: ctx, viewerID, username, slug
+func (_m *NotebookServiceMock) GetNotebookBySlug(ctx context.Context, viewerID string, username string, slug string) (*models.NotebookDetailResponse, error) {
+	ret := _m.Called(ctx, viewerID, username, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotebookBySlug")
+	}
+
+	var r0 *models.NotebookDetailResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.NotebookDetailResponse, error)); ok {
+		return rf(ctx, viewerID, username, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.NotebookDetailResponse); ok {
+		r0 = rf(ctx, viewerID, username, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotebookDetailResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, viewerID, username, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotebookServiceMock_GetNotebookBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotebookBySlug'
+type NotebookServiceMock_GetNotebookBySlug_Call struct {
+	*mock.Call
+}
+
+// GetNotebookBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerID string
+//   - username string
+//   - slug string
+func (_e *NotebookServiceMock_Expecter) GetNotebookBySlug(ctx interface{}, viewerID interface{}, username interface{}, slug interface{}) *NotebookServiceMock_GetNotebookBySlug_Call {
+	return &NotebookServiceMock_GetNotebookBySlug_Call{Call: _e.mock.On("GetNotebookBySlug", ctx, viewerID, username, slug)}
+}
+
+func (_c *NotebookServiceMock_GetNotebookBySlug_Call) Run(run func(ctx context.Context, viewerID string, username string, slug string)) *NotebookServiceMock_GetNotebookBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_GetNotebookBySlug_Call) Return(_a0 *models.NotebookDetailResponse, _a1 error) *NotebookServiceMock_GetNotebookBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotebookServiceMock_GetNotebookBySlug_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.NotebookDetailResponse, error)) *NotebookServiceMock_GetNotebookBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemovePost provides a mock function with given fields: ctx, userID, notebookID, postID
+func (_m *NotebookServiceMock) RemovePost(ctx context.Context, userID string, notebookID string, postID string) error {
+	ret := _m.Called(ctx, userID, notebookID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, notebookID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookServiceMock_RemovePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePost'
+type NotebookServiceMock_RemovePost_Call struct {
+	*mock.Call
+}
+
+// RemovePost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - notebookID string
+//   - postID string
+func (_e *NotebookServiceMock_Expecter) RemovePost(ctx interface{}, userID interface{}, notebookID interface{}, postID interface{}) *NotebookServiceMock_RemovePost_Call {
+	return &NotebookServiceMock_RemovePost_Call{Call: _e.mock.On("RemovePost", ctx, userID, notebookID, postID)}
+}
+
+func (_c *NotebookServiceMock_RemovePost_Call) Run(run func(ctx context.Context, userID string, notebookID string, postID string)) *NotebookServiceMock_RemovePost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_RemovePost_Call) Return(_a0 error) *NotebookServiceMock_RemovePost_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookServiceMock_RemovePost_Call) RunAndReturn(run func(context.Context, string, string, string) error) *NotebookServiceMock_RemovePost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderPosts provides a mock function with given fields: ctx, userID, notebookID, postIDs
+func (_m *NotebookServiceMock) ReorderPosts(ctx context.Context, userID string, notebookID string, postIDs []string) error {
+	ret := _m.Called(ctx, userID, notebookID, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderPosts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, userID, notebookID, postIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookServiceMock_ReorderPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderPosts'
+type NotebookServiceMock_ReorderPosts_Call struct {
+	*mock.Call
+}
+
+// ReorderPosts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - notebookID string
+//   - postIDs []string
+func (_e *NotebookServiceMock_Expecter) ReorderPosts(ctx interface{}, userID interface{}, notebookID interface{}, postIDs interface{}) *NotebookServiceMock_ReorderPosts_Call {
+	return &NotebookServiceMock_ReorderPosts_Call{Call: _e.mock.On("ReorderPosts", ctx, userID, notebookID, postIDs)}
+}
+
+func (_c *NotebookServiceMock_ReorderPosts_Call) Run(run func(ctx context.Context, userID string, notebookID string, postIDs []string)) *NotebookServiceMock_ReorderPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_ReorderPosts_Call) Return(_a0 error) *NotebookServiceMock_ReorderPosts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookServiceMock_ReorderPosts_Call) RunAndReturn(run func(context.Context, string, string, []string) error) *NotebookServiceMock_ReorderPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotebook provides a mock function with given fields: ctx, userID, notebookID, title, description
+func (_m *NotebookServiceMock) UpdateNotebook(ctx context.Context, userID string, notebookID string, title string, description string) error {
+	ret := _m.Called(ctx, userID, notebookID, title, description)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotebook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, userID, notebookID, title, description)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotebookServiceMock_UpdateNotebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotebook'
+type NotebookServiceMock_UpdateNotebook_Call struct {
+	*mock.Call
+}
+
+// UpdateNotebook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - notebookID string
+//   - title string
+//   - description string
+func (_e *NotebookServiceMock_Expecter) UpdateNotebook(ctx interface{}, userID interface{}, notebookID interface{}, title interface{}, description interface{}) *NotebookServiceMock_UpdateNotebook_Call {
+	return &NotebookServiceMock_UpdateNotebook_Call{Call: _e.mock.On("UpdateNotebook", ctx, userID, notebookID, title, description)}
+}
+
+func (_c *NotebookServiceMock_UpdateNotebook_Call) Run(run func(ctx context.Context, userID string, notebookID string, title string, description string)) *NotebookServiceMock_UpdateNotebook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *NotebookServiceMock_UpdateNotebook_Call) Return(_a0 error) *NotebookServiceMock_UpdateNotebook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotebookServiceMock_UpdateNotebook_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *NotebookServiceMock_UpdateNotebook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotebookServiceMock creates a new instance of NotebookServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotebookServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotebookServiceMock {
+	mock := &NotebookServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,138 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenHandlerMock is an autogenerated mock type for the PersonalAccessTokenHandler type
+type PersonalAccessTokenHandlerMock struct {
+	mock.Mock
+}
+
+type PersonalAccessTokenHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersonalAccessTokenHandlerMock) EXPECT() *PersonalAccessTokenHandlerMock_Expecter {
+	return &PersonalAccessTokenHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateToken provides a mock function with given fields: w, r
+func (_m *PersonalAccessTokenHandlerMock) CreateToken(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PersonalAccessTokenHandlerMock_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type PersonalAccessTokenHandlerMock_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PersonalAccessTokenHandlerMock_Expecter) CreateToken(w interface{}, r interface{}) *PersonalAccessTokenHandlerMock_CreateToken_Call {
+	return &PersonalAccessTokenHandlerMock_CreateToken_Call{Call: _e.mock.On("CreateToken", w, r)}
+}
+
+func (_c *PersonalAccessTokenHandlerMock_CreateToken_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PersonalAccessTokenHandlerMock_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenHandlerMock_CreateToken_Call) Return() *PersonalAccessTokenHandlerMock_CreateToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PersonalAccessTokenHandlerMock_CreateToken_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PersonalAccessTokenHandlerMock_CreateToken_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetTokens provides a mock function with given fields: w, r
+func (_m *PersonalAccessTokenHandlerMock) GetTokens(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PersonalAccessTokenHandlerMock_GetTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokens'
+type PersonalAccessTokenHandlerMock_GetTokens_Call struct {
+	*mock.Call
+}
+
+// GetTokens is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PersonalAccessTokenHandlerMock_Expecter) GetTokens(w interface{}, r interface{}) *PersonalAccessTokenHandlerMock_GetTokens_Call {
+	return &PersonalAccessTokenHandlerMock_GetTokens_Call{Call: _e.mock.On("GetTokens", w, r)}
+}
+
+func (_c *PersonalAccessTokenHandlerMock_GetTokens_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PersonalAccessTokenHandlerMock_GetTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenHandlerMock_GetTokens_Call) Return() *PersonalAccessTokenHandlerMock_GetTokens_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PersonalAccessTokenHandlerMock_GetTokens_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PersonalAccessTokenHandlerMock_GetTokens_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: w, r
+func (_m *PersonalAccessTokenHandlerMock) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PersonalAccessTokenHandlerMock_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type PersonalAccessTokenHandlerMock_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PersonalAccessTokenHandlerMock_Expecter) RevokeToken(w interface{}, r interface{}) *PersonalAccessTokenHandlerMock_RevokeToken_Call {
+	return &PersonalAccessTokenHandlerMock_RevokeToken_Call{Call: _e.mock.On("RevokeToken", w, r)}
+}
+
+func (_c *PersonalAccessTokenHandlerMock_RevokeToken_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PersonalAccessTokenHandlerMock_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenHandlerMock_RevokeToken_Call) Return() *PersonalAccessTokenHandlerMock_RevokeToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PersonalAccessTokenHandlerMock_RevokeToken_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PersonalAccessTokenHandlerMock_RevokeToken_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewPersonalAccessTokenHandlerMock creates a new instance of PersonalAccessTokenHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenHandlerMock {
+	mock := &PersonalAccessTokenHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,359 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PersonalAccessTokenRepositoryMock is an autogenerated mock type for the PersonalAccessTokenRepository type
+type PersonalAccessTokenRepositoryMock struct {
+	mock.Mock
+}
+
+type PersonalAccessTokenRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersonalAccessTokenRepositoryMock) EXPECT() *PersonalAccessTokenRepositoryMock_Expecter {
+	return &PersonalAccessTokenRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateToken provides a mock function with given fields: ctx, token
+func (_m *PersonalAccessTokenRepositoryMock) CreateToken(ctx context.Context, token *models.PersonalAccessToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PersonalAccessToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenRepositoryMock_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type PersonalAccessTokenRepositoryMock_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *models.PersonalAccessToken
+func (_e *PersonalAccessTokenRepositoryMock_Expecter) CreateToken(ctx interface{}, token interface{}) *PersonalAccessTokenRepositoryMock_CreateToken_Call {
+	return &PersonalAccessTokenRepositoryMock_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, token)}
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_CreateToken_Call) Run(run func(ctx context.Context, token *models.PersonalAccessToken)) *PersonalAccessTokenRepositoryMock_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PersonalAccessToken))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_CreateToken_Call) Return(_a0 error) *PersonalAccessTokenRepositoryMock_CreateToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_CreateToken_Call) RunAndReturn(run func(context.Context, *models.PersonalAccessToken) error) *PersonalAccessTokenRepositoryMock_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *PersonalAccessTokenRepositoryMock) GetTokenByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenByHash")
+	}
+
+	var r0 *models.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PersonalAccessToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PersonalAccessToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenRepositoryMock_GetTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenByHash'
+type PersonalAccessTokenRepositoryMock_GetTokenByHash_Call struct {
+	*mock.Call
+}
+
+// GetTokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *PersonalAccessTokenRepositoryMock_Expecter) GetTokenByHash(ctx interface{}, tokenHash interface{}) *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call {
+	return &PersonalAccessTokenRepositoryMock_GetTokenByHash_Call{Call: _e.mock.On("GetTokenByHash", ctx, tokenHash)}
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call) Return(_a0 *models.PersonalAccessToken, _a1 error) *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call) RunAndReturn(run func(context.Context, string) (*models.PersonalAccessToken, error)) *PersonalAccessTokenRepositoryMock_GetTokenByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenByID provides a mock function with given fields: ctx, id
+func (_m *PersonalAccessTokenRepositoryMock) GetTokenByID(ctx context.Context, id string) (*models.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenByID")
+	}
+
+	var r0 *models.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PersonalAccessToken, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PersonalAccessToken); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenRepositoryMock_GetTokenByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenByID'
+type PersonalAccessTokenRepositoryMock_GetTokenByID_Call struct {
+	*mock.Call
+}
+
+// GetTokenByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *PersonalAccessTokenRepositoryMock_Expecter) GetTokenByID(ctx interface{}, id interface{}) *PersonalAccessTokenRepositoryMock_GetTokenByID_Call {
+	return &PersonalAccessTokenRepositoryMock_GetTokenByID_Call{Call: _e.mock.On("GetTokenByID", ctx, id)}
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokenByID_Call) Run(run func(ctx context.Context, id string)) *PersonalAccessTokenRepositoryMock_GetTokenByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokenByID_Call) Return(_a0 *models.PersonalAccessToken, _a1 error) *PersonalAccessTokenRepositoryMock_GetTokenByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokenByID_Call) RunAndReturn(run func(context.Context, string) (*models.PersonalAccessToken, error)) *PersonalAccessTokenRepositoryMock_GetTokenByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokensByUserID provides a mock function with given fields: ctx, userID
+func (_m *PersonalAccessTokenRepositoryMock) GetTokensByUserID(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokensByUserID")
+	}
+
+	var r0 []*models.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.PersonalAccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.PersonalAccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokensByUserID'
+type PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call struct {
+	*mock.Call
+}
+
+// GetTokensByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PersonalAccessTokenRepositoryMock_Expecter) GetTokensByUserID(ctx interface{}, userID interface{}) *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call {
+	return &PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call{Call: _e.mock.On("GetTokensByUserID", ctx, userID)}
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call) Run(run func(ctx context.Context, userID string)) *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call) Return(_a0 []*models.PersonalAccessToken, _a1 error) *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call) RunAndReturn(run func(context.Context, string) ([]*models.PersonalAccessToken, error)) *PersonalAccessTokenRepositoryMock_GetTokensByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: ctx, id, revokedAt
+func (_m *PersonalAccessTokenRepositoryMock) RevokeToken(ctx context.Context, id string, revokedAt time.Time) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenRepositoryMock_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type PersonalAccessTokenRepositoryMock_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - revokedAt time.Time
+func (_e *PersonalAccessTokenRepositoryMock_Expecter) RevokeToken(ctx interface{}, id interface{}, revokedAt interface{}) *PersonalAccessTokenRepositoryMock_RevokeToken_Call {
+	return &PersonalAccessTokenRepositoryMock_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, id, revokedAt)}
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_RevokeToken_Call) Run(run func(ctx context.Context, id string, revokedAt time.Time)) *PersonalAccessTokenRepositoryMock_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_RevokeToken_Call) Return(_a0 error) *PersonalAccessTokenRepositoryMock_RevokeToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_RevokeToken_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *PersonalAccessTokenRepositoryMock_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchToken provides a mock function with given fields: ctx, id, usedAt
+func (_m *PersonalAccessTokenRepositoryMock) TouchToken(ctx context.Context, id string, usedAt time.Time) error {
+	ret := _m.Called(ctx, id, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenRepositoryMock_TouchToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchToken'
+type PersonalAccessTokenRepositoryMock_TouchToken_Call struct {
+	*mock.Call
+}
+
+// TouchToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - usedAt time.Time
+func (_e *PersonalAccessTokenRepositoryMock_Expecter) TouchToken(ctx interface{}, id interface{}, usedAt interface{}) *PersonalAccessTokenRepositoryMock_TouchToken_Call {
+	return &PersonalAccessTokenRepositoryMock_TouchToken_Call{Call: _e.mock.On("TouchToken", ctx, id, usedAt)}
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_TouchToken_Call) Run(run func(ctx context.Context, id string, usedAt time.Time)) *PersonalAccessTokenRepositoryMock_TouchToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_TouchToken_Call) Return(_a0 error) *PersonalAccessTokenRepositoryMock_TouchToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalAccessTokenRepositoryMock_TouchToken_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *PersonalAccessTokenRepositoryMock_TouchToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPersonalAccessTokenRepositoryMock creates a new instance of PersonalAccessTokenRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenRepositoryMock {
+	mock := &PersonalAccessTokenRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenServiceMock is an autogenerated mock type for the PersonalAccessTokenService type
+type PersonalAccessTokenServiceMock struct {
+	mock.Mock
+}
+
+type PersonalAccessTokenServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersonalAccessTokenServiceMock) EXPECT() *PersonalAccessTokenServiceMock_Expecter {
+	return &PersonalAccessTokenServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *PersonalAccessTokenServiceMock) Authenticate(ctx context.Context, token string) (*models.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *models.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PersonalAccessToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PersonalAccessToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenServiceMock_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type PersonalAccessTokenServiceMock_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *PersonalAccessTokenServiceMock_Expecter) Authenticate(ctx interface{}, token interface{}) *PersonalAccessTokenServiceMock_Authenticate_Call {
+	return &PersonalAccessTokenServiceMock_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, token)}
+}
+
+func (_c *PersonalAccessTokenServiceMock_Authenticate_Call) Run(run func(ctx context.Context, token string)) *PersonalAccessTokenServiceMock_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_Authenticate_Call) Return(_a0 *models.PersonalAccessToken, _a1 error) *PersonalAccessTokenServiceMock_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_Authenticate_Call) RunAndReturn(run func(context.Context, string) (*models.PersonalAccessToken, error)) *PersonalAccessTokenServiceMock_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateToken provides a mock function with given fields: ctx, userID, payload
+func (_m *PersonalAccessTokenServiceMock) CreateToken(ctx context.Context, userID string, payload *models.CreatePersonalAccessTokenPayload) (*models.CreatedPersonalAccessTokenResponse, error) {
+	ret := _m.Called(ctx, userID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *models.CreatedPersonalAccessTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CreatePersonalAccessTokenPayload) (*models.CreatedPersonalAccessTokenResponse, error)); ok {
+		return rf(ctx, userID, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CreatePersonalAccessTokenPayload) *models.CreatedPersonalAccessTokenResponse); ok {
+		r0 = rf(ctx, userID, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CreatedPersonalAccessTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.CreatePersonalAccessTokenPayload) error); ok {
+		r1 = rf(ctx, userID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenServiceMock_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type PersonalAccessTokenServiceMock_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - payload *models.CreatePersonalAccessTokenPayload
+func (_e *PersonalAccessTokenServiceMock_Expecter) CreateToken(ctx interface{}, userID interface{}, payload interface{}) *PersonalAccessTokenServiceMock_CreateToken_Call {
+	return &PersonalAccessTokenServiceMock_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, userID, payload)}
+}
+
+func (_c *PersonalAccessTokenServiceMock_CreateToken_Call) Run(run func(ctx context.Context, userID string, payload *models.CreatePersonalAccessTokenPayload)) *PersonalAccessTokenServiceMock_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.CreatePersonalAccessTokenPayload))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_CreateToken_Call) Return(_a0 *models.CreatedPersonalAccessTokenResponse, _a1 error) *PersonalAccessTokenServiceMock_CreateToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_CreateToken_Call) RunAndReturn(run func(context.Context, string, *models.CreatePersonalAccessTokenPayload) (*models.CreatedPersonalAccessTokenResponse, error)) *PersonalAccessTokenServiceMock_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokens provides a mock function with given fields: ctx, userID
+func (_m *PersonalAccessTokenServiceMock) GetTokens(ctx context.Context, userID string) ([]*models.PersonalAccessTokenResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokens")
+	}
+
+	var r0 []*models.PersonalAccessTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.PersonalAccessTokenResponse, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.PersonalAccessTokenResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PersonalAccessTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenServiceMock_GetTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokens'
+type PersonalAccessTokenServiceMock_GetTokens_Call struct {
+	*mock.Call
+}
+
+// GetTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PersonalAccessTokenServiceMock_Expecter) GetTokens(ctx interface{}, userID interface{}) *PersonalAccessTokenServiceMock_GetTokens_Call {
+	return &PersonalAccessTokenServiceMock_GetTokens_Call{Call: _e.mock.On("GetTokens", ctx, userID)}
+}
+
+func (_c *PersonalAccessTokenServiceMock_GetTokens_Call) Run(run func(ctx context.Context, userID string)) *PersonalAccessTokenServiceMock_GetTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_GetTokens_Call) Return(_a0 []*models.PersonalAccessTokenResponse, _a1 error) *PersonalAccessTokenServiceMock_GetTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_GetTokens_Call) RunAndReturn(run func(context.Context, string) ([]*models.PersonalAccessTokenResponse, error)) *PersonalAccessTokenServiceMock_GetTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: ctx, userID, tokenID
+func (_m *PersonalAccessTokenServiceMock) RevokeToken(ctx context.Context, userID string, tokenID string) error {
+	ret := _m.Called(ctx, userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenServiceMock_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type PersonalAccessTokenServiceMock_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tokenID string
+func (_e *PersonalAccessTokenServiceMock_Expecter) RevokeToken(ctx interface{}, userID interface{}, tokenID interface{}) *PersonalAccessTokenServiceMock_RevokeToken_Call {
+	return &PersonalAccessTokenServiceMock_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, userID, tokenID)}
+}
+
+func (_c *PersonalAccessTokenServiceMock_RevokeToken_Call) Run(run func(ctx context.Context, userID string, tokenID string)) *PersonalAccessTokenServiceMock_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_RevokeToken_Call) Return(_a0 error) *PersonalAccessTokenServiceMock_RevokeToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalAccessTokenServiceMock_RevokeToken_Call) RunAndReturn(run func(context.Context, string, string) error) *PersonalAccessTokenServiceMock_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPersonalAccessTokenServiceMock creates a new instance of PersonalAccessTokenServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenServiceMock {
+	mock := &PersonalAccessTokenServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PollHandlerMock is an autogenerated mock type for the PollHandler type
+type PollHandlerMock struct {
+	mock.Mock
+}
+
+type PollHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PollHandlerMock) EXPECT() *PollHandlerMock_Expecter {
+	return &PollHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// Vote provides a mock function with given fields: w, r
+func (_m *PollHandlerMock) Vote(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PollHandlerMock_Vote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Vote'
+type PollHandlerMock_Vote_Call struct {
+	*mock.Call
+}
+
+// Vote is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PollHandlerMock_Expecter) Vote(w interface{}, r interface{}) *PollHandlerMock_Vote_Call {
+	return &PollHandlerMock_Vote_Call{Call: _e.mock.On("Vote", w, r)}
+}
+
+func (_c *PollHandlerMock_Vote_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PollHandlerMock_Vote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PollHandlerMock_Vote_Call) Return() *PollHandlerMock_Vote_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PollHandlerMock_Vote_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PollHandlerMock_Vote_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewPollHandlerMock creates a new instance of PollHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPollHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PollHandlerMock {
+	mock := &PollHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,250 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PollRepositoryMock is an autogenerated mock type for the PollRepository type
+type PollRepositoryMock struct {
+	mock.Mock
+}
+
+type PollRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PollRepositoryMock) EXPECT() *PollRepositoryMock_Expecter {
+	return &PollRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// CreatePoll provides a mock function with given fields: ctx, poll
+func (_m *PollRepositoryMock) CreatePoll(ctx context.Context, poll *models.Poll) error {
+	ret := _m.Called(ctx, poll)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePoll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Poll) error); ok {
+		r0 = rf(ctx, poll)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PollRepositoryMock_CreatePoll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePoll'
+type PollRepositoryMock_CreatePoll_Call struct {
+	*mock.Call
+}
+
+// CreatePoll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - poll *models.Poll
+func (_e *PollRepositoryMock_Expecter) CreatePoll(ctx interface{}, poll interface{}) *PollRepositoryMock_CreatePoll_Call {
+	return &PollRepositoryMock_CreatePoll_Call{Call: _e.mock.On("CreatePoll", ctx, poll)}
+}
+
+func (_c *PollRepositoryMock_CreatePoll_Call) Run(run func(ctx context.Context, poll *models.Poll)) *PollRepositoryMock_CreatePoll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Poll))
+	})
+	return _c
+}
+
+func (_c *PollRepositoryMock_CreatePoll_Call) Return(_a0 error) *PollRepositoryMock_CreatePoll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PollRepositoryMock_CreatePoll_Call) RunAndReturn(run func(context.Context, *models.Poll) error) *PollRepositoryMock_CreatePoll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPollsByPostIDs provides a mock function with given fields: ctx, postIDs
+func (_m *PollRepositoryMock) GetPollsByPostIDs(ctx context.Context, postIDs []string) ([]*models.Poll, error) {
+	ret := _m.Called(ctx, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPollsByPostIDs")
+	}
+
+	var r0 []*models.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*models.Poll, error)); ok {
+		return rf(ctx, postIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.Poll); ok {
+		r0 = rf(ctx, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollRepositoryMock_GetPollsByPostIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPollsByPostIDs'
+type PollRepositoryMock_GetPollsByPostIDs_Call struct {
+	*mock.Call
+}
+
+// GetPollsByPostIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postIDs []string
+func (_e *PollRepositoryMock_Expecter) GetPollsByPostIDs(ctx interface{}, postIDs interface{}) *PollRepositoryMock_GetPollsByPostIDs_Call {
+	return &PollRepositoryMock_GetPollsByPostIDs_Call{Call: _e.mock.On("GetPollsByPostIDs", ctx, postIDs)}
+}
+
+func (_c *PollRepositoryMock_GetPollsByPostIDs_Call) Run(run func(ctx context.Context, postIDs []string)) *PollRepositoryMock_GetPollsByPostIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *PollRepositoryMock_GetPollsByPostIDs_Call) Return(_a0 []*models.Poll, _a1 error) *PollRepositoryMock_GetPollsByPostIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollRepositoryMock_GetPollsByPostIDs_Call) RunAndReturn(run func(context.Context, []string) ([]*models.Poll, error)) *PollRepositoryMock_GetPollsByPostIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVotedOptionIDs provides a mock function with given fields: ctx, userID, pollIDs
+func (_m *PollRepositoryMock) GetVotedOptionIDs(ctx context.Context, userID string, pollIDs []string) (map[string]string, error) {
+	ret := _m.Called(ctx, userID, pollIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVotedOptionIDs")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]string, error)); ok {
+		return rf(ctx, userID, pollIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]string); ok {
+		r0 = rf(ctx, userID, pollIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, pollIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollRepositoryMock_GetVotedOptionIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVotedOptionIDs'
+type PollRepositoryMock_GetVotedOptionIDs_Call struct {
+	*mock.Call
+}
+
+// GetVotedOptionIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - pollIDs []string
+func (_e *PollRepositoryMock_Expecter) GetVotedOptionIDs(ctx interface{}, userID interface{}, pollIDs interface{}) *PollRepositoryMock_GetVotedOptionIDs_Call {
+	return &PollRepositoryMock_GetVotedOptionIDs_Call{Call: _e.mock.On("GetVotedOptionIDs", ctx, userID, pollIDs)}
+}
+
+func (_c *PollRepositoryMock_GetVotedOptionIDs_Call) Run(run func(ctx context.Context, userID string, pollIDs []string)) *PollRepositoryMock_GetVotedOptionIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *PollRepositoryMock_GetVotedOptionIDs_Call) Return(_a0 map[string]string, _a1 error) *PollRepositoryMock_GetVotedOptionIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollRepositoryMock_GetVotedOptionIDs_Call) RunAndReturn(run func(context.Context, string, []string) (map[string]string, error)) *PollRepositoryMock_GetVotedOptionIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Vote provides a mock function with given fields: ctx, vote
+func (_m *PollRepositoryMock) Vote(ctx context.Context, vote *models.PollVote) error {
+	ret := _m.Called(ctx, vote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PollVote) error); ok {
+		r0 = rf(ctx, vote)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PollRepositoryMock_Vote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Vote'
+type PollRepositoryMock_Vote_Call struct {
+	*mock.Call
+}
+
+// Vote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vote *models.PollVote
+func (_e *PollRepositoryMock_Expecter) Vote(ctx interface{}, vote interface{}) *PollRepositoryMock_Vote_Call {
+	return &PollRepositoryMock_Vote_Call{Call: _e.mock.On("Vote", ctx, vote)}
+}
+
+func (_c *PollRepositoryMock_Vote_Call) Run(run func(ctx context.Context, vote *models.PollVote)) *PollRepositoryMock_Vote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PollVote))
+	})
+	return _c
+}
+
+func (_c *PollRepositoryMock_Vote_Call) Return(_a0 error) *PollRepositoryMock_Vote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PollRepositoryMock_Vote_Call) RunAndReturn(run func(context.Context, *models.PollVote) error) *PollRepositoryMock_Vote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPollRepositoryMock creates a new instance of PollRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPollRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PollRepositoryMock {
+	mock := &PollRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
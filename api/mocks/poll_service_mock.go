@@ -0,0 +1,218 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PollServiceMock is an autogenerated mock type for the PollService type
+type PollServiceMock struct {
+	mock.Mock
+}
+
+type PollServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PollServiceMock) EXPECT() *PollServiceMock_Expecter {
+	return &PollServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// CreatePoll provides a mock function with given fields: ctx, postID, payload
+func (_m *PollServiceMock) CreatePoll(ctx context.Context, postID string, payload *models.CreatePollPayload) (*models.PollResponse, error) {
+	ret := _m.Called(ctx, postID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePoll")
+	}
+
+	var r0 *models.PollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CreatePollPayload) (*models.PollResponse, error)); ok {
+		return rf(ctx, postID, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.CreatePollPayload) *models.PollResponse); ok {
+		r0 = rf(ctx, postID, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.CreatePollPayload) error); ok {
+		r1 = rf(ctx, postID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollServiceMock_CreatePoll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePoll'
+type PollServiceMock_CreatePoll_Call struct {
+	*mock.Call
+}
+
+// CreatePoll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+//   - payload *models.CreatePollPayload
+func (_e *PollServiceMock_Expecter) CreatePoll(ctx interface{}, postID interface{}, payload interface{}) *PollServiceMock_CreatePoll_Call {
+	return &PollServiceMock_CreatePoll_Call{Call: _e.mock.On("CreatePoll", ctx, postID, payload)}
+}
+
+func (_c *PollServiceMock_CreatePoll_Call) Run(run func(ctx context.Context, postID string, payload *models.CreatePollPayload)) *PollServiceMock_CreatePoll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.CreatePollPayload))
+	})
+	return _c
+}
+
+func (_c *PollServiceMock_CreatePoll_Call) Return(_a0 *models.PollResponse, _a1 error) *PollServiceMock_CreatePoll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollServiceMock_CreatePoll_Call) RunAndReturn(run func(context.Context, string, *models.CreatePollPayload) (*models.PollResponse, error)) *PollServiceMock_CreatePoll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPolls provides a mock function with given fields: ctx, userID, postIDs
+func (_m *PollServiceMock) GetPolls(ctx context.Context, userID string, postIDs []string) (map[string]*models.PollResponse, error) {
+	ret := _m.Called(ctx, userID, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolls")
+	}
+
+	var r0 map[string]*models.PollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]*models.PollResponse, error)); ok {
+		return rf(ctx, userID, postIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]*models.PollResponse); ok {
+		r0 = rf(ctx, userID, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*models.PollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollServiceMock_GetPolls_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPolls'
+type PollServiceMock_GetPolls_Call struct {
+	*mock.Call
+}
+
+// GetPolls is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postIDs []string
+func (_e *PollServiceMock_Expecter) GetPolls(ctx interface{}, userID interface{}, postIDs interface{}) *PollServiceMock_GetPolls_Call {
+	return &PollServiceMock_GetPolls_Call{Call: _e.mock.On("GetPolls", ctx, userID, postIDs)}
+}
+
+func (_c *PollServiceMock_GetPolls_Call) Run(run func(ctx context.Context, userID string, postIDs []string)) *PollServiceMock_GetPolls_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *PollServiceMock_GetPolls_Call) Return(_a0 map[string]*models.PollResponse, _a1 error) *PollServiceMock_GetPolls_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollServiceMock_GetPolls_Call) RunAndReturn(run func(context.Context, string, []string) (map[string]*models.PollResponse, error)) *PollServiceMock_GetPolls_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Vote provides a mock function with given fields: ctx, userID, postID, optionID
+func (_m *PollServiceMock) Vote(ctx context.Context, userID string, postID string, optionID string) (*models.PollResponse, error) {
+	ret := _m.Called(ctx, userID, postID, optionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 *models.PollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.PollResponse, error)); ok {
+		return rf(ctx, userID, postID, optionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.PollResponse); ok {
+		r0 = rf(ctx, userID, postID, optionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, postID, optionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollServiceMock_Vote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Vote'
+type PollServiceMock_Vote_Call struct {
+	*mock.Call
+}
+
+// Vote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postID string
+//   - optionID string
+func (_e *PollServiceMock_Expecter) Vote(ctx interface{}, userID interface{}, postID interface{}, optionID interface{}) *PollServiceMock_Vote_Call {
+	return &PollServiceMock_Vote_Call{Call: _e.mock.On("Vote", ctx, userID, postID, optionID)}
+}
+
+func (_c *PollServiceMock_Vote_Call) Run(run func(ctx context.Context, userID string, postID string, optionID string)) *PollServiceMock_Vote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *PollServiceMock_Vote_Call) Return(_a0 *models.PollResponse, _a1 error) *PollServiceMock_Vote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollServiceMock_Vote_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.PollResponse, error)) *PollServiceMock_Vote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPollServiceMock creates a new instance of PollServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPollServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PollServiceMock {
+	mock := &PollServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
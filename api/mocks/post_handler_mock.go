@@ -89,6 +89,74 @@ func (_c *PostHandlerMock_DeletePost_Call) RunAndReturn(run func(http.ResponseWr
 	return _c
 }
 
+// GetBacklinks provides a mock function with given fields: w, r
+func (_m *PostHandlerMock) GetBacklinks(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PostHandlerMock_GetBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklinks'
+type PostHandlerMock_GetBacklinks_Call struct {
+	*mock.Call
+}
+
+// GetBacklinks is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PostHandlerMock_Expecter) GetBacklinks(w interface{}, r interface{}) *PostHandlerMock_GetBacklinks_Call {
+	return &PostHandlerMock_GetBacklinks_Call{Call: _e.mock.On("GetBacklinks", w, r)}
+}
+
+func (_c *PostHandlerMock_GetBacklinks_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PostHandlerMock_GetBacklinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PostHandlerMock_GetBacklinks_Call) Return() *PostHandlerMock_GetBacklinks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PostHandlerMock_GetBacklinks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PostHandlerMock_GetBacklinks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetOEmbed provides a mock function with given fields: w, r
+func (_m *PostHandlerMock) GetOEmbed(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PostHandlerMock_GetOEmbed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOEmbed'
+type PostHandlerMock_GetOEmbed_Call struct {
+	*mock.Call
+}
+
+// GetOEmbed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PostHandlerMock_Expecter) GetOEmbed(w interface{}, r interface{}) *PostHandlerMock_GetOEmbed_Call {
+	return &PostHandlerMock_GetOEmbed_Call{Call: _e.mock.On("GetOEmbed", w, r)}
+}
+
+func (_c *PostHandlerMock_GetOEmbed_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PostHandlerMock_GetOEmbed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PostHandlerMock_GetOEmbed_Call) Return() *PostHandlerMock_GetOEmbed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PostHandlerMock_GetOEmbed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PostHandlerMock_GetOEmbed_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetPostByID provides a mock function with given fields: w, r
 func (_m *PostHandlerMock) GetPostByID(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -123,6 +191,40 @@ func (_c *PostHandlerMock_GetPostByID_Call) RunAndReturn(run func(http.ResponseW
 	return _c
 }
 
+// GetPostEmbed provides a mock function with given fields: w, r
+func (_m *PostHandlerMock) GetPostEmbed(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PostHandlerMock_GetPostEmbed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostEmbed'
+type PostHandlerMock_GetPostEmbed_Call struct {
+	*mock.Call
+}
+
+// GetPostEmbed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PostHandlerMock_Expecter) GetPostEmbed(w interface{}, r interface{}) *PostHandlerMock_GetPostEmbed_Call {
+	return &PostHandlerMock_GetPostEmbed_Call{Call: _e.mock.On("GetPostEmbed", w, r)}
+}
+
+func (_c *PostHandlerMock_GetPostEmbed_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PostHandlerMock_GetPostEmbed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PostHandlerMock_GetPostEmbed_Call) Return() *PostHandlerMock_GetPostEmbed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PostHandlerMock_GetPostEmbed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PostHandlerMock_GetPostEmbed_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetPostsByAuthorID provides a mock function with given fields: w, r
 func (_m *PostHandlerMock) GetPostsByAuthorID(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -191,6 +293,74 @@ func (_c *PostHandlerMock_GetPostsByUsername_Call) RunAndReturn(run func(http.Re
 	return _c
 }
 
+// GetUserAtomFeed provides a mock function with given fields: w, r
+func (_m *PostHandlerMock) GetUserAtomFeed(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PostHandlerMock_GetUserAtomFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAtomFeed'
+type PostHandlerMock_GetUserAtomFeed_Call struct {
+	*mock.Call
+}
+
+// GetUserAtomFeed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PostHandlerMock_Expecter) GetUserAtomFeed(w interface{}, r interface{}) *PostHandlerMock_GetUserAtomFeed_Call {
+	return &PostHandlerMock_GetUserAtomFeed_Call{Call: _e.mock.On("GetUserAtomFeed", w, r)}
+}
+
+func (_c *PostHandlerMock_GetUserAtomFeed_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PostHandlerMock_GetUserAtomFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PostHandlerMock_GetUserAtomFeed_Call) Return() *PostHandlerMock_GetUserAtomFeed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PostHandlerMock_GetUserAtomFeed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PostHandlerMock_GetUserAtomFeed_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetUserRSSFeed provides a mock function with given fields: w, r
+func (_m *PostHandlerMock) GetUserRSSFeed(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PostHandlerMock_GetUserRSSFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRSSFeed'
+type PostHandlerMock_GetUserRSSFeed_Call struct {
+	*mock.Call
+}
+
+// GetUserRSSFeed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PostHandlerMock_Expecter) GetUserRSSFeed(w interface{}, r interface{}) *PostHandlerMock_GetUserRSSFeed_Call {
+	return &PostHandlerMock_GetUserRSSFeed_Call{Call: _e.mock.On("GetUserRSSFeed", w, r)}
+}
+
+func (_c *PostHandlerMock_GetUserRSSFeed_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PostHandlerMock_GetUserRSSFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PostHandlerMock_GetUserRSSFeed_Call) Return() *PostHandlerMock_GetUserRSSFeed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PostHandlerMock_GetUserRSSFeed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PostHandlerMock_GetUserRSSFeed_Call {
+	_c.Run(run)
+	return _c
+}
+
 // LikePost provides a mock function with given fields: w, r
 func (_m *PostHandlerMock) LikePost(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -225,6 +395,40 @@ func (_c *PostHandlerMock_LikePost_Call) RunAndReturn(run func(http.ResponseWrit
 	return _c
 }
 
+// PatchPost provides a mock function with given fields: w, r
+func (_m *PostHandlerMock) PatchPost(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// PostHandlerMock_PatchPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchPost'
+type PostHandlerMock_PatchPost_Call struct {
+	*mock.Call
+}
+
+// PatchPost is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *PostHandlerMock_Expecter) PatchPost(w interface{}, r interface{}) *PostHandlerMock_PatchPost_Call {
+	return &PostHandlerMock_PatchPost_Call{Call: _e.mock.On("PatchPost", w, r)}
+}
+
+func (_c *PostHandlerMock_PatchPost_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *PostHandlerMock_PatchPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *PostHandlerMock_PatchPost_Call) Return() *PostHandlerMock_PatchPost_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PostHandlerMock_PatchPost_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *PostHandlerMock_PatchPost_Call {
+	_c.Run(run)
+	return _c
+}
+
 // UnlikePost provides a mock function with given fields: w, r
 func (_m *PostHandlerMock) UnlikePost(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
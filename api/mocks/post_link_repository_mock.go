@@ -0,0 +1,254 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PostLinkRepositoryMock is an autogenerated mock type for the PostLinkRepository type
+type PostLinkRepositoryMock struct {
+	mock.Mock
+}
+
+type PostLinkRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PostLinkRepositoryMock) EXPECT() *PostLinkRepositoryMock_Expecter {
+	return &PostLinkRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// GetBacklinks provides a mock function with given fields: ctx, viewerID, targetPostID
+func (_m *PostLinkRepositoryMock) GetBacklinks(ctx context.Context, viewerID string, targetPostID string) ([]*models.BacklinkResponse, error) {
+	ret := _m.Called(ctx, viewerID, targetPostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBacklinks")
+	}
+
+	var r0 []*models.BacklinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.BacklinkResponse, error)); ok {
+		return rf(ctx, viewerID, targetPostID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.BacklinkResponse); ok {
+		r0 = rf(ctx, viewerID, targetPostID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BacklinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, viewerID, targetPostID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostLinkRepositoryMock_GetBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklinks'
+type PostLinkRepositoryMock_GetBacklinks_Call struct {
+	*mock.Call
+}
+
+// GetBacklinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerID string
+//   - targetPostID string
+func (_e *PostLinkRepositoryMock_Expecter) GetBacklinks(ctx interface{}, viewerID interface{}, targetPostID interface{}) *PostLinkRepositoryMock_GetBacklinks_Call {
+	return &PostLinkRepositoryMock_GetBacklinks_Call{Call: _e.mock.On("GetBacklinks", ctx, viewerID, targetPostID)}
+}
+
+func (_c *PostLinkRepositoryMock_GetBacklinks_Call) Run(run func(ctx context.Context, viewerID string, targetPostID string)) *PostLinkRepositoryMock_GetBacklinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_GetBacklinks_Call) Return(_a0 []*models.BacklinkResponse, _a1 error) *PostLinkRepositoryMock_GetBacklinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_GetBacklinks_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.BacklinkResponse, error)) *PostLinkRepositoryMock_GetBacklinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLinksBySourcePostIDs provides a mock function with given fields: ctx, viewerID, sourcePostIDs
+func (_m *PostLinkRepositoryMock) GetLinksBySourcePostIDs(ctx context.Context, viewerID string, sourcePostIDs []string) ([]*models.PostLink, error) {
+	ret := _m.Called(ctx, viewerID, sourcePostIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinksBySourcePostIDs")
+	}
+
+	var r0 []*models.PostLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]*models.PostLink, error)); ok {
+		return rf(ctx, viewerID, sourcePostIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []*models.PostLink); ok {
+		r0 = rf(ctx, viewerID, sourcePostIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PostLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, viewerID, sourcePostIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinksBySourcePostIDs'
+type PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call struct {
+	*mock.Call
+}
+
+// GetLinksBySourcePostIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerID string
+//   - sourcePostIDs []string
+func (_e *PostLinkRepositoryMock_Expecter) GetLinksBySourcePostIDs(ctx interface{}, viewerID interface{}, sourcePostIDs interface{}) *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call {
+	return &PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call{Call: _e.mock.On("GetLinksBySourcePostIDs", ctx, viewerID, sourcePostIDs)}
+}
+
+func (_c *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call) Run(run func(ctx context.Context, viewerID string, sourcePostIDs []string)) *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call) Return(_a0 []*models.PostLink, _a1 error) *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call) RunAndReturn(run func(context.Context, string, []string) ([]*models.PostLink, error)) *PostLinkRepositoryMock_GetLinksBySourcePostIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplacePostLinks provides a mock function with given fields: ctx, sourcePostID, links
+func (_m *PostLinkRepositoryMock) ReplacePostLinks(ctx context.Context, sourcePostID string, links []*models.PostLink) error {
+	ret := _m.Called(ctx, sourcePostID, links)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplacePostLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*models.PostLink) error); ok {
+		r0 = rf(ctx, sourcePostID, links)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostLinkRepositoryMock_ReplacePostLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplacePostLinks'
+type PostLinkRepositoryMock_ReplacePostLinks_Call struct {
+	*mock.Call
+}
+
+// ReplacePostLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourcePostID string
+//   - links []*models.PostLink
+func (_e *PostLinkRepositoryMock_Expecter) ReplacePostLinks(ctx interface{}, sourcePostID interface{}, links interface{}) *PostLinkRepositoryMock_ReplacePostLinks_Call {
+	return &PostLinkRepositoryMock_ReplacePostLinks_Call{Call: _e.mock.On("ReplacePostLinks", ctx, sourcePostID, links)}
+}
+
+func (_c *PostLinkRepositoryMock_ReplacePostLinks_Call) Run(run func(ctx context.Context, sourcePostID string, links []*models.PostLink)) *PostLinkRepositoryMock_ReplacePostLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]*models.PostLink))
+	})
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_ReplacePostLinks_Call) Return(_a0 error) *PostLinkRepositoryMock_ReplacePostLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_ReplacePostLinks_Call) RunAndReturn(run func(context.Context, string, []*models.PostLink) error) *PostLinkRepositoryMock_ReplacePostLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolvePendingLinks provides a mock function with given fields: ctx, authorID, title, targetPostID
+func (_m *PostLinkRepositoryMock) ResolvePendingLinks(ctx context.Context, authorID string, title string, targetPostID string) error {
+	ret := _m.Called(ctx, authorID, title, targetPostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolvePendingLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, authorID, title, targetPostID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostLinkRepositoryMock_ResolvePendingLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolvePendingLinks'
+type PostLinkRepositoryMock_ResolvePendingLinks_Call struct {
+	*mock.Call
+}
+
+// ResolvePendingLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - title string
+//   - targetPostID string
+func (_e *PostLinkRepositoryMock_Expecter) ResolvePendingLinks(ctx interface{}, authorID interface{}, title interface{}, targetPostID interface{}) *PostLinkRepositoryMock_ResolvePendingLinks_Call {
+	return &PostLinkRepositoryMock_ResolvePendingLinks_Call{Call: _e.mock.On("ResolvePendingLinks", ctx, authorID, title, targetPostID)}
+}
+
+func (_c *PostLinkRepositoryMock_ResolvePendingLinks_Call) Run(run func(ctx context.Context, authorID string, title string, targetPostID string)) *PostLinkRepositoryMock_ResolvePendingLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_ResolvePendingLinks_Call) Return(_a0 error) *PostLinkRepositoryMock_ResolvePendingLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PostLinkRepositoryMock_ResolvePendingLinks_Call) RunAndReturn(run func(context.Context, string, string, string) error) *PostLinkRepositoryMock_ResolvePendingLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPostLinkRepositoryMock creates a new instance of PostLinkRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostLinkRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostLinkRepositoryMock {
+	mock := &PostLinkRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PostLinkServiceMock is an autogenerated mock type for the PostLinkService type
+type PostLinkServiceMock struct {
+	mock.Mock
+}
+
+type PostLinkServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PostLinkServiceMock) EXPECT() *PostLinkServiceMock_Expecter {
+	return &PostLinkServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// GetBacklinks provides a mock function with given fields: ctx, userID, postID
+func (_m *PostLinkServiceMock) GetBacklinks(ctx context.Context, userID string, postID string) ([]*models.BacklinkResponse, error) {
+	ret := _m.Called(ctx, userID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBacklinks")
+	}
+
+	var r0 []*models.BacklinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.BacklinkResponse, error)); ok {
+		return rf(ctx, userID, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.BacklinkResponse); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BacklinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostLinkServiceMock_GetBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklinks'
+type PostLinkServiceMock_GetBacklinks_Call struct {
+	*mock.Call
+}
+
+// GetBacklinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postID string
+func (_e *PostLinkServiceMock_Expecter) GetBacklinks(ctx interface{}, userID interface{}, postID interface{}) *PostLinkServiceMock_GetBacklinks_Call {
+	return &PostLinkServiceMock_GetBacklinks_Call{Call: _e.mock.On("GetBacklinks", ctx, userID, postID)}
+}
+
+func (_c *PostLinkServiceMock_GetBacklinks_Call) Run(run func(ctx context.Context, userID string, postID string)) *PostLinkServiceMock_GetBacklinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PostLinkServiceMock_GetBacklinks_Call) Return(_a0 []*models.BacklinkResponse, _a1 error) *PostLinkServiceMock_GetBacklinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostLinkServiceMock_GetBacklinks_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.BacklinkResponse, error)) *PostLinkServiceMock_GetBacklinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLinks provides a mock function with given fields: ctx, userID, postIDs
+func (_m *PostLinkServiceMock) GetLinks(ctx context.Context, userID string, postIDs []string) (map[string][]*models.PostLinkResponse, error) {
+	ret := _m.Called(ctx, userID, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinks")
+	}
+
+	var r0 map[string][]*models.PostLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string][]*models.PostLinkResponse, error)); ok {
+		return rf(ctx, userID, postIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string][]*models.PostLinkResponse); ok {
+		r0 = rf(ctx, userID, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]*models.PostLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostLinkServiceMock_GetLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinks'
+type PostLinkServiceMock_GetLinks_Call struct {
+	*mock.Call
+}
+
+// GetLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postIDs []string
+func (_e *PostLinkServiceMock_Expecter) GetLinks(ctx interface{}, userID interface{}, postIDs interface{}) *PostLinkServiceMock_GetLinks_Call {
+	return &PostLinkServiceMock_GetLinks_Call{Call: _e.mock.On("GetLinks", ctx, userID, postIDs)}
+}
+
+func (_c *PostLinkServiceMock_GetLinks_Call) Run(run func(ctx context.Context, userID string, postIDs []string)) *PostLinkServiceMock_GetLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *PostLinkServiceMock_GetLinks_Call) Return(_a0 map[string][]*models.PostLinkResponse, _a1 error) *PostLinkServiceMock_GetLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostLinkServiceMock_GetLinks_Call) RunAndReturn(run func(context.Context, string, []string) (map[string][]*models.PostLinkResponse, error)) *PostLinkServiceMock_GetLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolvePendingLinks provides a mock function with given fields: ctx, post
+func (_m *PostLinkServiceMock) ResolvePendingLinks(ctx context.Context, post *models.Post) error {
+	ret := _m.Called(ctx, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolvePendingLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Post) error); ok {
+		r0 = rf(ctx, post)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostLinkServiceMock_ResolvePendingLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolvePendingLinks'
+type PostLinkServiceMock_ResolvePendingLinks_Call struct {
+	*mock.Call
+}
+
+// ResolvePendingLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - post *models.Post
+func (_e *PostLinkServiceMock_Expecter) ResolvePendingLinks(ctx interface{}, post interface{}) *PostLinkServiceMock_ResolvePendingLinks_Call {
+	return &PostLinkServiceMock_ResolvePendingLinks_Call{Call: _e.mock.On("ResolvePendingLinks", ctx, post)}
+}
+
+func (_c *PostLinkServiceMock_ResolvePendingLinks_Call) Run(run func(ctx context.Context, post *models.Post)) *PostLinkServiceMock_ResolvePendingLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Post))
+	})
+	return _c
+}
+
+func (_c *PostLinkServiceMock_ResolvePendingLinks_Call) Return(_a0 error) *PostLinkServiceMock_ResolvePendingLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PostLinkServiceMock_ResolvePendingLinks_Call) RunAndReturn(run func(context.Context, *models.Post) error) *PostLinkServiceMock_ResolvePendingLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncLinks provides a mock function with given fields: ctx, post
+func (_m *PostLinkServiceMock) SyncLinks(ctx context.Context, post *models.Post) error {
+	ret := _m.Called(ctx, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Post) error); ok {
+		r0 = rf(ctx, post)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostLinkServiceMock_SyncLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncLinks'
+type PostLinkServiceMock_SyncLinks_Call struct {
+	*mock.Call
+}
+
+// SyncLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - post *models.Post
+func (_e *PostLinkServiceMock_Expecter) SyncLinks(ctx interface{}, post interface{}) *PostLinkServiceMock_SyncLinks_Call {
+	return &PostLinkServiceMock_SyncLinks_Call{Call: _e.mock.On("SyncLinks", ctx, post)}
+}
+
+func (_c *PostLinkServiceMock_SyncLinks_Call) Run(run func(ctx context.Context, post *models.Post)) *PostLinkServiceMock_SyncLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Post))
+	})
+	return _c
+}
+
+func (_c *PostLinkServiceMock_SyncLinks_Call) Return(_a0 error) *PostLinkServiceMock_SyncLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PostLinkServiceMock_SyncLinks_Call) RunAndReturn(run func(context.Context, *models.Post) error) *PostLinkServiceMock_SyncLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPostLinkServiceMock creates a new instance of PostLinkServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostLinkServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostLinkServiceMock {
+	mock := &PostLinkServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
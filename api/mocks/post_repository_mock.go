@@ -116,6 +116,66 @@ func (_c *PostRepositoryMock_DeletePost_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetPostByContentHash provides a mock function with given fields: ctx, authorID, contentHash
+func (_m *PostRepositoryMock) GetPostByContentHash(ctx context.Context, authorID string, contentHash string) (*models.Post, error) {
+	ret := _m.Called(ctx, authorID, contentHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostByContentHash")
+	}
+
+	var r0 *models.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Post, error)); ok {
+		return rf(ctx, authorID, contentHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Post); ok {
+		r0 = rf(ctx, authorID, contentHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, authorID, contentHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepositoryMock_GetPostByContentHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostByContentHash'
+type PostRepositoryMock_GetPostByContentHash_Call struct {
+	*mock.Call
+}
+
+// GetPostByContentHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - contentHash string
+func (_e *PostRepositoryMock_Expecter) GetPostByContentHash(ctx interface{}, authorID interface{}, contentHash interface{}) *PostRepositoryMock_GetPostByContentHash_Call {
+	return &PostRepositoryMock_GetPostByContentHash_Call{Call: _e.mock.On("GetPostByContentHash", ctx, authorID, contentHash)}
+}
+
+func (_c *PostRepositoryMock_GetPostByContentHash_Call) Run(run func(ctx context.Context, authorID string, contentHash string)) *PostRepositoryMock_GetPostByContentHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PostRepositoryMock_GetPostByContentHash_Call) Return(_a0 *models.Post, _a1 error) *PostRepositoryMock_GetPostByContentHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepositoryMock_GetPostByContentHash_Call) RunAndReturn(run func(context.Context, string, string) (*models.Post, error)) *PostRepositoryMock_GetPostByContentHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPostByID provides a mock function with given fields: ctx, ID
 func (_m *PostRepositoryMock) GetPostByID(ctx context.Context, ID string) (*models.Post, error) {
 	ret := _m.Called(ctx, ID)
@@ -175,6 +235,66 @@ func (_c *PostRepositoryMock_GetPostByID_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetPostByTitle provides a mock function with given fields: ctx, authorID, title
+func (_m *PostRepositoryMock) GetPostByTitle(ctx context.Context, authorID string, title string) (*models.Post, error) {
+	ret := _m.Called(ctx, authorID, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostByTitle")
+	}
+
+	var r0 *models.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Post, error)); ok {
+		return rf(ctx, authorID, title)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Post); ok {
+		r0 = rf(ctx, authorID, title)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, authorID, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepositoryMock_GetPostByTitle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostByTitle'
+type PostRepositoryMock_GetPostByTitle_Call struct {
+	*mock.Call
+}
+
+// GetPostByTitle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - title string
+func (_e *PostRepositoryMock_Expecter) GetPostByTitle(ctx interface{}, authorID interface{}, title interface{}) *PostRepositoryMock_GetPostByTitle_Call {
+	return &PostRepositoryMock_GetPostByTitle_Call{Call: _e.mock.On("GetPostByTitle", ctx, authorID, title)}
+}
+
+func (_c *PostRepositoryMock_GetPostByTitle_Call) Run(run func(ctx context.Context, authorID string, title string)) *PostRepositoryMock_GetPostByTitle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PostRepositoryMock_GetPostByTitle_Call) Return(_a0 *models.Post, _a1 error) *PostRepositoryMock_GetPostByTitle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepositoryMock_GetPostByTitle_Call) RunAndReturn(run func(context.Context, string, string) (*models.Post, error)) *PostRepositoryMock_GetPostByTitle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPostsByAuthorID provides a mock function with given fields: ctx, authorID
 func (_m *PostRepositoryMock) GetPostsByAuthorID(ctx context.Context, authorID string) ([]*models.Post, error) {
 	ret := _m.Called(ctx, authorID)
@@ -235,21 +355,31 @@ func (_c *PostRepositoryMock_GetPostsByAuthorID_Call) RunAndReturn(run func(cont
 }
 
 // UpdatePost provides a mock function with given fields: ctx, post
-func (_m *PostRepositoryMock) UpdatePost(ctx context.Context, post *models.Post) error {
+func (_m *PostRepositoryMock) UpdatePost(ctx context.Context, post *models.Post) (bool, error) {
 	ret := _m.Called(ctx, post)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdatePost")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Post) error); ok {
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Post) (bool, error)); ok {
+		return rf(ctx, post)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Post) bool); ok {
 		r0 = rf(ctx, post)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Post) error); ok {
+		r1 = rf(ctx, post)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // PostRepositoryMock_UpdatePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePost'
@@ -271,12 +401,12 @@ func (_c *PostRepositoryMock_UpdatePost_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *PostRepositoryMock_UpdatePost_Call) Return(_a0 error) *PostRepositoryMock_UpdatePost_Call {
-	_c.Call.Return(_a0)
+func (_c *PostRepositoryMock_UpdatePost_Call) Return(_a0 bool, _a1 error) *PostRepositoryMock_UpdatePost_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *PostRepositoryMock_UpdatePost_Call) RunAndReturn(run func(context.Context, *models.Post) error) *PostRepositoryMock_UpdatePost_Call {
+func (_c *PostRepositoryMock_UpdatePost_Call) RunAndReturn(run func(context.Context, *models.Post) (bool, error)) *PostRepositoryMock_UpdatePost_Call {
 	_c.Call.Return(run)
 	return _c
 }
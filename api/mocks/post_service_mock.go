@@ -22,9 +22,9 @@ func (_m *PostServiceMock) EXPECT() *PostServiceMock_Expecter {
 	return &PostServiceMock_Expecter{mock: &_m.Mock}
 }
 
-// CreatePost provides a mock function with given fields: ctx, userID, title, content
-func (_m *PostServiceMock) CreatePost(ctx context.Context, userID string, title string, content string) (*models.PostResponse, error) {
-	ret := _m.Called(ctx, userID, title, content)
+// CreatePost provides a mock function with given fields: ctx, userID, title, content, visibility, poll
+func (_m *PostServiceMock) CreatePost(ctx context.Context, userID string, title string, content string, visibility models.PostVisibility, poll *models.CreatePollPayload) (*models.PostResponse, error) {
+	ret := _m.Called(ctx, userID, title, content, visibility, poll)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreatePost")
@@ -32,19 +32,19 @@ func (_m *PostServiceMock) CreatePost(ctx context.Context, userID string, title
 
 	var r0 *models.PostResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.PostResponse, error)); ok {
-		return rf(ctx, userID, title, content)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, models.PostVisibility, *models.CreatePollPayload) (*models.PostResponse, error)); ok {
+		return rf(ctx, userID, title, content, visibility, poll)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.PostResponse); ok {
-		r0 = rf(ctx, userID, title, content)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, models.PostVisibility, *models.CreatePollPayload) *models.PostResponse); ok {
+		r0 = rf(ctx, userID, title, content, visibility, poll)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.PostResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, userID, title, content)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, models.PostVisibility, *models.CreatePollPayload) error); ok {
+		r1 = rf(ctx, userID, title, content, visibility, poll)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +62,15 @@ type PostServiceMock_CreatePost_Call struct {
 //   - userID string
 //   - title string
 //   - content string
-func (_e *PostServiceMock_Expecter) CreatePost(ctx interface{}, userID interface{}, title interface{}, content interface{}) *PostServiceMock_CreatePost_Call {
-	return &PostServiceMock_CreatePost_Call{Call: _e.mock.On("CreatePost", ctx, userID, title, content)}
+//   - visibility models.PostVisibility
+//   - poll *models.CreatePollPayload
+func (_e *PostServiceMock_Expecter) CreatePost(ctx interface{}, userID interface{}, title interface{}, content interface{}, visibility interface{}, poll interface{}) *PostServiceMock_CreatePost_Call {
+	return &PostServiceMock_CreatePost_Call{Call: _e.mock.On("CreatePost", ctx, userID, title, content, visibility, poll)}
 }
 
-func (_c *PostServiceMock_CreatePost_Call) Run(run func(ctx context.Context, userID string, title string, content string)) *PostServiceMock_CreatePost_Call {
+func (_c *PostServiceMock_CreatePost_Call) Run(run func(ctx context.Context, userID string, title string, content string, visibility models.PostVisibility, poll *models.CreatePollPayload)) *PostServiceMock_CreatePost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(models.PostVisibility), args[5].(*models.CreatePollPayload))
 	})
 	return _c
 }
@@ -78,7 +80,7 @@ func (_c *PostServiceMock_CreatePost_Call) Return(_a0 *models.PostResponse, _a1
 	return _c
 }
 
-func (_c *PostServiceMock_CreatePost_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.PostResponse, error)) *PostServiceMock_CreatePost_Call {
+func (_c *PostServiceMock_CreatePost_Call) RunAndReturn(run func(context.Context, string, string, string, models.PostVisibility, *models.CreatePollPayload) (*models.PostResponse, error)) *PostServiceMock_CreatePost_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -131,6 +133,66 @@ func (_c *PostServiceMock_DeletePost_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetBacklinks provides a mock function with given fields: ctx, userID, postID
+func (_m *PostServiceMock) GetBacklinks(ctx context.Context, userID string, postID string) ([]*models.BacklinkResponse, error) {
+	ret := _m.Called(ctx, userID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBacklinks")
+	}
+
+	var r0 []*models.BacklinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.BacklinkResponse, error)); ok {
+		return rf(ctx, userID, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.BacklinkResponse); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BacklinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostServiceMock_GetBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklinks'
+type PostServiceMock_GetBacklinks_Call struct {
+	*mock.Call
+}
+
+// GetBacklinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postID string
+func (_e *PostServiceMock_Expecter) GetBacklinks(ctx interface{}, userID interface{}, postID interface{}) *PostServiceMock_GetBacklinks_Call {
+	return &PostServiceMock_GetBacklinks_Call{Call: _e.mock.On("GetBacklinks", ctx, userID, postID)}
+}
+
+func (_c *PostServiceMock_GetBacklinks_Call) Run(run func(ctx context.Context, userID string, postID string)) *PostServiceMock_GetBacklinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PostServiceMock_GetBacklinks_Call) Return(_a0 []*models.BacklinkResponse, _a1 error) *PostServiceMock_GetBacklinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostServiceMock_GetBacklinks_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.BacklinkResponse, error)) *PostServiceMock_GetBacklinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPostByID provides a mock function with given fields: ctx, userID, ID
 func (_m *PostServiceMock) GetPostByID(ctx context.Context, userID string, ID string) (*models.PostResponse, error) {
 	ret := _m.Called(ctx, userID, ID)
@@ -191,6 +253,65 @@ func (_c *PostServiceMock_GetPostByID_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// GetPostEmbed provides a mock function with given fields: ctx, ID
+func (_m *PostServiceMock) GetPostEmbed(ctx context.Context, ID string) (*models.PostEmbed, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostEmbed")
+	}
+
+	var r0 *models.PostEmbed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PostEmbed, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PostEmbed); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PostEmbed)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostServiceMock_GetPostEmbed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostEmbed'
+type PostServiceMock_GetPostEmbed_Call struct {
+	*mock.Call
+}
+
+// GetPostEmbed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID string
+func (_e *PostServiceMock_Expecter) GetPostEmbed(ctx interface{}, ID interface{}) *PostServiceMock_GetPostEmbed_Call {
+	return &PostServiceMock_GetPostEmbed_Call{Call: _e.mock.On("GetPostEmbed", ctx, ID)}
+}
+
+func (_c *PostServiceMock_GetPostEmbed_Call) Run(run func(ctx context.Context, ID string)) *PostServiceMock_GetPostEmbed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PostServiceMock_GetPostEmbed_Call) Return(_a0 *models.PostEmbed, _a1 error) *PostServiceMock_GetPostEmbed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostServiceMock_GetPostEmbed_Call) RunAndReturn(run func(context.Context, string) (*models.PostEmbed, error)) *PostServiceMock_GetPostEmbed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPostsByAuthorID provides a mock function with given fields: ctx, authorID
 func (_m *PostServiceMock) GetPostsByAuthorID(ctx context.Context, authorID string) ([]*models.PostResponse, error) {
 	ret := _m.Called(ctx, authorID)
@@ -310,6 +431,64 @@ func (_c *PostServiceMock_GetPostsByUsername_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ImportPost provides a mock function with given fields: ctx, userID, imported
+func (_m *PostServiceMock) ImportPost(ctx context.Context, userID string, imported *models.ImportedPost) (bool, error) {
+	ret := _m.Called(ctx, userID, imported)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportPost")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ImportedPost) (bool, error)); ok {
+		return rf(ctx, userID, imported)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ImportedPost) bool); ok {
+		r0 = rf(ctx, userID, imported)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ImportedPost) error); ok {
+		r1 = rf(ctx, userID, imported)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostServiceMock_ImportPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportPost'
+type PostServiceMock_ImportPost_Call struct {
+	*mock.Call
+}
+
+// ImportPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - imported *models.ImportedPost
+func (_e *PostServiceMock_Expecter) ImportPost(ctx interface{}, userID interface{}, imported interface{}) *PostServiceMock_ImportPost_Call {
+	return &PostServiceMock_ImportPost_Call{Call: _e.mock.On("ImportPost", ctx, userID, imported)}
+}
+
+func (_c *PostServiceMock_ImportPost_Call) Run(run func(ctx context.Context, userID string, imported *models.ImportedPost)) *PostServiceMock_ImportPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ImportedPost))
+	})
+	return _c
+}
+
+func (_c *PostServiceMock_ImportPost_Call) Return(_a0 bool, _a1 error) *PostServiceMock_ImportPost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostServiceMock_ImportPost_Call) RunAndReturn(run func(context.Context, string, *models.ImportedPost) (bool, error)) *PostServiceMock_ImportPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LikePost provides a mock function with given fields: ctx, userID, postID
 func (_m *PostServiceMock) LikePost(ctx context.Context, userID string, postID string) error {
 	ret := _m.Called(ctx, userID, postID)
@@ -358,6 +537,66 @@ func (_c *PostServiceMock_LikePost_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// PatchPost provides a mock function with given fields: ctx, userID, ID, payload, version
+func (_m *PostServiceMock) PatchPost(ctx context.Context, userID string, ID string, payload *models.PatchPostPayload, version int) (int, error) {
+	ret := _m.Called(ctx, userID, ID, payload, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchPost")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PatchPostPayload, int) (int, error)); ok {
+		return rf(ctx, userID, ID, payload, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PatchPostPayload, int) int); ok {
+		r0 = rf(ctx, userID, ID, payload, version)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.PatchPostPayload, int) error); ok {
+		r1 = rf(ctx, userID, ID, payload, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostServiceMock_PatchPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchPost'
+type PostServiceMock_PatchPost_Call struct {
+	*mock.Call
+}
+
+// PatchPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - ID string
+//   - payload *models.PatchPostPayload
+//   - version int
+func (_e *PostServiceMock_Expecter) PatchPost(ctx interface{}, userID interface{}, ID interface{}, payload interface{}, version interface{}) *PostServiceMock_PatchPost_Call {
+	return &PostServiceMock_PatchPost_Call{Call: _e.mock.On("PatchPost", ctx, userID, ID, payload, version)}
+}
+
+func (_c *PostServiceMock_PatchPost_Call) Run(run func(ctx context.Context, userID string, ID string, payload *models.PatchPostPayload, version int)) *PostServiceMock_PatchPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.PatchPostPayload), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *PostServiceMock_PatchPost_Call) Return(_a0 int, _a1 error) *PostServiceMock_PatchPost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostServiceMock_PatchPost_Call) RunAndReturn(run func(context.Context, string, string, *models.PatchPostPayload, int) (int, error)) *PostServiceMock_PatchPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnlikePost provides a mock function with given fields: ctx, userID, postID
 func (_m *PostServiceMock) UnlikePost(ctx context.Context, userID string, postID string) error {
 	ret := _m.Called(ctx, userID, postID)
@@ -406,22 +645,32 @@ func (_c *PostServiceMock_UnlikePost_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// UpdatePost provides a mock function with given fields: ctx, userID, ID, title, content
-func (_m *PostServiceMock) UpdatePost(ctx context.Context, userID string, ID string, title string, content string) error {
-	ret := _m.Called(ctx, userID, ID, title, content)
+// UpdatePost provides a mock function with given fields: ctx, userID, ID, title, content, visibility, version
+func (_m *PostServiceMock) UpdatePost(ctx context.Context, userID string, ID string, title string, content string, visibility models.PostVisibility, version int) (int, error) {
+	ret := _m.Called(ctx, userID, ID, title, content, visibility, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdatePost")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, userID, ID, title, content)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, models.PostVisibility, int) (int, error)); ok {
+		return rf(ctx, userID, ID, title, content, visibility, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, models.PostVisibility, int) int); ok {
+		r0 = rf(ctx, userID, ID, title, content, visibility, version)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, models.PostVisibility, int) error); ok {
+		r1 = rf(ctx, userID, ID, title, content, visibility, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // PostServiceMock_UpdatePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePost'
@@ -435,23 +684,25 @@ type PostServiceMock_UpdatePost_Call struct {
 //   - ID string
 //   - title string
 //   - content string
-func (_e *PostServiceMock_Expecter) UpdatePost(ctx interface{}, userID interface{}, ID interface{}, title interface{}, content interface{}) *PostServiceMock_UpdatePost_Call {
-	return &PostServiceMock_UpdatePost_Call{Call: _e.mock.On("UpdatePost", ctx, userID, ID, title, content)}
+//   - visibility models.PostVisibility
+//   - version int
+func (_e *PostServiceMock_Expecter) UpdatePost(ctx interface{}, userID interface{}, ID interface{}, title interface{}, content interface{}, visibility interface{}, version interface{}) *PostServiceMock_UpdatePost_Call {
+	return &PostServiceMock_UpdatePost_Call{Call: _e.mock.On("UpdatePost", ctx, userID, ID, title, content, visibility, version)}
 }
 
-func (_c *PostServiceMock_UpdatePost_Call) Run(run func(ctx context.Context, userID string, ID string, title string, content string)) *PostServiceMock_UpdatePost_Call {
+func (_c *PostServiceMock_UpdatePost_Call) Run(run func(ctx context.Context, userID string, ID string, title string, content string, visibility models.PostVisibility, version int)) *PostServiceMock_UpdatePost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(models.PostVisibility), args[6].(int))
 	})
 	return _c
 }
 
-func (_c *PostServiceMock_UpdatePost_Call) Return(_a0 error) *PostServiceMock_UpdatePost_Call {
-	_c.Call.Return(_a0)
+func (_c *PostServiceMock_UpdatePost_Call) Return(_a0 int, _a1 error) *PostServiceMock_UpdatePost_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *PostServiceMock_UpdatePost_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *PostServiceMock_UpdatePost_Call {
+func (_c *PostServiceMock_UpdatePost_Call) RunAndReturn(run func(context.Context, string, string, string, string, models.PostVisibility, int) (int, error)) *PostServiceMock_UpdatePost_Call {
 	_c.Call.Return(run)
 	return _c
 }
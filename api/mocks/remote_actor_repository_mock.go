@@ -0,0 +1,396 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RemoteActorRepositoryMock is an autogenerated mock type for the RemoteActorRepository type
+type RemoteActorRepositoryMock struct {
+	mock.Mock
+}
+
+type RemoteActorRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RemoteActorRepositoryMock) EXPECT() *RemoteActorRepositoryMock_Expecter {
+	return &RemoteActorRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// AddFollower provides a mock function with given fields: ctx, userID, remoteActorID, activityID
+func (_m *RemoteActorRepositoryMock) AddFollower(ctx context.Context, userID string, remoteActorID string, activityID string) error {
+	ret := _m.Called(ctx, userID, remoteActorID, activityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFollower")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, remoteActorID, activityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoteActorRepositoryMock_AddFollower_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFollower'
+type RemoteActorRepositoryMock_AddFollower_Call struct {
+	*mock.Call
+}
+
+// AddFollower is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - remoteActorID string
+//   - activityID string
+func (_e *RemoteActorRepositoryMock_Expecter) AddFollower(ctx interface{}, userID interface{}, remoteActorID interface{}, activityID interface{}) *RemoteActorRepositoryMock_AddFollower_Call {
+	return &RemoteActorRepositoryMock_AddFollower_Call{Call: _e.mock.On("AddFollower", ctx, userID, remoteActorID, activityID)}
+}
+
+func (_c *RemoteActorRepositoryMock_AddFollower_Call) Run(run func(ctx context.Context, userID string, remoteActorID string, activityID string)) *RemoteActorRepositoryMock_AddFollower_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_AddFollower_Call) Return(_a0 error) *RemoteActorRepositoryMock_AddFollower_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_AddFollower_Call) RunAndReturn(run func(context.Context, string, string, string) error) *RemoteActorRepositoryMock_AddFollower_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddLike provides a mock function with given fields: ctx, postID, remoteActorID, activityID
+func (_m *RemoteActorRepositoryMock) AddLike(ctx context.Context, postID string, remoteActorID string, activityID string) error {
+	ret := _m.Called(ctx, postID, remoteActorID, activityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, postID, remoteActorID, activityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoteActorRepositoryMock_AddLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLike'
+type RemoteActorRepositoryMock_AddLike_Call struct {
+	*mock.Call
+}
+
+// AddLike is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+//   - remoteActorID string
+//   - activityID string
+func (_e *RemoteActorRepositoryMock_Expecter) AddLike(ctx interface{}, postID interface{}, remoteActorID interface{}, activityID interface{}) *RemoteActorRepositoryMock_AddLike_Call {
+	return &RemoteActorRepositoryMock_AddLike_Call{Call: _e.mock.On("AddLike", ctx, postID, remoteActorID, activityID)}
+}
+
+func (_c *RemoteActorRepositoryMock_AddLike_Call) Run(run func(ctx context.Context, postID string, remoteActorID string, activityID string)) *RemoteActorRepositoryMock_AddLike_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_AddLike_Call) Return(_a0 error) *RemoteActorRepositoryMock_AddLike_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_AddLike_Call) RunAndReturn(run func(context.Context, string, string, string) error) *RemoteActorRepositoryMock_AddLike_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFollowers provides a mock function with given fields: ctx, userID
+func (_m *RemoteActorRepositoryMock) GetFollowers(ctx context.Context, userID string) ([]*models.RemoteActor, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowers")
+	}
+
+	var r0 []*models.RemoteActor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.RemoteActor, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.RemoteActor); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RemoteActor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoteActorRepositoryMock_GetFollowers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFollowers'
+type RemoteActorRepositoryMock_GetFollowers_Call struct {
+	*mock.Call
+}
+
+// GetFollowers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *RemoteActorRepositoryMock_Expecter) GetFollowers(ctx interface{}, userID interface{}) *RemoteActorRepositoryMock_GetFollowers_Call {
+	return &RemoteActorRepositoryMock_GetFollowers_Call{Call: _e.mock.On("GetFollowers", ctx, userID)}
+}
+
+func (_c *RemoteActorRepositoryMock_GetFollowers_Call) Run(run func(ctx context.Context, userID string)) *RemoteActorRepositoryMock_GetFollowers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_GetFollowers_Call) Return(_a0 []*models.RemoteActor, _a1 error) *RemoteActorRepositoryMock_GetFollowers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_GetFollowers_Call) RunAndReturn(run func(context.Context, string) ([]*models.RemoteActor, error)) *RemoteActorRepositoryMock_GetFollowers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRemoteActorByURI provides a mock function with given fields: ctx, actorURI
+func (_m *RemoteActorRepositoryMock) GetRemoteActorByURI(ctx context.Context, actorURI string) (*models.RemoteActor, error) {
+	ret := _m.Called(ctx, actorURI)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemoteActorByURI")
+	}
+
+	var r0 *models.RemoteActor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.RemoteActor, error)); ok {
+		return rf(ctx, actorURI)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RemoteActor); ok {
+		r0 = rf(ctx, actorURI)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RemoteActor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, actorURI)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoteActorRepositoryMock_GetRemoteActorByURI_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRemoteActorByURI'
+type RemoteActorRepositoryMock_GetRemoteActorByURI_Call struct {
+	*mock.Call
+}
+
+// GetRemoteActorByURI is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorURI string
+func (_e *RemoteActorRepositoryMock_Expecter) GetRemoteActorByURI(ctx interface{}, actorURI interface{}) *RemoteActorRepositoryMock_GetRemoteActorByURI_Call {
+	return &RemoteActorRepositoryMock_GetRemoteActorByURI_Call{Call: _e.mock.On("GetRemoteActorByURI", ctx, actorURI)}
+}
+
+func (_c *RemoteActorRepositoryMock_GetRemoteActorByURI_Call) Run(run func(ctx context.Context, actorURI string)) *RemoteActorRepositoryMock_GetRemoteActorByURI_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_GetRemoteActorByURI_Call) Return(_a0 *models.RemoteActor, _a1 error) *RemoteActorRepositoryMock_GetRemoteActorByURI_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_GetRemoteActorByURI_Call) RunAndReturn(run func(context.Context, string) (*models.RemoteActor, error)) *RemoteActorRepositoryMock_GetRemoteActorByURI_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveFollower provides a mock function with given fields: ctx, userID, remoteActorID
+func (_m *RemoteActorRepositoryMock) RemoveFollower(ctx context.Context, userID string, remoteActorID string) error {
+	ret := _m.Called(ctx, userID, remoteActorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFollower")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, remoteActorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoteActorRepositoryMock_RemoveFollower_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveFollower'
+type RemoteActorRepositoryMock_RemoveFollower_Call struct {
+	*mock.Call
+}
+
+// RemoveFollower is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - remoteActorID string
+func (_e *RemoteActorRepositoryMock_Expecter) RemoveFollower(ctx interface{}, userID interface{}, remoteActorID interface{}) *RemoteActorRepositoryMock_RemoveFollower_Call {
+	return &RemoteActorRepositoryMock_RemoveFollower_Call{Call: _e.mock.On("RemoveFollower", ctx, userID, remoteActorID)}
+}
+
+func (_c *RemoteActorRepositoryMock_RemoveFollower_Call) Run(run func(ctx context.Context, userID string, remoteActorID string)) *RemoteActorRepositoryMock_RemoveFollower_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_RemoveFollower_Call) Return(_a0 error) *RemoteActorRepositoryMock_RemoveFollower_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_RemoveFollower_Call) RunAndReturn(run func(context.Context, string, string) error) *RemoteActorRepositoryMock_RemoveFollower_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveLike provides a mock function with given fields: ctx, postID, remoteActorID
+func (_m *RemoteActorRepositoryMock) RemoveLike(ctx context.Context, postID string, remoteActorID string) error {
+	ret := _m.Called(ctx, postID, remoteActorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, postID, remoteActorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoteActorRepositoryMock_RemoveLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLike'
+type RemoteActorRepositoryMock_RemoveLike_Call struct {
+	*mock.Call
+}
+
+// RemoveLike is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+//   - remoteActorID string
+func (_e *RemoteActorRepositoryMock_Expecter) RemoveLike(ctx interface{}, postID interface{}, remoteActorID interface{}) *RemoteActorRepositoryMock_RemoveLike_Call {
+	return &RemoteActorRepositoryMock_RemoveLike_Call{Call: _e.mock.On("RemoveLike", ctx, postID, remoteActorID)}
+}
+
+func (_c *RemoteActorRepositoryMock_RemoveLike_Call) Run(run func(ctx context.Context, postID string, remoteActorID string)) *RemoteActorRepositoryMock_RemoveLike_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_RemoveLike_Call) Return(_a0 error) *RemoteActorRepositoryMock_RemoveLike_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_RemoveLike_Call) RunAndReturn(run func(context.Context, string, string) error) *RemoteActorRepositoryMock_RemoveLike_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertRemoteActor provides a mock function with given fields: ctx, actor
+func (_m *RemoteActorRepositoryMock) UpsertRemoteActor(ctx context.Context, actor *models.RemoteActor) error {
+	ret := _m.Called(ctx, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRemoteActor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RemoteActor) error); ok {
+		r0 = rf(ctx, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoteActorRepositoryMock_UpsertRemoteActor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertRemoteActor'
+type RemoteActorRepositoryMock_UpsertRemoteActor_Call struct {
+	*mock.Call
+}
+
+// UpsertRemoteActor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor *models.RemoteActor
+func (_e *RemoteActorRepositoryMock_Expecter) UpsertRemoteActor(ctx interface{}, actor interface{}) *RemoteActorRepositoryMock_UpsertRemoteActor_Call {
+	return &RemoteActorRepositoryMock_UpsertRemoteActor_Call{Call: _e.mock.On("UpsertRemoteActor", ctx, actor)}
+}
+
+func (_c *RemoteActorRepositoryMock_UpsertRemoteActor_Call) Run(run func(ctx context.Context, actor *models.RemoteActor)) *RemoteActorRepositoryMock_UpsertRemoteActor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RemoteActor))
+	})
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_UpsertRemoteActor_Call) Return(_a0 error) *RemoteActorRepositoryMock_UpsertRemoteActor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RemoteActorRepositoryMock_UpsertRemoteActor_Call) RunAndReturn(run func(context.Context, *models.RemoteActor) error) *RemoteActorRepositoryMock_UpsertRemoteActor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRemoteActorRepositoryMock creates a new instance of RemoteActorRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRemoteActorRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RemoteActorRepositoryMock {
+	mock := &RemoteActorRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
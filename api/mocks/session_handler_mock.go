@@ -123,6 +123,74 @@ func (_c *SessionHandlerMock_RevokeSession_Call) RunAndReturn(run func(http.Resp
 	return _c
 }
 
+// RevokeSessionFromLink provides a mock function with given fields: w, r
+func (_m *SessionHandlerMock) RevokeSessionFromLink(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SessionHandlerMock_RevokeSessionFromLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionFromLink'
+type SessionHandlerMock_RevokeSessionFromLink_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionFromLink is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *SessionHandlerMock_Expecter) RevokeSessionFromLink(w interface{}, r interface{}) *SessionHandlerMock_RevokeSessionFromLink_Call {
+	return &SessionHandlerMock_RevokeSessionFromLink_Call{Call: _e.mock.On("RevokeSessionFromLink", w, r)}
+}
+
+func (_c *SessionHandlerMock_RevokeSessionFromLink_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *SessionHandlerMock_RevokeSessionFromLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *SessionHandlerMock_RevokeSessionFromLink_Call) Return() *SessionHandlerMock_RevokeSessionFromLink_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SessionHandlerMock_RevokeSessionFromLink_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *SessionHandlerMock_RevokeSessionFromLink_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RevokeSessionPage provides a mock function with given fields: w, r
+func (_m *SessionHandlerMock) RevokeSessionPage(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// SessionHandlerMock_RevokeSessionPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionPage'
+type SessionHandlerMock_RevokeSessionPage_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionPage is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *SessionHandlerMock_Expecter) RevokeSessionPage(w interface{}, r interface{}) *SessionHandlerMock_RevokeSessionPage_Call {
+	return &SessionHandlerMock_RevokeSessionPage_Call{Call: _e.mock.On("RevokeSessionPage", w, r)}
+}
+
+func (_c *SessionHandlerMock_RevokeSessionPage_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *SessionHandlerMock_RevokeSessionPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *SessionHandlerMock_RevokeSessionPage_Call) Return() *SessionHandlerMock_RevokeSessionPage_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SessionHandlerMock_RevokeSessionPage_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *SessionHandlerMock_RevokeSessionPage_Call {
+	_c.Run(run)
+	return _c
+}
+
 // NewSessionHandlerMock creates a new instance of SessionHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionHandlerMock(t interface {
@@ -8,6 +8,8 @@ import (
 	models "github.com/g-villarinho/tab-notes-api/models"
 	mock "github.com/stretchr/testify/mock"
 
+	sql "database/sql"
+
 	time "time"
 )
 
@@ -24,6 +26,217 @@ func (_m *SessionRepositoryMock) EXPECT() *SessionRepositoryMock_Expecter {
 	return &SessionRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// ApproveSession provides a mock function with given fields: ctx, id, approvedAt
+func (_m *SessionRepositoryMock) ApproveSession(ctx context.Context, id string, approvedAt time.Time) error {
+	ret := _m.Called(ctx, id, approvedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, approvedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepositoryMock_ApproveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveSession'
+type SessionRepositoryMock_ApproveSession_Call struct {
+	*mock.Call
+}
+
+// ApproveSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - approvedAt time.Time
+func (_e *SessionRepositoryMock_Expecter) ApproveSession(ctx interface{}, id interface{}, approvedAt interface{}) *SessionRepositoryMock_ApproveSession_Call {
+	return &SessionRepositoryMock_ApproveSession_Call{Call: _e.mock.On("ApproveSession", ctx, id, approvedAt)}
+}
+
+func (_c *SessionRepositoryMock_ApproveSession_Call) Run(run func(ctx context.Context, id string, approvedAt time.Time)) *SessionRepositoryMock_ApproveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_ApproveSession_Call) Return(_a0 error) *SessionRepositoryMock_ApproveSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_ApproveSession_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *SessionRepositoryMock_ApproveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimApprovedSession provides a mock function with given fields: ctx, id, verifiedAt
+func (_m *SessionRepositoryMock) ClaimApprovedSession(ctx context.Context, id string, verifiedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, verifiedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimApprovedSession")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return rf(ctx, id, verifiedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, id, verifiedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, id, verifiedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_ClaimApprovedSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimApprovedSession'
+type SessionRepositoryMock_ClaimApprovedSession_Call struct {
+	*mock.Call
+}
+
+// ClaimApprovedSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - verifiedAt time.Time
+func (_e *SessionRepositoryMock_Expecter) ClaimApprovedSession(ctx interface{}, id interface{}, verifiedAt interface{}) *SessionRepositoryMock_ClaimApprovedSession_Call {
+	return &SessionRepositoryMock_ClaimApprovedSession_Call{Call: _e.mock.On("ClaimApprovedSession", ctx, id, verifiedAt)}
+}
+
+func (_c *SessionRepositoryMock_ClaimApprovedSession_Call) Run(run func(ctx context.Context, id string, verifiedAt time.Time)) *SessionRepositoryMock_ClaimApprovedSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_ClaimApprovedSession_Call) Return(_a0 bool, _a1 error) *SessionRepositoryMock_ClaimApprovedSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_ClaimApprovedSession_Call) RunAndReturn(run func(context.Context, string, time.Time) (bool, error)) *SessionRepositoryMock_ClaimApprovedSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountCodeAttemptsByUserID provides a mock function with given fields: ctx, userID, since
+func (_m *SessionRepositoryMock) CountCodeAttemptsByUserID(ctx context.Context, userID string, since time.Time) (int, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountCodeAttemptsByUserID")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int, error)); ok {
+		return rf(ctx, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_CountCodeAttemptsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountCodeAttemptsByUserID'
+type SessionRepositoryMock_CountCodeAttemptsByUserID_Call struct {
+	*mock.Call
+}
+
+// CountCodeAttemptsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - since time.Time
+func (_e *SessionRepositoryMock_Expecter) CountCodeAttemptsByUserID(ctx interface{}, userID interface{}, since interface{}) *SessionRepositoryMock_CountCodeAttemptsByUserID_Call {
+	return &SessionRepositoryMock_CountCodeAttemptsByUserID_Call{Call: _e.mock.On("CountCodeAttemptsByUserID", ctx, userID, since)}
+}
+
+func (_c *SessionRepositoryMock_CountCodeAttemptsByUserID_Call) Run(run func(ctx context.Context, userID string, since time.Time)) *SessionRepositoryMock_CountCodeAttemptsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_CountCodeAttemptsByUserID_Call) Return(_a0 int, _a1 error) *SessionRepositoryMock_CountCodeAttemptsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_CountCodeAttemptsByUserID_Call) RunAndReturn(run func(context.Context, string, time.Time) (int, error)) *SessionRepositoryMock_CountCodeAttemptsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, token
+func (_m *SessionRepositoryMock) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRepositoryMock_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type SessionRepositoryMock_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *models.RefreshToken
+func (_e *SessionRepositoryMock_Expecter) CreateRefreshToken(ctx interface{}, token interface{}) *SessionRepositoryMock_CreateRefreshToken_Call {
+	return &SessionRepositoryMock_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, token)}
+}
+
+func (_c *SessionRepositoryMock_CreateRefreshToken_Call) Run(run func(ctx context.Context, token *models.RefreshToken)) *SessionRepositoryMock_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_CreateRefreshToken_Call) Return(_a0 error) *SessionRepositoryMock_CreateRefreshToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_CreateRefreshToken_Call) RunAndReturn(run func(context.Context, *models.RefreshToken) error) *SessionRepositoryMock_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateSession provides a mock function with given fields: ctx, session
 func (_m *SessionRepositoryMock) CreateSession(ctx context.Context, session *models.Session) error {
 	ret := _m.Called(ctx, session)
@@ -118,6 +331,125 @@ func (_c *SessionRepositoryMock_DeleteSession_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// GetPendingCodeSessionByUserID provides a mock function with given fields: ctx, userID, now
+func (_m *SessionRepositoryMock) GetPendingCodeSessionByUserID(ctx context.Context, userID string, now time.Time) (*models.Session, error) {
+	ret := _m.Called(ctx, userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingCodeSessionByUserID")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.Session, error)); ok {
+		return rf(ctx, userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.Session); ok {
+		r0 = rf(ctx, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_GetPendingCodeSessionByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingCodeSessionByUserID'
+type SessionRepositoryMock_GetPendingCodeSessionByUserID_Call struct {
+	*mock.Call
+}
+
+// GetPendingCodeSessionByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - now time.Time
+func (_e *SessionRepositoryMock_Expecter) GetPendingCodeSessionByUserID(ctx interface{}, userID interface{}, now interface{}) *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call {
+	return &SessionRepositoryMock_GetPendingCodeSessionByUserID_Call{Call: _e.mock.On("GetPendingCodeSessionByUserID", ctx, userID, now)}
+}
+
+func (_c *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call) Run(run func(ctx context.Context, userID string, now time.Time)) *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call) Return(_a0 *models.Session, _a1 error) *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call) RunAndReturn(run func(context.Context, string, time.Time) (*models.Session, error)) *SessionRepositoryMock_GetPendingCodeSessionByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRefreshTokenByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *SessionRepositoryMock) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenByHash")
+	}
+
+	var r0 *models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.RefreshToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RefreshToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_GetRefreshTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshTokenByHash'
+type SessionRepositoryMock_GetRefreshTokenByHash_Call struct {
+	*mock.Call
+}
+
+// GetRefreshTokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *SessionRepositoryMock_Expecter) GetRefreshTokenByHash(ctx interface{}, tokenHash interface{}) *SessionRepositoryMock_GetRefreshTokenByHash_Call {
+	return &SessionRepositoryMock_GetRefreshTokenByHash_Call{Call: _e.mock.On("GetRefreshTokenByHash", ctx, tokenHash)}
+}
+
+func (_c *SessionRepositoryMock_GetRefreshTokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *SessionRepositoryMock_GetRefreshTokenByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetRefreshTokenByHash_Call) Return(_a0 *models.RefreshToken, _a1 error) *SessionRepositoryMock_GetRefreshTokenByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetRefreshTokenByHash_Call) RunAndReturn(run func(context.Context, string) (*models.RefreshToken, error)) *SessionRepositoryMock_GetRefreshTokenByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSessionById provides a mock function with given fields: ctx, id
 func (_m *SessionRepositoryMock) GetSessionById(ctx context.Context, id string) (*models.Session, error) {
 	ret := _m.Called(ctx, id)
@@ -177,21 +509,21 @@ func (_c *SessionRepositoryMock_GetSessionById_Call) RunAndReturn(run func(conte
 	return _c
 }
 
-// GetSessionByToken provides a mock function with given fields: ctx, token
-func (_m *SessionRepositoryMock) GetSessionByToken(ctx context.Context, token string) (*models.Session, error) {
-	ret := _m.Called(ctx, token)
+// GetSessionByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *SessionRepositoryMock) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
+	ret := _m.Called(ctx, tokenHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSessionByToken")
+		panic("no return value specified for GetSessionByTokenHash")
 	}
 
 	var r0 *models.Session
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Session, error)); ok {
-		return rf(ctx, token)
+		return rf(ctx, tokenHash)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Session); ok {
-		r0 = rf(ctx, token)
+		r0 = rf(ctx, tokenHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Session)
@@ -199,7 +531,66 @@ func (_m *SessionRepositoryMock) GetSessionByToken(ctx context.Context, token st
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_GetSessionByTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionByTokenHash'
+type SessionRepositoryMock_GetSessionByTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetSessionByTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *SessionRepositoryMock_Expecter) GetSessionByTokenHash(ctx interface{}, tokenHash interface{}) *SessionRepositoryMock_GetSessionByTokenHash_Call {
+	return &SessionRepositoryMock_GetSessionByTokenHash_Call{Call: _e.mock.On("GetSessionByTokenHash", ctx, tokenHash)}
+}
+
+func (_c *SessionRepositoryMock_GetSessionByTokenHash_Call) Run(run func(ctx context.Context, tokenHash string)) *SessionRepositoryMock_GetSessionByTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetSessionByTokenHash_Call) Return(_a0 *models.Session, _a1 error) *SessionRepositoryMock_GetSessionByTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetSessionByTokenHash_Call) RunAndReturn(run func(context.Context, string) (*models.Session, error)) *SessionRepositoryMock_GetSessionByTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionHistoryByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionRepositoryMock) GetSessionHistoryByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionHistoryByUserID")
+	}
+
+	var r0 []*models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -207,31 +598,31 @@ func (_m *SessionRepositoryMock) GetSessionByToken(ctx context.Context, token st
 	return r0, r1
 }
 
-// SessionRepositoryMock_GetSessionByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionByToken'
-type SessionRepositoryMock_GetSessionByToken_Call struct {
+// SessionRepositoryMock_GetSessionHistoryByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionHistoryByUserID'
+type SessionRepositoryMock_GetSessionHistoryByUserID_Call struct {
 	*mock.Call
 }
 
-// GetSessionByToken is a helper method to define mock.On call
+// GetSessionHistoryByUserID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *SessionRepositoryMock_Expecter) GetSessionByToken(ctx interface{}, token interface{}) *SessionRepositoryMock_GetSessionByToken_Call {
-	return &SessionRepositoryMock_GetSessionByToken_Call{Call: _e.mock.On("GetSessionByToken", ctx, token)}
+//   - userID string
+func (_e *SessionRepositoryMock_Expecter) GetSessionHistoryByUserID(ctx interface{}, userID interface{}) *SessionRepositoryMock_GetSessionHistoryByUserID_Call {
+	return &SessionRepositoryMock_GetSessionHistoryByUserID_Call{Call: _e.mock.On("GetSessionHistoryByUserID", ctx, userID)}
 }
 
-func (_c *SessionRepositoryMock_GetSessionByToken_Call) Run(run func(ctx context.Context, token string)) *SessionRepositoryMock_GetSessionByToken_Call {
+func (_c *SessionRepositoryMock_GetSessionHistoryByUserID_Call) Run(run func(ctx context.Context, userID string)) *SessionRepositoryMock_GetSessionHistoryByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *SessionRepositoryMock_GetSessionByToken_Call) Return(_a0 *models.Session, _a1 error) *SessionRepositoryMock_GetSessionByToken_Call {
+func (_c *SessionRepositoryMock_GetSessionHistoryByUserID_Call) Return(_a0 []*models.Session, _a1 error) *SessionRepositoryMock_GetSessionHistoryByUserID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SessionRepositoryMock_GetSessionByToken_Call) RunAndReturn(run func(context.Context, string) (*models.Session, error)) *SessionRepositoryMock_GetSessionByToken_Call {
+func (_c *SessionRepositoryMock_GetSessionHistoryByUserID_Call) RunAndReturn(run func(context.Context, string) ([]*models.Session, error)) *SessionRepositoryMock_GetSessionHistoryByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -295,6 +686,124 @@ func (_c *SessionRepositoryMock_GetSessionsByUserID_Call) RunAndReturn(run func(
 	return _c
 }
 
+// GetSignInHistory provides a mock function with given fields: ctx, userID, deviceLabel, ipAddress
+func (_m *SessionRepositoryMock) GetSignInHistory(ctx context.Context, userID string, deviceLabel sql.NullString, ipAddress sql.NullString) (*models.SignInHistory, error) {
+	ret := _m.Called(ctx, userID, deviceLabel, ipAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignInHistory")
+	}
+
+	var r0 *models.SignInHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, sql.NullString, sql.NullString) (*models.SignInHistory, error)); ok {
+		return rf(ctx, userID, deviceLabel, ipAddress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, sql.NullString, sql.NullString) *models.SignInHistory); ok {
+		r0 = rf(ctx, userID, deviceLabel, ipAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignInHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, sql.NullString, sql.NullString) error); ok {
+		r1 = rf(ctx, userID, deviceLabel, ipAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_GetSignInHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSignInHistory'
+type SessionRepositoryMock_GetSignInHistory_Call struct {
+	*mock.Call
+}
+
+// GetSignInHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - deviceLabel sql.NullString
+//   - ipAddress sql.NullString
+func (_e *SessionRepositoryMock_Expecter) GetSignInHistory(ctx interface{}, userID interface{}, deviceLabel interface{}, ipAddress interface{}) *SessionRepositoryMock_GetSignInHistory_Call {
+	return &SessionRepositoryMock_GetSignInHistory_Call{Call: _e.mock.On("GetSignInHistory", ctx, userID, deviceLabel, ipAddress)}
+}
+
+func (_c *SessionRepositoryMock_GetSignInHistory_Call) Run(run func(ctx context.Context, userID string, deviceLabel sql.NullString, ipAddress sql.NullString)) *SessionRepositoryMock_GetSignInHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(sql.NullString), args[3].(sql.NullString))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetSignInHistory_Call) Return(_a0 *models.SignInHistory, _a1 error) *SessionRepositoryMock_GetSignInHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetSignInHistory_Call) RunAndReturn(run func(context.Context, string, sql.NullString, sql.NullString) (*models.SignInHistory, error)) *SessionRepositoryMock_GetSignInHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserStatus provides a mock function with given fields: ctx, userID
+func (_m *SessionRepositoryMock) GetUserStatus(ctx context.Context, userID string) (models.UserStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStatus")
+	}
+
+	var r0 models.UserStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.UserStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.UserStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(models.UserStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_GetUserStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserStatus'
+type SessionRepositoryMock_GetUserStatus_Call struct {
+	*mock.Call
+}
+
+// GetUserStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SessionRepositoryMock_Expecter) GetUserStatus(ctx interface{}, userID interface{}) *SessionRepositoryMock_GetUserStatus_Call {
+	return &SessionRepositoryMock_GetUserStatus_Call{Call: _e.mock.On("GetUserStatus", ctx, userID)}
+}
+
+func (_c *SessionRepositoryMock_GetUserStatus_Call) Run(run func(ctx context.Context, userID string)) *SessionRepositoryMock_GetUserStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetUserStatus_Call) Return(_a0 models.UserStatus, _a1 error) *SessionRepositoryMock_GetUserStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_GetUserStatus_Call) RunAndReturn(run func(context.Context, string) (models.UserStatus, error)) *SessionRepositoryMock_GetUserStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsSessionRevoked provides a mock function with given fields: ctx, id
 func (_m *SessionRepositoryMock) IsSessionRevoked(ctx context.Context, id string) (bool, error) {
 	ret := _m.Called(ctx, id)
@@ -352,6 +861,64 @@ func (_c *SessionRepositoryMock_IsSessionRevoked_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// RecordCodeAttempt provides a mock function with given fields: ctx, id, maxAttempts
+func (_m *SessionRepositoryMock) RecordCodeAttempt(ctx context.Context, id string, maxAttempts int) (bool, error) {
+	ret := _m.Called(ctx, id, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordCodeAttempt")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (bool, error)); ok {
+		return rf(ctx, id, maxAttempts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) bool); ok {
+		r0 = rf(ctx, id, maxAttempts)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, id, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_RecordCodeAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordCodeAttempt'
+type SessionRepositoryMock_RecordCodeAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordCodeAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - maxAttempts int
+func (_e *SessionRepositoryMock_Expecter) RecordCodeAttempt(ctx interface{}, id interface{}, maxAttempts interface{}) *SessionRepositoryMock_RecordCodeAttempt_Call {
+	return &SessionRepositoryMock_RecordCodeAttempt_Call{Call: _e.mock.On("RecordCodeAttempt", ctx, id, maxAttempts)}
+}
+
+func (_c *SessionRepositoryMock_RecordCodeAttempt_Call) Run(run func(ctx context.Context, id string, maxAttempts int)) *SessionRepositoryMock_RecordCodeAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_RecordCodeAttempt_Call) Return(_a0 bool, _a1 error) *SessionRepositoryMock_RecordCodeAttempt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_RecordCodeAttempt_Call) RunAndReturn(run func(context.Context, string, int) (bool, error)) *SessionRepositoryMock_RecordCodeAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RevokeAllSessionByUserIDExceptCurrent provides a mock function with given fields: ctx, userID, currentSessionID, revokedAt
 func (_m *SessionRepositoryMock) RevokeAllSessionByUserIDExceptCurrent(ctx context.Context, userID string, currentSessionID string, revokedAt time.Time) error {
 	ret := _m.Called(ctx, userID, currentSessionID, revokedAt)
@@ -497,17 +1064,76 @@ func (_c *SessionRepositoryMock_RevokeSession_Call) RunAndReturn(run func(contex
 	return _c
 }
 
-// UpdateSession provides a mock function with given fields: ctx, session
-func (_m *SessionRepositoryMock) UpdateSession(ctx context.Context, session *models.Session) error {
-	ret := _m.Called(ctx, session)
+// RotateRefreshToken provides a mock function with given fields: ctx, oldTokenHash, next, session
+func (_m *SessionRepositoryMock) RotateRefreshToken(ctx context.Context, oldTokenHash string, next *models.RefreshToken, session *models.Session) (bool, error) {
+	ret := _m.Called(ctx, oldTokenHash, next, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.RefreshToken, *models.Session) (bool, error)); ok {
+		return rf(ctx, oldTokenHash, next, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.RefreshToken, *models.Session) bool); ok {
+		r0 = rf(ctx, oldTokenHash, next, session)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.RefreshToken, *models.Session) error); ok {
+		r1 = rf(ctx, oldTokenHash, next, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type SessionRepositoryMock_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldTokenHash string
+//   - next *models.RefreshToken
+//   - session *models.Session
+func (_e *SessionRepositoryMock_Expecter) RotateRefreshToken(ctx interface{}, oldTokenHash interface{}, next interface{}, session interface{}) *SessionRepositoryMock_RotateRefreshToken_Call {
+	return &SessionRepositoryMock_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, oldTokenHash, next, session)}
+}
+
+func (_c *SessionRepositoryMock_RotateRefreshToken_Call) Run(run func(ctx context.Context, oldTokenHash string, next *models.RefreshToken, session *models.Session)) *SessionRepositoryMock_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.RefreshToken), args[3].(*models.Session))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_RotateRefreshToken_Call) Return(_a0 bool, _a1 error) *SessionRepositoryMock_RotateRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_RotateRefreshToken_Call) RunAndReturn(run func(context.Context, string, *models.RefreshToken, *models.Session) (bool, error)) *SessionRepositoryMock_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchSession provides a mock function with given fields: ctx, id, seenAt, staleBefore
+func (_m *SessionRepositoryMock) TouchSession(ctx context.Context, id string, seenAt time.Time, staleBefore time.Time) error {
+	ret := _m.Called(ctx, id, seenAt, staleBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateSession")
+		panic("no return value specified for TouchSession")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
-		r0 = rf(ctx, session)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, id, seenAt, staleBefore)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -515,31 +1141,91 @@ func (_m *SessionRepositoryMock) UpdateSession(ctx context.Context, session *mod
 	return r0
 }
 
-// SessionRepositoryMock_UpdateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSession'
-type SessionRepositoryMock_UpdateSession_Call struct {
+// SessionRepositoryMock_TouchSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchSession'
+type SessionRepositoryMock_TouchSession_Call struct {
 	*mock.Call
 }
 
-// UpdateSession is a helper method to define mock.On call
+// TouchSession is a helper method to define mock.On call
 //   - ctx context.Context
-//   - session *models.Session
-func (_e *SessionRepositoryMock_Expecter) UpdateSession(ctx interface{}, session interface{}) *SessionRepositoryMock_UpdateSession_Call {
-	return &SessionRepositoryMock_UpdateSession_Call{Call: _e.mock.On("UpdateSession", ctx, session)}
+//   - id string
+//   - seenAt time.Time
+//   - staleBefore time.Time
+func (_e *SessionRepositoryMock_Expecter) TouchSession(ctx interface{}, id interface{}, seenAt interface{}, staleBefore interface{}) *SessionRepositoryMock_TouchSession_Call {
+	return &SessionRepositoryMock_TouchSession_Call{Call: _e.mock.On("TouchSession", ctx, id, seenAt, staleBefore)}
 }
 
-func (_c *SessionRepositoryMock_UpdateSession_Call) Run(run func(ctx context.Context, session *models.Session)) *SessionRepositoryMock_UpdateSession_Call {
+func (_c *SessionRepositoryMock_TouchSession_Call) Run(run func(ctx context.Context, id string, seenAt time.Time, staleBefore time.Time)) *SessionRepositoryMock_TouchSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.Session))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *SessionRepositoryMock_UpdateSession_Call) Return(_a0 error) *SessionRepositoryMock_UpdateSession_Call {
+func (_c *SessionRepositoryMock_TouchSession_Call) Return(_a0 error) *SessionRepositoryMock_TouchSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *SessionRepositoryMock_UpdateSession_Call) RunAndReturn(run func(context.Context, *models.Session) error) *SessionRepositoryMock_UpdateSession_Call {
+func (_c *SessionRepositoryMock_TouchSession_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) error) *SessionRepositoryMock_TouchSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifySession provides a mock function with given fields: ctx, session, magicLinkTokenHash
+func (_m *SessionRepositoryMock) VerifySession(ctx context.Context, session *models.Session, magicLinkTokenHash string) (bool, error) {
+	ret := _m.Called(ctx, session, magicLinkTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySession")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session, string) (bool, error)); ok {
+		return rf(ctx, session, magicLinkTokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session, string) bool); ok {
+		r0 = rf(ctx, session, magicLinkTokenHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Session, string) error); ok {
+		r1 = rf(ctx, session, magicLinkTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRepositoryMock_VerifySession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifySession'
+type SessionRepositoryMock_VerifySession_Call struct {
+	*mock.Call
+}
+
+// VerifySession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *models.Session
+//   - magicLinkTokenHash string
+func (_e *SessionRepositoryMock_Expecter) VerifySession(ctx interface{}, session interface{}, magicLinkTokenHash interface{}) *SessionRepositoryMock_VerifySession_Call {
+	return &SessionRepositoryMock_VerifySession_Call{Call: _e.mock.On("VerifySession", ctx, session, magicLinkTokenHash)}
+}
+
+func (_c *SessionRepositoryMock_VerifySession_Call) Run(run func(ctx context.Context, session *models.Session, magicLinkTokenHash string)) *SessionRepositoryMock_VerifySession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Session), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SessionRepositoryMock_VerifySession_Call) Return(_a0 bool, _a1 error) *SessionRepositoryMock_VerifySession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionRepositoryMock_VerifySession_Call) RunAndReturn(run func(context.Context, *models.Session, string) (bool, error)) *SessionRepositoryMock_VerifySession_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -22,6 +22,176 @@ func (_m *SessionServiceMock) EXPECT() *SessionServiceMock_Expecter {
 	return &SessionServiceMock_Expecter{mock: &_m.Mock}
 }
 
+// ApproveSession provides a mock function with given fields: ctx, token, verificationCode
+func (_m *SessionServiceMock) ApproveSession(ctx context.Context, token string, verificationCode string) error {
+	ret := _m.Called(ctx, token, verificationCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, verificationCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionServiceMock_ApproveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveSession'
+type SessionServiceMock_ApproveSession_Call struct {
+	*mock.Call
+}
+
+// ApproveSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - verificationCode string
+func (_e *SessionServiceMock_Expecter) ApproveSession(ctx interface{}, token interface{}, verificationCode interface{}) *SessionServiceMock_ApproveSession_Call {
+	return &SessionServiceMock_ApproveSession_Call{Call: _e.mock.On("ApproveSession", ctx, token, verificationCode)}
+}
+
+func (_c *SessionServiceMock_ApproveSession_Call) Run(run func(ctx context.Context, token string, verificationCode string)) *SessionServiceMock_ApproveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_ApproveSession_Call) Return(_a0 error) *SessionServiceMock_ApproveSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionServiceMock_ApproveSession_Call) RunAndReturn(run func(context.Context, string, string) error) *SessionServiceMock_ApproveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimApprovedSession provides a mock function with given fields: ctx, sessionID, pollSecret, client
+func (_m *SessionServiceMock) ClaimApprovedSession(ctx context.Context, sessionID string, pollSecret string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, sessionID, pollSecret, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimApprovedSession")
+	}
+
+	var r0 *models.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)); ok {
+		return rf(ctx, sessionID, pollSecret, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) *models.AuthResponse); ok {
+		r0 = rf(ctx, sessionID, pollSecret, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.ClientInfo) error); ok {
+		r1 = rf(ctx, sessionID, pollSecret, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionServiceMock_ClaimApprovedSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimApprovedSession'
+type SessionServiceMock_ClaimApprovedSession_Call struct {
+	*mock.Call
+}
+
+// ClaimApprovedSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+//   - pollSecret string
+//   - client *models.ClientInfo
+func (_e *SessionServiceMock_Expecter) ClaimApprovedSession(ctx interface{}, sessionID interface{}, pollSecret interface{}, client interface{}) *SessionServiceMock_ClaimApprovedSession_Call {
+	return &SessionServiceMock_ClaimApprovedSession_Call{Call: _e.mock.On("ClaimApprovedSession", ctx, sessionID, pollSecret, client)}
+}
+
+func (_c *SessionServiceMock_ClaimApprovedSession_Call) Run(run func(ctx context.Context, sessionID string, pollSecret string, client *models.ClientInfo)) *SessionServiceMock_ClaimApprovedSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.ClientInfo))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_ClaimApprovedSession_Call) Return(_a0 *models.AuthResponse, _a1 error) *SessionServiceMock_ClaimApprovedSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionServiceMock_ClaimApprovedSession_Call) RunAndReturn(run func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)) *SessionServiceMock_ClaimApprovedSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateLoginSession provides a mock function with given fields: ctx, userID, email, withCode
+func (_m *SessionServiceMock) CreateLoginSession(ctx context.Context, userID string, email string, withCode bool) (*models.LoginSession, error) {
+	ret := _m.Called(ctx, userID, email, withCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoginSession")
+	}
+
+	var r0 *models.LoginSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*models.LoginSession, error)); ok {
+		return rf(ctx, userID, email, withCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *models.LoginSession); ok {
+		r0 = rf(ctx, userID, email, withCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, userID, email, withCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionServiceMock_CreateLoginSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLoginSession'
+type SessionServiceMock_CreateLoginSession_Call struct {
+	*mock.Call
+}
+
+// CreateLoginSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - email string
+//   - withCode bool
+func (_e *SessionServiceMock_Expecter) CreateLoginSession(ctx interface{}, userID interface{}, email interface{}, withCode interface{}) *SessionServiceMock_CreateLoginSession_Call {
+	return &SessionServiceMock_CreateLoginSession_Call{Call: _e.mock.On("CreateLoginSession", ctx, userID, email, withCode)}
+}
+
+func (_c *SessionServiceMock_CreateLoginSession_Call) Run(run func(ctx context.Context, userID string, email string, withCode bool)) *SessionServiceMock_CreateLoginSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_CreateLoginSession_Call) Return(_a0 *models.LoginSession, _a1 error) *SessionServiceMock_CreateLoginSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionServiceMock_CreateLoginSession_Call) RunAndReturn(run func(context.Context, string, string, bool) (*models.LoginSession, error)) *SessionServiceMock_CreateLoginSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateSession provides a mock function with given fields: ctx, userID, email
 func (_m *SessionServiceMock) CreateSession(ctx context.Context, userID string, email string) (string, error) {
 	ret := _m.Called(ctx, userID, email)
@@ -80,6 +250,53 @@ func (_c *SessionServiceMock_CreateSession_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// EnsureUserActive provides a mock function with given fields: ctx, userID
+func (_m *SessionServiceMock) EnsureUserActive(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureUserActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionServiceMock_EnsureUserActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureUserActive'
+type SessionServiceMock_EnsureUserActive_Call struct {
+	*mock.Call
+}
+
+// EnsureUserActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SessionServiceMock_Expecter) EnsureUserActive(ctx interface{}, userID interface{}) *SessionServiceMock_EnsureUserActive_Call {
+	return &SessionServiceMock_EnsureUserActive_Call{Call: _e.mock.On("EnsureUserActive", ctx, userID)}
+}
+
+func (_c *SessionServiceMock_EnsureUserActive_Call) Run(run func(ctx context.Context, userID string)) *SessionServiceMock_EnsureUserActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_EnsureUserActive_Call) Return(_a0 error) *SessionServiceMock_EnsureUserActive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionServiceMock_EnsureUserActive_Call) RunAndReturn(run func(context.Context, string) error) *SessionServiceMock_EnsureUserActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserSessions provides a mock function with given fields: ctx, userID, currentSessionID
 func (_m *SessionServiceMock) GetUserSessions(ctx context.Context, userID string, currentSessionID string) ([]*models.SessionResponse, error) {
 	ret := _m.Called(ctx, userID, currentSessionID)
@@ -197,6 +414,170 @@ func (_c *SessionServiceMock_IsSessionRevoked_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *SessionServiceMock) Refresh(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *models.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AuthResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AuthResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionServiceMock_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type SessionServiceMock_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *SessionServiceMock_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *SessionServiceMock_Refresh_Call {
+	return &SessionServiceMock_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *SessionServiceMock_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *SessionServiceMock_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_Refresh_Call) Return(_a0 *models.AuthResponse, _a1 error) *SessionServiceMock_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionServiceMock_Refresh_Call) RunAndReturn(run func(context.Context, string) (*models.AuthResponse, error)) *SessionServiceMock_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequiresApproval provides a mock function with given fields: ctx, token, pollSecret
+func (_m *SessionServiceMock) RequiresApproval(ctx context.Context, token string, pollSecret string) (bool, error) {
+	ret := _m.Called(ctx, token, pollSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequiresApproval")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, token, pollSecret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, token, pollSecret)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, pollSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionServiceMock_RequiresApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequiresApproval'
+type SessionServiceMock_RequiresApproval_Call struct {
+	*mock.Call
+}
+
+// RequiresApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - pollSecret string
+func (_e *SessionServiceMock_Expecter) RequiresApproval(ctx interface{}, token interface{}, pollSecret interface{}) *SessionServiceMock_RequiresApproval_Call {
+	return &SessionServiceMock_RequiresApproval_Call{Call: _e.mock.On("RequiresApproval", ctx, token, pollSecret)}
+}
+
+func (_c *SessionServiceMock_RequiresApproval_Call) Run(run func(ctx context.Context, token string, pollSecret string)) *SessionServiceMock_RequiresApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_RequiresApproval_Call) Return(_a0 bool, _a1 error) *SessionServiceMock_RequiresApproval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionServiceMock_RequiresApproval_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *SessionServiceMock_RequiresApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAllSessionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *SessionServiceMock) RevokeAllSessionsByUserID(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllSessionsByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionServiceMock_RevokeAllSessionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllSessionsByUserID'
+type SessionServiceMock_RevokeAllSessionsByUserID_Call struct {
+	*mock.Call
+}
+
+// RevokeAllSessionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SessionServiceMock_Expecter) RevokeAllSessionsByUserID(ctx interface{}, userID interface{}) *SessionServiceMock_RevokeAllSessionsByUserID_Call {
+	return &SessionServiceMock_RevokeAllSessionsByUserID_Call{Call: _e.mock.On("RevokeAllSessionsByUserID", ctx, userID)}
+}
+
+func (_c *SessionServiceMock_RevokeAllSessionsByUserID_Call) Run(run func(ctx context.Context, userID string)) *SessionServiceMock_RevokeAllSessionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_RevokeAllSessionsByUserID_Call) Return(_a0 error) *SessionServiceMock_RevokeAllSessionsByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionServiceMock_RevokeAllSessionsByUserID_Call) RunAndReturn(run func(context.Context, string) error) *SessionServiceMock_RevokeAllSessionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RevokeAllUserSessions provides a mock function with given fields: ctx, userID, currentSessionID, revokeCurrent
 func (_m *SessionServiceMock) RevokeAllUserSessions(ctx context.Context, userID string, currentSessionID string, revokeCurrent bool) error {
 	ret := _m.Called(ctx, userID, currentSessionID, revokeCurrent)
@@ -293,6 +674,53 @@ func (_c *SessionServiceMock_RevokeSession_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// RevokeSessionFromLink provides a mock function with given fields: ctx, token
+func (_m *SessionServiceMock) RevokeSessionFromLink(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessionFromLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionServiceMock_RevokeSessionFromLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionFromLink'
+type SessionServiceMock_RevokeSessionFromLink_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionFromLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *SessionServiceMock_Expecter) RevokeSessionFromLink(ctx interface{}, token interface{}) *SessionServiceMock_RevokeSessionFromLink_Call {
+	return &SessionServiceMock_RevokeSessionFromLink_Call{Call: _e.mock.On("RevokeSessionFromLink", ctx, token)}
+}
+
+func (_c *SessionServiceMock_RevokeSessionFromLink_Call) Run(run func(ctx context.Context, token string)) *SessionServiceMock_RevokeSessionFromLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_RevokeSessionFromLink_Call) Return(_a0 error) *SessionServiceMock_RevokeSessionFromLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionServiceMock_RevokeSessionFromLink_Call) RunAndReturn(run func(context.Context, string) error) *SessionServiceMock_RevokeSessionFromLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RevokeUserSession provides a mock function with given fields: ctx, userID, sessionID
 func (_m *SessionServiceMock) RevokeUserSession(ctx context.Context, userID string, sessionID string) error {
 	ret := _m.Called(ctx, userID, sessionID)
@@ -341,27 +769,76 @@ func (_c *SessionServiceMock_RevokeUserSession_Call) RunAndReturn(run func(conte
 	return _c
 }
 
-// ValidSession provides a mock function with given fields: ctx, token
-func (_m *SessionServiceMock) ValidSession(ctx context.Context, token string) (string, error) {
-	ret := _m.Called(ctx, token)
+// TouchSession provides a mock function with given fields: ctx, sessionID
+func (_m *SessionServiceMock) TouchSession(ctx context.Context, sessionID string) error {
+	ret := _m.Called(ctx, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionServiceMock_TouchSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchSession'
+type SessionServiceMock_TouchSession_Call struct {
+	*mock.Call
+}
+
+// TouchSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID string
+func (_e *SessionServiceMock_Expecter) TouchSession(ctx interface{}, sessionID interface{}) *SessionServiceMock_TouchSession_Call {
+	return &SessionServiceMock_TouchSession_Call{Call: _e.mock.On("TouchSession", ctx, sessionID)}
+}
+
+func (_c *SessionServiceMock_TouchSession_Call) Run(run func(ctx context.Context, sessionID string)) *SessionServiceMock_TouchSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_TouchSession_Call) Return(_a0 error) *SessionServiceMock_TouchSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionServiceMock_TouchSession_Call) RunAndReturn(run func(context.Context, string) error) *SessionServiceMock_TouchSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidSession provides a mock function with given fields: ctx, token, client
+func (_m *SessionServiceMock) ValidSession(ctx context.Context, token string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, token, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidSession")
 	}
 
-	var r0 string
+	var r0 *models.AuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ClientInfo) (*models.AuthResponse, error)); ok {
+		return rf(ctx, token, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ClientInfo) *models.AuthResponse); ok {
+		r0 = rf(ctx, token, client)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ClientInfo) error); ok {
+		r1 = rf(ctx, token, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -377,23 +854,85 @@ type SessionServiceMock_ValidSession_Call struct {
 // ValidSession is a helper method to define mock.On call
 //   - ctx context.Context
 //   - token string
-func (_e *SessionServiceMock_Expecter) ValidSession(ctx interface{}, token interface{}) *SessionServiceMock_ValidSession_Call {
-	return &SessionServiceMock_ValidSession_Call{Call: _e.mock.On("ValidSession", ctx, token)}
+//   - client *models.ClientInfo
+func (_e *SessionServiceMock_Expecter) ValidSession(ctx interface{}, token interface{}, client interface{}) *SessionServiceMock_ValidSession_Call {
+	return &SessionServiceMock_ValidSession_Call{Call: _e.mock.On("ValidSession", ctx, token, client)}
 }
 
-func (_c *SessionServiceMock_ValidSession_Call) Run(run func(ctx context.Context, token string)) *SessionServiceMock_ValidSession_Call {
+func (_c *SessionServiceMock_ValidSession_Call) Run(run func(ctx context.Context, token string, client *models.ClientInfo)) *SessionServiceMock_ValidSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ClientInfo))
+	})
+	return _c
+}
+
+func (_c *SessionServiceMock_ValidSession_Call) Return(_a0 *models.AuthResponse, _a1 error) *SessionServiceMock_ValidSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionServiceMock_ValidSession_Call) RunAndReturn(run func(context.Context, string, *models.ClientInfo) (*models.AuthResponse, error)) *SessionServiceMock_ValidSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidSessionCode provides a mock function with given fields: ctx, userID, code, client
+func (_m *SessionServiceMock) ValidSessionCode(ctx context.Context, userID string, code string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	ret := _m.Called(ctx, userID, code, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidSessionCode")
+	}
+
+	var r0 *models.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)); ok {
+		return rf(ctx, userID, code, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.ClientInfo) *models.AuthResponse); ok {
+		r0 = rf(ctx, userID, code, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.ClientInfo) error); ok {
+		r1 = rf(ctx, userID, code, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionServiceMock_ValidSessionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidSessionCode'
+type SessionServiceMock_ValidSessionCode_Call struct {
+	*mock.Call
+}
+
+// ValidSessionCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+//   - client *models.ClientInfo
+func (_e *SessionServiceMock_Expecter) ValidSessionCode(ctx interface{}, userID interface{}, code interface{}, client interface{}) *SessionServiceMock_ValidSessionCode_Call {
+	return &SessionServiceMock_ValidSessionCode_Call{Call: _e.mock.On("ValidSessionCode", ctx, userID, code, client)}
+}
+
+func (_c *SessionServiceMock_ValidSessionCode_Call) Run(run func(ctx context.Context, userID string, code string, client *models.ClientInfo)) *SessionServiceMock_ValidSessionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.ClientInfo))
 	})
 	return _c
 }
 
-func (_c *SessionServiceMock_ValidSession_Call) Return(_a0 string, _a1 error) *SessionServiceMock_ValidSession_Call {
+func (_c *SessionServiceMock_ValidSessionCode_Call) Return(_a0 *models.AuthResponse, _a1 error) *SessionServiceMock_ValidSessionCode_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SessionServiceMock_ValidSession_Call) RunAndReturn(run func(context.Context, string) (string, error)) *SessionServiceMock_ValidSession_Call {
+func (_c *SessionServiceMock_ValidSessionCode_Call) RunAndReturn(run func(context.Context, string, string, *models.ClientInfo) (*models.AuthResponse, error)) *SessionServiceMock_ValidSessionCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,172 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShareLinkHandlerMock is an autogenerated mock type for the ShareLinkHandler type
+type ShareLinkHandlerMock struct {
+	mock.Mock
+}
+
+type ShareLinkHandlerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ShareLinkHandlerMock) EXPECT() *ShareLinkHandlerMock_Expecter {
+	return &ShareLinkHandlerMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateShareLink provides a mock function with given fields: w, r
+func (_m *ShareLinkHandlerMock) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ShareLinkHandlerMock_CreateShareLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShareLink'
+type ShareLinkHandlerMock_CreateShareLink_Call struct {
+	*mock.Call
+}
+
+// CreateShareLink is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ShareLinkHandlerMock_Expecter) CreateShareLink(w interface{}, r interface{}) *ShareLinkHandlerMock_CreateShareLink_Call {
+	return &ShareLinkHandlerMock_CreateShareLink_Call{Call: _e.mock.On("CreateShareLink", w, r)}
+}
+
+func (_c *ShareLinkHandlerMock_CreateShareLink_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ShareLinkHandlerMock_CreateShareLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_CreateShareLink_Call) Return() *ShareLinkHandlerMock_CreateShareLink_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_CreateShareLink_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ShareLinkHandlerMock_CreateShareLink_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShareLinks provides a mock function with given fields: w, r
+func (_m *ShareLinkHandlerMock) GetShareLinks(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ShareLinkHandlerMock_GetShareLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShareLinks'
+type ShareLinkHandlerMock_GetShareLinks_Call struct {
+	*mock.Call
+}
+
+// GetShareLinks is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ShareLinkHandlerMock_Expecter) GetShareLinks(w interface{}, r interface{}) *ShareLinkHandlerMock_GetShareLinks_Call {
+	return &ShareLinkHandlerMock_GetShareLinks_Call{Call: _e.mock.On("GetShareLinks", w, r)}
+}
+
+func (_c *ShareLinkHandlerMock_GetShareLinks_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ShareLinkHandlerMock_GetShareLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_GetShareLinks_Call) Return() *ShareLinkHandlerMock_GetShareLinks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_GetShareLinks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ShareLinkHandlerMock_GetShareLinks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetSharedPost provides a mock function with given fields: w, r
+func (_m *ShareLinkHandlerMock) GetSharedPost(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ShareLinkHandlerMock_GetSharedPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSharedPost'
+type ShareLinkHandlerMock_GetSharedPost_Call struct {
+	*mock.Call
+}
+
+// GetSharedPost is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ShareLinkHandlerMock_Expecter) GetSharedPost(w interface{}, r interface{}) *ShareLinkHandlerMock_GetSharedPost_Call {
+	return &ShareLinkHandlerMock_GetSharedPost_Call{Call: _e.mock.On("GetSharedPost", w, r)}
+}
+
+func (_c *ShareLinkHandlerMock_GetSharedPost_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ShareLinkHandlerMock_GetSharedPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_GetSharedPost_Call) Return() *ShareLinkHandlerMock_GetSharedPost_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_GetSharedPost_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ShareLinkHandlerMock_GetSharedPost_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RevokeShareLink provides a mock function with given fields: w, r
+func (_m *ShareLinkHandlerMock) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ShareLinkHandlerMock_RevokeShareLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeShareLink'
+type ShareLinkHandlerMock_RevokeShareLink_Call struct {
+	*mock.Call
+}
+
+// RevokeShareLink is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ShareLinkHandlerMock_Expecter) RevokeShareLink(w interface{}, r interface{}) *ShareLinkHandlerMock_RevokeShareLink_Call {
+	return &ShareLinkHandlerMock_RevokeShareLink_Call{Call: _e.mock.On("RevokeShareLink", w, r)}
+}
+
+func (_c *ShareLinkHandlerMock_RevokeShareLink_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ShareLinkHandlerMock_RevokeShareLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_RevokeShareLink_Call) Return() *ShareLinkHandlerMock_RevokeShareLink_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ShareLinkHandlerMock_RevokeShareLink_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ShareLinkHandlerMock_RevokeShareLink_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewShareLinkHandlerMock creates a new instance of ShareLinkHandlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShareLinkHandlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShareLinkHandlerMock {
+	mock := &ShareLinkHandlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,300 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ShareLinkRepositoryMock is an autogenerated mock type for the ShareLinkRepository type
+type ShareLinkRepositoryMock struct {
+	mock.Mock
+}
+
+type ShareLinkRepositoryMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ShareLinkRepositoryMock) EXPECT() *ShareLinkRepositoryMock_Expecter {
+	return &ShareLinkRepositoryMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateShareLink provides a mock function with given fields: ctx, link
+func (_m *ShareLinkRepositoryMock) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	ret := _m.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShareLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ShareLink) error); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShareLinkRepositoryMock_CreateShareLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShareLink'
+type ShareLinkRepositoryMock_CreateShareLink_Call struct {
+	*mock.Call
+}
+
+// CreateShareLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link *models.ShareLink
+func (_e *ShareLinkRepositoryMock_Expecter) CreateShareLink(ctx interface{}, link interface{}) *ShareLinkRepositoryMock_CreateShareLink_Call {
+	return &ShareLinkRepositoryMock_CreateShareLink_Call{Call: _e.mock.On("CreateShareLink", ctx, link)}
+}
+
+func (_c *ShareLinkRepositoryMock_CreateShareLink_Call) Run(run func(ctx context.Context, link *models.ShareLink)) *ShareLinkRepositoryMock_CreateShareLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ShareLink))
+	})
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_CreateShareLink_Call) Return(_a0 error) *ShareLinkRepositoryMock_CreateShareLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_CreateShareLink_Call) RunAndReturn(run func(context.Context, *models.ShareLink) error) *ShareLinkRepositoryMock_CreateShareLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShareLinkByID provides a mock function with given fields: ctx, id
+func (_m *ShareLinkRepositoryMock) GetShareLinkByID(ctx context.Context, id string) (*models.ShareLink, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShareLinkByID")
+	}
+
+	var r0 *models.ShareLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ShareLink, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ShareLink); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShareLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareLinkRepositoryMock_GetShareLinkByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShareLinkByID'
+type ShareLinkRepositoryMock_GetShareLinkByID_Call struct {
+	*mock.Call
+}
+
+// GetShareLinkByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ShareLinkRepositoryMock_Expecter) GetShareLinkByID(ctx interface{}, id interface{}) *ShareLinkRepositoryMock_GetShareLinkByID_Call {
+	return &ShareLinkRepositoryMock_GetShareLinkByID_Call{Call: _e.mock.On("GetShareLinkByID", ctx, id)}
+}
+
+func (_c *ShareLinkRepositoryMock_GetShareLinkByID_Call) Run(run func(ctx context.Context, id string)) *ShareLinkRepositoryMock_GetShareLinkByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_GetShareLinkByID_Call) Return(_a0 *models.ShareLink, _a1 error) *ShareLinkRepositoryMock_GetShareLinkByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_GetShareLinkByID_Call) RunAndReturn(run func(context.Context, string) (*models.ShareLink, error)) *ShareLinkRepositoryMock_GetShareLinkByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShareLinksByPostID provides a mock function with given fields: ctx, postID
+func (_m *ShareLinkRepositoryMock) GetShareLinksByPostID(ctx context.Context, postID string) ([]*models.ShareLink, error) {
+	ret := _m.Called(ctx, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShareLinksByPostID")
+	}
+
+	var r0 []*models.ShareLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.ShareLink, error)); ok {
+		return rf(ctx, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.ShareLink); ok {
+		r0 = rf(ctx, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ShareLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareLinkRepositoryMock_GetShareLinksByPostID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShareLinksByPostID'
+type ShareLinkRepositoryMock_GetShareLinksByPostID_Call struct {
+	*mock.Call
+}
+
+// GetShareLinksByPostID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+func (_e *ShareLinkRepositoryMock_Expecter) GetShareLinksByPostID(ctx interface{}, postID interface{}) *ShareLinkRepositoryMock_GetShareLinksByPostID_Call {
+	return &ShareLinkRepositoryMock_GetShareLinksByPostID_Call{Call: _e.mock.On("GetShareLinksByPostID", ctx, postID)}
+}
+
+func (_c *ShareLinkRepositoryMock_GetShareLinksByPostID_Call) Run(run func(ctx context.Context, postID string)) *ShareLinkRepositoryMock_GetShareLinksByPostID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_GetShareLinksByPostID_Call) Return(_a0 []*models.ShareLink, _a1 error) *ShareLinkRepositoryMock_GetShareLinksByPostID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_GetShareLinksByPostID_Call) RunAndReturn(run func(context.Context, string) ([]*models.ShareLink, error)) *ShareLinkRepositoryMock_GetShareLinksByPostID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAccess provides a mock function with given fields: ctx, id, accessedAt
+func (_m *ShareLinkRepositoryMock) RecordAccess(ctx context.Context, id string, accessedAt time.Time) error {
+	ret := _m.Called(ctx, id, accessedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, accessedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShareLinkRepositoryMock_RecordAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAccess'
+type ShareLinkRepositoryMock_RecordAccess_Call struct {
+	*mock.Call
+}
+
+// RecordAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - accessedAt time.Time
+func (_e *ShareLinkRepositoryMock_Expecter) RecordAccess(ctx interface{}, id interface{}, accessedAt interface{}) *ShareLinkRepositoryMock_RecordAccess_Call {
+	return &ShareLinkRepositoryMock_RecordAccess_Call{Call: _e.mock.On("RecordAccess", ctx, id, accessedAt)}
+}
+
+func (_c *ShareLinkRepositoryMock_RecordAccess_Call) Run(run func(ctx context.Context, id string, accessedAt time.Time)) *ShareLinkRepositoryMock_RecordAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_RecordAccess_Call) Return(_a0 error) *ShareLinkRepositoryMock_RecordAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_RecordAccess_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *ShareLinkRepositoryMock_RecordAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeShareLink provides a mock function with given fields: ctx, id, revokedAt
+func (_m *ShareLinkRepositoryMock) RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeShareLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShareLinkRepositoryMock_RevokeShareLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeShareLink'
+type ShareLinkRepositoryMock_RevokeShareLink_Call struct {
+	*mock.Call
+}
+
+// RevokeShareLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - revokedAt time.Time
+func (_e *ShareLinkRepositoryMock_Expecter) RevokeShareLink(ctx interface{}, id interface{}, revokedAt interface{}) *ShareLinkRepositoryMock_RevokeShareLink_Call {
+	return &ShareLinkRepositoryMock_RevokeShareLink_Call{Call: _e.mock.On("RevokeShareLink", ctx, id, revokedAt)}
+}
+
+func (_c *ShareLinkRepositoryMock_RevokeShareLink_Call) Run(run func(ctx context.Context, id string, revokedAt time.Time)) *ShareLinkRepositoryMock_RevokeShareLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_RevokeShareLink_Call) Return(_a0 error) *ShareLinkRepositoryMock_RevokeShareLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ShareLinkRepositoryMock_RevokeShareLink_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *ShareLinkRepositoryMock_RevokeShareLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewShareLinkRepositoryMock creates a new instance of ShareLinkRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShareLinkRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShareLinkRepositoryMock {
+	mock := &ShareLinkRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,266 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/g-villarinho/tab-notes-api/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ShareLinkServiceMock is an autogenerated mock type for the ShareLinkService type
+type ShareLinkServiceMock struct {
+	mock.Mock
+}
+
+type ShareLinkServiceMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ShareLinkServiceMock) EXPECT() *ShareLinkServiceMock_Expecter {
+	return &ShareLinkServiceMock_Expecter{mock: &_m.Mock}
+}
+
+// CreateShareLink provides a mock function with given fields: ctx, userID, postID, payload
+func (_m *ShareLinkServiceMock) CreateShareLink(ctx context.Context, userID string, postID string, payload *models.CreateShareLinkPayload) (*models.ShareLinkResponse, error) {
+	ret := _m.Called(ctx, userID, postID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShareLink")
+	}
+
+	var r0 *models.ShareLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.CreateShareLinkPayload) (*models.ShareLinkResponse, error)); ok {
+		return rf(ctx, userID, postID, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.CreateShareLinkPayload) *models.ShareLinkResponse); ok {
+		r0 = rf(ctx, userID, postID, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShareLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.CreateShareLinkPayload) error); ok {
+		r1 = rf(ctx, userID, postID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareLinkServiceMock_CreateShareLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShareLink'
+type ShareLinkServiceMock_CreateShareLink_Call struct {
+	*mock.Call
+}
+
+// CreateShareLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postID string
+//   - payload *models.CreateShareLinkPayload
+func (_e *ShareLinkServiceMock_Expecter) CreateShareLink(ctx interface{}, userID interface{}, postID interface{}, payload interface{}) *ShareLinkServiceMock_CreateShareLink_Call {
+	return &ShareLinkServiceMock_CreateShareLink_Call{Call: _e.mock.On("CreateShareLink", ctx, userID, postID, payload)}
+}
+
+func (_c *ShareLinkServiceMock_CreateShareLink_Call) Run(run func(ctx context.Context, userID string, postID string, payload *models.CreateShareLinkPayload)) *ShareLinkServiceMock_CreateShareLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*models.CreateShareLinkPayload))
+	})
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_CreateShareLink_Call) Return(_a0 *models.ShareLinkResponse, _a1 error) *ShareLinkServiceMock_CreateShareLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_CreateShareLink_Call) RunAndReturn(run func(context.Context, string, string, *models.CreateShareLinkPayload) (*models.ShareLinkResponse, error)) *ShareLinkServiceMock_CreateShareLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShareLinks provides a mock function with given fields: ctx, userID, postID
+func (_m *ShareLinkServiceMock) GetShareLinks(ctx context.Context, userID string, postID string) ([]*models.ShareLinkResponse, error) {
+	ret := _m.Called(ctx, userID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShareLinks")
+	}
+
+	var r0 []*models.ShareLinkResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.ShareLinkResponse, error)); ok {
+		return rf(ctx, userID, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.ShareLinkResponse); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ShareLinkResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareLinkServiceMock_GetShareLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShareLinks'
+type ShareLinkServiceMock_GetShareLinks_Call struct {
+	*mock.Call
+}
+
+// GetShareLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postID string
+func (_e *ShareLinkServiceMock_Expecter) GetShareLinks(ctx interface{}, userID interface{}, postID interface{}) *ShareLinkServiceMock_GetShareLinks_Call {
+	return &ShareLinkServiceMock_GetShareLinks_Call{Call: _e.mock.On("GetShareLinks", ctx, userID, postID)}
+}
+
+func (_c *ShareLinkServiceMock_GetShareLinks_Call) Run(run func(ctx context.Context, userID string, postID string)) *ShareLinkServiceMock_GetShareLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_GetShareLinks_Call) Return(_a0 []*models.ShareLinkResponse, _a1 error) *ShareLinkServiceMock_GetShareLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_GetShareLinks_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.ShareLinkResponse, error)) *ShareLinkServiceMock_GetShareLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSharedPost provides a mock function with given fields: ctx, token
+func (_m *ShareLinkServiceMock) GetSharedPost(ctx context.Context, token string) (*models.PostResponse, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedPost")
+	}
+
+	var r0 *models.PostResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PostResponse, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PostResponse); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PostResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareLinkServiceMock_GetSharedPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSharedPost'
+type ShareLinkServiceMock_GetSharedPost_Call struct {
+	*mock.Call
+}
+
+// GetSharedPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *ShareLinkServiceMock_Expecter) GetSharedPost(ctx interface{}, token interface{}) *ShareLinkServiceMock_GetSharedPost_Call {
+	return &ShareLinkServiceMock_GetSharedPost_Call{Call: _e.mock.On("GetSharedPost", ctx, token)}
+}
+
+func (_c *ShareLinkServiceMock_GetSharedPost_Call) Run(run func(ctx context.Context, token string)) *ShareLinkServiceMock_GetSharedPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_GetSharedPost_Call) Return(_a0 *models.PostResponse, _a1 error) *ShareLinkServiceMock_GetSharedPost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_GetSharedPost_Call) RunAndReturn(run func(context.Context, string) (*models.PostResponse, error)) *ShareLinkServiceMock_GetSharedPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeShareLink provides a mock function with given fields: ctx, userID, postID, shareLinkID
+func (_m *ShareLinkServiceMock) RevokeShareLink(ctx context.Context, userID string, postID string, shareLinkID string) error {
+	ret := _m.Called(ctx, userID, postID, shareLinkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeShareLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, postID, shareLinkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShareLinkServiceMock_RevokeShareLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeShareLink'
+type ShareLinkServiceMock_RevokeShareLink_Call struct {
+	*mock.Call
+}
+
+// RevokeShareLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - postID string
+//   - shareLinkID string
+func (_e *ShareLinkServiceMock_Expecter) RevokeShareLink(ctx interface{}, userID interface{}, postID interface{}, shareLinkID interface{}) *ShareLinkServiceMock_RevokeShareLink_Call {
+	return &ShareLinkServiceMock_RevokeShareLink_Call{Call: _e.mock.On("RevokeShareLink", ctx, userID, postID, shareLinkID)}
+}
+
+func (_c *ShareLinkServiceMock_RevokeShareLink_Call) Run(run func(ctx context.Context, userID string, postID string, shareLinkID string)) *ShareLinkServiceMock_RevokeShareLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_RevokeShareLink_Call) Return(_a0 error) *ShareLinkServiceMock_RevokeShareLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ShareLinkServiceMock_RevokeShareLink_Call) RunAndReturn(run func(context.Context, string, string, string) error) *ShareLinkServiceMock_RevokeShareLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewShareLinkServiceMock creates a new instance of ShareLinkServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShareLinkServiceMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShareLinkServiceMock {
+	mock := &ShareLinkServiceMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,8 +5,11 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/g-villarinho/tab-notes-api/models"
 	mock "github.com/stretchr/testify/mock"
 
+	sql "database/sql"
+
 	time "time"
 )
 
@@ -83,6 +86,66 @@ func (_c *TokenServiceMock_GenerateAuthToken_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GenerateExportToken provides a mock function with given fields: ctx, userID, exportID, iat, exp
+func (_m *TokenServiceMock) GenerateExportToken(ctx context.Context, userID string, exportID string, iat time.Time, exp time.Time) (string, error) {
+	ret := _m.Called(ctx, userID, exportID, iat, exp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateExportToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (string, error)); ok {
+		return rf(ctx, userID, exportID, iat, exp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) string); ok {
+		r0 = rf(ctx, userID, exportID, iat, exp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, exportID, iat, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceMock_GenerateExportToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateExportToken'
+type TokenServiceMock_GenerateExportToken_Call struct {
+	*mock.Call
+}
+
+// GenerateExportToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - exportID string
+//   - iat time.Time
+//   - exp time.Time
+func (_e *TokenServiceMock_Expecter) GenerateExportToken(ctx interface{}, userID interface{}, exportID interface{}, iat interface{}, exp interface{}) *TokenServiceMock_GenerateExportToken_Call {
+	return &TokenServiceMock_GenerateExportToken_Call{Call: _e.mock.On("GenerateExportToken", ctx, userID, exportID, iat, exp)}
+}
+
+func (_c *TokenServiceMock_GenerateExportToken_Call) Run(run func(ctx context.Context, userID string, exportID string, iat time.Time, exp time.Time)) *TokenServiceMock_GenerateExportToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TokenServiceMock_GenerateExportToken_Call) Return(_a0 string, _a1 error) *TokenServiceMock_GenerateExportToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceMock_GenerateExportToken_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time) (string, error)) *TokenServiceMock_GenerateExportToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateMagicLinkToken provides a mock function with given fields: ctx, email, iat, exp
 func (_m *TokenServiceMock) GenerateMagicLinkToken(ctx context.Context, email string, iat time.Time, exp time.Time) (string, error) {
 	ret := _m.Called(ctx, email, iat, exp)
@@ -142,6 +205,303 @@ func (_c *TokenServiceMock_GenerateMagicLinkToken_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// GenerateSessionRevokeToken provides a mock function with given fields: ctx, userID, sessionID, iat, exp
+func (_m *TokenServiceMock) GenerateSessionRevokeToken(ctx context.Context, userID string, sessionID string, iat time.Time, exp time.Time) (string, error) {
+	ret := _m.Called(ctx, userID, sessionID, iat, exp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateSessionRevokeToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (string, error)); ok {
+		return rf(ctx, userID, sessionID, iat, exp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) string); ok {
+		r0 = rf(ctx, userID, sessionID, iat, exp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, sessionID, iat, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceMock_GenerateSessionRevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateSessionRevokeToken'
+type TokenServiceMock_GenerateSessionRevokeToken_Call struct {
+	*mock.Call
+}
+
+// GenerateSessionRevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+//   - iat time.Time
+//   - exp time.Time
+func (_e *TokenServiceMock_Expecter) GenerateSessionRevokeToken(ctx interface{}, userID interface{}, sessionID interface{}, iat interface{}, exp interface{}) *TokenServiceMock_GenerateSessionRevokeToken_Call {
+	return &TokenServiceMock_GenerateSessionRevokeToken_Call{Call: _e.mock.On("GenerateSessionRevokeToken", ctx, userID, sessionID, iat, exp)}
+}
+
+func (_c *TokenServiceMock_GenerateSessionRevokeToken_Call) Run(run func(ctx context.Context, userID string, sessionID string, iat time.Time, exp time.Time)) *TokenServiceMock_GenerateSessionRevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TokenServiceMock_GenerateSessionRevokeToken_Call) Return(_a0 string, _a1 error) *TokenServiceMock_GenerateSessionRevokeToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceMock_GenerateSessionRevokeToken_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time) (string, error)) *TokenServiceMock_GenerateSessionRevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateShareLinkToken provides a mock function with given fields: ctx, postID, shareLinkID, iat, exp
+func (_m *TokenServiceMock) GenerateShareLinkToken(ctx context.Context, postID string, shareLinkID string, iat time.Time, exp sql.NullTime) (string, error) {
+	ret := _m.Called(ctx, postID, shareLinkID, iat, exp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateShareLinkToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, sql.NullTime) (string, error)); ok {
+		return rf(ctx, postID, shareLinkID, iat, exp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, sql.NullTime) string); ok {
+		r0 = rf(ctx, postID, shareLinkID, iat, exp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, sql.NullTime) error); ok {
+		r1 = rf(ctx, postID, shareLinkID, iat, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceMock_GenerateShareLinkToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateShareLinkToken'
+type TokenServiceMock_GenerateShareLinkToken_Call struct {
+	*mock.Call
+}
+
+// GenerateShareLinkToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID string
+//   - shareLinkID string
+//   - iat time.Time
+//   - exp sql.NullTime
+func (_e *TokenServiceMock_Expecter) GenerateShareLinkToken(ctx interface{}, postID interface{}, shareLinkID interface{}, iat interface{}, exp interface{}) *TokenServiceMock_GenerateShareLinkToken_Call {
+	return &TokenServiceMock_GenerateShareLinkToken_Call{Call: _e.mock.On("GenerateShareLinkToken", ctx, postID, shareLinkID, iat, exp)}
+}
+
+func (_c *TokenServiceMock_GenerateShareLinkToken_Call) Run(run func(ctx context.Context, postID string, shareLinkID string, iat time.Time, exp sql.NullTime)) *TokenServiceMock_GenerateShareLinkToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *TokenServiceMock_GenerateShareLinkToken_Call) Return(_a0 string, _a1 error) *TokenServiceMock_GenerateShareLinkToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceMock_GenerateShareLinkToken_Call) RunAndReturn(run func(context.Context, string, string, time.Time, sql.NullTime) (string, error)) *TokenServiceMock_GenerateShareLinkToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParseExportToken provides a mock function with given fields: ctx, token
+func (_m *TokenServiceMock) ParseExportToken(ctx context.Context, token string) (*models.ExportTokenClaims, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseExportToken")
+	}
+
+	var r0 *models.ExportTokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ExportTokenClaims, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ExportTokenClaims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExportTokenClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceMock_ParseExportToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseExportToken'
+type TokenServiceMock_ParseExportToken_Call struct {
+	*mock.Call
+}
+
+// ParseExportToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *TokenServiceMock_Expecter) ParseExportToken(ctx interface{}, token interface{}) *TokenServiceMock_ParseExportToken_Call {
+	return &TokenServiceMock_ParseExportToken_Call{Call: _e.mock.On("ParseExportToken", ctx, token)}
+}
+
+func (_c *TokenServiceMock_ParseExportToken_Call) Run(run func(ctx context.Context, token string)) *TokenServiceMock_ParseExportToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TokenServiceMock_ParseExportToken_Call) Return(_a0 *models.ExportTokenClaims, _a1 error) *TokenServiceMock_ParseExportToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceMock_ParseExportToken_Call) RunAndReturn(run func(context.Context, string) (*models.ExportTokenClaims, error)) *TokenServiceMock_ParseExportToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParseSessionRevokeToken provides a mock function with given fields: ctx, token
+func (_m *TokenServiceMock) ParseSessionRevokeToken(ctx context.Context, token string) (*models.SessionRevokeTokenClaims, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseSessionRevokeToken")
+	}
+
+	var r0 *models.SessionRevokeTokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.SessionRevokeTokenClaims, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.SessionRevokeTokenClaims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionRevokeTokenClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceMock_ParseSessionRevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseSessionRevokeToken'
+type TokenServiceMock_ParseSessionRevokeToken_Call struct {
+	*mock.Call
+}
+
+// ParseSessionRevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *TokenServiceMock_Expecter) ParseSessionRevokeToken(ctx interface{}, token interface{}) *TokenServiceMock_ParseSessionRevokeToken_Call {
+	return &TokenServiceMock_ParseSessionRevokeToken_Call{Call: _e.mock.On("ParseSessionRevokeToken", ctx, token)}
+}
+
+func (_c *TokenServiceMock_ParseSessionRevokeToken_Call) Run(run func(ctx context.Context, token string)) *TokenServiceMock_ParseSessionRevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TokenServiceMock_ParseSessionRevokeToken_Call) Return(_a0 *models.SessionRevokeTokenClaims, _a1 error) *TokenServiceMock_ParseSessionRevokeToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceMock_ParseSessionRevokeToken_Call) RunAndReturn(run func(context.Context, string) (*models.SessionRevokeTokenClaims, error)) *TokenServiceMock_ParseSessionRevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParseShareLinkToken provides a mock function with given fields: ctx, token
+func (_m *TokenServiceMock) ParseShareLinkToken(ctx context.Context, token string) (*models.ShareLinkTokenClaims, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseShareLinkToken")
+	}
+
+	var r0 *models.ShareLinkTokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ShareLinkTokenClaims, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ShareLinkTokenClaims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShareLinkTokenClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceMock_ParseShareLinkToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseShareLinkToken'
+type TokenServiceMock_ParseShareLinkToken_Call struct {
+	*mock.Call
+}
+
+// ParseShareLinkToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *TokenServiceMock_Expecter) ParseShareLinkToken(ctx interface{}, token interface{}) *TokenServiceMock_ParseShareLinkToken_Call {
+	return &TokenServiceMock_ParseShareLinkToken_Call{Call: _e.mock.On("ParseShareLinkToken", ctx, token)}
+}
+
+func (_c *TokenServiceMock_ParseShareLinkToken_Call) Run(run func(ctx context.Context, token string)) *TokenServiceMock_ParseShareLinkToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TokenServiceMock_ParseShareLinkToken_Call) Return(_a0 *models.ShareLinkTokenClaims, _a1 error) *TokenServiceMock_ParseShareLinkToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceMock_ParseShareLinkToken_Call) RunAndReturn(run func(context.Context, string) (*models.ShareLinkTokenClaims, error)) *TokenServiceMock_ParseShareLinkToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTokenServiceMock creates a new instance of TokenServiceMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenServiceMock(t interface {
@@ -89,6 +89,40 @@ func (_c *UserHandlerMock_GetProfileByUsername_Call) RunAndReturn(run func(http.
 	return _c
 }
 
+// PatchUser provides a mock function with given fields: w, r
+func (_m *UserHandlerMock) PatchUser(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UserHandlerMock_PatchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchUser'
+type UserHandlerMock_PatchUser_Call struct {
+	*mock.Call
+}
+
+// PatchUser is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *UserHandlerMock_Expecter) PatchUser(w interface{}, r interface{}) *UserHandlerMock_PatchUser_Call {
+	return &UserHandlerMock_PatchUser_Call{Call: _e.mock.On("PatchUser", w, r)}
+}
+
+func (_c *UserHandlerMock_PatchUser_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *UserHandlerMock_PatchUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *UserHandlerMock_PatchUser_Call) Return() *UserHandlerMock_PatchUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UserHandlerMock_PatchUser_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *UserHandlerMock_PatchUser_Call {
+	_c.Run(run)
+	return _c
+}
+
 // SearchUsers provides a mock function with given fields: w, r
 func (_m *UserHandlerMock) SearchUsers(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
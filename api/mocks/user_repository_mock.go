@@ -7,6 +7,8 @@ import (
 
 	models "github.com/g-villarinho/tab-notes-api/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepositoryMock is an autogenerated mock type for the UserRepository type
@@ -22,6 +24,54 @@ func (_m *UserRepositoryMock) EXPECT() *UserRepositoryMock_Expecter {
 	return &UserRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// BanUser provides a mock function with given fields: ctx, id, bannedAt
+func (_m *UserRepositoryMock) BanUser(ctx context.Context, id string, bannedAt time.Time) error {
+	ret := _m.Called(ctx, id, bannedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BanUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, bannedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepositoryMock_BanUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanUser'
+type UserRepositoryMock_BanUser_Call struct {
+	*mock.Call
+}
+
+// BanUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - bannedAt time.Time
+func (_e *UserRepositoryMock_Expecter) BanUser(ctx interface{}, id interface{}, bannedAt interface{}) *UserRepositoryMock_BanUser_Call {
+	return &UserRepositoryMock_BanUser_Call{Call: _e.mock.On("BanUser", ctx, id, bannedAt)}
+}
+
+func (_c *UserRepositoryMock_BanUser_Call) Run(run func(ctx context.Context, id string, bannedAt time.Time)) *UserRepositoryMock_BanUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_BanUser_Call) Return(_a0 error) *UserRepositoryMock_BanUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepositoryMock_BanUser_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *UserRepositoryMock_BanUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: ctx, user
 func (_m *UserRepositoryMock) CreateUser(ctx context.Context, user *models.User) error {
 	ret := _m.Called(ctx, user)
@@ -22,6 +22,53 @@ func (_m *UserServiceMock) EXPECT() *UserServiceMock_Expecter {
 	return &UserServiceMock_Expecter{mock: &_m.Mock}
 }
 
+// BanUser provides a mock function with given fields: ctx, id
+func (_m *UserServiceMock) BanUser(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BanUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserServiceMock_BanUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanUser'
+type UserServiceMock_BanUser_Call struct {
+	*mock.Call
+}
+
+// BanUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *UserServiceMock_Expecter) BanUser(ctx interface{}, id interface{}) *UserServiceMock_BanUser_Call {
+	return &UserServiceMock_BanUser_Call{Call: _e.mock.On("BanUser", ctx, id)}
+}
+
+func (_c *UserServiceMock_BanUser_Call) Run(run func(ctx context.Context, id string)) *UserServiceMock_BanUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserServiceMock_BanUser_Call) Return(_a0 error) *UserServiceMock_BanUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserServiceMock_BanUser_Call) RunAndReturn(run func(context.Context, string) error) *UserServiceMock_BanUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: ctx, name, username, email
 func (_m *UserServiceMock) CreateUser(ctx context.Context, name string, username string, email string) (*models.User, error) {
 	ret := _m.Called(ctx, name, username, email)
@@ -261,6 +308,113 @@ func (_c *UserServiceMock_GetUserByEmail_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *UserServiceMock) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserServiceMock_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type UserServiceMock_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *UserServiceMock_Expecter) GetUserByID(ctx interface{}, id interface{}) *UserServiceMock_GetUserByID_Call {
+	return &UserServiceMock_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *UserServiceMock_GetUserByID_Call) Run(run func(ctx context.Context, id string)) *UserServiceMock_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserServiceMock_GetUserByID_Call) Return(_a0 *models.User, _a1 error) *UserServiceMock_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserServiceMock_GetUserByID_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *UserServiceMock_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchUser provides a mock function with given fields: ctx, id, payload
+func (_m *UserServiceMock) PatchUser(ctx context.Context, id string, payload *models.PatchUserPayload) error {
+	ret := _m.Called(ctx, id, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PatchUserPayload) error); ok {
+		r0 = rf(ctx, id, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserServiceMock_PatchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchUser'
+type UserServiceMock_PatchUser_Call struct {
+	*mock.Call
+}
+
+// PatchUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - payload *models.PatchUserPayload
+func (_e *UserServiceMock_Expecter) PatchUser(ctx interface{}, id interface{}, payload interface{}) *UserServiceMock_PatchUser_Call {
+	return &UserServiceMock_PatchUser_Call{Call: _e.mock.On("PatchUser", ctx, id, payload)}
+}
+
+func (_c *UserServiceMock_PatchUser_Call) Run(run func(ctx context.Context, id string, payload *models.PatchUserPayload)) *UserServiceMock_PatchUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PatchUserPayload))
+	})
+	return _c
+}
+
+func (_c *UserServiceMock_PatchUser_Call) Return(_a0 error) *UserServiceMock_PatchUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserServiceMock_PatchUser_Call) RunAndReturn(run func(context.Context, string, *models.PatchUserPayload) error) *UserServiceMock_PatchUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchUsers provides a mock function with given fields: ctx, query
 func (_m *UserServiceMock) SearchUsers(ctx context.Context, query string) ([]*models.SearchUserResponse, error) {
 	ret := _m.Called(ctx, query)
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	ActivityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	SecurityContext        = "https://w3id.org/security/v1"
+	PublicAudience         = "https://www.w3.org/ns/activitystreams#Public"
+
+	ActivityContentType = "application/activity+json"
+	JRDContentType      = "application/jrd+json"
+)
+
+var (
+	ErrInvalidSignature       = errors.New("invalid http signature")
+	ErrInvalidActivity        = errors.New("invalid activity")
+	ErrRemoteActorUnavailable = errors.New("remote actor unavailable")
+	ErrDeliveryRejected       = errors.New("delivery rejected by remote inbox")
+	ErrUnsafeRemoteURL        = errors.New("remote url is not https or points to an internal address")
+)
+
+type WebFinger struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases,omitempty"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href,omitempty"`
+}
+
+type Actor struct {
+	Context           any             `json:"@context,omitempty"`
+	ID                string          `json:"id"`
+	Type              string          `json:"type"`
+	PreferredUsername string          `json:"preferredUsername"`
+	Name              string          `json:"name,omitempty"`
+	URL               string          `json:"url,omitempty"`
+	Inbox             string          `json:"inbox"`
+	Outbox            string          `json:"outbox,omitempty"`
+	Followers         string          `json:"followers,omitempty"`
+	Following         string          `json:"following,omitempty"`
+	PublicKey         ActorPublicKey  `json:"publicKey"`
+	Endpoints         *ActorEndpoints `json:"endpoints,omitempty"`
+}
+
+type ActorPublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+type ActorEndpoints struct {
+	SharedInbox string `json:"sharedInbox,omitempty"`
+}
+
+type Note struct {
+	Context      any      `json:"@context,omitempty"`
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	AttributedTo string   `json:"attributedTo"`
+	Name         string   `json:"name"`
+	Content      string   `json:"content"`
+	URL          string   `json:"url,omitempty"`
+	Published    string   `json:"published"`
+	Updated      string   `json:"updated,omitempty"`
+	To           []string `json:"to"`
+	Cc           []string `json:"cc,omitempty"`
+}
+
+// Activity is an incoming or outgoing activity. Object is kept raw because
+// it may be a bare URI or an embedded object, depending on the sender.
+type Activity struct {
+	Context   any             `json:"@context,omitempty"`
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	Object    json.RawMessage `json:"object"`
+	To        []string        `json:"to,omitempty"`
+	Cc        []string        `json:"cc,omitempty"`
+	Published string          `json:"published,omitempty"`
+}
+
+type OrderedCollection struct {
+	Context      any    `json:"@context,omitempty"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	TotalItems   int    `json:"totalItems"`
+	OrderedItems []any  `json:"orderedItems,omitempty"`
+}
+
+type ActorKey struct {
+	UserID        string
+	PublicKeyPEM  string
+	PrivateKeyPEM string
+	CreatedAt     time.Time
+}
+
+type RemoteActor struct {
+	ID                string
+	ActorURI          string
+	Inbox             string
+	SharedInbox       sql.NullString
+	KeyID             string
+	PublicKeyPEM      string
+	PreferredUsername string
+	FetchedAt         time.Time
+	CreatedAt         time.Time
+}
+
+// Delivery is an activity waiting to be POSTed to a remote inbox, signed
+// with the key of the local actor that sent it.
+type Delivery struct {
+	Inbox         string
+	KeyID         string
+	PrivateKeyPEM string
+	Payload       []byte
+	Attempts      int
+}
@@ -1,15 +1,35 @@
 package models
 
+// SendAuthenticationLinkPayload asks for a login email; with_code adds a
+// one-time code to type in where the link can't be opened.
 type SendAuthenticationLinkPayload struct {
-	Email string `json:"email" validate:"required,email"`
+	Email    string `json:"email" validate:"required,email,max=100"`
+	WithCode bool   `json:"with_code"`
 }
 
 type RegisterPayload struct {
-	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
-	Username string `json:"username" validate:"required"`
+	Name     string `json:"name" validate:"required,notblank,max=100"`
+	Email    string `json:"email" validate:"required,email,max=100"`
+	Username string `json:"username" validate:"required,notblank,max=100"`
 }
 
+// ExchangeLinkTokenPayload carries the magic link token in the body, so
+// native clients never put it in a URL.
+type ExchangeLinkTokenPayload struct {
+	Token string `json:"token" validate:"required,notblank"`
+}
+
+// AuthResponse carries a short lived access token, valid for ExpiresIn
+// seconds, and the refresh token that renews it until the session expires,
+// RefreshExpiresIn seconds from now unless refreshed again.
 type AuthResponse struct {
-	Token string `json:"token"`
+	Token            string `json:"token"`
+	TokenType        string `json:"token_type,omitempty"`
+	ExpiresIn        int    `json:"expires_in,omitempty"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	RefreshExpiresIn int    `json:"refresh_expires_in,omitempty"`
+
+	// NewSignIn is set when the session was verified from an unfamiliar
+	// device, so its user can be alerted.
+	NewSignIn *NewSignIn `json:"-"`
 }
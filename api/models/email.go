@@ -9,6 +9,23 @@ type Email struct {
 
 type MagicLinkEmailData struct {
 	MagicLink string
+	Code      string
 	Name      string
 	Year      int
 }
+
+type ExportReadyEmailData struct {
+	DownloadLink string
+	Name         string
+	Year         int
+}
+
+type NewSignInEmailData struct {
+	Name       string
+	Device     string
+	Location   string
+	IPAddress  string
+	SignedInAt string
+	RevokeLink string
+	Year       int
+}
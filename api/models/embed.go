@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	OEmbedVersion      = "1.0"
+	OEmbedProviderName = "Tab Notes"
+
+	DefaultEmbedWidth  = 550
+	DefaultEmbedHeight = 180
+
+	EmbedCacheMaxAge = 300
+)
+
+// OEmbedResponse is a "rich" oEmbed 1.0 response whose html is an iframe
+// pointing at the post embed card.
+type OEmbedResponse struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	AuthorURL    string `json:"author_url"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	CacheAge     int    `json:"cache_age"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// PostEmbed holds what an embed card shows about a public post.
+type PostEmbed struct {
+	ID             string
+	Title          string
+	AuthorName     string
+	AuthorUsername string
+	Likes          int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
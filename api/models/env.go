@@ -1,16 +1,31 @@
 package models
 
+import (
+	"net/netip"
+	"time"
+)
+
 type Environment struct {
 	Env            string
 	APIPort        string
 	DB             Mysql
 	Key            Key
+	Session        SessionTTL
 	AllowedOrigins []string
 	MaxBodySize    int64
 	RedirectURL    string
 	APIURL         string
 	AMQPURL        string
+	ExportDir      string
 	Hermes         Hermes
+
+	// TrustedProxies are the networks whose X-Forwarded-For is believed
+	// when telling a request's client IP.
+	TrustedProxies []netip.Prefix
+	// GeoIPPath names the offline GeoIP database; empty disables lookups.
+	GeoIPPath string
+	// AdminAPIKey authorizes the /admin routes; empty disables them.
+	AdminAPIKey string
 }
 
 type Mysql struct {
@@ -21,9 +36,28 @@ type Mysql struct {
 	DBPassword string
 }
 
+// Key holds the PEM encoded signing key pair and the public keys of retired
+// pairs, which keep verifying tokens for GracePeriod after RetiredAt.
 type Key struct {
-	PrivateKey string
-	PublicKey  string
+	PrivateKey  string
+	PublicKey   string
+	Retired     []RetiredKey
+	GracePeriod time.Duration
+}
+
+// SessionTTL bounds a session: access tokens last AccessToken, and each
+// refresh slides the session's expiry to IdleTimeout from now, up to
+// MaxLifetime after sign in.
+type SessionTTL struct {
+	MagicLink   time.Duration
+	AccessToken time.Duration
+	IdleTimeout time.Duration
+	MaxLifetime time.Duration
+}
+
+type RetiredKey struct {
+	PublicKey string
+	RetiredAt time.Time
 }
 
 type Hermes struct {
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrExportNotFound     = errors.New("export not found")
+	ErrExportNotReady     = errors.New("export not ready")
+	ErrExportExpired      = errors.New("export expired")
+	ErrInvalidExportToken = errors.New("invalid export token")
+)
+
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+type Export struct {
+	ID          string
+	UserID      string
+	Status      ExportStatus
+	FilePath    sql.NullString
+	ExpiresAt   sql.NullTime
+	CreatedAt   time.Time
+	CompletedAt sql.NullTime
+}
+
+type ExportResponse struct {
+	ID        string       `json:"id"`
+	Status    ExportStatus `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type ExportProfile struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type ExportLike struct {
+	PostID    string    `json:"post_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ExportSession struct {
+	ID         string     `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrImportNotFound       = errors.New("import not found")
+	ErrInvalidImportArchive = errors.New("invalid import archive")
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+type Import struct {
+	ID            string
+	UserID        string
+	Status        ImportStatus
+	TotalFiles    int
+	ImportedCount int
+	SkippedCount  int
+	Errors        []*ImportFileError
+	Warnings      []*ImportFileError
+	CreatedAt     time.Time
+	CompletedAt   sql.NullTime
+}
+
+type ImportFileError struct {
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+// ImportedPost is a note read from an import archive, before it becomes a post.
+type ImportedPost struct {
+	Title       string
+	Content     string
+	ContentHash string
+	Visibility  PostVisibility
+	CreatedAt   time.Time
+}
+
+type ImportResponse struct {
+	ID          string             `json:"id"`
+	Status      ImportStatus       `json:"status"`
+	TotalFiles  int                `json:"total_files"`
+	Imported    int                `json:"imported"`
+	Skipped     int                `json:"skipped"`
+	Errors      []*ImportFileError `json:"errors"`
+	Warnings    []*ImportFileError `json:"warnings"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+}
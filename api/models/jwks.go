@@ -0,0 +1,18 @@
+package models
+
+const JWKSCacheMaxAge = 300
+
+// JWK is the RFC 7517 representation of an ECDSA P-256 public key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+type JWKSet struct {
+	Keys []*JWK `json:"keys"`
+}
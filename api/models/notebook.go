@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotebookNotFound     = errors.New("notebook not found")
+	ErrInvalidNotebookOrder = errors.New("post ids do not match notebook posts")
+)
+
+type Notebook struct {
+	ID          string
+	OwnerID     string
+	Title       string
+	Slug        string
+	Description string
+	PostsCount  int
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
+}
+
+type CreateNotebookPayload struct {
+	Title       string `json:"title" validate:"required,notblank,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+type UpdateNotebookPayload struct {
+	Title       string `json:"title" validate:"required,notblank,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+type AddNotebookPostPayload struct {
+	PostID string `json:"post_id" validate:"required"`
+}
+
+type ReorderNotebookPostsPayload struct {
+	PostIDs []string `json:"post_ids" validate:"required,dive,required"`
+}
+
+type NotebookResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	PostsCount  int        `json:"posts_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+type NotebookPostResponse struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Content     string         `json:"content"`
+	ContentHTML string         `json:"content_html"`
+	Visibility  PostVisibility `json:"visibility"`
+	Likes       int            `json:"likes"`
+	LikedByUser bool           `json:"liked_by_user"`
+	Position    int            `json:"position"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type NotebookDetailResponse struct {
+	NotebookResponse
+	OwnerName     string                  `json:"owner_name"`
+	OwnerUsername string                  `json:"owner_username"`
+	Posts         []*NotebookPostResponse `json:"posts"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// PersonalAccessTokenPrefix tells personal access tokens apart from session
+// JWTs in the Authorization header.
+const PersonalAccessTokenPrefix = "tnp_"
+
+type TokenScope string
+
+const (
+	ScopeProfileRead    TokenScope = "profile:read"
+	ScopeProfileWrite   TokenScope = "profile:write"
+	ScopePostsRead      TokenScope = "posts:read"
+	ScopePostsWrite     TokenScope = "posts:write"
+	ScopeFollowsRead    TokenScope = "follows:read"
+	ScopeFollowsWrite   TokenScope = "follows:write"
+	ScopeNotebooksRead  TokenScope = "notebooks:read"
+	ScopeNotebooksWrite TokenScope = "notebooks:write"
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrInvalidPersonalAccessToken  = errors.New("invalid personal access token")
+	ErrInsufficientScope           = errors.New("insufficient scope")
+)
+
+type PersonalAccessToken struct {
+	ID          string
+	UserID      string
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []TokenScope
+	ExpiresAt   sql.NullTime
+	LastUsedAt  sql.NullTime
+	RevokedAt   sql.NullTime
+	CreatedAt   time.Time
+}
+
+// CreatePersonalAccessTokenPayload creates a token that never expires when
+// expires_in_days is omitted.
+type CreatePersonalAccessTokenPayload struct {
+	Name          string       `json:"name" validate:"required,notblank,max=100"`
+	Scopes        []TokenScope `json:"scopes" validate:"required,min=1,dive,oneof=profile:read profile:write posts:read posts:write follows:read follows:write notebooks:read notebooks:write"`
+	ExpiresInDays *int         `json:"expires_in_days" validate:"omitnil,min=1,max=365"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	TokenPrefix string       `json:"token_prefix"`
+	Scopes      []TokenScope `json:"scopes"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// CreatedPersonalAccessTokenResponse is the only response that carries the
+// token itself; only its hash is stored.
+type CreatedPersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const MaxPollDuration = 30 * 24 * time.Hour
+
+var (
+	ErrPollNotFound           = errors.New("poll not found")
+	ErrPollClosed             = errors.New("poll closed")
+	ErrPollOptionNotFound     = errors.New("poll option not found")
+	ErrInvalidPollClosingTime = errors.New("invalid poll closing time")
+)
+
+type Poll struct {
+	ID        string
+	PostID    string
+	ClosesAt  time.Time
+	CreatedAt time.Time
+	Options   []*PollOption
+}
+
+type PollOption struct {
+	ID       string
+	PollID   string
+	Position int
+	Text     string
+	Votes    int
+}
+
+type PollVote struct {
+	PollID    string
+	UserID    string
+	OptionID  string
+	CreatedAt time.Time
+}
+
+// CreatePollPayload is the optional poll sent along with a new post.
+// closes_at must be in the future and at most MaxPollDuration away.
+type CreatePollPayload struct {
+	Options  []string  `json:"options" validate:"required,min=2,max=4,dive,notblank,max=80"`
+	ClosesAt time.Time `json:"closes_at" validate:"required"`
+}
+
+type VotePollPayload struct {
+	OptionID string `json:"option_id" validate:"required,notblank"`
+}
+
+// PollResponse only carries tallies. Who voted for what is never exposed,
+// not even to the author; VotedOptionID is the viewer's own vote.
+type PollResponse struct {
+	ID            string                `json:"id"`
+	Options       []*PollOptionResponse `json:"options"`
+	TotalVotes    int                   `json:"total_votes"`
+	VotedOptionID *string               `json:"voted_option_id"`
+	Closed        bool                  `json:"closed"`
+	ClosesAt      time.Time             `json:"closes_at"`
+}
+
+type PollOptionResponse struct {
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+	Votes int    `json:"votes"`
+}
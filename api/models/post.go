@@ -6,36 +6,78 @@ import (
 	"time"
 )
 
+const (
+	MaxPostTitleLength   = 50
+	MaxPostContentLength = 2000
+)
+
+type PostVisibility string
+
+const (
+	PostVisibilityPublic  PostVisibility = "public"
+	PostVisibilityPrivate PostVisibility = "private"
+)
+
 var (
 	ErrPostNotFound        = errors.New("post not found")
 	ErrPostNotBelongToUser = errors.New("post does not belong to user")
+	ErrPostVersionMismatch = errors.New("post version mismatch")
+	ErrPostContentExists   = errors.New("post with the same content already exists")
 )
 
 type Post struct {
-	ID        string
-	Title     string
-	Content   string
-	AuthorID  string
-	Likes     int
-	CreatedAt time.Time
-	UpdatedAt sql.NullTime
+	ID          string
+	Title       string
+	Content     string
+	ContentHTML sql.NullString
+	ContentHash sql.NullString
+	Visibility  PostVisibility
+	AuthorID    string
+	Likes       int
+	Version     int
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
 }
 
+// CreatePostPayload defaults to a public post when visibility is omitted.
 type CreatePostPayload struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title      string             `json:"title" validate:"required,notblank,max=50"`
+	Content    string             `json:"content" validate:"required,notblank,max=2000"`
+	Visibility PostVisibility     `json:"visibility" validate:"omitempty,oneof=public private"`
+	Poll       *CreatePollPayload `json:"poll" validate:"omitnil"`
 }
 
+// UpdatePostPayload keeps the current visibility when it is omitted.
 type UpdatePostPayload struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title      string         `json:"title" validate:"required,notblank,max=50"`
+	Content    string         `json:"content" validate:"required,notblank,max=2000"`
+	Visibility PostVisibility `json:"visibility" validate:"omitempty,oneof=public private"`
+}
+
+// PatchPostPayload follows JSON Merge Patch semantics: nil fields are left
+// unchanged.
+type PatchPostPayload struct {
+	Title      *string         `json:"title" validate:"omitnil,notblank,max=50"`
+	Content    *string         `json:"content" validate:"omitnil,notblank,max=2000"`
+	Visibility *PostVisibility `json:"visibility" validate:"omitnil,oneof=public private"`
 }
 
 type PostResponse struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Content     string    `json:"content"`
-	Likes       int       `json:"likes"`
-	LikedByUser bool      `json:"liked_by_user"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Content     string              `json:"content"`
+	ContentHTML string              `json:"content_html"`
+	Visibility  PostVisibility      `json:"visibility"`
+	Likes       int                 `json:"likes"`
+	LikedByUser bool                `json:"liked_by_user"`
+	Links       []*PostLinkResponse `json:"links"`
+	Poll        *PollResponse       `json:"poll,omitempty"`
+	Version     int                 `json:"version"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+type PostConflictResponse struct {
+	Problem
+	Current *PostResponse `json:"current"`
 }
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PostLink struct {
+	SourcePostID string
+	TargetRef    string
+	TargetPostID sql.NullString
+	TargetTitle  sql.NullString
+	CreatedAt    time.Time
+}
+
+type PostLinkResponse struct {
+	Ref    string `json:"ref"`
+	PostID string `json:"post_id,omitempty"`
+	Title  string `json:"title,omitempty"`
+}
+
+type BacklinkResponse struct {
+	PostID         string    `json:"post_id"`
+	Title          string    `json:"title"`
+	AuthorUsername string    `json:"author_username"`
+	CreatedAt      time.Time `json:"created_at"`
+}
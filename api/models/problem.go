@@ -0,0 +1,14 @@
+package models
+
+// Problem is an RFC 7807 problem details body. Code is the stable,
+// machine-readable identifier clients should branch on.
+type Problem struct {
+	Type      string        `json:"type"`
+	Title     string        `json:"title"`
+	Status    int           `json:"status"`
+	Detail    string        `json:"detail,omitempty"`
+	Instance  string        `json:"instance,omitempty"`
+	Code      string        `json:"code"`
+	RequestID string        `json:"request_id,omitempty"`
+	Errors    []*FieldError `json:"errors,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+)
+
+// RefreshToken is one link of a session's refresh token chain. Each refresh
+// rotates the current token, so a rotated token presented again means it
+// leaked.
+type RefreshToken struct {
+	TokenHash string
+	SessionID string
+	RotatedAt sql.NullTime
+	CreatedAt time.Time
+}
+
+// RefreshTokenPayload carries the refresh token of native clients; browsers
+// send it in the refresh cookie instead.
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refresh_token" validate:"required,notblank"`
+}
@@ -6,21 +6,133 @@ import (
 	"time"
 )
 
+// MaxLoginCodeAttempts is how many codes can be tried against a session
+// before its code stops working; its magic link still does.
+const MaxLoginCodeAttempts = 5
+
+// MaxUserLoginCodeAttempts caps the codes tried across every session a user
+// started within LoginCodeAttemptWindow, so asking for more login emails
+// does not buy more guesses.
+const (
+	MaxUserLoginCodeAttempts = 10
+	LoginCodeAttemptWindow   = time.Hour
+)
+
 var (
-	ErrSessionNotFound        = errors.New("session not found")
-	ErrSessionExpired         = errors.New("session expired")
-	ErrSessionNotBelongToUser = errors.New("session does not belong to user")
+	ErrSessionNotFound           = errors.New("session not found")
+	ErrSessionExpired            = errors.New("session expired")
+	ErrSessionNotBelongToUser    = errors.New("session does not belong to user")
+	ErrInvalidLoginCode          = errors.New("invalid login code")
+	ErrLoginCodeAttemptsExceeded = errors.New("login code attempts exceeded")
+	ErrPendingLoginNotFound      = errors.New("pending login not found")
+	ErrLoginApprovalRequired     = errors.New("login approval required")
+	ErrInvalidSessionRevokeToken = errors.New("invalid session revoke token")
 )
 
+// LastSeenInterval is how stale a session's last_seen_at may get before a
+// request refreshes it, so not every request writes to the database.
+const LastSeenInterval = 5 * time.Minute
+
+// PendingLoginPollInterval is how often, in seconds, the requesting device
+// should poll a pending login.
+const PendingLoginPollInterval = 3
+
+// Session stores the SHA-256 of its magic link token until it is verified,
+// then of its latest auth token. Tokens themselves are never stored.
 type Session struct {
 	ID         string
-	Token      string
+	TokenHash  string
 	ExpiresAt  time.Time
 	UserID     string
 	VerifiedAt sql.NullTime
 	RevokedAt  sql.NullTime
 	CreatedAt  time.Time
 	UpdatedAt  sql.NullTime
+
+	// CodeHash is set when the login email also carried a one-time code.
+	CodeHash     sql.NullString
+	CodeAttempts int
+
+	// PollSecretHash binds a login requested through /authenticate to the
+	// requesting device, which the link can approve from another device
+	// with the verification code shown on the first one.
+	PollSecretHash   sql.NullString
+	ApprovalCodeHash sql.NullString
+	ApprovedAt       sql.NullTime
+
+	// The device that signed the session in, recorded when it is verified.
+	UserAgent   sql.NullString
+	DeviceLabel sql.NullString
+	IPAddress   sql.NullString
+	Location    sql.NullString
+	LastSeenAt  sql.NullTime
+}
+
+// ClientInfo describes the device a request came from. Location is
+// approximate and empty when unknown.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	Location  string
+}
+
+// SignInHistory summarises a user's verified sessions, for telling whether a
+// sign-in comes from a device or IP address they have used before.
+type SignInHistory struct {
+	Sessions   int
+	DeviceSeen bool
+	IPSeen     bool
+}
+
+// NewSignIn describes a session verified from a device or IP address its
+// user had not signed in from, with the token of the link that revokes it.
+type NewSignIn struct {
+	SessionID   string
+	UserID      string
+	Device      string
+	IPAddress   string
+	Location    string
+	At          time.Time
+	RevokeToken string
+}
+
+// LoginSession is a session created for a login email, with the secrets
+// handed out for it. Only their hashes are stored.
+type LoginSession struct {
+	SessionID        string
+	Token            string
+	Code             string
+	PollSecret       string
+	VerificationCode string
+	ExpiresAt        time.Time
+}
+
+// PendingLoginResponse lets the device that asked for a login email wait for
+// the link to be approved elsewhere. The verification code is typed on the
+// device that opens the link.
+type PendingLoginResponse struct {
+	PendingLoginID   string `json:"pending_login_id"`
+	VerificationCode string `json:"verification_code"`
+	ExpiresIn        int    `json:"expires_in"`
+	PollInterval     int    `json:"poll_interval"`
+}
+
+type PendingLoginStatus string
+
+const (
+	PendingLoginStatusPending  PendingLoginStatus = "pending"
+	PendingLoginStatusApproved PendingLoginStatus = "approved"
+)
+
+type PendingLoginStatusResponse struct {
+	Status PendingLoginStatus `json:"status"`
+}
+
+// LoginCodePayload signs in with the code of a login email, for when its
+// link is opened on another device.
+type LoginCodePayload struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+	Code  string `json:"code" validate:"required,len=6,numeric"`
 }
 
 type RevokeAllSessionsPayload struct {
@@ -28,10 +140,15 @@ type RevokeAllSessionsPayload struct {
 }
 
 type SessionResponse struct {
-	ID               string     `json:"id"`
-	ExpiresAt        time.Time  `json:"expires_at"`
-	CurrentSessionID string     `json:"current_session_id"`
-	VerifiedAt       *time.Time `json:"verified_at,omitempty"`
-	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
-	CreatedAt        time.Time  `json:"created_at"`
+	ID         string     `json:"id"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	IsCurrent  bool       `json:"is_current"`
+	Device     string     `json:"device,omitempty"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	IPAddress  string     `json:"ip_address,omitempty"`
+	Location   string     `json:"location,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
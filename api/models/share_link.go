@@ -0,0 +1,39 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrShareLinkNotFound = errors.New("share link not found")
+	ErrInvalidShareLink  = errors.New("invalid share link")
+)
+
+type ShareLink struct {
+	ID             string
+	PostID         string
+	AccessCount    int
+	LastAccessedAt sql.NullTime
+	ExpiresAt      sql.NullTime
+	RevokedAt      sql.NullTime
+	CreatedAt      time.Time
+}
+
+// CreateShareLinkPayload creates a link that never expires when
+// expires_in_hours is omitted.
+type CreateShareLinkPayload struct {
+	ExpiresInHours *int `json:"expires_in_hours" validate:"omitnil,min=1,max=8760"`
+}
+
+// ShareLinkResponse only carries the URL while the link is still usable.
+type ShareLinkResponse struct {
+	ID             string     `json:"id"`
+	URL            string     `json:"url,omitempty"`
+	AccessCount    int        `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
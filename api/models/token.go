@@ -10,3 +10,20 @@ type AuthTokenClaims struct {
 type MagicLinkTokenClaims struct {
 	jwt.RegisteredClaims
 }
+
+type ExportTokenClaims struct {
+	UserID string `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+type ShareLinkTokenClaims struct {
+	PostID string `json:"pid"`
+	jwt.RegisteredClaims
+}
+
+// SessionRevokeTokenClaims authorize revoking one session, for the link of a
+// new sign-in alert. Subject is the session.
+type SessionRevokeTokenClaims struct {
+	UserID string `json:"uid"`
+	jwt.RegisteredClaims
+}
@@ -6,11 +6,18 @@ import (
 	"time"
 )
 
+const (
+	MaxUserNameLength = 100
+	MaxUsernameLength = 100
+)
+
 var (
 	ErrUserAlreadyExists     = errors.New("user already exists")
 	ErrUserNotFound          = errors.New("user not found")
 	ErrEmailAlreadyExists    = errors.New("email already exists")
 	ErrUsernameAlreadyExists = errors.New("username already exists")
+	ErrUserBanned            = errors.New("user banned")
+	ErrUserInactive          = errors.New("user inactive")
 )
 
 type UserStatus string
@@ -33,13 +40,20 @@ type User struct {
 }
 
 type CreateUserPayload struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name  string `json:"name" validate:"required,notblank,max=100"`
+	Email string `json:"email" validate:"required,email,max=100"`
 }
 
 type UpdateUserPayload struct {
-	Name     string `json:"name"`
-	Username string `json:"username"`
+	Name     string `json:"name" validate:"required,notblank,max=100"`
+	Username string `json:"username" validate:"required,notblank,max=100"`
+}
+
+// PatchUserPayload follows JSON Merge Patch semantics: nil fields are left
+// unchanged.
+type PatchUserPayload struct {
+	Name     *string `json:"name" validate:"omitnil,notblank,max=100"`
+	Username *string `json:"username" validate:"omitnil,notblank,max=100"`
 }
 
 type UserResponse struct {
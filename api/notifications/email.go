@@ -13,8 +13,10 @@ import (
 )
 
 type EmailNotification interface {
-	SendMagicLink(ctx context.Context, name string, email string, magicLink string) error
+	SendMagicLink(ctx context.Context, name string, email string, magicLink string, code string) error
 	SendWelcomeEmail(ctx context.Context, name, email, magicLink string) error
+	SendExportReady(ctx context.Context, name string, email string, downloadLink string) error
+	SendNewSignInAlert(ctx context.Context, name string, email string, signIn *models.NewSignIn, revokeLink string) error
 }
 
 type emailNotification struct {
@@ -28,7 +30,10 @@ func NewEmailNotification(ec clients.EmailClient) EmailNotification {
 		path: "notifications/templates",
 	}
 }
-func (e *emailNotification) SendMagicLink(ctx context.Context, name string, email string, magicLink string) error {
+
+// SendMagicLink sends the login email, showing code alongside the link when
+// one is given.
+func (e *emailNotification) SendMagicLink(ctx context.Context, name string, email string, magicLink string, code string) error {
 	tmpl, err := template.ParseFiles(fmt.Sprintf("%s/access-link-email.html", e.path))
 	if err != nil {
 		log.Fatalf("parse template: %v", err)
@@ -37,6 +42,7 @@ func (e *emailNotification) SendMagicLink(ctx context.Context, name string, emai
 	var htmlBuffer bytes.Buffer
 	data := models.MagicLinkEmailData{
 		MagicLink: magicLink,
+		Code:      code,
 		Name:      name,
 		Year:      time.Now().Year(),
 	}
@@ -45,10 +51,16 @@ func (e *emailNotification) SendMagicLink(ctx context.Context, name string, emai
 		return fmt.Errorf("execute template: %w", err)
 	}
 
+	bodyText := fmt.Sprintf("Hello %s,\n\nClick the link below to login to Tab Notes:\n%s\n\n", name, magicLink)
+	if code != "" {
+		bodyText += fmt.Sprintf("Or enter this code in the app: %s\n\n", code)
+	}
+	bodyText += "Best regards,\nTab Notes Team"
+
 	emailData := &models.Email{
 		To:       email,
 		Subject:  "Login to Tab Notes",
-		BodyText: fmt.Sprintf("Hello %s,\n\nClick the link below to login to Tab Notes:\n%s\n\nBest regards,\nTab Notes Team", name, magicLink),
+		BodyText: bodyText,
 		BodyHTML: htmlBuffer.String(),
 	}
 
@@ -85,3 +97,84 @@ func (e *emailNotification) SendWelcomeEmail(ctx context.Context, name, email, m
 
 	return e.ec.SendEmail(ctx, emailData)
 }
+
+func (e *emailNotification) SendExportReady(ctx context.Context, name string, email string, downloadLink string) error {
+	tmpl, err := template.ParseFiles(fmt.Sprintf("%s/export-ready-email.html", e.path))
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	var htmlBuffer bytes.Buffer
+	data := models.ExportReadyEmailData{
+		DownloadLink: downloadLink,
+		Name:         name,
+		Year:         time.Now().Year(),
+	}
+
+	if err := tmpl.Execute(&htmlBuffer, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	emailData := &models.Email{
+		To:       email,
+		Subject:  "Seus dados do Tab Notes estão prontos",
+		BodyText: fmt.Sprintf("Olá %s!\n\nA exportação dos seus dados foi concluída. Baixe o arquivo pelo link abaixo:\n%s\n\nO link expira em 24 horas.", name, downloadLink),
+		BodyHTML: htmlBuffer.String(),
+	}
+
+	if err := e.ec.SendEmail(ctx, emailData); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+
+	return nil
+}
+
+// SendNewSignInAlert tells the user about a sign-in from an unfamiliar
+// device, with a link that ends that session if it was not them.
+func (e *emailNotification) SendNewSignInAlert(ctx context.Context, name string, email string, signIn *models.NewSignIn, revokeLink string) error {
+	tmpl, err := template.ParseFiles(fmt.Sprintf("%s/new-sign-in-email.html", e.path))
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	device := signIn.Device
+	if device == "" {
+		device = "Dispositivo desconhecido"
+	}
+
+	location := signIn.Location
+	if location == "" {
+		location = "Localização desconhecida"
+	}
+
+	var htmlBuffer bytes.Buffer
+	data := models.NewSignInEmailData{
+		Name:       name,
+		Device:     device,
+		Location:   location,
+		IPAddress:  signIn.IPAddress,
+		SignedInAt: signIn.At.UTC().Format("02/01/2006 15:04 UTC"),
+		RevokeLink: revokeLink,
+		Year:       time.Now().Year(),
+	}
+
+	if err := tmpl.Execute(&htmlBuffer, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	emailData := &models.Email{
+		To:      email,
+		Subject: "Novo acesso à sua conta do Tab Notes",
+		BodyText: fmt.Sprintf(
+			"Olá %s!\n\nSua conta do Tab Notes foi acessada de um novo dispositivo.\n\nDispositivo: %s\nQuando: %s\nLocal aproximado: %s\nIP: %s\n\nSe foi você, não precisa fazer nada. Se não foi, encerre essa sessão pelo link abaixo:\n%s",
+			name, data.Device, data.SignedInAt, data.Location, data.IPAddress, revokeLink,
+		),
+		BodyHTML: htmlBuffer.String(),
+	}
+
+	if err := e.ec.SendEmail(ctx, emailData); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+
+	return nil
+}
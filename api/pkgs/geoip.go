@@ -0,0 +1,132 @@
+package pkgs
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// GeoIP resolves an IP address to an approximate location, such as
+// "São Paulo, SP, BR", without calling out to a service. An empty string
+// means the location is unknown.
+type GeoIP interface {
+	Lookup(ip netip.Addr) string
+}
+
+// NewGeoIP loads the database at path, or returns a GeoIP that knows no
+// locations when path is empty.
+func NewGeoIP(path string) (GeoIP, error) {
+	if path == "" {
+		return NewNoopGeoIP(), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open geoip database: %w", err)
+	}
+	defer file.Close()
+
+	return NewRangeGeoIP(file)
+}
+
+// NewNoopGeoIP returns a GeoIP that knows no locations.
+func NewNoopGeoIP() GeoIP {
+	return noopGeoIP{}
+}
+
+type noopGeoIP struct{}
+
+func (noopGeoIP) Lookup(netip.Addr) string {
+	return ""
+}
+
+type ipRange struct {
+	start    netip.Addr
+	end      netip.Addr
+	location string
+}
+
+type rangeGeoIP struct {
+	ranges []ipRange
+}
+
+// NewRangeGeoIP reads an IP range CSV in the layout of the free DB-IP lite
+// databases: start and end address, then either the country code alone or
+// continent, country, state and city.
+func NewRangeGeoIP(r io.Reader) (GeoIP, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var ranges []ipRange
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read geoip database: %w", err)
+		}
+
+		if len(record) < 3 {
+			return nil, fmt.Errorf("geoip database line %d: expected at least 3 fields", len(ranges)+1)
+		}
+
+		start, err := netip.ParseAddr(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("geoip database start address %q: %w", record[0], err)
+		}
+
+		end, err := netip.ParseAddr(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("geoip database end address %q: %w", record[1], err)
+		}
+
+		ranges = append(ranges, ipRange{start: start, end: end, location: rangeLocation(record)})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Less(ranges[j].start)
+	})
+
+	return &rangeGeoIP{ranges: ranges}, nil
+}
+
+func rangeLocation(record []string) string {
+	if len(record) < 6 {
+		return strings.TrimSpace(record[2])
+	}
+
+	var parts []string
+	for _, part := range []string{record[5], record[4], record[3]} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (g *rangeGeoIP) Lookup(ip netip.Addr) string {
+	ip = ip.Unmap()
+
+	// The last range starting at or before ip is the only one that can hold it.
+	i := sort.Search(len(g.ranges), func(i int) bool {
+		return ip.Less(g.ranges[i].start)
+	})
+	if i == 0 {
+		return ""
+	}
+
+	r := g.ranges[i-1]
+	if r.start.Is4() != ip.Is4() || r.end.Less(ip) {
+		return ""
+	}
+
+	return r.location
+}
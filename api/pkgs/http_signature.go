@@ -0,0 +1,259 @@
+package pkgs
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	signatureAlgorithm = "rsa-sha256"
+	signatureMaxSkew   = 12 * time.Hour
+	rsaKeyBits         = 2048
+)
+
+var signedHeaders = []string{"(request-target)", "host", "date", "digest"}
+
+// HTTPSigner signs and verifies requests with HTTP Signatures
+// (draft-cavage-http-signatures), the scheme ActivityPub servers use to
+// authenticate deliveries. Only rsa-sha256 is supported.
+type HTTPSigner interface {
+	GenerateKeyPair() (publicKeyPEM string, privateKeyPEM string, err error)
+	Sign(r *http.Request, keyID string, privateKeyPEM string, body []byte) error
+	Verify(r *http.Request, publicKeyPEM string, body []byte) error
+	KeyID(r *http.Request) (string, error)
+}
+
+type httpSigner struct {
+	now func() time.Time
+}
+
+func NewHTTPSigner() HTTPSigner {
+	return &httpSigner{
+		now: time.Now,
+	}
+}
+
+func (h *httpSigner) GenerateKeyPair() (string, string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	return string(publicPEM), string(privatePEM), nil
+}
+
+// Sign sets the Date, Digest and Signature headers of r. body must be the
+// exact bytes that will be sent.
+func (h *httpSigner) Sign(r *http.Request, keyID string, privateKeyPEM string, body []byte) error {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	if r.Host == "" {
+		r.Host = r.URL.Host
+	}
+	r.Header.Set("Date", h.now().UTC().Format(http.TimeFormat))
+	r.Header.Set("Digest", digest(body))
+
+	signingString, err := buildSigningString(r, signedHeaders)
+	if err != nil {
+		return err
+	}
+
+	hashed := sha256.Sum256([]byte(signingString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Signature", fmt.Sprintf(`keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		keyID,
+		signatureAlgorithm,
+		strings.Join(signedHeaders, " "),
+		base64.StdEncoding.EncodeToString(signature),
+	))
+
+	return nil
+}
+
+// Verify checks the Signature header of r against publicKeyPEM. The
+// signature must cover the request target, host and date, and the digest
+// whenever there is a body, and the date must be recent.
+func (h *httpSigner) Verify(r *http.Request, publicKeyPEM string, body []byte) error {
+	params, err := parseSignatureHeader(r.Header.Get("Signature"))
+	if err != nil {
+		return err
+	}
+
+	if algorithm := params["algorithm"]; algorithm != "" && algorithm != signatureAlgorithm && algorithm != "hs2019" {
+		return fmt.Errorf("unsupported signature algorithm %q", algorithm)
+	}
+
+	headers := strings.Fields(strings.ToLower(params["headers"]))
+	if len(headers) == 0 {
+		headers = []string{"date"}
+	}
+
+	required := []string{"(request-target)", "host", "date"}
+	if len(body) > 0 {
+		required = append(required, "digest")
+	}
+	for _, name := range required {
+		if !slices.Contains(headers, name) {
+			return fmt.Errorf("signature does not cover %s", name)
+		}
+	}
+
+	date, err := http.ParseTime(r.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("parse date: %w", err)
+	}
+	if skew := h.now().Sub(date); skew > signatureMaxSkew || skew < -signatureMaxSkew {
+		return errors.New("signature date out of range")
+	}
+
+	if slices.Contains(headers, "digest") && r.Header.Get("Digest") != digest(body) {
+		return errors.New("digest mismatch")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	publicKey, err := parseRSAPublicKey(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	signingString, err := buildSigningString(r, headers)
+	if err != nil {
+		return err
+	}
+
+	hashed := sha256.Sum256([]byte(signingString))
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature)
+}
+
+func (h *httpSigner) KeyID(r *http.Request) (string, error) {
+	params, err := parseSignatureHeader(r.Header.Get("Signature"))
+	if err != nil {
+		return "", err
+	}
+
+	return params["keyId"], nil
+}
+
+func parseSignatureHeader(header string) (map[string]string, error) {
+	if header == "" {
+		return nil, errors.New("missing signature header")
+	}
+
+	params := make(map[string]string)
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		params[key] = strings.Trim(value, `"`)
+	}
+
+	if params["keyId"] == "" || params["signature"] == "" {
+		return nil, errors.New("malformed signature header")
+	}
+
+	return params, nil
+}
+
+func buildSigningString(r *http.Request, headers []string) (string, error) {
+	lines := make([]string, len(headers))
+	for i, name := range headers {
+		var value string
+		switch name {
+		case "(request-target)":
+			value = strings.ToLower(r.Method) + " " + r.URL.RequestURI()
+		case "host":
+			value = r.Host
+		default:
+			value = r.Header.Get(name)
+		}
+
+		if value == "" {
+			return "", fmt.Errorf("missing signed header %s", name)
+		}
+		lines[i] = name + ": " + value
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func parseRSAPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, errors.New("failed to parse RSA private key")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
+
+	return privateKey, nil
+}
+
+func parseRSAPublicKey(pemKey string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, errors.New("failed to parse RSA public key")
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not RSA")
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,170 @@
+package pkgs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Keyring holds the ECDSA keys that sign and verify our JWTs, parsed once at
+// startup. Tokens name their signing key in the kid header, so a rotation
+// only moves new tokens to the new key: tokens signed with a retired key stay
+// valid until the grace period after its retirement ends.
+type Keyring interface {
+	SigningKey() (string, *ecdsa.PrivateKey)
+	Keyfunc(token *jwt.Token) (any, error)
+	JWKS() *models.JWKSet
+}
+
+type verificationKey struct {
+	id        string
+	publicKey *ecdsa.PublicKey
+	retiredAt time.Time
+}
+
+type keyring struct {
+	signingID  string
+	signingKey *ecdsa.PrivateKey
+	keys       []*verificationKey
+	grace      time.Duration
+}
+
+func NewKeyring(kp EcdsaKeyPair, key models.Key) (Keyring, error) {
+	privateKey, err := kp.ParseECDSAPrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	publicKey, err := kp.ParseECDSAPublicKey(key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	if !privateKey.PublicKey.Equal(publicKey) {
+		return nil, errors.New("public key does not match private key")
+	}
+
+	signingID, err := KeyID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &keyring{
+		signingID:  signingID,
+		signingKey: privateKey,
+		keys:       []*verificationKey{{id: signingID, publicKey: publicKey}},
+		grace:      key.GracePeriod,
+	}
+
+	for i, retired := range key.Retired {
+		publicKey, err := kp.ParseECDSAPublicKey(retired.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse retired public key %d: %w", i, err)
+		}
+
+		id, err := KeyID(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("retired public key %d: %w", i, err)
+		}
+
+		if id == signingID {
+			return nil, fmt.Errorf("retired public key %d is the signing key", i)
+		}
+
+		k.keys = append(k.keys, &verificationKey{id: id, publicKey: publicKey, retiredAt: retired.RetiredAt})
+	}
+
+	return k, nil
+}
+
+func (k *keyring) SigningKey() (string, *ecdsa.PrivateKey) {
+	return k.signingID, k.signingKey
+}
+
+// Keyfunc picks the verification key named by the token's kid header.
+// Tokens issued before key IDs existed carry none and are checked against
+// every key still in use.
+func (k *keyring) Keyfunc(token *jwt.Token) (any, error) {
+	keys := k.usableKeys()
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		var set jwt.VerificationKeySet
+		for _, key := range keys {
+			set.Keys = append(set.Keys, key.publicKey)
+		}
+		return set, nil
+	}
+
+	for _, key := range keys {
+		if key.id == kid {
+			return key.publicKey, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+// JWKS publishes the public half of every key still in use, signing key
+// first.
+func (k *keyring) JWKS() *models.JWKSet {
+	keys := k.usableKeys()
+
+	set := &models.JWKSet{Keys: make([]*models.JWK, len(keys))}
+	for i, key := range keys {
+		x, y := coordinates(key.publicKey)
+		set.Keys[i] = &models.JWK{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   x,
+			Y:   y,
+			Kid: key.id,
+			Use: "sig",
+			Alg: jwt.SigningMethodES256.Alg(),
+		}
+	}
+
+	return set
+}
+
+func (k *keyring) usableKeys() []*verificationKey {
+	now := time.Now()
+
+	return slices.DeleteFunc(slices.Clone(k.keys), func(key *verificationKey) bool {
+		return !key.retiredAt.IsZero() && !now.Before(key.retiredAt.Add(k.grace))
+	})
+}
+
+// KeyID returns the RFC 7638 thumbprint of a P-256 public key, which is
+// stable across restarts without having to configure IDs.
+func KeyID(publicKey *ecdsa.PublicKey) (string, error) {
+	if publicKey.Curve != elliptic.P256() {
+		return "", errors.New("key is not on the P-256 curve")
+	}
+
+	x, y := coordinates(publicKey)
+	thumbprint := sha256.Sum256(fmt.Appendf(nil, `{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, x, y))
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint[:]), nil
+}
+
+// coordinates returns the base64url encoded x and y of a P-256 public key.
+func coordinates(publicKey *ecdsa.PublicKey) (string, string) {
+	ecdhKey, err := publicKey.ECDH()
+	if err != nil {
+		return "", ""
+	}
+
+	point := ecdhKey.Bytes()[1:]
+	size := len(point) / 2
+
+	return base64.RawURLEncoding.EncodeToString(point[:size]), base64.RawURLEncoding.EncodeToString(point[size:])
+}
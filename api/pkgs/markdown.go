@@ -0,0 +1,79 @@
+package pkgs
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+const linkRel = "nofollow ugc"
+
+type MarkdownRenderer interface {
+	Render(source string) (string, error)
+}
+
+type markdownRenderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+// NewMarkdownRenderer renders CommonMark plus the GFM tables, strikethrough,
+// autolink and task list extensions. Raw HTML in the source is never passed
+// through, and the output is sanitized against an allowlist.
+func NewMarkdownRenderer() MarkdownRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.Table,
+			extension.Strikethrough,
+			extension.Linkify,
+			extension.TaskList,
+		),
+		goldmark.WithParserOptions(
+			parser.WithASTTransformers(util.Prioritized(&linkRelTransformer{}, 100)),
+		),
+	)
+
+	policy := bluemonday.UGCPolicy()
+	policy.RequireNoFollowOnLinks(true)
+	policy.AllowAttrs("rel").Matching(regexp.MustCompile("^" + linkRel + "$")).OnElements("a")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile("^checkbox$")).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return &markdownRenderer{
+		md:     md,
+		policy: policy,
+	}
+}
+
+func (m *markdownRenderer) Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := m.md.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return m.policy.Sanitize(buf.String()), nil
+}
+
+type linkRelTransformer struct{}
+
+func (t *linkRelTransformer) Transform(node *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n.Kind() {
+		case ast.KindLink, ast.KindAutoLink:
+			n.SetAttributeString("rel", []byte(linkRel))
+		}
+
+		return ast.WalkContinue, nil
+	})
+}
@@ -20,8 +20,8 @@ func SQLNullTimeZero() sql.NullTime {
 	return sql.NullTime{Valid: false}
 }
 
-func MockSessionWithToken(token string) any {
+func MockSessionWithTokenHash(tokenHash string) any {
 	return mock.MatchedBy(func(s *models.Session) bool {
-		return s != nil && s.Token == token
+		return s != nil && s.TokenHash == tokenHash
 	})
 }
@@ -0,0 +1,66 @@
+package pkgs
+
+import "strings"
+
+// The order matters: Edge and Opera also claim Chrome, Chrome claims
+// Safari, and Android claims Linux.
+var (
+	browserTokens = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"EdgA/", "Edge"},
+		{"EdgiOS/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+
+	systemTokens = []struct{ token, name string }{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Macintosh", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DeviceLabel names the browser and system of a User-Agent for people
+// telling their sessions apart, like "Chrome · Windows". Clients it does not
+// recognise are labelled by their product name, so API clients show up as
+// e.g. "curl".
+func DeviceLabel(userAgent string) string {
+	var browser, system string
+
+	for _, b := range browserTokens {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	for _, s := range systemTokens {
+		if strings.Contains(userAgent, s.token) {
+			system = s.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " · " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+
+	product, _, _ := strings.Cut(userAgent, "/")
+	product, _, _ = strings.Cut(product, " ")
+
+	return product
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+type ActorKeyRepository interface {
+	CreateActorKey(ctx context.Context, key *models.ActorKey) error
+	GetActorKeyByUserID(ctx context.Context, userID string) (*models.ActorKey, error)
+}
+
+type actorKeyRepository struct {
+	db *sql.DB
+}
+
+func NewActorKeyRepository(db *sql.DB) ActorKeyRepository {
+	return &actorKeyRepository{
+		db: db,
+	}
+}
+
+// CreateActorKey keeps the first key stored for the user, so concurrent
+// requests generating one at the same time end up publishing the same key.
+func (a *actorKeyRepository) CreateActorKey(ctx context.Context, key *models.ActorKey) error {
+	key.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT IGNORE INTO actor_keys (user_id, public_key_pem, private_key_pem, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err := a.db.ExecContext(ctx, query, key.UserID, key.PublicKeyPEM, key.PrivateKeyPEM, key.CreatedAt)
+	return err
+}
+
+func (a *actorKeyRepository) GetActorKeyByUserID(ctx context.Context, userID string) (*models.ActorKey, error) {
+	query := `SELECT user_id, public_key_pem, private_key_pem, created_at FROM actor_keys WHERE user_id = ?`
+
+	key := &models.ActorKey{}
+	err := a.db.QueryRowContext(ctx, query, userID).Scan(&key.UserID, &key.PublicKeyPEM, &key.PrivateKeyPEM, &key.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"github.com/google/uuid"
+)
+
+type ExportRepository interface {
+	CreateExport(ctx context.Context, export *models.Export) error
+	GetExportByID(ctx context.Context, id string) (*models.Export, error)
+	GetPendingExportByUserID(ctx context.Context, userID string, createdAfter time.Time) (*models.Export, error)
+	GetExpiredExports(ctx context.Context, now time.Time) ([]*models.Export, error)
+	UpdateExport(ctx context.Context, export *models.Export) error
+}
+
+type exportRepository struct {
+	db *sql.DB
+}
+
+func NewExportRepository(db *sql.DB) ExportRepository {
+	return &exportRepository{
+		db: db,
+	}
+}
+
+func (r *exportRepository) CreateExport(ctx context.Context, export *models.Export) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	export.ID = id.String()
+	export.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO exports (id, user_id, status, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, export.ID, export.UserID, export.Status, export.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *exportRepository) GetExportByID(ctx context.Context, id string) (*models.Export, error) {
+	query := `
+		SELECT id, user_id, status, file_path, expires_at, created_at, completed_at
+		FROM exports
+		WHERE id = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanExport, id)
+}
+
+// GetPendingExportByUserID ignores exports created before createdAfter, so a
+// build that died without updating its row does not block new requests.
+func (r *exportRepository) GetPendingExportByUserID(ctx context.Context, userID string, createdAfter time.Time) (*models.Export, error) {
+	query := `
+		SELECT id, user_id, status, file_path, expires_at, created_at, completed_at
+		FROM exports
+		WHERE user_id = ? AND status = ? AND created_at > ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanExport, userID, models.ExportStatusPending, createdAfter)
+}
+
+// GetExpiredExports returns the exports whose archive is still on disk after
+// their download link expired.
+func (r *exportRepository) GetExpiredExports(ctx context.Context, now time.Time) ([]*models.Export, error) {
+	query := `
+		SELECT id, user_id, status, file_path, expires_at, created_at, completed_at
+		FROM exports
+		WHERE file_path IS NOT NULL AND expires_at <= ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []*models.Export
+	for rows.Next() {
+		var e models.Export
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Status, &e.FilePath, &e.ExpiresAt, &e.CreatedAt, &e.CompletedAt); err != nil {
+			return nil, err
+		}
+		exports = append(exports, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (r *exportRepository) UpdateExport(ctx context.Context, export *models.Export) error {
+	query := `
+		UPDATE exports
+		SET status = ?, file_path = ?, expires_at = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, export.Status, export.FilePath, export.ExpiresAt, export.CompletedAt, export.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanExport(row *sql.Row) (*models.Export, error) {
+	var e models.Export
+	err := row.Scan(&e.ID, &e.UserID, &e.Status, &e.FilePath, &e.ExpiresAt, &e.CreatedAt, &e.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
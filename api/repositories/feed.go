@@ -24,12 +24,13 @@ func NewFeedRepository(db *sql.DB) FeedRepository {
 
 func (r *feedRepository) GetFeed(ctx context.Context, userID string, limit, offset int) ([]*models.FeedPostResponse, error) {
 	query := `
-		SELECT p.id, p.title, p.content, p.likes, p.created_at,
+		SELECT p.id, p.title, p.content, p.content_html, p.likes, p.created_at,
 		       u.name AS author_name, u.username AS author_username
 		FROM posts p
 		INNER JOIN users u ON u.id = p.author_id
 		LEFT JOIN followers f ON f.user_id = p.author_id AND f.follower_id = ?
-		WHERE f.follower_id IS NOT NULL OR p.author_id = ?
+		WHERE ((f.follower_id IS NOT NULL AND p.visibility = 'public') OR p.author_id = ?)
+		AND u.status <> 'banned'
 		ORDER BY p.created_at DESC
 		LIMIT ? OFFSET ?
 	`
@@ -43,10 +44,12 @@ func (r *feedRepository) GetFeed(ctx context.Context, userID string, limit, offs
 	var feed []*models.FeedPostResponse
 	for rows.Next() {
 		var post models.FeedPostResponse
-		err := rows.Scan(&post.PostID, &post.Title, &post.Content, &post.Likes, &post.CreatedAt, &post.AuthorName, &post.AuthorUsername)
+		var contentHTML sql.NullString
+		err := rows.Scan(&post.PostID, &post.Title, &post.Content, &contentHTML, &post.Likes, &post.CreatedAt, &post.AuthorName, &post.AuthorUsername)
 		if err != nil {
 			return nil, fmt.Errorf("scan feed post: %w", err)
 		}
+		post.ContentHTML = contentHTML.String
 		feed = append(feed, &post)
 	}
 
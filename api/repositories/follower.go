@@ -146,14 +146,15 @@ func (r *followerRepository) CountFollowing(ctx context.Context, userID string)
 func (f *followerRepository) GetFollowStats(ctx context.Context, userID string, viewerID string) (*models.FollowStats, error) {
 	query := `
 		SELECT
-			(SELECT COUNT(*) FROM followers WHERE user_id = ?) AS followers,
+			(SELECT COUNT(*) FROM followers WHERE user_id = ?)
+				+ (SELECT COUNT(*) FROM remote_followers WHERE user_id = ?) AS followers,
 			(SELECT COUNT(*) FROM followers WHERE follower_id = ?) AS following,
 			EXISTS(SELECT 1 FROM followers WHERE user_id = ? AND follower_id = ?) AS followed_by_me,
 			EXISTS(SELECT 1 FROM followers WHERE user_id = ? AND follower_id = ?) AS following_me
 	`
 
 	row := f.db.QueryRowContext(ctx, query,
-		userID,
+		userID, userID,
 		userID,
 		userID, viewerID,
 		viewerID, userID,
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"github.com/google/uuid"
+)
+
+type ImportRepository interface {
+	CreateImport(ctx context.Context, imp *models.Import) error
+	GetImportByID(ctx context.Context, id string) (*models.Import, error)
+	UpdateImport(ctx context.Context, imp *models.Import) error
+}
+
+type importRepository struct {
+	db *sql.DB
+}
+
+func NewImportRepository(db *sql.DB) ImportRepository {
+	return &importRepository{
+		db: db,
+	}
+}
+
+func (r *importRepository) CreateImport(ctx context.Context, imp *models.Import) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	imp.ID = id.String()
+	imp.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO imports (id, user_id, status, total_files, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, imp.ID, imp.UserID, imp.Status, imp.TotalFiles, imp.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *importRepository) GetImportByID(ctx context.Context, id string) (*models.Import, error) {
+	query := `
+		SELECT id, user_id, status, total_files, imported_count, skipped_count, errors, warnings, created_at, completed_at
+		FROM imports
+		WHERE id = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanImport, id)
+}
+
+func (r *importRepository) UpdateImport(ctx context.Context, imp *models.Import) error {
+	errorsJSON, err := json.Marshal(imp.Errors)
+	if err != nil {
+		return err
+	}
+
+	warningsJSON, err := json.Marshal(imp.Warnings)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE imports
+		SET status = ?, imported_count = ?, skipped_count = ?, errors = ?, warnings = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, imp.Status, imp.ImportedCount, imp.SkippedCount, errorsJSON, warningsJSON, imp.CompletedAt, imp.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanImport(row *sql.Row) (*models.Import, error) {
+	var i models.Import
+	var errorsJSON, warningsJSON []byte
+	err := row.Scan(&i.ID, &i.UserID, &i.Status, &i.TotalFiles, &i.ImportedCount, &i.SkippedCount, &errorsJSON, &warningsJSON, &i.CreatedAt, &i.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errorsJSON) > 0 {
+		if err := json.Unmarshal(errorsJSON, &i.Errors); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(warningsJSON) > 0 {
+		if err := json.Unmarshal(warningsJSON, &i.Warnings); err != nil {
+			return nil, err
+		}
+	}
+
+	return &i, nil
+}
@@ -15,6 +15,7 @@ type LikeRepository interface {
 	DeleteLike(ctx context.Context, like *models.Like) error
 	CheckLike(ctx context.Context, userID, postID string) (bool, error)
 	GetLikedPostIDs(ctx context.Context, userID string, postIDs []string) ([]string, error)
+	GetLikesByUserID(ctx context.Context, userID string) ([]*models.Like, error)
 }
 
 type likeRepository struct {
@@ -167,3 +168,33 @@ func (l *likeRepository) GetLikedPostIDs(ctx context.Context, userID string, pos
 
 	return likedPostIDs, nil
 }
+
+func (l *likeRepository) GetLikesByUserID(ctx context.Context, userID string) ([]*models.Like, error) {
+	query := `
+		SELECT user_id, post_id, created_at
+		FROM likes
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := l.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var likes []*models.Like
+	for rows.Next() {
+		var like models.Like
+		if err := rows.Scan(&like.UserID, &like.PostID, &like.CreatedAt); err != nil {
+			return nil, err
+		}
+		likes = append(likes, &like)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return likes, nil
+}
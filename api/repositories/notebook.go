@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"github.com/google/uuid"
+)
+
+type NotebookRepository interface {
+	CreateNotebook(ctx context.Context, notebook *models.Notebook) error
+	GetNotebookByID(ctx context.Context, id string) (*models.Notebook, error)
+	GetNotebookBySlug(ctx context.Context, ownerID string, slug string) (*models.Notebook, error)
+	GetNotebooksByOwnerID(ctx context.Context, ownerID string) ([]*models.Notebook, error)
+	UpdateNotebook(ctx context.Context, notebook *models.Notebook) error
+	DeleteNotebook(ctx context.Context, id string) error
+	AddPost(ctx context.Context, notebookID string, postID string) error
+	RemovePost(ctx context.Context, notebookID string, postID string) error
+	GetPostIDs(ctx context.Context, notebookID string) ([]string, error)
+	ReorderPosts(ctx context.Context, notebookID string, postIDs []string) error
+	GetNotebookPosts(ctx context.Context, notebookID string) ([]*models.NotebookPostResponse, error)
+}
+
+type notebookRepository struct {
+	db *sql.DB
+}
+
+func NewNotebookRepository(db *sql.DB) NotebookRepository {
+	return &notebookRepository{
+		db: db,
+	}
+}
+
+func (r *notebookRepository) CreateNotebook(ctx context.Context, notebook *models.Notebook) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	notebook.ID = id.String()
+	notebook.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO notebooks (id, owner_id, title, slug, description, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, notebook.ID, notebook.OwnerID, notebook.Title, notebook.Slug, notebook.Description, notebook.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *notebookRepository) GetNotebookByID(ctx context.Context, id string) (*models.Notebook, error) {
+	query := `
+		SELECT n.id, n.owner_id, n.title, n.slug, n.description,
+		       (SELECT COUNT(*) FROM notebook_posts np WHERE np.notebook_id = n.id) AS posts_count,
+		       n.created_at, n.updated_at
+		FROM notebooks n
+		WHERE n.id = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanNotebook, id)
+}
+
+func (r *notebookRepository) GetNotebookBySlug(ctx context.Context, ownerID string, slug string) (*models.Notebook, error) {
+	query := `
+		SELECT n.id, n.owner_id, n.title, n.slug, n.description,
+		       (SELECT COUNT(*) FROM notebook_posts np WHERE np.notebook_id = n.id) AS posts_count,
+		       n.created_at, n.updated_at
+		FROM notebooks n
+		WHERE n.owner_id = ? AND n.slug = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanNotebook, ownerID, slug)
+}
+
+func (r *notebookRepository) GetNotebooksByOwnerID(ctx context.Context, ownerID string) ([]*models.Notebook, error) {
+	query := `
+		SELECT n.id, n.owner_id, n.title, n.slug, n.description,
+		       (SELECT COUNT(*) FROM notebook_posts np WHERE np.notebook_id = n.id) AS posts_count,
+		       n.created_at, n.updated_at
+		FROM notebooks n
+		WHERE n.owner_id = ?
+		ORDER BY n.created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notebooks []*models.Notebook
+	for rows.Next() {
+		var n models.Notebook
+		if err := rows.Scan(&n.ID, &n.OwnerID, &n.Title, &n.Slug, &n.Description, &n.PostsCount, &n.CreatedAt, &n.UpdatedAt); err != nil {
+			return nil, err
+		}
+		notebooks = append(notebooks, &n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notebooks, nil
+}
+
+func (r *notebookRepository) UpdateNotebook(ctx context.Context, notebook *models.Notebook) error {
+	notebook.UpdatedAt = sql.NullTime{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	query := `UPDATE notebooks SET title = ?, description = ?, updated_at = ? WHERE id = ?`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, notebook.Title, notebook.Description, notebook.UpdatedAt, notebook.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *notebookRepository) DeleteNotebook(ctx context.Context, id string) error {
+	query := `DELETE FROM notebooks WHERE id = ?`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *notebookRepository) AddPost(ctx context.Context, notebookID string, postID string) error {
+	query := `
+		INSERT IGNORE INTO notebook_posts (notebook_id, post_id, position, created_at)
+		SELECT ?, ?, COALESCE(MAX(position), -1) + 1, ?
+		FROM notebook_posts
+		WHERE notebook_id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, notebookID, postID, time.Now().UTC(), notebookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *notebookRepository) RemovePost(ctx context.Context, notebookID string, postID string) error {
+	query := `DELETE FROM notebook_posts WHERE notebook_id = ? AND post_id = ?`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, notebookID, postID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *notebookRepository) GetPostIDs(ctx context.Context, notebookID string) ([]string, error) {
+	query := `SELECT post_id FROM notebook_posts WHERE notebook_id = ? ORDER BY position ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, notebookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var postIDs []string
+	for rows.Next() {
+		var postID string
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return postIDs, nil
+}
+
+func (r *notebookRepository) ReorderPosts(ctx context.Context, notebookID string, postIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	updateQuery := `UPDATE notebook_posts SET position = ? WHERE notebook_id = ? AND post_id = ?`
+	for position, postID := range postIDs {
+		if _, err := tx.ExecContext(ctx, updateQuery, position, notebookID, postID); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	updateNotebookQuery := `UPDATE notebooks SET updated_at = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, updateNotebookQuery, time.Now().UTC(), notebookID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *notebookRepository) GetNotebookPosts(ctx context.Context, notebookID string) ([]*models.NotebookPostResponse, error) {
+	query := `
+		SELECT p.id, p.title, p.content, p.content_html, p.visibility, p.likes, np.position, p.created_at
+		FROM notebook_posts np
+		INNER JOIN posts p ON p.id = np.post_id
+		WHERE np.notebook_id = ?
+		ORDER BY np.position ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, notebookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*models.NotebookPostResponse
+	for rows.Next() {
+		var post models.NotebookPostResponse
+		var contentHTML sql.NullString
+		if err := rows.Scan(&post.ID, &post.Title, &post.Content, &contentHTML, &post.Visibility, &post.Likes, &post.Position, &post.CreatedAt); err != nil {
+			return nil, err
+		}
+		post.ContentHTML = contentHTML.String
+		posts = append(posts, &post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+func scanNotebook(row *sql.Row) (*models.Notebook, error) {
+	var n models.Notebook
+	err := row.Scan(&n.ID, &n.OwnerID, &n.Title, &n.Slug, &n.Description, &n.PostsCount, &n.CreatedAt, &n.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
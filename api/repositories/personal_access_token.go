@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenRepository interface {
+	CreateToken(ctx context.Context, token *models.PersonalAccessToken) error
+	GetTokenByID(ctx context.Context, id string) (*models.PersonalAccessToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error)
+	GetTokensByUserID(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, id string, revokedAt time.Time) error
+	TouchToken(ctx context.Context, id string, usedAt time.Time) error
+}
+
+type personalAccessTokenRepository struct {
+	db *sql.DB
+}
+
+func NewPersonalAccessTokenRepository(db *sql.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *personalAccessTokenRepository) CreateToken(ctx context.Context, token *models.PersonalAccessToken) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	token.ID = id.String()
+	token.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_hash, token_prefix, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		token.ID,
+		token.UserID,
+		token.Name,
+		token.TokenHash,
+		token.TokenPrefix,
+		joinScopes(token.Scopes),
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *personalAccessTokenRepository) GetTokenByID(ctx context.Context, id string) (*models.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM personal_access_tokens
+		WHERE id = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanPersonalAccessToken, id)
+}
+
+func (r *personalAccessTokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM personal_access_tokens
+		WHERE token_hash = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanPersonalAccessToken, tokenHash)
+}
+
+func (r *personalAccessTokenRepository) GetTokensByUserID(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM personal_access_tokens
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*models.PersonalAccessToken
+	for rows.Next() {
+		var token models.PersonalAccessToken
+		var scopes string
+		if err := rows.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.TokenPrefix, &scopes, &token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt); err != nil {
+			return nil, err
+		}
+		token.Scopes = splitScopes(scopes)
+		tokens = append(tokens, &token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RevokeToken keeps the first revocation time when called again.
+func (r *personalAccessTokenRepository) RevokeToken(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `UPDATE personal_access_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, revokedAt, id)
+	return err
+}
+
+// TouchToken records a use of the token, writing at most once a minute so
+// busy scripts do not turn every request into a write.
+func (r *personalAccessTokenRepository) TouchToken(ctx context.Context, id string, usedAt time.Time) error {
+	query := `
+		UPDATE personal_access_tokens
+		SET last_used_at = ?
+		WHERE id = ? AND (last_used_at IS NULL OR last_used_at < ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, usedAt, id, usedAt.Add(-time.Minute))
+	return err
+}
+
+func scanPersonalAccessToken(row *sql.Row) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	var scopes string
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.TokenPrefix, &scopes, &token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	token.Scopes = splitScopes(scopes)
+	return &token, nil
+}
+
+// Scopes are stored space separated, as in an OAuth scope parameter.
+func joinScopes(scopes []models.TokenScope) string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+	return strings.Join(values, " ")
+}
+
+func splitScopes(value string) []models.TokenScope {
+	fields := strings.Fields(value)
+	scopes := make([]models.TokenScope, len(fields))
+	for i, field := range fields {
+		scopes[i] = models.TokenScope(field)
+	}
+	return scopes
+}
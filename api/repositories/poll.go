@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/google/uuid"
+)
+
+type PollRepository interface {
+	CreatePoll(ctx context.Context, poll *models.Poll) error
+	GetPollsByPostIDs(ctx context.Context, postIDs []string) ([]*models.Poll, error)
+	GetVotedOptionIDs(ctx context.Context, userID string, pollIDs []string) (map[string]string, error)
+	Vote(ctx context.Context, vote *models.PollVote) error
+}
+
+type pollRepository struct {
+	db *sql.DB
+}
+
+func NewPollRepository(db *sql.DB) PollRepository {
+	return &pollRepository{
+		db: db,
+	}
+}
+
+func (r *pollRepository) CreatePoll(ctx context.Context, poll *models.Poll) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	poll.ID = id.String()
+	poll.CreatedAt = time.Now().UTC()
+
+	for i, option := range poll.Options {
+		optionID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		option.ID = optionID.String()
+		option.PollID = poll.ID
+		option.Position = i
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	pollQuery := `
+		INSERT INTO polls (id, post_id, closes_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	if _, err := tx.ExecContext(ctx, pollQuery, poll.ID, poll.PostID, poll.ClosesAt, poll.CreatedAt); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	optionQuery := `
+		INSERT INTO poll_options (id, poll_id, position, text)
+		VALUES (?, ?, ?, ?)
+	`
+	for _, option := range poll.Options {
+		if _, err := tx.ExecContext(ctx, optionQuery, option.ID, option.PollID, option.Position, option.Text); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPollsByPostIDs returns the polls attached to postIDs with their options
+// in the order the author wrote them.
+func (r *pollRepository) GetPollsByPostIDs(ctx context.Context, postIDs []string) ([]*models.Poll, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(postIDs))
+	placeholders = placeholders[:len(placeholders)-1]
+
+	args := make([]any, len(postIDs))
+	for i, id := range postIDs {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.id, p.post_id, p.closes_at, p.created_at, o.id, o.position, o.text, o.votes
+		FROM polls p
+		JOIN poll_options o ON o.poll_id = p.id
+		WHERE p.post_id IN (%s)
+		ORDER BY p.id, o.position
+	`, placeholders)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var polls []*models.Poll
+	var current *models.Poll
+	for rows.Next() {
+		var poll models.Poll
+		var option models.PollOption
+		if err := rows.Scan(&poll.ID, &poll.PostID, &poll.ClosesAt, &poll.CreatedAt, &option.ID, &option.Position, &option.Text, &option.Votes); err != nil {
+			return nil, err
+		}
+
+		if current == nil || current.ID != poll.ID {
+			current = &poll
+			polls = append(polls, current)
+		}
+
+		option.PollID = current.ID
+		current.Options = append(current.Options, &option)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return polls, nil
+}
+
+// GetVotedOptionIDs maps each of pollIDs the user voted in to the option
+// they picked.
+func (r *pollRepository) GetVotedOptionIDs(ctx context.Context, userID string, pollIDs []string) (map[string]string, error) {
+	if len(pollIDs) == 0 {
+		return map[string]string{}, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pollIDs))
+	placeholders = placeholders[:len(placeholders)-1]
+
+	args := make([]any, 0, len(pollIDs)+1)
+	args = append(args, userID)
+	for _, id := range pollIDs {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT poll_id, option_id FROM poll_votes
+		WHERE user_id = ? AND poll_id IN (%s)
+	`, placeholders)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	voted := make(map[string]string)
+	for rows.Next() {
+		var pollID, optionID string
+		if err := rows.Scan(&pollID, &optionID); err != nil {
+			return nil, err
+		}
+		voted[pollID] = optionID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return voted, nil
+}
+
+// Vote records the user's vote, or moves it to another option, keeping the
+// option counters in step with poll_votes in the same transaction. The
+// existing vote is read with FOR UPDATE so concurrent votes by the same user
+// are applied one after the other.
+func (r *pollRepository) Vote(ctx context.Context, vote *models.PollVote) error {
+	vote.CreatedAt = time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	selectQuery := `
+		SELECT option_id FROM poll_votes
+		WHERE poll_id = ? AND user_id = ?
+		FOR UPDATE
+	`
+	var previousOptionID string
+	err = tx.QueryRowContext(ctx, selectQuery, vote.PollID, vote.UserID).Scan(&previousOptionID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if previousOptionID == vote.OptionID {
+		return tx.Commit()
+	}
+
+	if previousOptionID == "" {
+		insertQuery := `
+			INSERT IGNORE INTO poll_votes (poll_id, user_id, option_id, created_at)
+			VALUES (?, ?, ?, ?)
+		`
+		res, err := tx.ExecContext(ctx, insertQuery, vote.PollID, vote.UserID, vote.OptionID, vote.CreatedAt)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return tx.Commit()
+		}
+	} else {
+		updateVoteQuery := `
+			UPDATE poll_votes
+			SET option_id = ?, updated_at = ?
+			WHERE poll_id = ? AND user_id = ?
+		`
+		if _, err := tx.ExecContext(ctx, updateVoteQuery, vote.OptionID, vote.CreatedAt, vote.PollID, vote.UserID); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		decrementQuery := `
+			UPDATE poll_options
+			SET votes = votes - 1
+			WHERE id = ?
+		`
+		if _, err := tx.ExecContext(ctx, decrementQuery, previousOptionID); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	incrementQuery := `
+		UPDATE poll_options
+		SET votes = votes + 1
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, incrementQuery, vote.OptionID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
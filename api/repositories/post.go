@@ -6,15 +6,18 @@ import (
 	"time"
 
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
 	"github.com/google/uuid"
 )
 
 type PostRepository interface {
 	CreatePost(ctx context.Context, post *models.Post) error
 	GetPostByID(ctx context.Context, ID string) (*models.Post, error)
+	GetPostByTitle(ctx context.Context, authorID string, title string) (*models.Post, error)
+	GetPostByContentHash(ctx context.Context, authorID string, contentHash string) (*models.Post, error)
 	GetPostsByAuthorID(ctx context.Context, authorID string) ([]*models.Post, error)
 	DeletePost(ctx context.Context, ID string) error
-	UpdatePost(ctx context.Context, post *models.Post) error
+	UpdatePost(ctx context.Context, post *models.Post) (bool, error)
 }
 
 type postRepository struct {
@@ -34,9 +37,15 @@ func (p *postRepository) CreatePost(ctx context.Context, post *models.Post) erro
 	}
 
 	post.ID = id.String()
-	post.CreatedAt = time.Now().UTC()
+	post.Version = 1
+	if post.Visibility == "" {
+		post.Visibility = models.PostVisibilityPublic
+	}
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now().UTC()
+	}
 
-	query := `INSERT INTO posts (id, title, content, author_id, created_at) VALUES (?, ?, ?, ?, ?)`
+	query := `INSERT INTO posts (id, title, content, content_html, content_hash, visibility, author_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	stmt, err := p.db.PrepareContext(ctx, query)
 	if err != nil {
@@ -44,7 +53,11 @@ func (p *postRepository) CreatePost(ctx context.Context, post *models.Post) erro
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, post.ID, post.Title, post.Content, post.AuthorID, post.CreatedAt)
+	_, err = stmt.ExecContext(ctx, post.ID, post.Title, post.Content, post.ContentHTML, post.ContentHash, post.Visibility, post.AuthorID, post.CreatedAt)
+	if utils.IsDuplicateKey(err, "uq_posts_author_content_hash") {
+		return models.ErrPostContentExists
+	}
+
 	if err != nil {
 		return err
 	}
@@ -52,8 +65,14 @@ func (p *postRepository) CreatePost(ctx context.Context, post *models.Post) erro
 	return nil
 }
 
+// GetPostByID does not find posts of banned authors.
 func (p *postRepository) GetPostByID(ctx context.Context, id string) (*models.Post, error) {
-	query := `SELECT id, title, content, author_id, likes, created_at FROM posts WHERE id = ?`
+	query := `
+		SELECT p.id, p.title, p.content, p.content_html, p.visibility, p.author_id, p.likes, p.version, p.created_at, p.updated_at
+		FROM posts p
+		INNER JOIN users u ON u.id = p.author_id
+		WHERE p.id = ? AND u.status <> 'banned'
+	`
 
 	stmt, err := p.db.PrepareContext(ctx, query)
 	if err != nil {
@@ -64,7 +83,64 @@ func (p *postRepository) GetPostByID(ctx context.Context, id string) (*models.Po
 	row := stmt.QueryRowContext(ctx, id)
 
 	post := &models.Post{}
-	err = row.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.Likes, &post.CreatedAt)
+	err = row.Scan(&post.ID, &post.Title, &post.Content, &post.ContentHTML, &post.Visibility, &post.AuthorID, &post.Likes, &post.Version, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (p *postRepository) GetPostByTitle(ctx context.Context, authorID string, title string) (*models.Post, error) {
+	query := `
+		SELECT id, title, content, content_html, visibility, author_id, likes, version, created_at, updated_at
+		FROM posts
+		WHERE author_id = ? AND title = ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	stmt, err := p.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, authorID, title)
+
+	post := &models.Post{}
+	err = row.Scan(&post.ID, &post.Title, &post.Content, &post.ContentHTML, &post.Visibility, &post.AuthorID, &post.Likes, &post.Version, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (p *postRepository) GetPostByContentHash(ctx context.Context, authorID string, contentHash string) (*models.Post, error) {
+	query := `
+		SELECT id, title, content, content_html, visibility, author_id, likes, version, created_at, updated_at
+		FROM posts
+		WHERE author_id = ? AND content_hash = ?
+		LIMIT 1
+	`
+
+	stmt, err := p.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, authorID, contentHash)
+
+	post := &models.Post{}
+	err = row.Scan(&post.ID, &post.Title, &post.Content, &post.ContentHTML, &post.Visibility, &post.AuthorID, &post.Likes, &post.Version, &post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -76,7 +152,7 @@ func (p *postRepository) GetPostByID(ctx context.Context, id string) (*models.Po
 }
 
 func (p *postRepository) GetPostsByAuthorID(ctx context.Context, authorID string) ([]*models.Post, error) {
-	query := `SELECT id, title, content, author_id, likes, created_at FROM posts WHERE author_id = ?`
+	query := `SELECT id, title, content, content_html, visibility, author_id, likes, version, created_at, updated_at FROM posts WHERE author_id = ?`
 
 	stmt, err := p.db.PrepareContext(ctx, query)
 	if err != nil {
@@ -93,7 +169,7 @@ func (p *postRepository) GetPostsByAuthorID(ctx context.Context, authorID string
 	var posts []*models.Post
 	for rows.Next() {
 		post := &models.Post{}
-		err = rows.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.Likes, &post.CreatedAt)
+		err = rows.Scan(&post.ID, &post.Title, &post.Content, &post.ContentHTML, &post.Visibility, &post.AuthorID, &post.Likes, &post.Version, &post.CreatedAt, &post.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -120,24 +196,42 @@ func (p *postRepository) DeletePost(ctx context.Context, ID string) error {
 	return nil
 }
 
-func (p *postRepository) UpdatePost(ctx context.Context, post *models.Post) error {
-	post.UpdatedAt = sql.NullTime{
+// UpdatePost only writes when the stored version still matches post.Version,
+// and reports whether it did. On success the version is incremented.
+func (p *postRepository) UpdatePost(ctx context.Context, post *models.Post) (bool, error) {
+	updatedAt := sql.NullTime{
 		Time:  time.Now().UTC(),
 		Valid: true,
 	}
 
-	query := `UPDATE posts SET title = ?, content = ?, likes = ?, updated_at = ? WHERE id = ?`
+	query := `
+		UPDATE posts
+		SET title = ?, content = ?, content_html = ?, visibility = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND version = ?
+	`
 
 	stmt, err := p.db.PrepareContext(ctx, query)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, post.Title, post.Content, post.Likes, post.UpdatedAt, post.ID)
+	res, err := stmt.ExecContext(ctx, post.Title, post.Content, post.ContentHTML, post.Visibility, updatedAt, post.ID, post.Version)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	post.UpdatedAt = updatedAt
+	post.Version++
+
+	return true, nil
 }
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+type PostLinkRepository interface {
+	ReplacePostLinks(ctx context.Context, sourcePostID string, links []*models.PostLink) error
+	GetLinksBySourcePostIDs(ctx context.Context, viewerID string, sourcePostIDs []string) ([]*models.PostLink, error)
+	GetBacklinks(ctx context.Context, viewerID string, targetPostID string) ([]*models.BacklinkResponse, error)
+	ResolvePendingLinks(ctx context.Context, authorID string, title string, targetPostID string) error
+}
+
+type postLinkRepository struct {
+	db *sql.DB
+}
+
+func NewPostLinkRepository(db *sql.DB) PostLinkRepository {
+	return &postLinkRepository{
+		db: db,
+	}
+}
+
+func (r *postLinkRepository) ReplacePostLinks(ctx context.Context, sourcePostID string, links []*models.PostLink) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	deleteQuery := `DELETE FROM post_links WHERE source_post_id = ?`
+	if _, err := tx.ExecContext(ctx, deleteQuery, sourcePostID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO post_links (source_post_id, target_ref, target_post_id, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	for _, link := range links {
+		_, err := tx.ExecContext(ctx, insertQuery, sourcePostID, link.TargetRef, link.TargetPostID, link.CreatedAt)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetLinksBySourcePostIDs leaves the target empty when it is a private post
+// of someone other than viewerID or belongs to a banned author.
+func (r *postLinkRepository) GetLinksBySourcePostIDs(ctx context.Context, viewerID string, sourcePostIDs []string) ([]*models.PostLink, error) {
+	if len(sourcePostIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(sourcePostIDs))
+	placeholders = placeholders[:len(placeholders)-1]
+
+	args := make([]any, 0, len(sourcePostIDs)+1)
+	args = append(args, viewerID)
+	for _, id := range sourcePostIDs {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pl.source_post_id, pl.target_ref, t.id, t.title, pl.created_at
+		FROM post_links pl
+		LEFT JOIN (posts t INNER JOIN users tu ON tu.id = t.author_id AND tu.status <> 'banned')
+			ON t.id = pl.target_post_id AND (t.visibility = 'public' OR t.author_id = ?)
+		WHERE pl.source_post_id IN (%s)
+		ORDER BY pl.created_at ASC
+	`, placeholders)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*models.PostLink
+	for rows.Next() {
+		link := &models.PostLink{}
+		if err := rows.Scan(&link.SourcePostID, &link.TargetRef, &link.TargetPostID, &link.TargetTitle, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// GetBacklinks lists the posts linking to targetPostID that viewerID can see:
+// public posts and the viewer's own, never those of banned authors.
+func (r *postLinkRepository) GetBacklinks(ctx context.Context, viewerID string, targetPostID string) ([]*models.BacklinkResponse, error) {
+	query := `
+		SELECT p.id, p.title, u.username, p.created_at
+		FROM post_links pl
+		INNER JOIN posts p ON p.id = pl.source_post_id
+		INNER JOIN users u ON u.id = p.author_id
+		WHERE pl.target_post_id = ?
+		AND (p.visibility = 'public' OR p.author_id = ?)
+		AND u.status <> 'banned'
+		ORDER BY p.created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, targetPostID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("query backlinks: %w", err)
+	}
+	defer rows.Close()
+
+	var backlinks []*models.BacklinkResponse
+	for rows.Next() {
+		var backlink models.BacklinkResponse
+		if err := rows.Scan(&backlink.PostID, &backlink.Title, &backlink.AuthorUsername, &backlink.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan backlink: %w", err)
+		}
+		backlinks = append(backlinks, &backlink)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return backlinks, nil
+}
+
+func (r *postLinkRepository) ResolvePendingLinks(ctx context.Context, authorID string, title string, targetPostID string) error {
+	query := `
+		UPDATE post_links pl
+		INNER JOIN posts s ON s.id = pl.source_post_id
+		SET pl.target_post_id = ?
+		WHERE pl.target_post_id IS NULL
+		AND pl.target_ref = ?
+		AND s.author_id = ?
+		AND pl.source_post_id != ?
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, targetPostID, title, authorID, targetPostID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
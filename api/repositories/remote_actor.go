@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/google/uuid"
+)
+
+type RemoteActorRepository interface {
+	UpsertRemoteActor(ctx context.Context, actor *models.RemoteActor) error
+	GetRemoteActorByURI(ctx context.Context, actorURI string) (*models.RemoteActor, error)
+	AddFollower(ctx context.Context, userID string, remoteActorID string, activityID string) error
+	RemoveFollower(ctx context.Context, userID string, remoteActorID string) error
+	GetFollowers(ctx context.Context, userID string) ([]*models.RemoteActor, error)
+	AddLike(ctx context.Context, postID string, remoteActorID string, activityID string) error
+	RemoveLike(ctx context.Context, postID string, remoteActorID string) error
+}
+
+type remoteActorRepository struct {
+	db *sql.DB
+}
+
+func NewRemoteActorRepository(db *sql.DB) RemoteActorRepository {
+	return &remoteActorRepository{
+		db: db,
+	}
+}
+
+// UpsertRemoteActor stores the actor keyed by its URI, refreshing the inbox
+// and key of an actor seen before. actor.ID is set to the stored row's ID.
+func (r *remoteActorRepository) UpsertRemoteActor(ctx context.Context, actor *models.RemoteActor) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	actor.FetchedAt = now
+	if actor.CreatedAt.IsZero() {
+		actor.CreatedAt = now
+	}
+
+	query := `
+		INSERT INTO remote_actors (id, actor_uri, inbox, shared_inbox, key_id, public_key_pem, preferred_username, fetched_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			inbox = VALUES(inbox),
+			shared_inbox = VALUES(shared_inbox),
+			key_id = VALUES(key_id),
+			public_key_pem = VALUES(public_key_pem),
+			preferred_username = VALUES(preferred_username),
+			fetched_at = VALUES(fetched_at)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		id.String(),
+		actor.ActorURI,
+		actor.Inbox,
+		actor.SharedInbox,
+		actor.KeyID,
+		actor.PublicKeyPEM,
+		actor.PreferredUsername,
+		actor.FetchedAt,
+		actor.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRowContext(ctx, `SELECT id FROM remote_actors WHERE actor_uri = ?`, actor.ActorURI).Scan(&actor.ID)
+}
+
+func (r *remoteActorRepository) GetRemoteActorByURI(ctx context.Context, actorURI string) (*models.RemoteActor, error) {
+	query := `
+		SELECT id, actor_uri, inbox, shared_inbox, key_id, public_key_pem, preferred_username, fetched_at, created_at
+		FROM remote_actors
+		WHERE actor_uri = ?
+	`
+
+	actor := &models.RemoteActor{}
+	err := r.db.QueryRowContext(ctx, query, actorURI).Scan(
+		&actor.ID,
+		&actor.ActorURI,
+		&actor.Inbox,
+		&actor.SharedInbox,
+		&actor.KeyID,
+		&actor.PublicKeyPEM,
+		&actor.PreferredUsername,
+		&actor.FetchedAt,
+		&actor.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return actor, nil
+}
+
+func (r *remoteActorRepository) AddFollower(ctx context.Context, userID string, remoteActorID string, activityID string) error {
+	query := `
+		INSERT INTO remote_followers (user_id, remote_actor_id, activity_id, created_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE activity_id = VALUES(activity_id)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, remoteActorID, activityID, time.Now().UTC())
+	return err
+}
+
+func (r *remoteActorRepository) RemoveFollower(ctx context.Context, userID string, remoteActorID string) error {
+	query := `DELETE FROM remote_followers WHERE user_id = ? AND remote_actor_id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, userID, remoteActorID)
+	return err
+}
+
+func (r *remoteActorRepository) GetFollowers(ctx context.Context, userID string) ([]*models.RemoteActor, error) {
+	query := `
+		SELECT ra.id, ra.actor_uri, ra.inbox, ra.shared_inbox, ra.key_id, ra.public_key_pem, ra.preferred_username, ra.fetched_at, ra.created_at
+		FROM remote_followers rf
+		JOIN remote_actors ra ON ra.id = rf.remote_actor_id
+		WHERE rf.user_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var actors []*models.RemoteActor
+	for rows.Next() {
+		actor := &models.RemoteActor{}
+		if err := rows.Scan(
+			&actor.ID,
+			&actor.ActorURI,
+			&actor.Inbox,
+			&actor.SharedInbox,
+			&actor.KeyID,
+			&actor.PublicKeyPEM,
+			&actor.PreferredUsername,
+			&actor.FetchedAt,
+			&actor.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		actors = append(actors, actor)
+	}
+
+	return actors, rows.Err()
+}
+
+// AddLike records a remote like and bumps the post's like counter, the same
+// way LikeRepository.CreateLike does for local users.
+func (r *remoteActorRepository) AddLike(ctx context.Context, postID string, remoteActorID string, activityID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	insertQuery := `
+		INSERT IGNORE INTO remote_likes (post_id, remote_actor_id, activity_id, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, insertQuery, postID, remoteActorID, activityID, time.Now().UTC())
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if rowsAffected > 0 {
+		_, err = tx.ExecContext(ctx, `UPDATE posts SET likes = likes + 1 WHERE id = ?`, postID)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *remoteActorRepository) RemoveLike(ctx context.Context, postID string, remoteActorID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM remote_likes WHERE post_id = ? AND remote_actor_id = ?`, postID, remoteActorID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if rowsAffected > 0 {
+		_, err = tx.ExecContext(ctx, `UPDATE posts SET likes = likes - 1 WHERE id = ?`, postID)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -7,20 +7,33 @@ import (
 	"time"
 
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
 	"github.com/google/uuid"
 )
 
 type SessionRepository interface {
 	CreateSession(ctx context.Context, session *models.Session) error
-	GetSessionByToken(ctx context.Context, token string) (*models.Session, error)
+	GetSessionByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error)
 	DeleteSession(ctx context.Context, id string) error
-	UpdateSession(ctx context.Context, session *models.Session) error
+	VerifySession(ctx context.Context, session *models.Session, magicLinkTokenHash string) (bool, error)
 	GetSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error)
+	GetSessionHistoryByUserID(ctx context.Context, userID string) ([]*models.Session, error)
+	GetUserStatus(ctx context.Context, userID string) (models.UserStatus, error)
+	GetSignInHistory(ctx context.Context, userID string, deviceLabel sql.NullString, ipAddress sql.NullString) (*models.SignInHistory, error)
 	RevokeSession(ctx context.Context, id string, revokedAt time.Time) error
 	IsSessionRevoked(ctx context.Context, id string) (bool, error)
+	TouchSession(ctx context.Context, id string, seenAt time.Time, staleBefore time.Time) error
 	GetSessionById(ctx context.Context, id string) (*models.Session, error)
 	RevokeAllSessionsByUserID(ctx context.Context, userID string, revoketAt time.Time) error
 	RevokeAllSessionByUserIDExceptCurrent(ctx context.Context, userID string, currentSessionID string, revokedAt time.Time) error
+	GetPendingCodeSessionByUserID(ctx context.Context, userID string, now time.Time) (*models.Session, error)
+	RecordCodeAttempt(ctx context.Context, id string, maxAttempts int) (bool, error)
+	CountCodeAttemptsByUserID(ctx context.Context, userID string, since time.Time) (int, error)
+	ApproveSession(ctx context.Context, id string, approvedAt time.Time) error
+	ClaimApprovedSession(ctx context.Context, id string, verifiedAt time.Time) (bool, error)
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, oldTokenHash string, next *models.RefreshToken, session *models.Session) (bool, error)
 }
 
 type sessionRepository struct {
@@ -41,8 +54,8 @@ func (r *sessionRepository) CreateSession(ctx context.Context, session *models.S
 	session.ID = id.String()
 
 	query := `
-		INSERT INTO sessions (id, token, expires_at, user_id, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO sessions (id, token_hash, expires_at, user_id, created_at, code_hash, poll_secret_hash, approval_code_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	stmt, err := r.db.PrepareContext(ctx, query)
@@ -51,7 +64,7 @@ func (r *sessionRepository) CreateSession(ctx context.Context, session *models.S
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, session.ID, session.Token, session.ExpiresAt, session.UserID, session.CreatedAt)
+	_, err = stmt.ExecContext(ctx, session.ID, session.TokenHash, session.ExpiresAt, session.UserID, session.CreatedAt, session.CodeHash, session.PollSecretHash, session.ApprovalCodeHash)
 	if err != nil {
 		return err
 	}
@@ -59,25 +72,31 @@ func (r *sessionRepository) CreateSession(ctx context.Context, session *models.S
 	return err
 }
 
-func (r *sessionRepository) GetSessionByToken(ctx context.Context, token string) (*models.Session, error) {
+func (r *sessionRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
 	query := `
-		SELECT id, token, expires_at, user_id, revoked_at, verified_at, created_at, updated_at
+		SELECT id, token_hash, expires_at, user_id, revoked_at, verified_at, created_at, updated_at,
+			code_hash, code_attempts, poll_secret_hash, approval_code_hash, approved_at
 		FROM sessions
-		WHERE token = ?
+		WHERE token_hash = ?
 	`
 
-	row := r.db.QueryRowContext(ctx, query, token)
+	row := r.db.QueryRowContext(ctx, query, tokenHash)
 
 	var session models.Session
 	err := row.Scan(
 		&session.ID,
-		&session.Token,
+		&session.TokenHash,
 		&session.ExpiresAt,
 		&session.UserID,
 		&session.RevokedAt,
 		&session.VerifiedAt,
 		&session.CreatedAt,
 		&session.UpdatedAt,
+		&session.CodeHash,
+		&session.CodeAttempts,
+		&session.PollSecretHash,
+		&session.ApprovalCodeHash,
+		&session.ApprovedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -91,7 +110,10 @@ func (r *sessionRepository) GetSessionByToken(ctx context.Context, token string)
 	return &session, nil
 }
 
-func (r *sessionRepository) UpdateSession(ctx context.Context, session *models.Session) error {
+// VerifySession signs a pending session in, replacing its magic link token.
+// It only succeeds while the session still holds that token, so of two
+// requests spending the same link only one gets the session.
+func (r *sessionRepository) VerifySession(ctx context.Context, session *models.Session, magicLinkTokenHash string) (bool, error) {
 	session.UpdatedAt = sql.NullTime{
 		Time:  time.Now().UTC(),
 		Valid: true,
@@ -99,22 +121,25 @@ func (r *sessionRepository) UpdateSession(ctx context.Context, session *models.S
 
 	query := `
 		UPDATE sessions
-		SET token = ?, expires_at = ?, user_id = ?, revoked_at = ?, verified_at = ?, updated_at = ?
-		WHERE id = ?
+		SET token_hash = ?, expires_at = ?, verified_at = ?, updated_at = ?,
+			user_agent = ?, device_label = ?, ip_address = ?, location = ?, last_seen_at = ?
+		WHERE id = ? AND token_hash = ?
 	`
 
-	stmt, err := r.db.PrepareContext(ctx, query)
+	result, err := r.db.ExecContext(ctx, query,
+		session.TokenHash, session.ExpiresAt, session.VerifiedAt, session.UpdatedAt,
+		session.UserAgent, session.DeviceLabel, session.IPAddress, session.Location, session.LastSeenAt,
+		session.ID, magicLinkTokenHash)
 	if err != nil {
-		return err
+		return false, err
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, session.Token, session.ExpiresAt, session.UserID, session.RevokedAt, session.VerifiedAt, session.UpdatedAt, session.ID)
+	rows, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return rows > 0, nil
 }
 
 func (r *sessionRepository) DeleteSession(ctx context.Context, id string) error {
@@ -139,7 +164,8 @@ func (r *sessionRepository) DeleteSession(ctx context.Context, id string) error
 
 func (r *sessionRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
 	query := `
-		SELECT id, token, expires_at, user_id, revoked_at, verified_at, created_at, updated_at
+		SELECT id, token_hash, expires_at, user_id, revoked_at, verified_at, created_at, updated_at,
+			user_agent, device_label, ip_address, location, last_seen_at
 		FROM sessions
 		WHERE user_id = ? 
 		AND verified_at IS NOT NULL 
@@ -156,7 +182,10 @@ func (r *sessionRepository) GetSessionsByUserID(ctx context.Context, userID stri
 	var sessions []*models.Session
 	for rows.Next() {
 		var s models.Session
-		if err := rows.Scan(&s.ID, &s.Token, &s.ExpiresAt, &s.UserID, &s.RevokedAt, &s.VerifiedAt, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		if err := rows.Scan(
+			&s.ID, &s.TokenHash, &s.ExpiresAt, &s.UserID, &s.RevokedAt, &s.VerifiedAt, &s.CreatedAt, &s.UpdatedAt,
+			&s.UserAgent, &s.DeviceLabel, &s.IPAddress, &s.Location, &s.LastSeenAt,
+		); err != nil {
 			return nil, err
 		}
 		sessions = append(sessions, &s)
@@ -165,6 +194,73 @@ func (r *sessionRepository) GetSessionsByUserID(ctx context.Context, userID stri
 	return sessions, nil
 }
 
+func (r *sessionRepository) GetSessionHistoryByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	query := `
+		SELECT id, token_hash, expires_at, user_id, revoked_at, verified_at, created_at, updated_at
+		FROM sessions
+		WHERE user_id = ?
+		AND verified_at IS NOT NULL
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		var s models.Session
+		if err := rows.Scan(&s.ID, &s.TokenHash, &s.ExpiresAt, &s.UserID, &s.RevokedAt, &s.VerifiedAt, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetUserStatus returns the account status of the session's user, or an
+// empty status when the user does not exist.
+func (r *sessionRepository) GetUserStatus(ctx context.Context, userID string) (models.UserStatus, error) {
+	query := `SELECT status FROM users WHERE id = ?`
+
+	var status models.UserStatus
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return status, nil
+}
+
+// GetSignInHistory counts the user's verified sessions, revoked ones
+// included, and whether any was signed in from deviceLabel or ipAddress.
+func (r *sessionRepository) GetSignInHistory(ctx context.Context, userID string, deviceLabel sql.NullString, ipAddress sql.NullString) (*models.SignInHistory, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(MAX(device_label <=> ?), 0), COALESCE(MAX(ip_address <=> ?), 0)
+		FROM sessions
+		WHERE user_id = ?
+		AND verified_at IS NOT NULL
+	`
+
+	var history models.SignInHistory
+	if err := r.db.QueryRowContext(ctx, query, deviceLabel, ipAddress, userID).Scan(
+		&history.Sessions, &history.DeviceSeen, &history.IPSeen,
+	); err != nil {
+		return nil, err
+	}
+
+	return &history, nil
+}
+
 func (r *sessionRepository) RevokeSession(ctx context.Context, id string, revokedAt time.Time) error {
 	query := `UPDATE sessions SET revoked_at = ?, updated_at = ? WHERE id = ?`
 
@@ -197,9 +293,22 @@ func (r *sessionRepository) IsSessionRevoked(ctx context.Context, id string) (bo
 	return revokedAt.Valid, nil
 }
 
+// TouchSession sets last_seen_at unless it is already newer than
+// staleBefore.
+func (r *sessionRepository) TouchSession(ctx context.Context, id string, seenAt time.Time, staleBefore time.Time) error {
+	query := `
+		UPDATE sessions SET last_seen_at = ?
+		WHERE id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, seenAt, id, staleBefore)
+	return err
+}
+
 func (r *sessionRepository) GetSessionById(ctx context.Context, id string) (*models.Session, error) {
 	query := `
-		SELECT id, token, expires_at, user_id, revoked_at, verified_at, created_at, updated_at
+		SELECT id, token_hash, expires_at, user_id, revoked_at, verified_at, created_at, updated_at,
+			code_hash, code_attempts, poll_secret_hash, approval_code_hash, approved_at
 		FROM sessions
 		WHERE id = ?
 	`
@@ -209,13 +318,18 @@ func (r *sessionRepository) GetSessionById(ctx context.Context, id string) (*mod
 	var session models.Session
 	err := row.Scan(
 		&session.ID,
-		&session.Token,
+		&session.TokenHash,
 		&session.ExpiresAt,
 		&session.UserID,
 		&session.RevokedAt,
 		&session.VerifiedAt,
 		&session.CreatedAt,
 		&session.UpdatedAt,
+		&session.CodeHash,
+		&session.CodeAttempts,
+		&session.PollSecretHash,
+		&session.ApprovalCodeHash,
+		&session.ApprovedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -264,3 +378,191 @@ func (r *sessionRepository) RevokeAllSessionByUserIDExceptCurrent(ctx context.Co
 
 	return nil
 }
+
+// GetPendingCodeSessionByUserID returns the latest session of the user still
+// waiting for its login code.
+func (r *sessionRepository) GetPendingCodeSessionByUserID(ctx context.Context, userID string, now time.Time) (*models.Session, error) {
+	query := `
+		SELECT id, token_hash, expires_at, user_id, revoked_at, verified_at, created_at, updated_at, code_hash, code_attempts
+		FROM sessions
+		WHERE user_id = ?
+		AND code_hash IS NOT NULL
+		AND verified_at IS NULL
+		AND revoked_at IS NULL
+		AND expires_at > ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, func(row *sql.Row) (*models.Session, error) {
+		var session models.Session
+		err := row.Scan(
+			&session.ID,
+			&session.TokenHash,
+			&session.ExpiresAt,
+			&session.UserID,
+			&session.RevokedAt,
+			&session.VerifiedAt,
+			&session.CreatedAt,
+			&session.UpdatedAt,
+			&session.CodeHash,
+			&session.CodeAttempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &session, nil
+	}, userID, now)
+}
+
+// RecordCodeAttempt counts one code attempt against the session, returning
+// false without counting once maxAttempts were made. Counting happens before
+// the code is compared, so concurrent guesses cannot exceed the limit.
+func (r *sessionRepository) RecordCodeAttempt(ctx context.Context, id string, maxAttempts int) (bool, error) {
+	query := `UPDATE sessions SET code_attempts = code_attempts + 1 WHERE id = ? AND code_attempts < ?`
+
+	result, err := r.db.ExecContext(ctx, query, id, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// CountCodeAttemptsByUserID sums the code attempts made against the user's
+// sessions created after since.
+func (r *sessionRepository) CountCodeAttemptsByUserID(ctx context.Context, userID string, since time.Time) (int, error) {
+	query := `SELECT COALESCE(SUM(code_attempts), 0) FROM sessions WHERE user_id = ? AND created_at > ?`
+
+	var attempts int
+	if err := r.db.QueryRowContext(ctx, query, userID, since).Scan(&attempts); err != nil {
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+func (r *sessionRepository) ApproveSession(ctx context.Context, id string, approvedAt time.Time) error {
+	query := `UPDATE sessions SET approved_at = ?, updated_at = ? WHERE id = ? AND verified_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, approvedAt, approvedAt, id)
+	return err
+}
+
+// ClaimApprovedSession marks an approved session verified, returning false
+// when it is not approved or was already claimed, so only one poll gets its
+// tokens.
+func (r *sessionRepository) ClaimApprovedSession(ctx context.Context, id string, verifiedAt time.Time) (bool, error) {
+	query := `UPDATE sessions SET verified_at = ? WHERE id = ? AND approved_at IS NOT NULL AND verified_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, verifiedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *sessionRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (token_hash, session_id, created_at)
+		VALUES (?, ?, ?)
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, token.TokenHash, token.SessionID, token.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT token_hash, session_id, rotated_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, func(row *sql.Row) (*models.RefreshToken, error) {
+		var token models.RefreshToken
+		if err := row.Scan(&token.TokenHash, &token.SessionID, &token.RotatedAt, &token.CreatedAt); err != nil {
+			return nil, err
+		}
+		return &token, nil
+	}, tokenHash)
+}
+
+// RotateRefreshToken replaces the current refresh token of a session with
+// next and stores the session's new token and expiry, all or nothing. It
+// returns false, changing nothing, when the old token was already rotated,
+// which is how two racing refreshes with the same token are told apart.
+func (r *sessionRepository) RotateRefreshToken(ctx context.Context, oldTokenHash string, next *models.RefreshToken, session *models.Session) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	now := time.Now().UTC()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET rotated_at = ? WHERE token_hash = ? AND rotated_at IS NULL`,
+		now, oldTokenHash,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	if rows == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	insertQuery := `
+		INSERT INTO refresh_tokens (token_hash, session_id, created_at)
+		VALUES (?, ?, ?)
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, next.TokenHash, next.SessionID, next.CreatedAt); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	session.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+
+	sessionQuery := `UPDATE sessions SET token_hash = ?, expires_at = ?, updated_at = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, sessionQuery, session.TokenHash, session.ExpiresAt, session.UpdatedAt, session.ID); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
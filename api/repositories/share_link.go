@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"github.com/google/uuid"
+)
+
+type ShareLinkRepository interface {
+	CreateShareLink(ctx context.Context, link *models.ShareLink) error
+	GetShareLinkByID(ctx context.Context, id string) (*models.ShareLink, error)
+	GetShareLinksByPostID(ctx context.Context, postID string) ([]*models.ShareLink, error)
+	RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error
+	RecordAccess(ctx context.Context, id string, accessedAt time.Time) error
+}
+
+type shareLinkRepository struct {
+	db *sql.DB
+}
+
+func NewShareLinkRepository(db *sql.DB) ShareLinkRepository {
+	return &shareLinkRepository{
+		db: db,
+	}
+}
+
+func (r *shareLinkRepository) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	link.ID = id.String()
+	link.CreatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO share_links (id, post_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, link.ID, link.PostID, link.ExpiresAt, link.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *shareLinkRepository) GetShareLinkByID(ctx context.Context, id string) (*models.ShareLink, error) {
+	query := `
+		SELECT id, post_id, access_count, last_accessed_at, expires_at, revoked_at, created_at
+		FROM share_links
+		WHERE id = ?
+	`
+
+	return utils.QueryRowScan(ctx, r.db, query, scanShareLink, id)
+}
+
+func (r *shareLinkRepository) GetShareLinksByPostID(ctx context.Context, postID string) ([]*models.ShareLink, error) {
+	query := `
+		SELECT id, post_id, access_count, last_accessed_at, expires_at, revoked_at, created_at
+		FROM share_links
+		WHERE post_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*models.ShareLink
+	for rows.Next() {
+		var link models.ShareLink
+		if err := rows.Scan(&link.ID, &link.PostID, &link.AccessCount, &link.LastAccessedAt, &link.ExpiresAt, &link.RevokedAt, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, &link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink keeps the first revocation time when called again.
+func (r *shareLinkRepository) RevokeShareLink(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `UPDATE share_links SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, revokedAt, id)
+	return err
+}
+
+func (r *shareLinkRepository) RecordAccess(ctx context.Context, id string, accessedAt time.Time) error {
+	query := `UPDATE share_links SET access_count = access_count + 1, last_accessed_at = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, accessedAt, id)
+	return err
+}
+
+func scanShareLink(row *sql.Row) (*models.ShareLink, error) {
+	var link models.ShareLink
+	err := row.Scan(&link.ID, &link.PostID, &link.AccessCount, &link.LastAccessedAt, &link.ExpiresAt, &link.RevokedAt, &link.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
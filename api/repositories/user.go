@@ -18,6 +18,7 @@ type UserRepository interface {
 	GetUsersByIds(ctx context.Context, ids []string) ([]*models.User, error)
 	SearchUsers(ctx context.Context, query string) ([]*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
+	BanUser(ctx context.Context, id string, bannedAt time.Time) error
 }
 
 type userRepository struct {
@@ -133,7 +134,8 @@ func (r *userRepository) SearchUsers(ctx context.Context, query string) ([]*mode
 	sqlQuery := `
 		SELECT name, username
 		FROM users
-		WHERE name LIKE ? OR username LIKE ?
+		WHERE (name LIKE ? OR username LIKE ?)
+		AND status <> 'banned'
 	`
 
 	rows, err := r.db.QueryContext(ctx, sqlQuery, search, search)
@@ -180,6 +182,17 @@ func (r *userRepository) UpdateUser(ctx context.Context, user *models.User) erro
 	return nil
 }
 
+func (r *userRepository) BanUser(ctx context.Context, id string, bannedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET status = 'banned', banned_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, bannedAt, bannedAt, id)
+	return err
+}
+
 func join(strs []string, sep string) string {
 	if len(strs) == 0 {
 		return ""
@@ -8,13 +8,14 @@ import (
 	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/handlers"
 	"github.com/g-villarinho/tab-notes-api/middlewares"
+	"github.com/g-villarinho/tab-notes-api/models"
 	"github.com/g-villarinho/tab-notes-api/notifications"
 	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/g-villarinho/tab-notes-api/repositories"
 	"github.com/g-villarinho/tab-notes-api/services"
 )
 
-func SetupRoutes(db *sql.DB) *Router {
+func SetupRoutes(db *sql.DB, keyring pkgs.Keyring, geoIP pkgs.GeoIP) *Router {
 	router := NewRouter()
 
 	if strings.ToLower(configs.Env.Env) == "development" {
@@ -23,14 +24,26 @@ func SetupRoutes(db *sql.DB) *Router {
 		router.GET("/envs", envHandler.GetEnvs)
 	}
 
+	// One federation service for every group, so they share the delivery
+	// workers.
+	federationService := newFederationService(db)
+
 	setupHealthRoutes(db, router)
-	setupAuthRoutes(db, router)
-	setupRegisterRoutes(db, router)
-	setupUserRoutes(db, router)
-	setupFollowerRoutes(db, router)
-	setupSessionRoutes(db, router)
-	setupPostRoutes(db, router)
-	setupFeedRoutes(db, router)
+	setupKeyRoutes(keyring, router)
+	setupAuthRoutes(db, keyring, geoIP, router)
+	setupRegisterRoutes(db, keyring, router)
+	setupUserRoutes(db, keyring, router)
+	setupFollowerRoutes(db, keyring, router)
+	setupSessionRoutes(db, keyring, router)
+	setupPersonalAccessTokenRoutes(db, keyring, router)
+	setupPostRoutes(db, keyring, federationService, router)
+	setupFeedRoutes(db, keyring, router)
+	setupNotebookRoutes(db, keyring, router)
+	setupExportRoutes(db, keyring, router)
+	setupImportRoutes(db, keyring, federationService, router)
+	setupShareLinkRoutes(db, keyring, router)
+	setupActivityPubRoutes(federationService, router)
+	setupAdminRoutes(db, keyring, router)
 
 	return router
 }
@@ -42,15 +55,20 @@ func setupHealthRoutes(db *sql.DB, router *Router) {
 	router.GET("/health", healthHandler.Check)
 }
 
-func setupAuthRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
+func setupKeyRoutes(keyring pkgs.Keyring, router *Router) {
+	jwksHandler := handlers.NewJWKSHandler(keyring)
+
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+}
+
+func setupAuthRoutes(db *sql.DB, keyring pkgs.Keyring, geoIP pkgs.GeoIP, router *Router) {
 	requestContext := pkgs.NewRequestContext()
 
 	emailClient := clients.NewHermesMailerClient()
 
 	emailNotifcation := notifications.NewEmailNotification(emailClient)
 
-	tokenService := services.NewTokenService(ecdsa)
+	tokenService := services.NewTokenService(keyring)
 	sessionRepository := repositories.NewSessionRepository(db)
 
 	userRepository := repositories.NewUserRepository(db)
@@ -60,18 +78,25 @@ func setupAuthRoutes(db *sql.DB, router *Router) {
 
 	sessionService := services.NewSessionService(tokenService, sessionRepository)
 	authService := services.NewAuthService(sessionService, userService, emailNotifcation)
-	authHandler := handlers.NewAuthHandler(authService, requestContext)
+	authHandler := handlers.NewAuthHandler(authService, requestContext, geoIP)
 
-	authMiddleware := middlewares.NewAuthMiddleware(ecdsa, requestContext, sessionService)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
 
 	router.POST("/authenticate", authHandler.SendAuthenticationLink)
+	router.POST("/authenticate/code", authHandler.AuthenticateWithCode)
+	router.GET("/authenticate/pending/{pendingLoginId}", authHandler.PollPendingLogin)
 	router.GET("/magic-link/authenticate", authHandler.AuthenticateFromLink)
+	router.POST("/magic-link/approve", authHandler.ApproveLogin)
+	router.POST("/magic-link/token", authHandler.ExchangeLinkToken)
+	router.POST("/auth/refresh", authHandler.RefreshToken)
 	router.POST("/logout", authMiddleware.Authenticated(authHandler.Logout))
 }
 
-func setupRegisterRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
-	tokenService := services.NewTokenService(ecdsa)
+func setupRegisterRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
+	tokenService := services.NewTokenService(keyring)
 
 	emailClient := clients.NewHermesMailerClient()
 
@@ -90,15 +115,17 @@ func setupRegisterRoutes(db *sql.DB, router *Router) {
 	router.POST("/register", registerHandler.RegisterUser)
 }
 
-func setupUserRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
+func setupUserRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
 	requestContext := pkgs.NewRequestContext()
 
-	tokenService := services.NewTokenService(ecdsa)
+	tokenService := services.NewTokenService(keyring)
 	sessionRepository := repositories.NewSessionRepository(db)
 	sessionService := services.NewSessionService(tokenService, sessionRepository)
 
-	authMiddleware := middlewares.NewAuthMiddleware(ecdsa, requestContext, sessionService)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
 
 	userRepository := repositories.NewUserRepository(db)
 	followerRepository := repositories.NewFollowerRepository(db)
@@ -107,17 +134,17 @@ func setupUserRoutes(db *sql.DB, router *Router) {
 	userService := services.NewUserService(followerService, userRepository)
 	userHandler := handlers.NewUserHandler(requestContext, userService)
 
-	router.GET("/me", authMiddleware.Authenticated(userHandler.GetProfile))
-	router.GET("/users", authMiddleware.Authenticated(userHandler.SearchUsers))
-	router.GET("/users/{username}", authMiddleware.Authenticated(userHandler.GetProfileByUsername))
-	router.PUT("/users", authMiddleware.Authenticated(userHandler.UpdateUser))
+	router.GET("/me", authMiddleware.Scoped(models.ScopeProfileRead, userHandler.GetProfile))
+	router.GET("/users", authMiddleware.Scoped(models.ScopeProfileRead, userHandler.SearchUsers))
+	router.GET("/users/{username}", authMiddleware.OptionalScoped(models.ScopeProfileRead, userHandler.GetProfileByUsername))
+	router.PUT("/users", authMiddleware.Scoped(models.ScopeProfileWrite, userHandler.UpdateUser))
+	router.PATCH("/me", authMiddleware.Scoped(models.ScopeProfileWrite, userHandler.PatchUser))
 }
 
-func setupFollowerRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
+func setupFollowerRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
 	requestContext := pkgs.NewRequestContext()
 
-	tokenService := services.NewTokenService(ecdsa)
+	tokenService := services.NewTokenService(keyring)
 	sessionRepository := repositories.NewSessionRepository(db)
 	sessionService := services.NewSessionService(tokenService, sessionRepository)
 
@@ -126,78 +153,307 @@ func setupFollowerRoutes(db *sql.DB, router *Router) {
 	followerService := services.NewFollowerService(followerRepository, userRepository)
 	followerHandler := handlers.NewFollowerHandler(requestContext, followerService)
 
-	authMiddleware := middlewares.NewAuthMiddleware(ecdsa, requestContext, sessionService)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
 
-	router.POST("/users/{username}/follow", authMiddleware.Authenticated(followerHandler.FollowUser))
-	router.POST("/users/{username}/unfollow", authMiddleware.Authenticated(followerHandler.UnfollowUser))
-	router.GET("/users/{username}/followers", authMiddleware.Authenticated(followerHandler.GetFollowers))
-	router.GET("/users/{username}/following", authMiddleware.Authenticated(followerHandler.GetFollowing))
-	router.GET("/me/followers", authMiddleware.Authenticated(followerHandler.GetMyFollowers))
-	router.GET("/me/following", authMiddleware.Authenticated(followerHandler.GetMyFollowing))
+	router.POST("/users/{username}/follow", authMiddleware.Scoped(models.ScopeFollowsWrite, followerHandler.FollowUser))
+	router.POST("/users/{username}/unfollow", authMiddleware.Scoped(models.ScopeFollowsWrite, followerHandler.UnfollowUser))
+	router.GET("/users/{username}/followers", authMiddleware.OptionalScoped(models.ScopeFollowsRead, followerHandler.GetFollowers))
+	router.GET("/users/{username}/following", authMiddleware.OptionalScoped(models.ScopeFollowsRead, followerHandler.GetFollowing))
+	router.GET("/me/followers", authMiddleware.Scoped(models.ScopeFollowsRead, followerHandler.GetMyFollowers))
+	router.GET("/me/following", authMiddleware.Scoped(models.ScopeFollowsRead, followerHandler.GetMyFollowing))
 }
 
-func setupSessionRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
+func setupSessionRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
 	requestContext := pkgs.NewRequestContext()
 
-	tokenService := services.NewTokenService(ecdsa)
+	tokenService := services.NewTokenService(keyring)
 	sessionRepository := repositories.NewSessionRepository(db)
 	sessionService := services.NewSessionService(tokenService, sessionRepository)
 
-	authMiddleware := middlewares.NewAuthMiddleware(ecdsa, requestContext, sessionService)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
 
 	sessionHandler := handlers.NewSessionHandler(requestContext, sessionService)
 
 	router.GET("/me/sessions", authMiddleware.Authenticated(sessionHandler.GetUserSessions))
 	router.DELETE("/me/sessions/{sessionId}", authMiddleware.Authenticated(sessionHandler.RevokeSession))
 	router.DELETE("/me/sessions", authMiddleware.Authenticated(sessionHandler.RevokeAllSessions))
+	router.GET("/sessions/revoke", sessionHandler.RevokeSessionPage)
+	router.POST("/sessions/revoke", sessionHandler.RevokeSessionFromLink)
 }
 
-func setupPostRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
+// setupPersonalAccessTokenRoutes keeps token management to session tokens, so
+// a personal access token cannot mint or revoke others.
+func setupPersonalAccessTokenRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
 	requestContext := pkgs.NewRequestContext()
 
-	tokenService := services.NewTokenService(ecdsa)
+	tokenService := services.NewTokenService(keyring)
 	sessionRepository := repositories.NewSessionRepository(db)
 	sessionService := services.NewSessionService(tokenService, sessionRepository)
 
-	authMiddleware := middlewares.NewAuthMiddleware(ecdsa, requestContext, sessionService)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
+
+	personalAccessTokenHandler := handlers.NewPersonalAccessTokenHandler(requestContext, personalAccessTokenService)
+
+	router.POST("/me/tokens", authMiddleware.Authenticated(personalAccessTokenHandler.CreateToken))
+	router.GET("/me/tokens", authMiddleware.Authenticated(personalAccessTokenHandler.GetTokens))
+	router.DELETE("/me/tokens/{tokenId}", authMiddleware.Authenticated(personalAccessTokenHandler.RevokeToken))
+}
+
+func setupPostRoutes(db *sql.DB, keyring pkgs.Keyring, federationService services.FederationService, router *Router) {
+	requestContext := pkgs.NewRequestContext()
+
+	tokenService := services.NewTokenService(keyring)
+	sessionRepository := repositories.NewSessionRepository(db)
+	sessionService := services.NewSessionService(tokenService, sessionRepository)
+
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
 
 	postRepository := repositories.NewPostRepository(db)
 	likeRepository := repositories.NewLikeRepository(db)
+	postLinkRepository := repositories.NewPostLinkRepository(db)
 	userRepository := repositories.NewUserRepository(db)
+	pollRepository := repositories.NewPollRepository(db)
 	likeService := services.NewLikeService(likeRepository)
-	postService := services.NewPostService(likeService, postRepository, userRepository)
+	pollService := services.NewPollService(postRepository, pollRepository)
+	postLinkService := services.NewPostLinkService(postLinkRepository, postRepository)
+	markdownRenderer := pkgs.NewMarkdownRenderer()
+	postService := services.NewPostService(federationService, likeService, pollService, postLinkService, postRepository, userRepository, markdownRenderer)
 	postHandler := handlers.NewPostHandler(requestContext, postService)
-
-	router.POST("/posts", authMiddleware.Authenticated(postHandler.CreatePost))
-	router.GET("/posts/{postId}", authMiddleware.Authenticated(postHandler.GetPostByID))
-	router.PUT("/posts/{postId}", authMiddleware.Authenticated(postHandler.UpdatePost))
-	router.DELETE("/posts/{postId}", authMiddleware.Authenticated(postHandler.DeletePost))
-	router.POST("/posts/{postId}/like", authMiddleware.Authenticated(postHandler.LikePost))
-	router.POST("/posts/{postId}/unlike", authMiddleware.Authenticated(postHandler.UnlikePost))
-	router.GET("/me/posts", authMiddleware.Authenticated(postHandler.GetPostsByAuthorID))
-	router.GET("/users/{username}/posts", authMiddleware.Authenticated(postHandler.GetPostsByUsername))
+	pollHandler := handlers.NewPollHandler(requestContext, pollService)
+
+	router.POST("/posts", authMiddleware.Scoped(models.ScopePostsWrite, postHandler.CreatePost))
+	router.GET("/posts/{postId}", authMiddleware.OptionalScoped(models.ScopePostsRead, postHandler.GetPostByID))
+	router.PUT("/posts/{postId}", authMiddleware.Scoped(models.ScopePostsWrite, postHandler.UpdatePost))
+	router.PATCH("/posts/{postId}", authMiddleware.Scoped(models.ScopePostsWrite, postHandler.PatchPost))
+	router.DELETE("/posts/{postId}", authMiddleware.Scoped(models.ScopePostsWrite, postHandler.DeletePost))
+	router.POST("/posts/{postId}/like", authMiddleware.Scoped(models.ScopePostsWrite, postHandler.LikePost))
+	router.POST("/posts/{postId}/unlike", authMiddleware.Scoped(models.ScopePostsWrite, postHandler.UnlikePost))
+	router.POST("/posts/{postId}/poll/vote", authMiddleware.Scoped(models.ScopePostsWrite, pollHandler.Vote))
+	router.GET("/posts/{postId}/backlinks", authMiddleware.OptionalScoped(models.ScopePostsRead, postHandler.GetBacklinks))
+	router.GET("/me/posts", authMiddleware.Scoped(models.ScopePostsRead, postHandler.GetPostsByAuthorID))
+	router.GET("/users/{username}/posts", authMiddleware.OptionalScoped(models.ScopePostsRead, postHandler.GetPostsByUsername))
+	router.GET("/users/{username}/feed.rss", postHandler.GetUserRSSFeed)
+	router.GET("/users/{username}/feed.atom", postHandler.GetUserAtomFeed)
+	router.GET("/oembed", postHandler.GetOEmbed)
+	router.GET("/posts/{postId}/embed", postHandler.GetPostEmbed)
 }
 
-func setupFeedRoutes(db *sql.DB, router *Router) {
-	ecdsa := pkgs.NewEcdsaKeyPair()
+func setupFeedRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
 	requestContext := pkgs.NewRequestContext()
 
-	tokenService := services.NewTokenService(ecdsa)
+	tokenService := services.NewTokenService(keyring)
 	sessionRepository := repositories.NewSessionRepository(db)
 	sessionService := services.NewSessionService(tokenService, sessionRepository)
 
-	authMiddleware := middlewares.NewAuthMiddleware(ecdsa, requestContext, sessionService)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
 
 	likeRepository := repositories.NewLikeRepository(db)
 	likeService := services.NewLikeService(likeRepository)
 
+	postRepository := repositories.NewPostRepository(db)
+	pollRepository := repositories.NewPollRepository(db)
+	pollService := services.NewPollService(postRepository, pollRepository)
+
 	feedRepository := repositories.NewFeedRepository(db)
 
-	feedService := services.NewFeedService(likeService, feedRepository)
+	markdownRenderer := pkgs.NewMarkdownRenderer()
+	feedService := services.NewFeedService(likeService, pollService, feedRepository, markdownRenderer)
 
 	feedHandler := handlers.NewFeedHandler(requestContext, feedService)
 
-	router.GET("/feed", authMiddleware.Authenticated(feedHandler.GetFeed))
+	router.GET("/feed", authMiddleware.Scoped(models.ScopePostsRead, feedHandler.GetFeed))
+}
+
+func setupNotebookRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
+	requestContext := pkgs.NewRequestContext()
+
+	tokenService := services.NewTokenService(keyring)
+	sessionRepository := repositories.NewSessionRepository(db)
+	sessionService := services.NewSessionService(tokenService, sessionRepository)
+
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
+
+	likeRepository := repositories.NewLikeRepository(db)
+	likeService := services.NewLikeService(likeRepository)
+
+	notebookRepository := repositories.NewNotebookRepository(db)
+	postRepository := repositories.NewPostRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+
+	markdownRenderer := pkgs.NewMarkdownRenderer()
+	notebookService := services.NewNotebookService(likeService, notebookRepository, postRepository, userRepository, markdownRenderer)
+	notebookHandler := handlers.NewNotebookHandler(requestContext, notebookService)
+
+	router.POST("/me/notebooks", authMiddleware.Scoped(models.ScopeNotebooksWrite, notebookHandler.CreateNotebook))
+	router.GET("/me/notebooks", authMiddleware.Scoped(models.ScopeNotebooksRead, notebookHandler.GetMyNotebooks))
+	router.GET("/me/notebooks/{notebookId}", authMiddleware.Scoped(models.ScopeNotebooksRead, notebookHandler.GetMyNotebook))
+	router.PUT("/me/notebooks/{notebookId}", authMiddleware.Scoped(models.ScopeNotebooksWrite, notebookHandler.UpdateNotebook))
+	router.DELETE("/me/notebooks/{notebookId}", authMiddleware.Scoped(models.ScopeNotebooksWrite, notebookHandler.DeleteNotebook))
+	router.POST("/me/notebooks/{notebookId}/posts", authMiddleware.Scoped(models.ScopeNotebooksWrite, notebookHandler.AddPost))
+	router.PUT("/me/notebooks/{notebookId}/posts", authMiddleware.Scoped(models.ScopeNotebooksWrite, notebookHandler.ReorderPosts))
+	router.DELETE("/me/notebooks/{notebookId}/posts/{postId}", authMiddleware.Scoped(models.ScopeNotebooksWrite, notebookHandler.RemovePost))
+	router.GET("/users/{username}/notebooks/{slug}", authMiddleware.OptionalScoped(models.ScopeNotebooksRead, notebookHandler.GetNotebookBySlug))
+}
+
+func setupExportRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
+	requestContext := pkgs.NewRequestContext()
+
+	emailClient := clients.NewHermesMailerClient()
+	emailNotification := notifications.NewEmailNotification(emailClient)
+
+	tokenService := services.NewTokenService(keyring)
+	sessionRepository := repositories.NewSessionRepository(db)
+	sessionService := services.NewSessionService(tokenService, sessionRepository)
+
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
+
+	userRepository := repositories.NewUserRepository(db)
+	followerRepository := repositories.NewFollowerRepository(db)
+	followerService := services.NewFollowerService(followerRepository, userRepository)
+	likeRepository := repositories.NewLikeRepository(db)
+	postRepository := repositories.NewPostRepository(db)
+	exportRepository := repositories.NewExportRepository(db)
+
+	exportService := services.NewExportService(
+		followerService,
+		tokenService,
+		exportRepository,
+		likeRepository,
+		postRepository,
+		sessionRepository,
+		userRepository,
+		emailNotification,
+	)
+	exportHandler := handlers.NewExportHandler(requestContext, exportService)
+
+	router.POST("/me/export", authMiddleware.Authenticated(exportHandler.RequestExport))
+	router.GET("/exports/download", exportHandler.DownloadExport)
+}
+
+func setupImportRoutes(db *sql.DB, keyring pkgs.Keyring, federationService services.FederationService, router *Router) {
+	requestContext := pkgs.NewRequestContext()
+
+	tokenService := services.NewTokenService(keyring)
+	sessionRepository := repositories.NewSessionRepository(db)
+	sessionService := services.NewSessionService(tokenService, sessionRepository)
+
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
+
+	postRepository := repositories.NewPostRepository(db)
+	likeRepository := repositories.NewLikeRepository(db)
+	postLinkRepository := repositories.NewPostLinkRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+	importRepository := repositories.NewImportRepository(db)
+	pollRepository := repositories.NewPollRepository(db)
+	likeService := services.NewLikeService(likeRepository)
+	pollService := services.NewPollService(postRepository, pollRepository)
+	postLinkService := services.NewPostLinkService(postLinkRepository, postRepository)
+	markdownRenderer := pkgs.NewMarkdownRenderer()
+	postService := services.NewPostService(federationService, likeService, pollService, postLinkService, postRepository, userRepository, markdownRenderer)
+	importService := services.NewImportService(postService, importRepository)
+	importHandler := handlers.NewImportHandler(requestContext, importService)
+
+	router.POST("/me/import", authMiddleware.Authenticated(importHandler.RequestImport))
+	router.GET("/me/imports/{importId}", authMiddleware.Authenticated(importHandler.GetImport))
+}
+
+func setupShareLinkRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
+	requestContext := pkgs.NewRequestContext()
+
+	tokenService := services.NewTokenService(keyring)
+	sessionRepository := repositories.NewSessionRepository(db)
+	sessionService := services.NewSessionService(tokenService, sessionRepository)
+
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	authMiddleware := middlewares.NewAuthMiddleware(keyring, requestContext, sessionService, personalAccessTokenService)
+
+	postRepository := repositories.NewPostRepository(db)
+	shareLinkRepository := repositories.NewShareLinkRepository(db)
+	markdownRenderer := pkgs.NewMarkdownRenderer()
+	shareLinkService := services.NewShareLinkService(tokenService, postRepository, shareLinkRepository, markdownRenderer)
+	shareLinkHandler := handlers.NewShareLinkHandler(requestContext, shareLinkService)
+
+	router.POST("/posts/{postId}/share-links", authMiddleware.Authenticated(shareLinkHandler.CreateShareLink))
+	router.GET("/posts/{postId}/share-links", authMiddleware.Authenticated(shareLinkHandler.GetShareLinks))
+	router.DELETE("/posts/{postId}/share-links/{linkId}", authMiddleware.Authenticated(shareLinkHandler.RevokeShareLink))
+	router.GET("/shared/{token}", shareLinkHandler.GetSharedPost)
+}
+
+func setupActivityPubRoutes(federationService services.FederationService, router *Router) {
+	activityPubHandler := handlers.NewActivityPubHandler(federationService)
+
+	router.GET("/.well-known/webfinger", activityPubHandler.WebFinger)
+	router.GET("/ap/users/{username}", activityPubHandler.GetActor)
+	router.GET("/ap/users/{username}/outbox", activityPubHandler.GetOutbox)
+	router.POST("/ap/users/{username}/inbox", activityPubHandler.PostInbox)
+	router.GET("/ap/posts/{postId}", activityPubHandler.GetNote)
+}
+
+func setupAdminRoutes(db *sql.DB, keyring pkgs.Keyring, router *Router) {
+	emailClient := clients.NewHermesMailerClient()
+	emailNotifcation := notifications.NewEmailNotification(emailClient)
+
+	tokenService := services.NewTokenService(keyring)
+	sessionRepository := repositories.NewSessionRepository(db)
+	sessionService := services.NewSessionService(tokenService, sessionRepository)
+
+	userRepository := repositories.NewUserRepository(db)
+	followerRepository := repositories.NewFollowerRepository(db)
+	followerService := services.NewFollowerService(followerRepository, userRepository)
+	userService := services.NewUserService(followerService, userRepository)
+
+	authService := services.NewAuthService(sessionService, userService, emailNotifcation)
+	adminHandler := handlers.NewAdminHandler(authService)
+
+	router.POST("/admin/users/{userId}/ban", middlewares.AdminOnly(adminHandler.BanUser))
+}
+
+// newFederationService wires the federation dependencies shared by every
+// route group that publishes or serves activities.
+func newFederationService(db *sql.DB) services.FederationService {
+	postRepository := repositories.NewPostRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+	markdownRenderer := pkgs.NewMarkdownRenderer()
+	httpSigner := pkgs.NewHTTPSigner()
+	activityPubClient := clients.NewActivityPubClient(httpSigner)
+	deliveryService := services.NewDeliveryService(activityPubClient)
+	actorKeyRepository := repositories.NewActorKeyRepository(db)
+	remoteActorRepository := repositories.NewRemoteActorRepository(db)
+
+	return services.NewFederationService(
+		deliveryService,
+		actorKeyRepository,
+		postRepository,
+		remoteActorRepository,
+		userRepository,
+		activityPubClient,
+		httpSigner,
+		markdownRenderer,
+	)
 }
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/url"
 
 	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/models"
@@ -10,9 +13,14 @@ import (
 )
 
 type AuthService interface {
-	SendAuthenticationLink(ctx context.Context, email string) error
-	AuthenticateFromLink(ctx context.Context, token string) (*models.AuthResponse, error)
+	SendAuthenticationLink(ctx context.Context, email string, withCode bool) (*models.LoginSession, error)
+	AuthenticateFromLink(ctx context.Context, token string, pollSecret string, here bool, client *models.ClientInfo) (*models.AuthResponse, error)
+	ApproveLogin(ctx context.Context, token string, verificationCode string) error
+	PollLogin(ctx context.Context, pendingLoginID string, pollSecret string, client *models.ClientInfo) (*models.AuthResponse, error)
+	AuthenticateWithCode(ctx context.Context, email string, code string, client *models.ClientInfo) (*models.AuthResponse, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*models.AuthResponse, error)
 	Logout(ctx context.Context, sessionId string) error
+	BanUser(ctx context.Context, userID string) error
 }
 
 type authService struct {
@@ -33,35 +41,123 @@ func NewAuthService(
 	}
 }
 
-func (a *authService) SendAuthenticationLink(ctx context.Context, email string) error {
+// SendAuthenticationLink emails a magic link and returns the pending login
+// the requesting device waits on, in case the link is opened elsewhere.
+// Accounts that are not active get no link.
+func (a *authService) SendAuthenticationLink(ctx context.Context, email string, withCode bool) (*models.LoginSession, error) {
 	user, err := a.us.GetUserByEmail(ctx, email)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	tokenMagicLink, err := a.ss.CreateSession(ctx, user.ID, email)
+	if err := userStatusError(user.Status); err != nil {
+		return nil, err
+	}
+
+	login, err := a.ss.CreateLoginSession(ctx, user.ID, email, withCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	magicLink := fmt.Sprintf("%s/magic-link/authenticate?token=%s", configs.Env.APIURL, tokenMagicLink)
+	magicLink := fmt.Sprintf("%s/magic-link/authenticate?token=%s", configs.Env.APIURL, login.Token)
 
-	if err := a.en.SendMagicLink(ctx, user.Name, user.Email, magicLink); err != nil {
-		return fmt.Errorf("send email: %w", err)
+	if err := a.en.SendMagicLink(ctx, user.Name, user.Email, magicLink, login.Code); err != nil {
+		return nil, fmt.Errorf("send email: %w", err)
 	}
 
-	return nil
+	return login, nil
 }
 
-func (a *authService) AuthenticateFromLink(ctx context.Context, token string) (*models.AuthResponse, error) {
-	authToken, err := a.ss.ValidSession(ctx, token)
+// AuthenticateFromLink signs in the device that opened the link. A link
+// opened away from the device that asked for it must approve that device
+// instead, unless here is set to sign in where it was opened.
+func (a *authService) AuthenticateFromLink(ctx context.Context, token string, pollSecret string, here bool, client *models.ClientInfo) (*models.AuthResponse, error) {
+	if !here {
+		required, err := a.ss.RequiresApproval(ctx, token, pollSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		if required {
+			return nil, models.ErrLoginApprovalRequired
+		}
+	}
+
+	response, err := a.ss.ValidSession(ctx, token, client)
 	if err != nil {
 		return nil, err
 	}
 
-	return &models.AuthResponse{
-		Token: authToken,
-	}, nil
+	a.alertNewSignIn(ctx, response.NewSignIn)
+
+	return response, nil
+}
+
+func (a *authService) ApproveLogin(ctx context.Context, token string, verificationCode string) error {
+	return a.ss.ApproveSession(ctx, token, verificationCode)
+}
+
+// PollLogin returns nil while the pending login waits for approval.
+func (a *authService) PollLogin(ctx context.Context, pendingLoginID string, pollSecret string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	response, err := a.ss.ClaimApprovedSession(ctx, pendingLoginID, pollSecret, client)
+	if err != nil || response == nil {
+		return response, err
+	}
+
+	a.alertNewSignIn(ctx, response.NewSignIn)
+
+	return response, nil
+}
+
+// AuthenticateWithCode reports unknown emails as a wrong code, so the
+// endpoint cannot be used to probe for accounts.
+func (a *authService) AuthenticateWithCode(ctx context.Context, email string, code string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	user, err := a.us.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, models.ErrInvalidLoginCode
+		}
+		return nil, err
+	}
+
+	response, err := a.ss.ValidSessionCode(ctx, user.ID, code, client)
+	if err != nil {
+		return nil, err
+	}
+
+	a.alertNewSignIn(ctx, response.NewSignIn)
+
+	return response, nil
+}
+
+// alertNewSignIn emails the user about a sign-in from an unfamiliar device.
+// The sign-in has already happened, so failures are only logged.
+func (a *authService) alertNewSignIn(ctx context.Context, signIn *models.NewSignIn) {
+	if signIn == nil {
+		return
+	}
+
+	logger := slog.With(
+		slog.String("service", "auth"),
+		slog.String("method", "alertNewSignIn"),
+		slog.String("sessionID", signIn.SessionID),
+	)
+
+	user, err := a.us.GetUserByID(ctx, signIn.UserID)
+	if err != nil {
+		logger.Error("get user for new sign-in alert", "error", err)
+		return
+	}
+
+	revokeLink := fmt.Sprintf("%s/sessions/revoke?token=%s", configs.Env.APIURL, url.QueryEscape(signIn.RevokeToken))
+
+	if err := a.en.SendNewSignInAlert(ctx, user.Name, user.Email, signIn, revokeLink); err != nil {
+		logger.Error("send new sign-in alert", "error", err)
+	}
+}
+
+func (a *authService) RefreshSession(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
+	return a.ss.Refresh(ctx, refreshToken)
 }
 
 func (a *authService) Logout(ctx context.Context, sessionId string) error {
@@ -71,3 +167,18 @@ func (a *authService) Logout(ctx context.Context, sessionId string) error {
 
 	return nil
 }
+
+// BanUser bans the user and revokes all their sessions, so no token issued
+// before the ban keeps working. Personal access tokens have no session; they
+// are refused by the status check every request makes.
+func (a *authService) BanUser(ctx context.Context, userID string) error {
+	if err := a.us.BanUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := a.ss.RevokeAllSessionsByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("revoke sessions of banned user %s: %w", userID, err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/g-villarinho/tab-notes-api/mocks"
 	"github.com/g-villarinho/tab-notes-api/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestSendAuthenticationLink(t *testing.T) {
@@ -25,67 +26,160 @@ func TestSendAuthenticationLink(t *testing.T) {
 			On("GetUserByEmail", ctx, "joao@example.com").
 			Return(nil, errors.New("not found"))
 
-		err := auth.SendAuthenticationLink(ctx, "joao@example.com")
+		login, err := auth.SendAuthenticationLink(ctx, "joao@example.com", false)
 
+		assert.Nil(t, login)
 		assert.ErrorContains(t, err, "not found")
 		userService.AssertExpectations(t)
 	})
 
-	t.Run("should return error if CreateSession fails", func(t *testing.T) {
+	t.Run("should refuse a banned user", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		auth := NewAuthService(sessionService, userService, nil)
+
+		userService.
+			On("GetUserByEmail", ctx, "joao@example.com").
+			Return(&models.User{ID: "user-1", Email: "joao@example.com", Status: models.UserStatusBanned}, nil)
+
+		login, err := auth.SendAuthenticationLink(ctx, "joao@example.com", false)
+
+		assert.Nil(t, login)
+		assert.ErrorIs(t, err, models.ErrUserBanned)
+		sessionService.AssertNotCalled(t, "CreateLoginSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse an inactive user", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		auth := NewAuthService(sessionService, userService, nil)
+
+		userService.
+			On("GetUserByEmail", ctx, "joao@example.com").
+			Return(&models.User{ID: "user-1", Email: "joao@example.com", Status: models.UserStatusInactive}, nil)
+
+		login, err := auth.SendAuthenticationLink(ctx, "joao@example.com", false)
+
+		assert.Nil(t, login)
+		assert.ErrorIs(t, err, models.ErrUserInactive)
+	})
+
+	t.Run("should return error if CreateLoginSession fails", func(t *testing.T) {
 		sessionService := new(mocks.SessionServiceMock)
 		userService := new(mocks.UserServiceMock)
 		emailNotification := new(mocks.EmailNotificationMock)
 		auth := NewAuthService(sessionService, userService, emailNotification)
 
-		user := &models.User{ID: "user-1", Name: "João", Email: "joao@example.com"}
+		user := &models.User{ID: "user-1", Name: "João", Email: "joao@example.com", Status: models.UserStatusActive}
 
 		userService.
 			On("GetUserByEmail", ctx, user.Email).
 			Return(user, nil)
 
 		sessionService.
-			On("CreateSession", ctx, user.ID, user.Email).
-			Return("", errors.New("session fail"))
+			On("CreateLoginSession", ctx, user.ID, user.Email, false).
+			Return(nil, errors.New("session fail"))
 
-		err := auth.SendAuthenticationLink(ctx, user.Email)
+		_, err := auth.SendAuthenticationLink(ctx, user.Email, false)
 
 		assert.ErrorContains(t, err, "session fail")
 		userService.AssertExpectations(t)
 		sessionService.AssertExpectations(t)
 	})
 
-	t.Run("should send email with magic link", func(t *testing.T) {
+	t.Run("should send email with magic link and return the pending login", func(t *testing.T) {
 		sessionService := new(mocks.SessionServiceMock)
 		userService := new(mocks.UserServiceMock)
 		emailNotification := new(mocks.EmailNotificationMock)
 		auth := NewAuthService(sessionService, userService, emailNotification)
 
-		user := &models.User{ID: "user-1", Name: "João", Email: "joao@example.com"}
+		user := &models.User{ID: "user-1", Name: "João", Email: "joao@example.com", Status: models.UserStatusActive}
 
 		userService.
 			On("GetUserByEmail", ctx, user.Email).
 			Return(user, nil)
 
+		pending := &models.LoginSession{SessionID: "sess-1", Token: "token-xyz", PollSecret: "poll-secret", VerificationCode: "381204"}
+
 		sessionService.
-			On("CreateSession", ctx, user.ID, user.Email).
-			Return("token-xyz", nil)
+			On("CreateLoginSession", ctx, user.ID, user.Email, false).
+			Return(pending, nil)
 
 		configs.Env.APIURL = "http://localhost:8080"
 
 		expectedLink := fmt.Sprintf("%s/magic-link/authenticate?token=%s", configs.Env.APIURL, "token-xyz")
 
 		emailNotification.
-			On("SendMagicLink", ctx, user.Name, user.Email, expectedLink).
+			On("SendMagicLink", ctx, user.Name, user.Email, expectedLink, "").
 			Return(nil)
 
-		err := auth.SendAuthenticationLink(ctx, user.Email)
+		login, err := auth.SendAuthenticationLink(ctx, user.Email, false)
 
 		assert.NoError(t, err)
+		assert.Equal(t, pending, login)
 		userService.AssertExpectations(t)
 		sessionService.AssertExpectations(t)
 		emailNotification.AssertExpectations(t)
 	})
 }
+
+func TestSendAuthenticationLink_WithCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send the code along with the magic link", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		emailNotification := new(mocks.EmailNotificationMock)
+		auth := NewAuthService(sessionService, userService, emailNotification)
+
+		user := &models.User{ID: "user-1", Name: "João", Email: "joao@example.com", Status: models.UserStatusActive}
+		configs.Env.APIURL = "http://localhost:8080"
+
+		userService.On("GetUserByEmail", ctx, user.Email).Return(user, nil)
+		sessionService.On("CreateLoginSession", ctx, user.ID, user.Email, true).Return(&models.LoginSession{Token: "token-xyz", Code: "042137"}, nil)
+		emailNotification.
+			On("SendMagicLink", ctx, user.Name, user.Email, "http://localhost:8080/magic-link/authenticate?token=token-xyz", "042137").
+			Return(nil)
+
+		_, err := auth.SendAuthenticationLink(ctx, user.Email, true)
+
+		assert.NoError(t, err)
+		emailNotification.AssertExpectations(t)
+	})
+}
+
+func TestAuthenticateWithCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report an unknown email as an invalid code", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		auth := NewAuthService(sessionService, userService, nil)
+
+		userService.On("GetUserByEmail", ctx, "ninguem@example.com").Return(nil, models.ErrUserNotFound)
+
+		resp, err := auth.AuthenticateWithCode(ctx, "ninguem@example.com", "123456", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidLoginCode)
+		sessionService.AssertNotCalled(t, "ValidSessionCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should sign in the user of the email", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		auth := NewAuthService(sessionService, userService, nil)
+
+		userService.On("GetUserByEmail", ctx, "joao@example.com").Return(&models.User{ID: "user-1"}, nil)
+		sessionService.On("ValidSessionCode", ctx, "user-1", "123456", mock.Anything).Return(&models.AuthResponse{Token: "auth-token"}, nil)
+
+		resp, err := auth.AuthenticateWithCode(ctx, "joao@example.com", "123456", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "auth-token", resp.Token)
+	})
+}
+
 func TestAuthenticateFromLink(t *testing.T) {
 	ctx := context.Background()
 
@@ -96,10 +190,10 @@ func TestAuthenticateFromLink(t *testing.T) {
 		auth := NewAuthService(sessionService, userService, emailNotification)
 
 		sessionService.
-			On("ValidSession", ctx, "invalid-token").
-			Return("", errors.New("invalid or expired"))
+			On("ValidSession", ctx, "invalid-token", mock.Anything).
+			Return(nil, errors.New("invalid or expired"))
 
-		resp, err := auth.AuthenticateFromLink(ctx, "invalid-token")
+		resp, err := auth.AuthenticateFromLink(ctx, "invalid-token", "", true, nil)
 
 		assert.Nil(t, resp)
 		assert.ErrorContains(t, err, "invalid or expired")
@@ -113,16 +207,82 @@ func TestAuthenticateFromLink(t *testing.T) {
 		auth := NewAuthService(sessionService, userService, emailNotification)
 
 		sessionService.
-			On("ValidSession", ctx, "valid-token").
-			Return("new-auth-token", nil)
+			On("ValidSession", ctx, "valid-token", mock.Anything).
+			Return(&models.AuthResponse{Token: "new-auth-token", RefreshToken: "refresh-token"}, nil)
 
-		resp, err := auth.AuthenticateFromLink(ctx, "valid-token")
+		resp, err := auth.AuthenticateFromLink(ctx, "valid-token", "", true, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 		assert.Equal(t, "new-auth-token", resp.Token)
 		sessionService.AssertExpectations(t)
 	})
+
+	t.Run("should ask for approval when opened on another device", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		auth := NewAuthService(sessionService, nil, nil)
+
+		sessionService.On("RequiresApproval", ctx, "valid-token", "").Return(true, nil)
+
+		resp, err := auth.AuthenticateFromLink(ctx, "valid-token", "", false, nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrLoginApprovalRequired)
+		sessionService.AssertNotCalled(t, "ValidSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should sign in on the device that asked for the link", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		auth := NewAuthService(sessionService, nil, nil)
+
+		sessionService.On("RequiresApproval", ctx, "valid-token", "poll-secret").Return(false, nil)
+		sessionService.On("ValidSession", ctx, "valid-token", mock.Anything).Return(&models.AuthResponse{Token: "new-auth-token"}, nil)
+
+		resp, err := auth.AuthenticateFromLink(ctx, "valid-token", "poll-secret", false, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-auth-token", resp.Token)
+	})
+
+	t.Run("should alert the user of a sign-in from a new device", func(t *testing.T) {
+		configs.Env.APIURL = "http://api.test"
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		emailNotification := new(mocks.EmailNotificationMock)
+		auth := NewAuthService(sessionService, userService, emailNotification)
+
+		signIn := &models.NewSignIn{SessionID: "session-1", UserID: "user-1", RevokeToken: "revoke-token"}
+		sessionService.On("ValidSession", ctx, "valid-token", mock.Anything).
+			Return(&models.AuthResponse{Token: "new-auth-token", NewSignIn: signIn}, nil)
+		userService.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1", Name: "João", Email: "joao@example.com", Status: models.UserStatusActive}, nil)
+		emailNotification.On("SendNewSignInAlert", ctx, "João", "joao@example.com", signIn,
+			"http://api.test/sessions/revoke?token=revoke-token").Return(nil)
+
+		resp, err := auth.AuthenticateFromLink(ctx, "valid-token", "", true, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-auth-token", resp.Token)
+		emailNotification.AssertExpectations(t)
+	})
+
+	t.Run("should sign in even if the alert fails", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		emailNotification := new(mocks.EmailNotificationMock)
+		auth := NewAuthService(sessionService, userService, emailNotification)
+
+		signIn := &models.NewSignIn{SessionID: "session-1", UserID: "user-1", RevokeToken: "revoke-token"}
+		sessionService.On("ValidSession", ctx, "valid-token", mock.Anything).
+			Return(&models.AuthResponse{Token: "new-auth-token", NewSignIn: signIn}, nil)
+		userService.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1", Email: "joao@example.com"}, nil)
+		emailNotification.On("SendNewSignInAlert", ctx, mock.Anything, mock.Anything, signIn, mock.Anything).
+			Return(errors.New("smtp down"))
+
+		resp, err := auth.AuthenticateFromLink(ctx, "valid-token", "", true, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-auth-token", resp.Token)
+	})
 }
 
 func TestLogout(t *testing.T) {
@@ -160,3 +320,34 @@ func TestLogout(t *testing.T) {
 		sessionService.AssertExpectations(t)
 	})
 }
+
+func TestAuthService_BanUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should revoke every session of the banned user", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		auth := NewAuthService(sessionService, userService, nil)
+
+		userService.On("BanUser", ctx, "user-1").Return(nil)
+		sessionService.On("RevokeAllSessionsByUserID", ctx, "user-1").Return(nil)
+
+		err := auth.BanUser(ctx, "user-1")
+
+		assert.NoError(t, err)
+		sessionService.AssertExpectations(t)
+	})
+
+	t.Run("should not revoke sessions if the ban fails", func(t *testing.T) {
+		sessionService := new(mocks.SessionServiceMock)
+		userService := new(mocks.UserServiceMock)
+		auth := NewAuthService(sessionService, userService, nil)
+
+		userService.On("BanUser", ctx, "missing").Return(models.ErrUserNotFound)
+
+		err := auth.BanUser(ctx, "missing")
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		sessionService.AssertNotCalled(t, "RevokeAllSessionsByUserID", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/clients"
+	"github.com/g-villarinho/tab-notes-api/models"
+)
+
+const (
+	maxDeliveryAttempts = 6
+	deliveryTimeout     = 30 * time.Second
+	baseDeliveryBackoff = 30 * time.Second
+	deliveryWorkers     = 8
+	deliveryQueueSize   = 1024
+)
+
+// DeliveryService POSTs activities to remote inboxes in the background,
+// retrying failed deliveries with exponential backoff. Deliveries are only
+// kept in memory: the ones pending or waiting for a retry are lost when the
+// process stops.
+type DeliveryService interface {
+	Enqueue(delivery *models.Delivery)
+}
+
+type deliveryService struct {
+	ac       clients.ActivityPubClient
+	queue    chan func()
+	schedule func(delay time.Duration, task func())
+}
+
+// NewDeliveryService starts a fixed pool of workers, so a post with many
+// remote followers cannot open an unbounded number of connections.
+func NewDeliveryService(activityPubClient clients.ActivityPubClient) DeliveryService {
+	d := &deliveryService{
+		ac:    activityPubClient,
+		queue: make(chan func(), deliveryQueueSize),
+	}
+	d.schedule = d.queueAfter
+
+	for range deliveryWorkers {
+		go d.work()
+	}
+
+	return d
+}
+
+func (d *deliveryService) work() {
+	for task := range d.queue {
+		task()
+	}
+}
+
+// queueAfter hands task to the workers once delay has passed. A full queue
+// drops the task instead of piling up goroutines waiting for a worker.
+func (d *deliveryService) queueAfter(delay time.Duration, task func()) {
+	time.AfterFunc(delay, func() {
+		select {
+		case d.queue <- task:
+		default:
+			slog.Error("delivery queue full, dropping delivery", slog.String("service", "delivery"))
+		}
+	})
+}
+
+func (d *deliveryService) Enqueue(delivery *models.Delivery) {
+	d.schedule(0, func() { d.attempt(delivery) })
+}
+
+func (d *deliveryService) attempt(delivery *models.Delivery) {
+	logger := slog.With(
+		slog.String("service", "delivery"),
+		slog.String("inbox", delivery.Inbox),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	delivery.Attempts++
+
+	err := d.ac.Deliver(ctx, delivery)
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, models.ErrDeliveryRejected) {
+		logger.Warn("delivery rejected, dropping", "error", err)
+		return
+	}
+
+	if delivery.Attempts >= maxDeliveryAttempts {
+		logger.Error("delivery failed, giving up", "attempts", delivery.Attempts, "error", err)
+		return
+	}
+
+	logger.Warn("delivery failed, retrying", "attempts", delivery.Attempts, "error", err)
+	d.schedule(deliveryBackoff(delivery.Attempts), func() { d.attempt(delivery) })
+}
+
+// deliveryBackoff doubles the wait after each failed attempt: 30s, 1m, 2m...
+func deliveryBackoff(attempts int) time.Duration {
+	return baseDeliveryBackoff << (attempts - 1)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeliveryService_Enqueue(t *testing.T) {
+	newDeliveryService := func(client *mocks.ActivityPubClientMock) (DeliveryService, *[]time.Duration) {
+		delays := []time.Duration{}
+		ds := NewDeliveryService(client)
+		ds.(*deliveryService).schedule = func(delay time.Duration, task func()) {
+			delays = append(delays, delay)
+			task()
+		}
+		return ds, &delays
+	}
+
+	t.Run("should retry failed deliveries with backoff", func(t *testing.T) {
+		client := new(mocks.ActivityPubClientMock)
+		ds, delays := newDeliveryService(client)
+
+		client.On("Deliver", mock.Anything, mock.AnythingOfType("*models.Delivery")).
+			Return(errors.New("connection refused")).Twice()
+		client.On("Deliver", mock.Anything, mock.AnythingOfType("*models.Delivery")).
+			Return(nil).Once()
+
+		delivery := &models.Delivery{Inbox: "https://remote.test/inbox"}
+		ds.Enqueue(delivery)
+
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Equal(t, []time.Duration{0, 30 * time.Second, time.Minute}, *delays)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should give up after the maximum attempts", func(t *testing.T) {
+		client := new(mocks.ActivityPubClientMock)
+		ds, _ := newDeliveryService(client)
+
+		client.On("Deliver", mock.Anything, mock.AnythingOfType("*models.Delivery")).
+			Return(errors.New("bad gateway"))
+
+		delivery := &models.Delivery{Inbox: "https://remote.test/inbox"}
+		ds.Enqueue(delivery)
+
+		assert.Equal(t, maxDeliveryAttempts, delivery.Attempts)
+		client.AssertNumberOfCalls(t, "Deliver", maxDeliveryAttempts)
+	})
+
+	t.Run("should drop deliveries rejected by the remote inbox", func(t *testing.T) {
+		client := new(mocks.ActivityPubClientMock)
+		ds, delays := newDeliveryService(client)
+
+		client.On("Deliver", mock.Anything, mock.AnythingOfType("*models.Delivery")).
+			Return(fmt.Errorf("status 403: %w", models.ErrDeliveryRejected))
+
+		delivery := &models.Delivery{Inbox: "https://remote.test/inbox"}
+		ds.Enqueue(delivery)
+
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Len(t, *delays, 1)
+	})
+
+	t.Run("should deliver on a bounded number of workers", func(t *testing.T) {
+		client := new(mocks.ActivityPubClientMock)
+		ds := NewDeliveryService(client)
+
+		var running, peak, done atomic.Int32
+		release := make(chan struct{})
+		client.On("Deliver", mock.Anything, mock.AnythingOfType("*models.Delivery")).
+			Run(func(mock.Arguments) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				<-release
+				running.Add(-1)
+				done.Add(1)
+			}).
+			Return(nil)
+
+		for range 3 * deliveryWorkers {
+			ds.Enqueue(&models.Delivery{Inbox: "https://remote.test/inbox"})
+		}
+
+		assert.Eventually(t, func() bool { return running.Load() == deliveryWorkers }, time.Second, time.Millisecond)
+		close(release)
+		assert.Eventually(t, func() bool { return done.Load() == 3*deliveryWorkers }, time.Second, time.Millisecond)
+		assert.EqualValues(t, deliveryWorkers, peak.Load())
+	})
+}
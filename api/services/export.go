@@ -0,0 +1,387 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/notifications"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+	"github.com/g-villarinho/tab-notes-api/utils"
+)
+
+// Archives are kept for exportLinkTTL after they are built and removed from
+// disk by the next export build after that. A pending export older than
+// exportBuildTimeout is assumed dead and no longer blocks a new request.
+const (
+	exportLinkTTL      = 24 * time.Hour
+	exportBuildTimeout = time.Hour
+)
+
+type ExportService interface {
+	RequestExport(ctx context.Context, userID string) (*models.ExportResponse, error)
+	BuildExport(ctx context.Context, exportID string) error
+	GetExportFile(ctx context.Context, token string) (string, error)
+}
+
+type exportService struct {
+	fs       FollowerService
+	ts       TokenService
+	er       repositories.ExportRepository
+	lr       repositories.LikeRepository
+	pr       repositories.PostRepository
+	sr       repositories.SessionRepository
+	ur       repositories.UserRepository
+	en       notifications.EmailNotification
+	dispatch func(task func())
+}
+
+func NewExportService(
+	followerService FollowerService,
+	tokenService TokenService,
+	exportRepository repositories.ExportRepository,
+	likeRepository repositories.LikeRepository,
+	postRepository repositories.PostRepository,
+	sessionRepository repositories.SessionRepository,
+	userRepository repositories.UserRepository,
+	emailNotification notifications.EmailNotification) ExportService {
+	return &exportService{
+		fs: followerService,
+		ts: tokenService,
+		er: exportRepository,
+		lr: likeRepository,
+		pr: postRepository,
+		sr: sessionRepository,
+		ur: userRepository,
+		en: emailNotification,
+		dispatch: func(task func()) {
+			go task()
+		},
+	}
+}
+
+func (e *exportService) RequestExport(ctx context.Context, userID string) (*models.ExportResponse, error) {
+	pending, err := e.er.GetPendingExportByUserID(ctx, userID, time.Now().UTC().Add(-exportBuildTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("get pending export by user id %s: %w", userID, err)
+	}
+
+	if pending != nil {
+		return toExportResponse(pending), nil
+	}
+
+	export := &models.Export{
+		UserID: userID,
+		Status: models.ExportStatusPending,
+	}
+
+	if err := e.er.CreateExport(ctx, export); err != nil {
+		return nil, fmt.Errorf("create export: %w", err)
+	}
+
+	e.dispatch(func() {
+		if err := e.purgeExpiredExports(context.Background()); err != nil {
+			slog.Error("error purging expired exports", "error", err)
+		}
+
+		if err := e.BuildExport(context.Background(), export.ID); err != nil {
+			slog.Error("error building export", "exportID", export.ID, "error", err)
+		}
+	})
+
+	return toExportResponse(export), nil
+}
+
+func (e *exportService) BuildExport(ctx context.Context, exportID string) error {
+	export, err := e.er.GetExportByID(ctx, exportID)
+	if err != nil {
+		return e.failExport(ctx, &models.Export{ID: exportID}, fmt.Errorf("get export by id %s: %w", exportID, err))
+	}
+
+	if export == nil {
+		return models.ErrExportNotFound
+	}
+
+	user, err := e.ur.GetUserByID(ctx, export.UserID)
+	if err != nil {
+		return e.failExport(ctx, export, fmt.Errorf("get user by id %s: %w", export.UserID, err))
+	}
+
+	if user == nil {
+		return e.failExport(ctx, export, models.ErrUserNotFound)
+	}
+
+	path, err := e.writeArchive(ctx, export, user)
+	if err != nil {
+		return e.failExport(ctx, export, fmt.Errorf("write archive: %w", err))
+	}
+
+	now := time.Now().UTC()
+	export.Status = models.ExportStatusCompleted
+	export.FilePath = sql.NullString{String: path, Valid: true}
+	export.CompletedAt = sql.NullTime{Time: now, Valid: true}
+	export.ExpiresAt = sql.NullTime{Time: now.Add(exportLinkTTL), Valid: true}
+
+	if err := e.er.UpdateExport(ctx, export); err != nil {
+		return fmt.Errorf("update export %s: %w", exportID, err)
+	}
+
+	token, err := e.ts.GenerateExportToken(ctx, user.ID, export.ID, now, export.ExpiresAt.Time)
+	if err != nil {
+		return fmt.Errorf("generate export token: %w", err)
+	}
+
+	downloadLink := fmt.Sprintf("%s/exports/download?token=%s", configs.Env.APIURL, token)
+
+	if err := e.en.SendExportReady(ctx, user.Name, user.Email, downloadLink); err != nil {
+		return fmt.Errorf("send export ready email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *exportService) GetExportFile(ctx context.Context, token string) (string, error) {
+	claims, err := e.ts.ParseExportToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	export, err := e.er.GetExportByID(ctx, claims.Subject)
+	if err != nil {
+		return "", fmt.Errorf("get export by id %s: %w", claims.Subject, err)
+	}
+
+	if export == nil || export.UserID != claims.UserID {
+		return "", models.ErrExportNotFound
+	}
+
+	if export.ExpiresAt.Valid && export.ExpiresAt.Time.Before(time.Now().UTC()) {
+		return "", models.ErrExportExpired
+	}
+
+	if export.Status != models.ExportStatusCompleted || !export.FilePath.Valid {
+		return "", models.ErrExportNotReady
+	}
+
+	return export.FilePath.String, nil
+}
+
+// failExport marks the export as failed, so it stops showing up as pending,
+// and returns cause.
+func (e *exportService) failExport(ctx context.Context, export *models.Export, cause error) error {
+	export.Status = models.ExportStatusFailed
+	if err := e.er.UpdateExport(ctx, export); err != nil {
+		return fmt.Errorf("update export %s: %w", export.ID, err)
+	}
+
+	return cause
+}
+
+// purgeExpiredExports deletes the archives whose download link has expired
+// and forgets their path.
+func (e *exportService) purgeExpiredExports(ctx context.Context) error {
+	exports, err := e.er.GetExpiredExports(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("get expired exports: %w", err)
+	}
+
+	for _, export := range exports {
+		if err := os.Remove(export.FilePath.String); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove archive %s: %w", export.ID, err)
+		}
+
+		export.FilePath = sql.NullString{}
+		if err := e.er.UpdateExport(ctx, export); err != nil {
+			return fmt.Errorf("update export %s: %w", export.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// writeArchive writes the ZIP to a temporary file first, so a download link is
+// never handed out for a partially written archive.
+func (e *exportService) writeArchive(ctx context.Context, export *models.Export, user *models.User) (string, error) {
+	if err := os.MkdirAll(configs.Env.ExportDir, 0o750); err != nil {
+		return "", fmt.Errorf("create export dir: %w", err)
+	}
+
+	path := filepath.Join(configs.Env.ExportDir, export.ID+".zip")
+
+	file, err := os.CreateTemp(configs.Env.ExportDir, export.ID+"-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	zw := zip.NewWriter(file)
+	if err := e.writeEntries(ctx, zw, user); err != nil {
+		file.Close()
+		return "", err
+	}
+
+	if err := zw.Close(); err != nil {
+		file.Close()
+		return "", fmt.Errorf("close zip writer: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", fmt.Errorf("rename archive: %w", err)
+	}
+
+	return path, nil
+}
+
+func (e *exportService) writeEntries(ctx context.Context, zw *zip.Writer, user *models.User) error {
+	profile := &models.ExportProfile{
+		ID:        user.ID,
+		Name:      user.Name,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+	}
+
+	if user.UpdatedAt.Valid {
+		profile.UpdatedAt = &user.UpdatedAt.Time
+	}
+
+	if err := writeJSONEntry(zw, "profile.json", profile); err != nil {
+		return err
+	}
+
+	posts, err := e.pr.GetPostsByAuthorID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get posts by author id %s: %w", user.ID, err)
+	}
+
+	for _, post := range posts {
+		w, err := zw.Create(postFileName(post))
+		if err != nil {
+			return fmt.Errorf("create post entry %s: %w", post.ID, err)
+		}
+
+		if _, err := w.Write([]byte(postMarkdown(post))); err != nil {
+			return fmt.Errorf("write post entry %s: %w", post.ID, err)
+		}
+	}
+
+	likes, err := e.lr.GetLikesByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get likes by user id %s: %w", user.ID, err)
+	}
+
+	exportLikes := make([]*models.ExportLike, len(likes))
+	for i, like := range likes {
+		exportLikes[i] = &models.ExportLike{PostID: like.PostID, CreatedAt: like.CreatedAt}
+	}
+
+	if err := writeJSONEntry(zw, "likes.json", exportLikes); err != nil {
+		return err
+	}
+
+	followers, err := e.fs.GetMyFollowers(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get followers: %w", err)
+	}
+
+	if err := writeJSONEntry(zw, "followers.json", followers); err != nil {
+		return err
+	}
+
+	following, err := e.fs.GetMyFollowing(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get following: %w", err)
+	}
+
+	if err := writeJSONEntry(zw, "following.json", following); err != nil {
+		return err
+	}
+
+	sessions, err := e.sr.GetSessionHistoryByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get session history by user id %s: %w", user.ID, err)
+	}
+
+	exportSessions := make([]*models.ExportSession, len(sessions))
+	for i, session := range sessions {
+		exportSessions[i] = &models.ExportSession{
+			ID:        session.ID,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+		}
+
+		if session.VerifiedAt.Valid {
+			exportSessions[i].VerifiedAt = &session.VerifiedAt.Time
+		}
+
+		if session.RevokedAt.Valid {
+			exportSessions[i].RevokedAt = &session.RevokedAt.Time
+		}
+	}
+
+	return writeJSONEntry(zw, "sessions.json", exportSessions)
+}
+
+func writeJSONEntry(zw *zip.Writer, name string, data any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("create entry %s: %w", name, err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("encode entry %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func postFileName(post *models.Post) string {
+	slug := utils.Slugify(post.Title)
+	if slug == "" {
+		return fmt.Sprintf("posts/%s.md", post.ID)
+	}
+
+	return fmt.Sprintf("posts/%s-%s.md", slug, post.ID)
+}
+
+func postMarkdown(post *models.Post) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %s\n", post.ID)
+	fmt.Fprintf(&b, "title: %s\n", strconv.Quote(post.Title))
+	fmt.Fprintf(&b, "likes: %d\n", post.Likes)
+	fmt.Fprintf(&b, "created_at: %s\n", post.CreatedAt.Format(time.RFC3339))
+	if post.UpdatedAt.Valid {
+		fmt.Fprintf(&b, "updated_at: %s\n", post.UpdatedAt.Time.Format(time.RFC3339))
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(post.Content)
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func toExportResponse(export *models.Export) *models.ExportResponse {
+	return &models.ExportResponse{
+		ID:        export.ID,
+		Status:    export.Status,
+		CreatedAt: export.CreatedAt,
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportService_RequestExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return pending export instead of starting a new one", func(t *testing.T) {
+		er := new(mocks.ExportRepositoryMock)
+		es := NewExportService(nil, nil, er, nil, nil, nil, nil, nil)
+
+		er.On("GetPendingExportByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).
+			Return(&models.Export{ID: "export-1", Status: models.ExportStatusPending}, nil)
+
+		resp, err := es.RequestExport(ctx, "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "export-1", resp.ID)
+		er.AssertNotCalled(t, "CreateExport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create export and dispatch the build", func(t *testing.T) {
+		er := new(mocks.ExportRepositoryMock)
+		es := NewExportService(nil, nil, er, nil, nil, nil, nil, nil)
+
+		dispatched := false
+		es.(*exportService).dispatch = func(task func()) {
+			dispatched = true
+		}
+
+		er.On("GetPendingExportByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(nil, nil)
+		er.On("CreateExport", ctx, mock.MatchedBy(func(e *models.Export) bool {
+			return e.UserID == "user-1" && e.Status == models.ExportStatusPending
+		})).Return(nil)
+
+		resp, err := es.RequestExport(ctx, "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ExportStatusPending, resp.Status)
+		assert.True(t, dispatched)
+		er.AssertExpectations(t)
+	})
+}
+
+func TestExportService_BuildExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should write archive and email a signed link", func(t *testing.T) {
+		configs.Env.ExportDir = t.TempDir()
+		configs.Env.APIURL = "http://api.test"
+
+		fs := new(mocks.FollowerServiceMock)
+		ts := new(mocks.TokenServiceMock)
+		er := new(mocks.ExportRepositoryMock)
+		lr := new(mocks.LikeRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		sr := new(mocks.SessionRepositoryMock)
+		ur := new(mocks.UserRepositoryMock)
+		en := new(mocks.EmailNotificationMock)
+		es := NewExportService(fs, ts, er, lr, pr, sr, ur, en)
+
+		user := &models.User{ID: "user-1", Name: "Ana", Email: "ana@test.com", Username: "ana"}
+
+		er.On("GetExportByID", ctx, "export-1").Return(&models.Export{ID: "export-1", UserID: "user-1"}, nil)
+		ur.On("GetUserByID", ctx, "user-1").Return(user, nil)
+		pr.On("GetPostsByAuthorID", ctx, "user-1").Return([]*models.Post{
+			{ID: "post-1", Title: "Minhas Receitas", Content: "# Bolo", CreatedAt: time.Now()},
+		}, nil)
+		lr.On("GetLikesByUserID", ctx, "user-1").Return([]*models.Like{{PostID: "post-9"}}, nil)
+		fs.On("GetMyFollowers", ctx, "user-1").Return([]*models.FollowerResponse{}, nil)
+		fs.On("GetMyFollowing", ctx, "user-1").Return([]*models.FollowerResponse{}, nil)
+		sr.On("GetSessionHistoryByUserID", ctx, "user-1").Return([]*models.Session{{ID: "session-1"}}, nil)
+
+		var archivePath string
+		er.On("UpdateExport", ctx, mock.MatchedBy(func(e *models.Export) bool {
+			archivePath = e.FilePath.String
+			return e.Status == models.ExportStatusCompleted && e.FilePath.Valid && e.ExpiresAt.Valid
+		})).Return(nil)
+		ts.On("GenerateExportToken", ctx, "user-1", "export-1", mock.Anything, mock.Anything).Return("signed", nil)
+		en.On("SendExportReady", ctx, "Ana", "ana@test.com", "http://api.test/exports/download?token=signed").Return(nil)
+
+		err := es.BuildExport(ctx, "export-1")
+
+		require.NoError(t, err)
+		en.AssertExpectations(t)
+
+		archive, err := zip.OpenReader(archivePath)
+		require.NoError(t, err)
+		defer archive.Close()
+
+		entries := map[string]string{}
+		for _, f := range archive.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			rc.Close()
+			require.NoError(t, err)
+			entries[f.Name] = string(content)
+		}
+
+		assert.Contains(t, entries, "profile.json")
+		assert.Contains(t, entries, "likes.json")
+		assert.Contains(t, entries, "followers.json")
+		assert.Contains(t, entries, "following.json")
+		assert.Contains(t, entries, "sessions.json")
+		assert.Contains(t, entries["posts/minhas-receitas-post-1.md"], "title: \"Minhas Receitas\"")
+		assert.Contains(t, entries["posts/minhas-receitas-post-1.md"], "# Bolo")
+	})
+
+	t.Run("should mark export as failed if data cannot be read", func(t *testing.T) {
+		configs.Env.ExportDir = t.TempDir()
+
+		er := new(mocks.ExportRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		ur := new(mocks.UserRepositoryMock)
+		en := new(mocks.EmailNotificationMock)
+		es := NewExportService(nil, nil, er, nil, pr, nil, ur, en)
+
+		er.On("GetExportByID", ctx, "export-1").Return(&models.Export{ID: "export-1", UserID: "user-1"}, nil)
+		ur.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1"}, nil)
+		pr.On("GetPostsByAuthorID", ctx, "user-1").Return(nil, errors.New("db error"))
+		er.On("UpdateExport", ctx, mock.MatchedBy(func(e *models.Export) bool {
+			return e.Status == models.ExportStatusFailed
+		})).Return(nil)
+
+		err := es.BuildExport(ctx, "export-1")
+
+		assert.ErrorContains(t, err, "write archive")
+		er.AssertExpectations(t)
+		en.AssertNotCalled(t, "SendExportReady", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should mark export as failed if user no longer exists", func(t *testing.T) {
+		er := new(mocks.ExportRepositoryMock)
+		ur := new(mocks.UserRepositoryMock)
+		es := NewExportService(nil, nil, er, nil, nil, nil, ur, nil)
+
+		er.On("GetExportByID", ctx, "export-1").Return(&models.Export{ID: "export-1", UserID: "user-1"}, nil)
+		ur.On("GetUserByID", ctx, "user-1").Return(nil, nil)
+		er.On("UpdateExport", ctx, mock.MatchedBy(func(e *models.Export) bool {
+			return e.ID == "export-1" && e.Status == models.ExportStatusFailed
+		})).Return(nil)
+
+		err := es.BuildExport(ctx, "export-1")
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		er.AssertExpectations(t)
+	})
+
+	t.Run("should mark export as failed if it cannot be loaded", func(t *testing.T) {
+		er := new(mocks.ExportRepositoryMock)
+		es := NewExportService(nil, nil, er, nil, nil, nil, nil, nil)
+
+		er.On("GetExportByID", ctx, "export-1").Return(nil, errors.New("db error"))
+		er.On("UpdateExport", ctx, mock.MatchedBy(func(e *models.Export) bool {
+			return e.ID == "export-1" && e.Status == models.ExportStatusFailed
+		})).Return(nil)
+
+		err := es.BuildExport(ctx, "export-1")
+
+		assert.ErrorContains(t, err, "get export by id")
+		er.AssertExpectations(t)
+	})
+}
+
+func TestExportService_PurgeExpiredExports(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should remove expired archives and clear their path", func(t *testing.T) {
+		er := new(mocks.ExportRepositoryMock)
+		es := NewExportService(nil, nil, er, nil, nil, nil, nil, nil)
+
+		path := filepath.Join(t.TempDir(), "export-1.zip")
+		require.NoError(t, os.WriteFile(path, []byte("zip"), 0o600))
+
+		er.On("GetExpiredExports", ctx, mock.AnythingOfType("time.Time")).Return([]*models.Export{
+			{ID: "export-1", Status: models.ExportStatusCompleted, FilePath: sql.NullString{String: path, Valid: true}},
+			{ID: "export-2", Status: models.ExportStatusCompleted, FilePath: sql.NullString{String: path + ".gone", Valid: true}},
+		}, nil)
+		er.On("UpdateExport", ctx, mock.MatchedBy(func(e *models.Export) bool {
+			return !e.FilePath.Valid && e.Status == models.ExportStatusCompleted
+		})).Return(nil).Twice()
+
+		err := es.(*exportService).purgeExpiredExports(ctx)
+
+		assert.NoError(t, err)
+		assert.NoFileExists(t, path)
+		er.AssertExpectations(t)
+	})
+}
+
+func TestExportService_GetExportFile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrInvalidExportToken if token is invalid", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		es := NewExportService(nil, ts, nil, nil, nil, nil, nil, nil)
+
+		ts.On("ParseExportToken", ctx, "bad").Return(nil, models.ErrInvalidExportToken)
+
+		path, err := es.GetExportFile(ctx, "bad")
+
+		assert.Empty(t, path)
+		assert.ErrorIs(t, err, models.ErrInvalidExportToken)
+	})
+
+	t.Run("should return ErrExportExpired if export has expired", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		er := new(mocks.ExportRepositoryMock)
+		es := NewExportService(nil, ts, er, nil, nil, nil, nil, nil)
+
+		claims := &models.ExportTokenClaims{UserID: "user-1"}
+		claims.Subject = "export-1"
+		ts.On("ParseExportToken", ctx, "token").Return(claims, nil)
+		er.On("GetExportByID", ctx, "export-1").Return(&models.Export{
+			ID:        "export-1",
+			UserID:    "user-1",
+			Status:    models.ExportStatusCompleted,
+			FilePath:  sql.NullString{String: "exports/export-1.zip", Valid: true},
+			ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		}, nil)
+
+		path, err := es.GetExportFile(ctx, "token")
+
+		assert.Empty(t, path)
+		assert.ErrorIs(t, err, models.ErrExportExpired)
+	})
+
+	t.Run("should return file path for a completed export", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		er := new(mocks.ExportRepositoryMock)
+		es := NewExportService(nil, ts, er, nil, nil, nil, nil, nil)
+
+		claims := &models.ExportTokenClaims{UserID: "user-1"}
+		claims.Subject = "export-1"
+		ts.On("ParseExportToken", ctx, "token").Return(claims, nil)
+		er.On("GetExportByID", ctx, "export-1").Return(&models.Export{
+			ID:        "export-1",
+			UserID:    "user-1",
+			Status:    models.ExportStatusCompleted,
+			FilePath:  sql.NullString{String: "exports/export-1.zip", Valid: true},
+			ExpiresAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		}, nil)
+
+		path, err := es.GetExportFile(ctx, "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "exports/export-1.zip", path)
+	})
+}
@@ -0,0 +1,609 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/clients"
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+	"github.com/google/uuid"
+)
+
+// FederationService exposes local users as ActivityPub actors and applies
+// the activities remote servers deliver to their inboxes.
+type FederationService interface {
+	GetWebFinger(ctx context.Context, resource string) (*models.WebFinger, error)
+	GetActor(ctx context.Context, username string) (*models.Actor, error)
+	GetOutbox(ctx context.Context, username string) (*models.OrderedCollection, error)
+	GetNote(ctx context.Context, postID string) (*models.Note, error)
+	HandleInbox(ctx context.Context, username string, r *http.Request, body []byte) error
+	PublishPost(ctx context.Context, post *models.Post) error
+}
+
+type federationService struct {
+	ds  DeliveryService
+	akr repositories.ActorKeyRepository
+	pr  repositories.PostRepository
+	rar repositories.RemoteActorRepository
+	ur  repositories.UserRepository
+	ac  clients.ActivityPubClient
+	hs  pkgs.HTTPSigner
+	mr  pkgs.MarkdownRenderer
+}
+
+func NewFederationService(
+	deliveryService DeliveryService,
+	actorKeyRepository repositories.ActorKeyRepository,
+	postRepository repositories.PostRepository,
+	remoteActorRepository repositories.RemoteActorRepository,
+	userRepository repositories.UserRepository,
+	activityPubClient clients.ActivityPubClient,
+	httpSigner pkgs.HTTPSigner,
+	markdownRenderer pkgs.MarkdownRenderer) FederationService {
+	return &federationService{
+		ds:  deliveryService,
+		akr: actorKeyRepository,
+		pr:  postRepository,
+		rar: remoteActorRepository,
+		ur:  userRepository,
+		ac:  activityPubClient,
+		hs:  httpSigner,
+		mr:  markdownRenderer,
+	}
+}
+
+// GetWebFinger resolves acct:username@host, where host is the API host, or
+// the actor URI itself.
+func (f *federationService) GetWebFinger(ctx context.Context, resource string) (*models.WebFinger, error) {
+	username, ok := strings.CutPrefix(resource, actorURI(""))
+	if !ok {
+		acct, found := strings.CutPrefix(resource, "acct:")
+		if !found {
+			return nil, models.ErrUserNotFound
+		}
+
+		var host string
+		username, host, found = strings.Cut(acct, "@")
+		if !found || !strings.EqualFold(host, federationHost()) {
+			return nil, models.ErrUserNotFound
+		}
+	}
+
+	user, err := f.ur.GetUserByUsername(ctx, strings.ToLower(username))
+	if err != nil {
+		return nil, fmt.Errorf("get user by username: %w", err)
+	}
+
+	if hiddenUser(user) {
+		return nil, models.ErrUserNotFound
+	}
+
+	return &models.WebFinger{
+		Subject: fmt.Sprintf("acct:%s@%s", user.Username, federationHost()),
+		Aliases: []string{actorURI(user.Username), profileURL(user.Username)},
+		Links: []models.WebFingerLink{
+			{Rel: "self", Type: models.ActivityContentType, Href: actorURI(user.Username)},
+			{Rel: "http://webfinger.net/rel/profile-page", Type: "text/html", Href: profileURL(user.Username)},
+		},
+	}, nil
+}
+
+func (f *federationService) GetActor(ctx context.Context, username string) (*models.Actor, error) {
+	user, err := f.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := f.actorKey(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := actorURI(user.Username)
+	return &models.Actor{
+		Context:           []string{models.ActivityStreamsContext, models.SecurityContext},
+		ID:                id,
+		Type:              "Person",
+		PreferredUsername: user.Username,
+		Name:              user.Name,
+		URL:               profileURL(user.Username),
+		Inbox:             id + "/inbox",
+		Outbox:            id + "/outbox",
+		PublicKey: models.ActorPublicKey{
+			ID:           keyID(user.Username),
+			Owner:        id,
+			PublicKeyPem: key.PublicKeyPEM,
+		},
+	}, nil
+}
+
+func (f *federationService) GetOutbox(ctx context.Context, username string) (*models.OrderedCollection, error) {
+	user, err := f.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := f.pr.GetPostsByAuthorID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get posts by author id %s: %w", user.ID, err)
+	}
+
+	posts = slices.DeleteFunc(posts, func(post *models.Post) bool {
+		return post.Visibility != models.PostVisibilityPublic
+	})
+
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].CreatedAt.After(posts[j].CreatedAt)
+	})
+
+	items := make([]any, len(posts))
+	for i, post := range posts {
+		note, err := f.toNote(user, post)
+		if err != nil {
+			return nil, err
+		}
+
+		create, err := createActivity(note)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = create
+	}
+
+	return &models.OrderedCollection{
+		Context:      models.ActivityStreamsContext,
+		ID:           actorURI(user.Username) + "/outbox",
+		Type:         "OrderedCollection",
+		TotalItems:   len(items),
+		OrderedItems: items,
+	}, nil
+}
+
+func (f *federationService) GetNote(ctx context.Context, postID string) (*models.Note, error) {
+	post, err := f.pr.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id: %w", err)
+	}
+
+	if post == nil || post.Visibility != models.PostVisibilityPublic {
+		return nil, models.ErrPostNotFound
+	}
+
+	author, err := f.ur.GetUserByID(ctx, post.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if author == nil {
+		return nil, models.ErrPostNotFound
+	}
+
+	note, err := f.toNote(author, post)
+	if err != nil {
+		return nil, err
+	}
+
+	note.Context = models.ActivityStreamsContext
+	return note, nil
+}
+
+// HandleInbox verifies the HTTP signature of a delivery to username's inbox
+// and applies it. Follow, Like and their Undo are supported; other activity
+// types are accepted and ignored.
+func (f *federationService) HandleInbox(ctx context.Context, username string, r *http.Request, body []byte) error {
+	user, err := f.getUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	var activity models.Activity
+	if err := json.Unmarshal(body, &activity); err != nil || activity.Actor == "" || activity.Type == "" {
+		return models.ErrInvalidActivity
+	}
+
+	remote, err := f.verifySignature(ctx, r, body, activity.Actor)
+	if err != nil {
+		return err
+	}
+
+	switch activity.Type {
+	case "Follow":
+		return f.handleFollow(ctx, user, remote, &activity, body)
+	case "Like":
+		return f.handleLike(ctx, remote, &activity)
+	case "Undo":
+		return f.handleUndo(ctx, user, remote, &activity)
+	default:
+		return nil
+	}
+}
+
+// PublishPost delivers a Create activity for a public post to every remote
+// follower of its author, once per shared inbox.
+func (f *federationService) PublishPost(ctx context.Context, post *models.Post) error {
+	if post.Visibility == models.PostVisibilityPrivate {
+		return nil
+	}
+
+	followers, err := f.rar.GetFollowers(ctx, post.AuthorID)
+	if err != nil {
+		return fmt.Errorf("get remote followers: %w", err)
+	}
+
+	if len(followers) == 0 {
+		return nil
+	}
+
+	author, err := f.ur.GetUserByID(ctx, post.AuthorID)
+	if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if author == nil {
+		return models.ErrUserNotFound
+	}
+
+	note, err := f.toNote(author, post)
+	if err != nil {
+		return err
+	}
+
+	create, err := createActivity(note)
+	if err != nil {
+		return err
+	}
+	create.Context = models.ActivityStreamsContext
+
+	payload, err := json.Marshal(create)
+	if err != nil {
+		return fmt.Errorf("marshal create activity: %w", err)
+	}
+
+	inboxes := make(map[string]bool, len(followers))
+	for _, follower := range followers {
+		inbox := follower.Inbox
+		if follower.SharedInbox.Valid && follower.SharedInbox.String != "" {
+			inbox = follower.SharedInbox.String
+		}
+		inboxes[inbox] = true
+	}
+
+	for inbox := range inboxes {
+		if err := f.deliver(ctx, author, inbox, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *federationService) handleFollow(ctx context.Context, user *models.User, remote *models.RemoteActor, activity *models.Activity, body []byte) error {
+	if objectID(activity.Object) != actorURI(user.Username) {
+		return models.ErrInvalidActivity
+	}
+
+	if err := f.rar.AddFollower(ctx, user.ID, remote.ID, activity.ID); err != nil {
+		return fmt.Errorf("add remote follower: %w", err)
+	}
+
+	accept := models.Activity{
+		Context: models.ActivityStreamsContext,
+		ID:      actorURI(user.Username) + "#accepts/" + uuid.NewString(),
+		Type:    "Accept",
+		Actor:   actorURI(user.Username),
+		Object:  json.RawMessage(body),
+	}
+
+	payload, err := json.Marshal(accept)
+	if err != nil {
+		return fmt.Errorf("marshal accept activity: %w", err)
+	}
+
+	return f.deliver(ctx, user, remote.Inbox, payload)
+}
+
+func (f *federationService) handleLike(ctx context.Context, remote *models.RemoteActor, activity *models.Activity) error {
+	post, err := f.localPost(ctx, objectID(activity.Object))
+	if err != nil || post == nil {
+		return err
+	}
+
+	if err := f.rar.AddLike(ctx, post.ID, remote.ID, activity.ID); err != nil {
+		return fmt.Errorf("add remote like: %w", err)
+	}
+
+	return nil
+}
+
+// handleUndo reverts a Follow or Like. The undone activity must be embedded
+// and belong to the same actor; bare references are ignored.
+func (f *federationService) handleUndo(ctx context.Context, user *models.User, remote *models.RemoteActor, activity *models.Activity) error {
+	var undone models.Activity
+	if err := json.Unmarshal(activity.Object, &undone); err != nil || undone.Type == "" {
+		return nil
+	}
+
+	if undone.Actor != "" && undone.Actor != remote.ActorURI {
+		return models.ErrInvalidActivity
+	}
+
+	switch undone.Type {
+	case "Follow":
+		if err := f.rar.RemoveFollower(ctx, user.ID, remote.ID); err != nil {
+			return fmt.Errorf("remove remote follower: %w", err)
+		}
+	case "Like":
+		post, err := f.localPost(ctx, objectID(undone.Object))
+		if err != nil || post == nil {
+			return err
+		}
+
+		if err := f.rar.RemoveLike(ctx, post.ID, remote.ID); err != nil {
+			return fmt.Errorf("remove remote like: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// verifySignature checks the request was signed by actorURI. The actor is
+// refetched once when the cached key does not verify, to follow rotations.
+func (f *federationService) verifySignature(ctx context.Context, r *http.Request, body []byte, actorURI string) (*models.RemoteActor, error) {
+	signatureKeyID, err := f.hs.KeyID(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidSignature, err)
+	}
+
+	if err := checkKeyHost(actorURI, signatureKeyID); err != nil {
+		return nil, err
+	}
+
+	remote, err := f.rar.GetRemoteActorByURI(ctx, actorURI)
+	if err != nil {
+		return nil, fmt.Errorf("get remote actor: %w", err)
+	}
+
+	if remote != nil && remote.KeyID == signatureKeyID && f.hs.Verify(r, remote.PublicKeyPEM, body) == nil {
+		return remote, nil
+	}
+
+	remote, err = f.fetchRemoteActor(ctx, actorURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if remote.KeyID != signatureKeyID {
+		return nil, fmt.Errorf("%w: key %s does not belong to %s", models.ErrInvalidSignature, signatureKeyID, actorURI)
+	}
+
+	if err := f.hs.Verify(r, remote.PublicKeyPEM, body); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidSignature, err)
+	}
+
+	return remote, nil
+}
+
+// checkKeyHost runs before the actor is fetched. Both URIs come from the
+// sender, so the actor must be safe to fetch and serve the key itself.
+func checkKeyHost(actorURI, signatureKeyID string) error {
+	if err := clients.CheckRemoteURL(actorURI); err != nil {
+		return fmt.Errorf("%w: %v", models.ErrInvalidSignature, err)
+	}
+
+	actor, _ := url.Parse(actorURI)
+	key, err := url.Parse(signatureKeyID)
+	if err != nil || !strings.EqualFold(key.Host, actor.Host) {
+		return fmt.Errorf("%w: key %s is not hosted by %s", models.ErrInvalidSignature, signatureKeyID, actor.Host)
+	}
+
+	return nil
+}
+
+func (f *federationService) fetchRemoteActor(ctx context.Context, uri string) (*models.RemoteActor, error) {
+	actor, err := f.ac.FetchActor(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("fetch remote actor: %w", err)
+	}
+
+	if actor.ID != uri || actor.Inbox == "" || actor.PublicKey.Owner != actor.ID || actor.PublicKey.PublicKeyPem == "" {
+		return nil, fmt.Errorf("%w: malformed actor %s", models.ErrInvalidSignature, uri)
+	}
+
+	remote := &models.RemoteActor{
+		ActorURI:          actor.ID,
+		Inbox:             actor.Inbox,
+		KeyID:             actor.PublicKey.ID,
+		PublicKeyPEM:      actor.PublicKey.PublicKeyPem,
+		PreferredUsername: actor.PreferredUsername,
+	}
+
+	if actor.Endpoints != nil && actor.Endpoints.SharedInbox != "" {
+		remote.SharedInbox.String = actor.Endpoints.SharedInbox
+		remote.SharedInbox.Valid = true
+	}
+
+	if err := f.rar.UpsertRemoteActor(ctx, remote); err != nil {
+		return nil, fmt.Errorf("upsert remote actor: %w", err)
+	}
+
+	return remote, nil
+}
+
+func (f *federationService) deliver(ctx context.Context, sender *models.User, inbox string, payload []byte) error {
+	key, err := f.actorKey(ctx, sender.ID)
+	if err != nil {
+		return err
+	}
+
+	f.ds.Enqueue(&models.Delivery{
+		Inbox:         inbox,
+		KeyID:         keyID(sender.Username),
+		PrivateKeyPEM: key.PrivateKeyPEM,
+		Payload:       payload,
+	})
+
+	return nil
+}
+
+// actorKey returns the user's signing key, generating it on first use.
+func (f *federationService) actorKey(ctx context.Context, userID string) (*models.ActorKey, error) {
+	key, err := f.akr.GetActorKeyByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get actor key: %w", err)
+	}
+
+	if key != nil {
+		return key, nil
+	}
+
+	publicKeyPEM, privateKeyPEM, err := f.hs.GenerateKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("generate actor key: %w", err)
+	}
+
+	if err := f.akr.CreateActorKey(ctx, &models.ActorKey{
+		UserID:        userID,
+		PublicKeyPEM:  publicKeyPEM,
+		PrivateKeyPEM: privateKeyPEM,
+	}); err != nil {
+		return nil, fmt.Errorf("create actor key: %w", err)
+	}
+
+	key, err = f.akr.GetActorKeyByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get actor key: %w", err)
+	}
+
+	if key == nil {
+		return nil, errors.New("actor key not found after creation")
+	}
+
+	return key, nil
+}
+
+func (f *federationService) getUser(ctx context.Context, username string) (*models.User, error) {
+	user, err := f.ur.GetUserByUsername(ctx, strings.ToLower(username))
+	if err != nil {
+		return nil, fmt.Errorf("get user by username: %w", err)
+	}
+
+	if hiddenUser(user) {
+		return nil, models.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// localPost returns the public post a note URI of ours points to, or nil
+// when the URI is foreign or the post is gone or private.
+func (f *federationService) localPost(ctx context.Context, uri string) (*models.Post, error) {
+	postID, ok := strings.CutPrefix(uri, noteURI(""))
+	if !ok || postID == "" {
+		return nil, nil
+	}
+
+	post, err := f.pr.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id: %w", err)
+	}
+
+	if post == nil || post.Visibility != models.PostVisibilityPublic {
+		return nil, nil
+	}
+
+	return post, nil
+}
+
+func (f *federationService) toNote(author *models.User, post *models.Post) (*models.Note, error) {
+	contentHTML, err := renderContent(f.mr, post.Content, post.ContentHTML)
+	if err != nil {
+		return nil, fmt.Errorf("render content %s: %w", post.ID, err)
+	}
+
+	note := &models.Note{
+		ID:           noteURI(post.ID),
+		Type:         "Note",
+		AttributedTo: actorURI(author.Username),
+		Name:         post.Title,
+		Content:      "<p><strong>" + html.EscapeString(post.Title) + "</strong></p>" + contentHTML,
+		URL:          profileURL(author.Username) + "#" + post.ID,
+		Published:    post.CreatedAt.UTC().Format(time.RFC3339),
+		To:           []string{models.PublicAudience},
+	}
+
+	if post.UpdatedAt.Valid {
+		note.Updated = post.UpdatedAt.Time.UTC().Format(time.RFC3339)
+	}
+
+	return note, nil
+}
+
+func createActivity(note *models.Note) (*models.Activity, error) {
+	object, err := json.Marshal(note)
+	if err != nil {
+		return nil, fmt.Errorf("marshal note: %w", err)
+	}
+
+	return &models.Activity{
+		ID:        note.ID + "#create",
+		Type:      "Create",
+		Actor:     note.AttributedTo,
+		Object:    object,
+		To:        note.To,
+		Published: note.Published,
+	}, nil
+}
+
+// objectID reads the id of an activity object, which may be a bare URI or
+// an embedded object.
+func objectID(object json.RawMessage) string {
+	var id string
+	if err := json.Unmarshal(object, &id); err == nil {
+		return id
+	}
+
+	var embedded struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(object, &embedded); err == nil {
+		return embedded.ID
+	}
+
+	return ""
+}
+
+func federationHost() string {
+	u, err := url.Parse(configs.Env.APIURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func actorURI(username string) string {
+	return configs.Env.APIURL + "/ap/users/" + username
+}
+
+func keyID(username string) string {
+	return actorURI(username) + "#main-key"
+}
+
+func noteURI(postID string) string {
+	return configs.Env.APIURL + "/ap/posts/" + postID
+}
+
+func profileURL(username string) string {
+	return strings.TrimSuffix(configs.Env.RedirectURL, "/") + "/" + username
+}
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/clients"
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemoteURL is where the fixture client sends every request to the fake
+// remote, since the real client refuses the loopback address it listens on.
+const fakeRemoteURL = "https://remote.test"
+
+// fakeRemote is an in-process fediverse server with a single actor, alice,
+// whose inbox records every delivery it receives.
+type fakeRemote struct {
+	server        *httptest.Server
+	actor         *models.Actor
+	privateKeyPEM string
+
+	mu         sync.Mutex
+	requests   int
+	deliveries []*http.Request
+	bodies     [][]byte
+}
+
+func newFakeRemote(t *testing.T, f *federationFixture) *fakeRemote {
+	publicKeyPEM, privateKeyPEM, err := f.signer.GenerateKeyPair()
+	require.NoError(t, err)
+
+	remote := &fakeRemote{privateKeyPEM: privateKeyPEM}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/alice", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", models.ActivityContentType)
+		_ = json.NewEncoder(w).Encode(remote.actor)
+	})
+	mux.HandleFunc("POST /inbox", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		remote.mu.Lock()
+		remote.deliveries = append(remote.deliveries, r)
+		remote.bodies = append(remote.bodies, body)
+		remote.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	remote.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote.mu.Lock()
+		remote.requests++
+		remote.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(remote.server.Close)
+	f.remoteAddr = remote.server.Listener.Addr().String()
+
+	actorURI := fakeRemoteURL + "/users/alice"
+	remote.actor = &models.Actor{
+		ID:                actorURI,
+		Type:              "Person",
+		PreferredUsername: "alice",
+		Inbox:             fakeRemoteURL + "/inbox",
+		PublicKey: models.ActorPublicKey{
+			ID:           actorURI + "#main-key",
+			Owner:        actorURI,
+			PublicKeyPem: publicKeyPEM,
+		},
+	}
+
+	return remote
+}
+
+func (f *fakeRemote) signedRequest(t *testing.T, signer pkgs.HTTPSigner, activity map[string]any) (*http.Request, []byte) {
+	body, err := json.Marshal(activity)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "http://api.test/ap/users/bob/inbox", bytes.NewReader(body))
+	require.NoError(t, signer.Sign(req, f.actor.PublicKey.ID, f.privateKeyPEM, body))
+
+	return req, body
+}
+
+func (f *fakeRemote) remoteActor() *models.RemoteActor {
+	return &models.RemoteActor{
+		ID:           "remote-1",
+		ActorURI:     f.actor.ID,
+		Inbox:        f.actor.Inbox,
+		KeyID:        f.actor.PublicKey.ID,
+		PublicKeyPEM: f.actor.PublicKey.PublicKeyPem,
+	}
+}
+
+func cachedHTML(html string) sql.NullString {
+	return sql.NullString{String: html, Valid: true}
+}
+
+type federationFixture struct {
+	fs     FederationService
+	signer pkgs.HTTPSigner
+	akr    *mocks.ActorKeyRepositoryMock
+	pr     *mocks.PostRepositoryMock
+	rar    *mocks.RemoteActorRepositoryMock
+	ur     *mocks.UserRepositoryMock
+	key    *models.ActorKey
+
+	remoteAddr string
+}
+
+// newFederationFixture wires the real signer, client and delivery queue,
+// with retries run synchronously, against mocked repositories. The client
+// connects to the fake remote whatever host a URL names.
+func newFederationFixture(t *testing.T) *federationFixture {
+	configs.Env.APIURL = "http://api.test"
+	configs.Env.RedirectURL = "http://app.test/"
+
+	signer := pkgs.NewHTTPSigner()
+
+	publicKeyPEM, privateKeyPEM, err := signer.GenerateKeyPair()
+	require.NoError(t, err)
+
+	f := &federationFixture{
+		signer: signer,
+		akr:    new(mocks.ActorKeyRepositoryMock),
+		pr:     new(mocks.PostRepositoryMock),
+		rar:    new(mocks.RemoteActorRepositoryMock),
+		ur:     new(mocks.UserRepositoryMock),
+		key:    &models.ActorKey{UserID: "user-1", PublicKeyPEM: publicKeyPEM, PrivateKeyPEM: privateKeyPEM},
+	}
+
+	client := clients.NewActivityPubClientWithHTTPClient(signer, &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, f.remoteAddr)
+		},
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}})
+	ds := NewDeliveryService(client)
+	ds.(*deliveryService).schedule = func(_ time.Duration, task func()) { task() }
+
+	f.fs = NewFederationService(ds, f.akr, f.pr, f.rar, f.ur, client, signer, new(mocks.MarkdownRendererMock))
+
+	return f
+}
+
+func TestFederationService_GetWebFinger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should resolve acct resource on the api host", func(t *testing.T) {
+		f := newFederationFixture(t)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(&models.User{ID: "user-1", Username: "bob"}, nil)
+
+		webFinger, err := f.fs.GetWebFinger(ctx, "acct:Bob@api.test")
+
+		require.NoError(t, err)
+		assert.Equal(t, "acct:bob@api.test", webFinger.Subject)
+		assert.Equal(t, "http://api.test/ap/users/bob", webFinger.Links[0].Href)
+		assert.Equal(t, models.ActivityContentType, webFinger.Links[0].Type)
+	})
+
+	t.Run("should return ErrUserNotFound for another host", func(t *testing.T) {
+		f := newFederationFixture(t)
+
+		_, err := f.fs.GetWebFinger(ctx, "acct:bob@elsewhere.test")
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		f.ur.AssertNotCalled(t, "GetUserByUsername", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFederationService_GetActor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should generate the actor key on first use", func(t *testing.T) {
+		f := newFederationFixture(t)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(&models.User{ID: "user-1", Username: "bob", Name: "Bob"}, nil)
+		f.akr.On("GetActorKeyByUserID", ctx, "user-1").Return(nil, nil).Once()
+		f.akr.On("CreateActorKey", ctx, mock.AnythingOfType("*models.ActorKey")).Return(nil)
+		f.akr.On("GetActorKeyByUserID", ctx, "user-1").Return(f.key, nil).Once()
+
+		actor, err := f.fs.GetActor(ctx, "bob")
+
+		require.NoError(t, err)
+		assert.Equal(t, "http://api.test/ap/users/bob", actor.ID)
+		assert.Equal(t, "http://api.test/ap/users/bob/inbox", actor.Inbox)
+		assert.Equal(t, "http://api.test/ap/users/bob#main-key", actor.PublicKey.ID)
+		assert.Equal(t, f.key.PublicKeyPEM, actor.PublicKey.PublicKeyPem)
+		f.akr.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrUserNotFound for unknown user", func(t *testing.T) {
+		f := newFederationFixture(t)
+		f.ur.On("GetUserByUsername", ctx, "ghost").Return(nil, nil)
+
+		_, err := f.fs.GetActor(ctx, "ghost")
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
+}
+
+func TestFederationService_GetOutbox(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should list create activities newest first", func(t *testing.T) {
+		f := newFederationFixture(t)
+		older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(&models.User{ID: "user-1", Username: "bob"}, nil)
+		f.pr.On("GetPostsByAuthorID", ctx, "user-1").Return([]*models.Post{
+			{ID: "post-1", Title: "Antigo", Visibility: models.PostVisibilityPublic, ContentHTML: cachedHTML("<p>a</p>"), CreatedAt: older},
+			{ID: "post-2", Title: "Novo", Visibility: models.PostVisibilityPublic, ContentHTML: cachedHTML("<p>b</p>"), CreatedAt: older.Add(time.Hour)},
+		}, nil)
+
+		outbox, err := f.fs.GetOutbox(ctx, "bob")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, outbox.TotalItems)
+		first := outbox.OrderedItems[0].(*models.Activity)
+		assert.Equal(t, "Create", first.Type)
+		assert.Equal(t, "http://api.test/ap/posts/post-2#create", first.ID)
+	})
+}
+
+func TestFederationService_HandleInbox(t *testing.T) {
+	ctx := context.Background()
+	bob := &models.User{ID: "user-1", Username: "bob"}
+
+	t.Run("should store follower and deliver a signed Accept", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+		f.rar.On("GetRemoteActorByURI", ctx, remote.actor.ID).Return(nil, nil)
+		f.rar.On("UpsertRemoteActor", ctx, mock.AnythingOfType("*models.RemoteActor")).
+			Run(func(args mock.Arguments) { args.Get(1).(*models.RemoteActor).ID = "remote-1" }).
+			Return(nil)
+		f.rar.On("AddFollower", ctx, "user-1", "remote-1", "https://remote.test/follows/1").Return(nil)
+		f.akr.On("GetActorKeyByUserID", ctx, "user-1").Return(f.key, nil)
+
+		req, body := remote.signedRequest(t, f.signer, map[string]any{
+			"id":     "https://remote.test/follows/1",
+			"type":   "Follow",
+			"actor":  remote.actor.ID,
+			"object": "http://api.test/ap/users/bob",
+		})
+
+		err := f.fs.HandleInbox(ctx, "bob", req, body)
+
+		require.NoError(t, err)
+		f.rar.AssertExpectations(t)
+		require.Len(t, remote.deliveries, 1)
+
+		var accept models.Activity
+		require.NoError(t, json.Unmarshal(remote.bodies[0], &accept))
+		assert.Equal(t, "Accept", accept.Type)
+		assert.Equal(t, "http://api.test/ap/users/bob", accept.Actor)
+		assert.Equal(t, "https://remote.test/follows/1", objectID(accept.Object))
+
+		delivered := remote.deliveries[0]
+		keyID, err := f.signer.KeyID(delivered)
+		require.NoError(t, err)
+		assert.Equal(t, "http://api.test/ap/users/bob#main-key", keyID)
+		assert.NoError(t, f.signer.Verify(delivered, f.key.PublicKeyPEM, remote.bodies[0]))
+	})
+
+	t.Run("should reject signature that does not match the actor key", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+		f.rar.On("GetRemoteActorByURI", ctx, remote.actor.ID).Return(remote.remoteActor(), nil)
+		f.rar.On("UpsertRemoteActor", ctx, mock.AnythingOfType("*models.RemoteActor")).Return(nil)
+
+		_, otherPrivateKeyPEM, err := f.signer.GenerateKeyPair()
+		require.NoError(t, err)
+		remote.privateKeyPEM = otherPrivateKeyPEM
+
+		req, body := remote.signedRequest(t, f.signer, map[string]any{
+			"id":     "https://remote.test/follows/1",
+			"type":   "Follow",
+			"actor":  remote.actor.ID,
+			"object": "http://api.test/ap/users/bob",
+		})
+
+		err = f.fs.HandleInbox(ctx, "bob", req, body)
+
+		assert.ErrorIs(t, err, models.ErrInvalidSignature)
+		f.rar.AssertNotCalled(t, "AddFollower", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject activity signed for another actor", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+		f.rar.On("GetRemoteActorByURI", ctx, fakeRemoteURL+"/users/mallory").Return(nil, nil)
+
+		req, body := remote.signedRequest(t, f.signer, map[string]any{
+			"id":     "https://remote.test/follows/1",
+			"type":   "Follow",
+			"actor":  fakeRemoteURL + "/users/mallory",
+			"object": "http://api.test/ap/users/bob",
+		})
+
+		err := f.fs.HandleInbox(ctx, "bob", req, body)
+
+		assert.Error(t, err)
+		f.rar.AssertNotCalled(t, "AddFollower", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an internal actor without fetching it", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+
+		for _, actorURI := range []string{
+			"http://remote.test/users/alice",
+			"https://169.254.169.254/latest/meta-data",
+			"https://10.0.0.1/users/alice",
+			"https://[::1]/users/alice",
+			"https://localhost/users/alice",
+		} {
+			remote.actor.PublicKey.ID = actorURI + "#main-key"
+			req, body := remote.signedRequest(t, f.signer, map[string]any{
+				"id":     "https://remote.test/follows/1",
+				"type":   "Follow",
+				"actor":  actorURI,
+				"object": "http://api.test/ap/users/bob",
+			})
+
+			err := f.fs.HandleInbox(ctx, "bob", req, body)
+
+			assert.ErrorIs(t, err, models.ErrInvalidSignature, actorURI)
+		}
+
+		assert.Zero(t, remote.requests)
+		f.rar.AssertNotCalled(t, "GetRemoteActorByURI", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a key hosted outside the actor's host", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+
+		remote.actor.PublicKey.ID = "https://evil.test/users/alice#main-key"
+		req, body := remote.signedRequest(t, f.signer, map[string]any{
+			"id":     "https://remote.test/follows/1",
+			"type":   "Follow",
+			"actor":  remote.actor.ID,
+			"object": "http://api.test/ap/users/bob",
+		})
+
+		err := f.fs.HandleInbox(ctx, "bob", req, body)
+
+		assert.ErrorIs(t, err, models.ErrInvalidSignature)
+		assert.Zero(t, remote.requests)
+		f.rar.AssertNotCalled(t, "GetRemoteActorByURI", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should count Like on a local note and remove it on Undo", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+		f.rar.On("GetRemoteActorByURI", ctx, remote.actor.ID).Return(remote.remoteActor(), nil)
+		f.pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1", Visibility: models.PostVisibilityPublic}, nil)
+		f.rar.On("AddLike", ctx, "post-1", "remote-1", "https://remote.test/likes/1").Return(nil)
+		f.rar.On("RemoveLike", ctx, "post-1", "remote-1").Return(nil)
+
+		like := map[string]any{
+			"id":     "https://remote.test/likes/1",
+			"type":   "Like",
+			"actor":  remote.actor.ID,
+			"object": "http://api.test/ap/posts/post-1",
+		}
+
+		req, body := remote.signedRequest(t, f.signer, like)
+		require.NoError(t, f.fs.HandleInbox(ctx, "bob", req, body))
+
+		req, body = remote.signedRequest(t, f.signer, map[string]any{
+			"id":     "https://remote.test/undos/1",
+			"type":   "Undo",
+			"actor":  remote.actor.ID,
+			"object": like,
+		})
+		require.NoError(t, f.fs.HandleInbox(ctx, "bob", req, body))
+
+		f.rar.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrInvalidActivity for malformed body", func(t *testing.T) {
+		f := newFederationFixture(t)
+		f.ur.On("GetUserByUsername", ctx, "bob").Return(bob, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "http://api.test/ap/users/bob/inbox", nil)
+
+		err := f.fs.HandleInbox(ctx, "bob", req, []byte(`{"type":"Follow"}`))
+
+		assert.ErrorIs(t, err, models.ErrInvalidActivity)
+	})
+}
+
+func TestFederationService_PublishPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should deliver once per shared inbox", func(t *testing.T) {
+		f := newFederationFixture(t)
+		remote := newFakeRemote(t, f)
+
+		shared := remote.remoteActor()
+		shared.SharedInbox.String = remote.actor.Inbox
+		shared.SharedInbox.Valid = true
+		sibling := *shared
+		sibling.ID = "remote-2"
+		sibling.Inbox = fakeRemoteURL + "/users/carol/inbox"
+
+		post := &models.Post{ID: "post-1", AuthorID: "user-1", Title: "Olá", ContentHTML: cachedHTML("<p>oi</p>")}
+		f.rar.On("GetFollowers", ctx, "user-1").Return([]*models.RemoteActor{shared, &sibling}, nil)
+		f.ur.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1", Username: "bob"}, nil)
+		f.akr.On("GetActorKeyByUserID", ctx, "user-1").Return(f.key, nil)
+
+		err := f.fs.PublishPost(ctx, post)
+
+		require.NoError(t, err)
+		require.Len(t, remote.bodies, 1)
+
+		var create models.Activity
+		require.NoError(t, json.Unmarshal(remote.bodies[0], &create))
+		assert.Equal(t, "Create", create.Type)
+		assert.Equal(t, "http://api.test/ap/posts/post-1", objectID(create.Object))
+	})
+
+	t.Run("should skip posts without remote followers", func(t *testing.T) {
+		f := newFederationFixture(t)
+		f.rar.On("GetFollowers", ctx, "user-1").Return([]*models.RemoteActor{}, nil)
+
+		err := f.fs.PublishPost(ctx, &models.Post{ID: "post-1", AuthorID: "user-1"})
+
+		assert.NoError(t, err)
+		f.ur.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+}
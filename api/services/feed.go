@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/g-villarinho/tab-notes-api/repositories"
 )
 
@@ -13,15 +14,21 @@ type FeedService interface {
 
 type feedService struct {
 	ls LikeService
+	ps PollService
 	fr repositories.FeedRepository
+	mr pkgs.MarkdownRenderer
 }
 
 func NewFeedService(
 	likeService LikeService,
-	feedRepository repositories.FeedRepository) FeedService {
+	pollService PollService,
+	feedRepository repositories.FeedRepository,
+	markdownRenderer pkgs.MarkdownRenderer) FeedService {
 	return &feedService{
 		ls: likeService,
+		ps: pollService,
 		fr: feedRepository,
+		mr: markdownRenderer,
 	}
 }
 
@@ -45,8 +52,22 @@ func (f *feedService) GetFeed(ctx context.Context, userID string, limit int, off
 		return nil, err
 	}
 
+	pollsMap, err := f.ps.GetPolls(ctx, userID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, post := range feed {
 		post.LikedByUser = likedMap[post.PostID]
+		post.Poll = pollsMap[post.PostID]
+
+		if post.ContentHTML == "" && post.Content != "" {
+			contentHTML, err := f.mr.Render(post.Content)
+			if err != nil {
+				return nil, err
+			}
+			post.ContentHTML = contentHTML
+		}
 	}
 
 	return feed, nil
@@ -37,7 +37,7 @@ func (f *followerService) FollowUser(ctx context.Context, followerID string, use
 		return fmt.Errorf("get user by username: %w", err)
 	}
 
-	if user == nil {
+	if hiddenUser(user) {
 		return models.ErrUserNotFound
 	}
 
@@ -64,7 +64,7 @@ func (f *followerService) UnfollowUser(ctx context.Context, followerID string, u
 		return fmt.Errorf("get user by username: %w", err)
 	}
 
-	if user == nil {
+	if hiddenUser(user) {
 		return models.ErrUserNotFound
 	}
 
@@ -85,7 +85,7 @@ func (f *followerService) GetFollowers(ctx context.Context, username string) ([]
 		return nil, fmt.Errorf("get user by username: %w", err)
 	}
 
-	if user == nil {
+	if hiddenUser(user) {
 		return nil, models.ErrUserNotFound
 	}
 
@@ -125,7 +125,7 @@ func (f *followerService) GetFollowing(ctx context.Context, username string) ([]
 		return nil, fmt.Errorf("get user by username: %w", err)
 	}
 
-	if user == nil {
+	if hiddenUser(user) {
 		return nil, models.ErrUserNotFound
 	}
 
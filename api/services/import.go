@@ -0,0 +1,321 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maxImportFiles    = 1000
+	maxImportFileSize = 64 << 10
+
+	// An import still pending after importRunTimeout died with the process
+	// that ran it and is reported as failed.
+	importRunTimeout = time.Hour
+
+	importFileFailedMessage       = "Não foi possível importar o arquivo."
+	importTagsIgnoredMessage      = "Tags não são suportadas e foram ignoradas."
+	importInvalidVisibilityFormat = "Visibilidade inválida: %q. Use public ou private."
+)
+
+// importFileError is a reason to reject a file that is safe to show to the
+// user. Any other error is logged and reported with a generic message.
+type importFileError string
+
+func (e importFileError) Error() string {
+	return string(e)
+}
+
+type ImportService interface {
+	RequestImport(ctx context.Context, userID string, archive []byte) (*models.ImportResponse, error)
+	GetImport(ctx context.Context, userID string, importID string) (*models.ImportResponse, error)
+}
+
+type importService struct {
+	ps       PostService
+	ir       repositories.ImportRepository
+	dispatch func(task func())
+}
+
+func NewImportService(postService PostService, importRepository repositories.ImportRepository) ImportService {
+	return &importService{
+		ps: postService,
+		ir: importRepository,
+		dispatch: func(task func()) {
+			go task()
+		},
+	}
+}
+
+// importFrontMatter lists the supported front matter keys. Notes without a
+// visibility are imported as public. Tags are read in any form only to warn
+// that they were dropped, since posts have no tags yet.
+type importFrontMatter struct {
+	Title      string                `yaml:"title"`
+	CreatedAt  time.Time             `yaml:"created_at"`
+	Visibility models.PostVisibility `yaml:"visibility"`
+	Tags       any                   `yaml:"tags"`
+}
+
+func (i *importService) RequestImport(ctx context.Context, userID string, archive []byte) (*models.ImportResponse, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, models.ErrInvalidImportArchive
+	}
+
+	files := importableFiles(zr)
+	if len(files) == 0 || len(files) > maxImportFiles {
+		return nil, models.ErrInvalidImportArchive
+	}
+
+	imp := &models.Import{
+		UserID:     userID,
+		Status:     models.ImportStatusPending,
+		TotalFiles: len(files),
+	}
+
+	if err := i.ir.CreateImport(ctx, imp); err != nil {
+		return nil, fmt.Errorf("create import: %w", err)
+	}
+
+	i.dispatch(func() {
+		defer func() {
+			if p := recover(); p != nil {
+				slog.Error("import panicked", "importID", imp.ID, "panic", p)
+				if err := i.failImport(context.Background(), imp); err != nil {
+					slog.Error("error failing import", "importID", imp.ID, "error", err)
+				}
+			}
+		}()
+
+		if err := i.runImport(context.Background(), imp, files); err != nil {
+			slog.Error("error running import", "importID", imp.ID, "error", err)
+		}
+	})
+
+	return toImportResponse(imp), nil
+}
+
+func (i *importService) GetImport(ctx context.Context, userID string, importID string) (*models.ImportResponse, error) {
+	imp, err := i.ir.GetImportByID(ctx, importID)
+	if err != nil {
+		return nil, fmt.Errorf("get import by id %s: %w", importID, err)
+	}
+
+	if imp == nil || imp.UserID != userID {
+		return nil, models.ErrImportNotFound
+	}
+
+	if imp.Status == models.ImportStatusPending && imp.CreatedAt.Before(time.Now().UTC().Add(-importRunTimeout)) {
+		if err := i.failImport(ctx, imp); err != nil {
+			return nil, err
+		}
+	}
+
+	return toImportResponse(imp), nil
+}
+
+// failImport marks an import that stopped before finishing as failed.
+func (i *importService) failImport(ctx context.Context, imp *models.Import) error {
+	imp.Status = models.ImportStatusFailed
+	imp.CompletedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	if err := i.ir.UpdateImport(ctx, imp); err != nil {
+		return fmt.Errorf("update import %s: %w", imp.ID, err)
+	}
+
+	return nil
+}
+
+func (i *importService) runImport(ctx context.Context, imp *models.Import, files []*zip.File) error {
+	imp.Errors = []*models.ImportFileError{}
+	imp.Warnings = []*models.ImportFileError{}
+
+	for _, file := range files {
+		imported, warnings, err := readImportedPost(file)
+		if err != nil {
+			imp.Errors = append(imp.Errors, importFileFailure(imp.ID, file.Name, err))
+			continue
+		}
+
+		created, err := i.ps.ImportPost(ctx, imp.UserID, imported)
+		if err != nil {
+			imp.Errors = append(imp.Errors, importFileFailure(imp.ID, file.Name, err))
+			continue
+		}
+
+		if !created {
+			imp.SkippedCount++
+			continue
+		}
+
+		imp.ImportedCount++
+		for _, warning := range warnings {
+			imp.Warnings = append(imp.Warnings, &models.ImportFileError{File: file.Name, Message: warning})
+		}
+	}
+
+	imp.Status = models.ImportStatusCompleted
+	if imp.ImportedCount == 0 && imp.SkippedCount == 0 {
+		imp.Status = models.ImportStatusFailed
+	}
+	imp.CompletedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	if err := i.ir.UpdateImport(ctx, imp); err != nil {
+		return fmt.Errorf("update import %s: %w", imp.ID, err)
+	}
+
+	return nil
+}
+
+func importFileFailure(importID string, fileName string, err error) *models.ImportFileError {
+	var fileErr importFileError
+	if errors.As(err, &fileErr) {
+		return &models.ImportFileError{File: fileName, Message: fileErr.Error()}
+	}
+
+	slog.Error("error importing file", "importID", importID, "file", fileName, "error", err)
+	return &models.ImportFileError{File: fileName, Message: importFileFailedMessage}
+}
+
+func importableFiles(zr *zip.Reader) []*zip.File {
+	var files []*zip.File
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") || strings.HasPrefix(path.Base(file.Name), ".") {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// readImportedPost also returns warnings about front matter that was read but
+// could not be kept.
+func readImportedPost(file *zip.File) (*models.ImportedPost, []string, error) {
+	ext := strings.ToLower(path.Ext(file.Name))
+	if ext != ".md" && ext != ".markdown" {
+		return nil, nil, importFileError(fmt.Sprintf("Tipo de arquivo não suportado: %s.", ext))
+	}
+
+	if file.UncompressedSize64 > maxImportFileSize {
+		return nil, nil, importFileError(fmt.Sprintf("O arquivo excede %d KB.", maxImportFileSize>>10))
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("open file: %w", err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxImportFileSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read file: %w", err)
+	}
+
+	if len(data) > maxImportFileSize {
+		return nil, nil, importFileError(fmt.Sprintf("O arquivo excede %d KB.", maxImportFileSize>>10))
+	}
+
+	if !utf8.Valid(data) {
+		return nil, nil, importFileError("O arquivo não está em UTF-8.")
+	}
+
+	rawFrontMatter, body := utils.SplitFrontMatter(string(data))
+
+	var fm importFrontMatter
+	if rawFrontMatter != "" {
+		if err := yaml.Unmarshal([]byte(rawFrontMatter), &fm); err != nil {
+			return nil, nil, importFileError("O front matter não é um YAML válido.")
+		}
+	}
+
+	title := strings.TrimSpace(fm.Title)
+	if title == "" {
+		title = strings.TrimSpace(strings.TrimSuffix(path.Base(file.Name), path.Ext(file.Name)))
+	}
+
+	content := strings.TrimSpace(body)
+
+	if title == "" {
+		return nil, nil, importFileError("O título é obrigatório.")
+	}
+
+	if utf8.RuneCountInString(title) > models.MaxPostTitleLength {
+		return nil, nil, importFileError(fmt.Sprintf("O título excede %d caracteres.", models.MaxPostTitleLength))
+	}
+
+	if content == "" {
+		return nil, nil, importFileError("O conteúdo é obrigatório.")
+	}
+
+	if utf8.RuneCountInString(content) > models.MaxPostContentLength {
+		return nil, nil, importFileError(fmt.Sprintf("O conteúdo excede %d caracteres.", models.MaxPostContentLength))
+	}
+
+	switch fm.Visibility {
+	case "":
+		fm.Visibility = models.PostVisibilityPublic
+	case models.PostVisibilityPublic, models.PostVisibilityPrivate:
+	default:
+		return nil, nil, importFileError(fmt.Sprintf(importInvalidVisibilityFormat, fm.Visibility))
+	}
+
+	var warnings []string
+	if fm.Tags != nil {
+		warnings = append(warnings, importTagsIgnoredMessage)
+	}
+
+	hash := sha256.Sum256([]byte(title + "\n" + content))
+
+	return &models.ImportedPost{
+		Title:       title,
+		Content:     content,
+		ContentHash: hex.EncodeToString(hash[:]),
+		Visibility:  fm.Visibility,
+		CreatedAt:   fm.CreatedAt.UTC(),
+	}, warnings, nil
+}
+
+func toImportResponse(imp *models.Import) *models.ImportResponse {
+	resp := &models.ImportResponse{
+		ID:         imp.ID,
+		Status:     imp.Status,
+		TotalFiles: imp.TotalFiles,
+		Imported:   imp.ImportedCount,
+		Skipped:    imp.SkippedCount,
+		Errors:     imp.Errors,
+		Warnings:   imp.Warnings,
+		CreatedAt:  imp.CreatedAt,
+	}
+
+	if resp.Errors == nil {
+		resp.Errors = []*models.ImportFileError{}
+	}
+
+	if resp.Warnings == nil {
+		resp.Warnings = []*models.ImportFileError{}
+	}
+
+	if imp.CompletedAt.Valid {
+		resp.CompletedAt = &imp.CompletedAt.Time
+	}
+
+	return resp
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestImportService_RequestImport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrInvalidImportArchive if body is not a zip", func(t *testing.T) {
+		ir := new(mocks.ImportRepositoryMock)
+		is := NewImportService(nil, ir)
+
+		resp, err := is.RequestImport(ctx, "user-1", []byte("not a zip"))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidImportArchive)
+		ir.AssertNotCalled(t, "CreateImport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should import notes and report per-file errors", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		ir := new(mocks.ImportRepositoryMock)
+		is := NewImportService(ps, ir)
+		is.(*importService).dispatch = func(task func()) { task() }
+
+		archive := buildZip(t, map[string]string{
+			"receitas.md":         "---\ntitle: Receitas\ncreated_at: 2021-03-04T10:00:00Z\ntags: [cozinha]\n---\n\n# Bolo\n",
+			"notas/sem-titulo.md": "Conteúdo sem front matter",
+			"repetida.md":         "Já importada",
+			"longa.md":            "---\ntitle: " + strings.Repeat("a", models.MaxPostTitleLength+1) + "\n---\nTexto",
+			"imagem.png":          "binary",
+			"__MACOSX/._receitas": "junk",
+		})
+
+		ir.On("CreateImport", ctx, mock.MatchedBy(func(i *models.Import) bool {
+			return i.UserID == "user-1" && i.TotalFiles == 5
+		})).Return(nil)
+
+		ps.On("ImportPost", mock.Anything, "user-1", mock.MatchedBy(func(p *models.ImportedPost) bool {
+			return p.Title == "Receitas" && p.Content == "# Bolo" &&
+				p.CreatedAt.Equal(time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC))
+		})).Return(true, nil)
+		ps.On("ImportPost", mock.Anything, "user-1", mock.MatchedBy(func(p *models.ImportedPost) bool {
+			return p.Title == "sem-titulo" && p.CreatedAt.IsZero()
+		})).Return(true, nil)
+		ps.On("ImportPost", mock.Anything, "user-1", mock.MatchedBy(func(p *models.ImportedPost) bool {
+			return p.Title == "repetida"
+		})).Return(false, nil)
+
+		var result *models.Import
+		ir.On("UpdateImport", mock.Anything, mock.MatchedBy(func(i *models.Import) bool {
+			result = i
+			return true
+		})).Return(nil)
+
+		resp, err := is.RequestImport(ctx, "user-1", archive)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, resp.TotalFiles)
+		require.NotNil(t, result)
+		assert.Equal(t, models.ImportStatusCompleted, result.Status)
+		assert.Equal(t, 2, result.ImportedCount)
+		assert.Equal(t, 1, result.SkippedCount)
+		assert.ElementsMatch(t, []*models.ImportFileError{
+			{File: "longa.md", Message: "O título excede 50 caracteres."},
+			{File: "imagem.png", Message: "Tipo de arquivo não suportado: .png."},
+		}, result.Errors)
+		assert.Equal(t, []*models.ImportFileError{
+			{File: "receitas.md", Message: importTagsIgnoredMessage},
+		}, result.Warnings)
+		ps.AssertExpectations(t)
+	})
+
+	t.Run("should not expose unexpected errors", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		ir := new(mocks.ImportRepositoryMock)
+		is := NewImportService(ps, ir)
+		is.(*importService).dispatch = func(task func()) { task() }
+
+		archive := buildZip(t, map[string]string{"nota.md": "Texto"})
+
+		ir.On("CreateImport", ctx, mock.Anything).Return(nil)
+		ps.On("ImportPost", mock.Anything, "user-1", mock.Anything).
+			Return(false, errors.New("create post: Error 1205: Lock wait timeout exceeded"))
+
+		var result *models.Import
+		ir.On("UpdateImport", mock.Anything, mock.MatchedBy(func(i *models.Import) bool {
+			result = i
+			return true
+		})).Return(nil)
+
+		_, err := is.RequestImport(ctx, "user-1", archive)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, models.ImportStatusFailed, result.Status)
+		assert.Equal(t, []*models.ImportFileError{
+			{File: "nota.md", Message: importFileFailedMessage},
+		}, result.Errors)
+	})
+
+	t.Run("should import the visibility from front matter", func(t *testing.T) {
+		ps := new(mocks.PostServiceMock)
+		ir := new(mocks.ImportRepositoryMock)
+		is := NewImportService(ps, ir)
+		is.(*importService).dispatch = func(task func()) { task() }
+
+		archive := buildZip(t, map[string]string{
+			"privada.md": "---\nvisibility: private\n---\nTexto",
+			"publica.md": "Texto",
+			"secreta.md": "---\nvisibility: secret\n---\nTexto",
+		})
+
+		ir.On("CreateImport", ctx, mock.Anything).Return(nil)
+		ps.On("ImportPost", mock.Anything, "user-1", mock.MatchedBy(func(p *models.ImportedPost) bool {
+			return p.Title == "privada" && p.Visibility == models.PostVisibilityPrivate
+		})).Return(true, nil)
+		ps.On("ImportPost", mock.Anything, "user-1", mock.MatchedBy(func(p *models.ImportedPost) bool {
+			return p.Title == "publica" && p.Visibility == models.PostVisibilityPublic
+		})).Return(true, nil)
+
+		var result *models.Import
+		ir.On("UpdateImport", mock.Anything, mock.MatchedBy(func(i *models.Import) bool {
+			result = i
+			return true
+		})).Return(nil)
+
+		_, err := is.RequestImport(ctx, "user-1", archive)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, 2, result.ImportedCount)
+		assert.Equal(t, []*models.ImportFileError{
+			{File: "secreta.md", Message: `Visibilidade inválida: "secret". Use public ou private.`},
+		}, result.Errors)
+		ps.AssertExpectations(t)
+	})
+}
+
+func TestImportService_GetImport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrImportNotFound if import belongs to another user", func(t *testing.T) {
+		ir := new(mocks.ImportRepositoryMock)
+		is := NewImportService(nil, ir)
+
+		ir.On("GetImportByID", ctx, "import-1").Return(&models.Import{ID: "import-1", UserID: "user-2"}, nil)
+
+		resp, err := is.GetImport(ctx, "user-1", "import-1")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrImportNotFound)
+	})
+
+	t.Run("should mark an import stuck in pending as failed", func(t *testing.T) {
+		ir := new(mocks.ImportRepositoryMock)
+		is := NewImportService(nil, ir)
+
+		ir.On("GetImportByID", ctx, "import-1").Return(&models.Import{
+			ID:        "import-1",
+			UserID:    "user-1",
+			Status:    models.ImportStatusPending,
+			CreatedAt: time.Now().UTC().Add(-2 * importRunTimeout),
+		}, nil)
+		ir.On("UpdateImport", ctx, mock.MatchedBy(func(i *models.Import) bool {
+			return i.Status == models.ImportStatusFailed && i.CompletedAt.Valid
+		})).Return(nil)
+
+		resp, err := is.GetImport(ctx, "user-1", "import-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, models.ImportStatusFailed, resp.Status)
+		ir.AssertExpectations(t)
+	})
+}
+
+func TestPostService_ImportPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should skip a note that was already imported", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
+
+		pr.On("GetPostByContentHash", ctx, "user-1", "hash").Return(&models.Post{ID: "post-1"}, nil)
+
+		created, err := ps.ImportPost(ctx, "user-1", &models.ImportedPost{Title: "Nota", Content: "Texto", ContentHash: "hash"})
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		pr.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should skip a note inserted by a concurrent import", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, mr)
+
+		pr.On("GetPostByContentHash", ctx, "user-1", "hash").Return(nil, nil)
+		mr.On("Render", "Texto").Return("<p>Texto</p>", nil)
+		pr.On("CreatePost", ctx, mock.Anything).Return(models.ErrPostContentExists)
+
+		created, err := ps.ImportPost(ctx, "user-1", &models.ImportedPost{Title: "Nota", Content: "Texto", ContentHash: "hash"})
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		pls.AssertNotCalled(t, "SyncLinks", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create post keeping the original timestamp", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, mr)
+
+		createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		pr.On("GetPostByContentHash", ctx, "user-1", "hash").Return(nil, nil)
+		mr.On("Render", "Texto").Return("<p>Texto</p>", nil)
+		pr.On("CreatePost", ctx, mock.MatchedBy(func(p *models.Post) bool {
+			return p.CreatedAt.Equal(createdAt) && p.ContentHash.String == "hash" && p.ContentHTML.String == "<p>Texto</p>" &&
+				p.Visibility == models.PostVisibilityPrivate
+		})).Return(nil)
+		pls.On("SyncLinks", ctx, mock.Anything).Return(nil)
+		pls.On("ResolvePendingLinks", ctx, mock.Anything).Return(nil)
+
+		created, err := ps.ImportPost(ctx, "user-1", &models.ImportedPost{
+			Title:       "Nota",
+			Content:     "Texto",
+			ContentHash: "hash",
+			Visibility:  models.PostVisibilityPrivate,
+			CreatedAt:   createdAt,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		pr.AssertExpectations(t)
+		pls.AssertExpectations(t)
+	})
+}
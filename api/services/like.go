@@ -51,7 +51,13 @@ func (l *likeService) UnlikePost(ctx context.Context, userID string, postID stri
 	return nil
 }
 
+// CheckLikes reports which of postIDs the user liked. Anonymous viewers, with
+// an empty userID, have liked nothing.
 func (l *likeService) CheckLikes(ctx context.Context, userID string, postIDs []string) (map[string]bool, error) {
+	if userID == "" {
+		return map[string]bool{}, nil
+	}
+
 	IDs, err := l.lr.GetLikedPostIDs(ctx, userID, postIDs)
 	if err != nil {
 		return nil, err
@@ -66,6 +72,10 @@ func (l *likeService) CheckLikes(ctx context.Context, userID string, postIDs []s
 }
 
 func (l *likeService) CheckLike(ctx context.Context, userID, postID string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+
 	liked, err := l.lr.CheckLike(ctx, userID, postID)
 	if err != nil {
 		return false, fmt.Errorf("check like: %w", err)
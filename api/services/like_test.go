@@ -134,6 +134,17 @@ func TestCheckLikes(t *testing.T) {
 		assert.False(t, likedMap["p2"]) // implícito: não está presente
 		lr.AssertExpectations(t)
 	})
+
+	t.Run("should return an empty map for anonymous viewers", func(t *testing.T) {
+		lr := new(mocks.LikeRepositoryMock)
+		ls := NewLikeService(lr)
+
+		likedMap, err := ls.CheckLikes(ctx, "", []string{"p1"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, likedMap)
+		lr.AssertNotCalled(t, "GetLikedPostIDs", ctx, "", []string{"p1"})
+	})
 }
 
 func TestCheckLike(t *testing.T) {
@@ -183,4 +194,15 @@ func TestCheckLike(t *testing.T) {
 		assert.False(t, liked)
 		lr.AssertExpectations(t)
 	})
+
+	t.Run("should return false for anonymous viewers", func(t *testing.T) {
+		lr := new(mocks.LikeRepositoryMock)
+		ls := NewLikeService(lr)
+
+		liked, err := ls.CheckLike(ctx, "", "post-456")
+
+		assert.NoError(t, err)
+		assert.False(t, liked)
+		lr.AssertNotCalled(t, "CheckLike", ctx, "", "post-456")
+	})
 }
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+	"github.com/g-villarinho/tab-notes-api/utils"
+)
+
+const maxSlugAttempts = 50
+
+type NotebookService interface {
+	CreateNotebook(ctx context.Context, userID string, title string, description string) (*models.NotebookResponse, error)
+	GetMyNotebooks(ctx context.Context, userID string) ([]*models.NotebookResponse, error)
+	GetMyNotebook(ctx context.Context, userID string, notebookID string) (*models.NotebookDetailResponse, error)
+	UpdateNotebook(ctx context.Context, userID string, notebookID string, title string, description string) error
+	DeleteNotebook(ctx context.Context, userID string, notebookID string) error
+	AddPost(ctx context.Context, userID string, notebookID string, postID string) error
+	RemovePost(ctx context.Context, userID string, notebookID string, postID string) error
+	ReorderPosts(ctx context.Context, userID string, notebookID string, postIDs []string) error
+	GetNotebookBySlug(ctx context.Context, viewerID string, username string, slug string) (*models.NotebookDetailResponse, error)
+}
+
+type notebookService struct {
+	ls LikeService
+	nr repositories.NotebookRepository
+	pr repositories.PostRepository
+	ur repositories.UserRepository
+	mr pkgs.MarkdownRenderer
+}
+
+func NewNotebookService(
+	likeService LikeService,
+	notebookRepository repositories.NotebookRepository,
+	postRepository repositories.PostRepository,
+	userRepository repositories.UserRepository,
+	markdownRenderer pkgs.MarkdownRenderer) NotebookService {
+	return &notebookService{
+		ls: likeService,
+		nr: notebookRepository,
+		pr: postRepository,
+		ur: userRepository,
+		mr: markdownRenderer,
+	}
+}
+
+func (n *notebookService) CreateNotebook(ctx context.Context, userID string, title string, description string) (*models.NotebookResponse, error) {
+	slug, err := n.uniqueSlug(ctx, userID, title)
+	if err != nil {
+		return nil, err
+	}
+
+	notebook := &models.Notebook{
+		OwnerID:     userID,
+		Title:       title,
+		Slug:        slug,
+		Description: description,
+	}
+
+	if err := n.nr.CreateNotebook(ctx, notebook); err != nil {
+		return nil, fmt.Errorf("create notebook: %w", err)
+	}
+
+	return toNotebookResponse(notebook), nil
+}
+
+func (n *notebookService) GetMyNotebooks(ctx context.Context, userID string) ([]*models.NotebookResponse, error) {
+	notebooks, err := n.nr.GetNotebooksByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get notebooks by owner id %s: %w", userID, err)
+	}
+
+	responses := make([]*models.NotebookResponse, len(notebooks))
+	for i, notebook := range notebooks {
+		responses[i] = toNotebookResponse(notebook)
+	}
+
+	return responses, nil
+}
+
+func (n *notebookService) GetMyNotebook(ctx context.Context, userID string, notebookID string) (*models.NotebookDetailResponse, error) {
+	notebook, err := n.getOwnedNotebook(ctx, userID, notebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := n.ur.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id %s: %w", userID, err)
+	}
+
+	if owner == nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	return n.toNotebookDetailResponse(ctx, userID, owner, notebook)
+}
+
+func (n *notebookService) UpdateNotebook(ctx context.Context, userID string, notebookID string, title string, description string) error {
+	notebook, err := n.getOwnedNotebook(ctx, userID, notebookID)
+	if err != nil {
+		return err
+	}
+
+	notebook.Title = title
+	notebook.Description = description
+
+	if err := n.nr.UpdateNotebook(ctx, notebook); err != nil {
+		return fmt.Errorf("update notebook %s: %w", notebookID, err)
+	}
+
+	return nil
+}
+
+func (n *notebookService) DeleteNotebook(ctx context.Context, userID string, notebookID string) error {
+	notebook, err := n.getOwnedNotebook(ctx, userID, notebookID)
+	if err != nil {
+		return err
+	}
+
+	if err := n.nr.DeleteNotebook(ctx, notebook.ID); err != nil {
+		return fmt.Errorf("delete notebook %s: %w", notebookID, err)
+	}
+
+	return nil
+}
+
+func (n *notebookService) AddPost(ctx context.Context, userID string, notebookID string, postID string) error {
+	notebook, err := n.getOwnedNotebook(ctx, userID, notebookID)
+	if err != nil {
+		return err
+	}
+
+	post, err := n.pr.GetPostByID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("get post by id %s: %w", postID, err)
+	}
+
+	if post == nil {
+		return models.ErrPostNotFound
+	}
+
+	if post.AuthorID != userID {
+		return models.ErrPostNotBelongToUser
+	}
+
+	if err := n.nr.AddPost(ctx, notebook.ID, post.ID); err != nil {
+		return fmt.Errorf("add post %s to notebook %s: %w", postID, notebookID, err)
+	}
+
+	return nil
+}
+
+func (n *notebookService) RemovePost(ctx context.Context, userID string, notebookID string, postID string) error {
+	notebook, err := n.getOwnedNotebook(ctx, userID, notebookID)
+	if err != nil {
+		return err
+	}
+
+	if err := n.nr.RemovePost(ctx, notebook.ID, postID); err != nil {
+		return fmt.Errorf("remove post %s from notebook %s: %w", postID, notebookID, err)
+	}
+
+	return nil
+}
+
+func (n *notebookService) ReorderPosts(ctx context.Context, userID string, notebookID string, postIDs []string) error {
+	notebook, err := n.getOwnedNotebook(ctx, userID, notebookID)
+	if err != nil {
+		return err
+	}
+
+	currentIDs, err := n.nr.GetPostIDs(ctx, notebook.ID)
+	if err != nil {
+		return fmt.Errorf("get post ids of notebook %s: %w", notebookID, err)
+	}
+
+	if !sameIDs(currentIDs, postIDs) {
+		return models.ErrInvalidNotebookOrder
+	}
+
+	if err := n.nr.ReorderPosts(ctx, notebook.ID, postIDs); err != nil {
+		return fmt.Errorf("reorder posts of notebook %s: %w", notebookID, err)
+	}
+
+	return nil
+}
+
+func (n *notebookService) GetNotebookBySlug(ctx context.Context, viewerID string, username string, slug string) (*models.NotebookDetailResponse, error) {
+	owner, err := n.ur.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("get user by username %s: %w", username, err)
+	}
+
+	if hiddenUser(owner) {
+		return nil, models.ErrUserNotFound
+	}
+
+	notebook, err := n.nr.GetNotebookBySlug(ctx, owner.ID, slug)
+	if err != nil {
+		return nil, fmt.Errorf("get notebook by slug %s: %w", slug, err)
+	}
+
+	if notebook == nil {
+		return nil, models.ErrNotebookNotFound
+	}
+
+	return n.toNotebookDetailResponse(ctx, viewerID, owner, notebook)
+}
+
+func (n *notebookService) getOwnedNotebook(ctx context.Context, userID string, notebookID string) (*models.Notebook, error) {
+	notebook, err := n.nr.GetNotebookByID(ctx, notebookID)
+	if err != nil {
+		return nil, fmt.Errorf("get notebook by id %s: %w", notebookID, err)
+	}
+
+	if notebook == nil || notebook.OwnerID != userID {
+		return nil, models.ErrNotebookNotFound
+	}
+
+	return notebook, nil
+}
+
+func (n *notebookService) uniqueSlug(ctx context.Context, ownerID string, title string) (string, error) {
+	base := utils.Slugify(title)
+	if base == "" {
+		base = "notebook"
+	}
+
+	slug := base
+	for i := 2; i <= maxSlugAttempts; i++ {
+		existing, err := n.nr.GetNotebookBySlug(ctx, ownerID, slug)
+		if err != nil {
+			return "", fmt.Errorf("get notebook by slug %s: %w", slug, err)
+		}
+
+		if existing == nil {
+			return slug, nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return "", fmt.Errorf("generate unique slug for %q: too many notebooks with the same title", title)
+}
+
+func (n *notebookService) toNotebookDetailResponse(ctx context.Context, viewerID string, owner *models.User, notebook *models.Notebook) (*models.NotebookDetailResponse, error) {
+	posts, err := n.nr.GetNotebookPosts(ctx, notebook.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get notebook posts %s: %w", notebook.ID, err)
+	}
+
+	if viewerID != owner.ID {
+		posts = slices.DeleteFunc(posts, func(post *models.NotebookPostResponse) bool {
+			return post.Visibility == models.PostVisibilityPrivate
+		})
+	}
+
+	if len(posts) == 0 {
+		posts = []*models.NotebookPostResponse{}
+	}
+
+	postIDs := make([]string, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	likedMap, err := n.ls.CheckLikes(ctx, viewerID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("check likes: %w", err)
+	}
+
+	for _, post := range posts {
+		post.LikedByUser = likedMap[post.ID]
+
+		if post.ContentHTML == "" && post.Content != "" {
+			contentHTML, err := n.mr.Render(post.Content)
+			if err != nil {
+				return nil, fmt.Errorf("render content %s: %w", post.ID, err)
+			}
+			post.ContentHTML = contentHTML
+		}
+	}
+
+	notebookResponse := toNotebookResponse(notebook)
+	notebookResponse.PostsCount = len(posts)
+
+	return &models.NotebookDetailResponse{
+		NotebookResponse: *notebookResponse,
+		OwnerName:        owner.Name,
+		OwnerUsername:    owner.Username,
+		Posts:            posts,
+	}, nil
+}
+
+func toNotebookResponse(notebook *models.Notebook) *models.NotebookResponse {
+	resp := &models.NotebookResponse{
+		ID:          notebook.ID,
+		Title:       notebook.Title,
+		Slug:        notebook.Slug,
+		Description: notebook.Description,
+		PostsCount:  notebook.PostsCount,
+		CreatedAt:   notebook.CreatedAt,
+	}
+
+	if notebook.UpdatedAt.Valid {
+		resp.UpdatedAt = &notebook.UpdatedAt.Time
+	}
+
+	return resp
+}
+
+func sameIDs(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, id := range a {
+		counts[id]++
+	}
+
+	for _, id := range b {
+		if counts[id] == 0 {
+			return false
+		}
+		counts[id]--
+	}
+
+	return true
+}
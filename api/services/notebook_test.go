@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotebookService_CreateNotebook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create notebook with slug from title", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, nil, nil)
+
+		nr.On("GetNotebookBySlug", ctx, "user-1", "receitas-da-vovo").Return(nil, nil)
+		nr.On("CreateNotebook", ctx, mock.MatchedBy(func(n *models.Notebook) bool {
+			return n.OwnerID == "user-1" && n.Slug == "receitas-da-vovo"
+		})).Return(nil)
+
+		resp, err := ns.CreateNotebook(ctx, "user-1", "Receitas da Vovó", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "receitas-da-vovo", resp.Slug)
+		nr.AssertExpectations(t)
+	})
+
+	t.Run("should suffix slug when it is already taken", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, nil, nil)
+
+		nr.On("GetNotebookBySlug", ctx, "user-1", "ideias").Return(&models.Notebook{ID: "nb-1"}, nil)
+		nr.On("GetNotebookBySlug", ctx, "user-1", "ideias-2").Return(nil, nil)
+		nr.On("CreateNotebook", ctx, mock.MatchedBy(func(n *models.Notebook) bool {
+			return n.Slug == "ideias-2"
+		})).Return(nil)
+
+		resp, err := ns.CreateNotebook(ctx, "user-1", "Ideias", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ideias-2", resp.Slug)
+		nr.AssertExpectations(t)
+	})
+
+	t.Run("should return error if repository fails", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, nil, nil)
+
+		nr.On("GetNotebookBySlug", ctx, "user-1", "ideias").Return(nil, nil)
+		nr.On("CreateNotebook", ctx, mock.Anything).Return(errors.New("db error"))
+
+		resp, err := ns.CreateNotebook(ctx, "user-1", "Ideias", "")
+
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, "create notebook")
+		nr.AssertExpectations(t)
+	})
+}
+
+func TestNotebookService_AddPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrNotebookNotFound if notebook belongs to another user", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, nil, nil)
+
+		nr.On("GetNotebookByID", ctx, "nb-1").Return(&models.Notebook{ID: "nb-1", OwnerID: "user-2"}, nil)
+
+		err := ns.AddPost(ctx, "user-1", "nb-1", "post-1")
+
+		assert.ErrorIs(t, err, models.ErrNotebookNotFound)
+		nr.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrPostNotBelongToUser if post is from another user", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		ns := NewNotebookService(nil, nr, pr, nil, nil)
+
+		nr.On("GetNotebookByID", ctx, "nb-1").Return(&models.Notebook{ID: "nb-1", OwnerID: "user-1"}, nil)
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-2"}, nil)
+
+		err := ns.AddPost(ctx, "user-1", "nb-1", "post-1")
+
+		assert.ErrorIs(t, err, models.ErrPostNotBelongToUser)
+		nr.AssertNotCalled(t, "AddPost", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should add post to notebook", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		ns := NewNotebookService(nil, nr, pr, nil, nil)
+
+		nr.On("GetNotebookByID", ctx, "nb-1").Return(&models.Notebook{ID: "nb-1", OwnerID: "user-1"}, nil)
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1"}, nil)
+		nr.On("AddPost", ctx, "nb-1", "post-1").Return(nil)
+
+		err := ns.AddPost(ctx, "user-1", "nb-1", "post-1")
+
+		assert.NoError(t, err)
+		nr.AssertExpectations(t)
+		pr.AssertExpectations(t)
+	})
+}
+
+func TestNotebookService_ReorderPosts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrInvalidNotebookOrder if ids do not match", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, nil, nil)
+
+		nr.On("GetNotebookByID", ctx, "nb-1").Return(&models.Notebook{ID: "nb-1", OwnerID: "user-1"}, nil)
+		nr.On("GetPostIDs", ctx, "nb-1").Return([]string{"post-1", "post-2"}, nil)
+
+		err := ns.ReorderPosts(ctx, "user-1", "nb-1", []string{"post-2", "post-3"})
+
+		assert.ErrorIs(t, err, models.ErrInvalidNotebookOrder)
+		nr.AssertNotCalled(t, "ReorderPosts", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reorder posts", func(t *testing.T) {
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, nil, nil)
+
+		nr.On("GetNotebookByID", ctx, "nb-1").Return(&models.Notebook{ID: "nb-1", OwnerID: "user-1"}, nil)
+		nr.On("GetPostIDs", ctx, "nb-1").Return([]string{"post-1", "post-2"}, nil)
+		nr.On("ReorderPosts", ctx, "nb-1", []string{"post-2", "post-1"}).Return(nil)
+
+		err := ns.ReorderPosts(ctx, "user-1", "nb-1", []string{"post-2", "post-1"})
+
+		assert.NoError(t, err)
+		nr.AssertExpectations(t)
+	})
+}
+
+func TestNotebookService_GetNotebookBySlug(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrUserNotFound if owner does not exist", func(t *testing.T) {
+		ur := new(mocks.UserRepositoryMock)
+		ns := NewNotebookService(nil, nil, nil, ur, nil)
+
+		ur.On("GetUserByUsername", ctx, "joao").Return(nil, nil)
+
+		resp, err := ns.GetNotebookBySlug(ctx, "viewer-1", "joao", "ideias")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
+
+	t.Run("should return ErrNotebookNotFound if slug does not exist", func(t *testing.T) {
+		ur := new(mocks.UserRepositoryMock)
+		nr := new(mocks.NotebookRepositoryMock)
+		ns := NewNotebookService(nil, nr, nil, ur, nil)
+
+		ur.On("GetUserByUsername", ctx, "joao").Return(&models.User{ID: "user-1"}, nil)
+		nr.On("GetNotebookBySlug", ctx, "user-1", "ideias").Return(nil, nil)
+
+		resp, err := ns.GetNotebookBySlug(ctx, "viewer-1", "joao", "ideias")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrNotebookNotFound)
+	})
+
+	t.Run("should return notebook with ordered posts", func(t *testing.T) {
+		ur := new(mocks.UserRepositoryMock)
+		nr := new(mocks.NotebookRepositoryMock)
+		ls := new(mocks.LikeServiceMock)
+		ns := NewNotebookService(ls, nr, nil, ur, nil)
+
+		ur.On("GetUserByUsername", ctx, "joao").Return(&models.User{ID: "user-1", Username: "joao"}, nil)
+		nr.On("GetNotebookBySlug", ctx, "user-1", "ideias").Return(&models.Notebook{ID: "nb-1", Slug: "ideias", PostsCount: 2}, nil)
+		nr.On("GetNotebookPosts", ctx, "nb-1").Return([]*models.NotebookPostResponse{
+			{ID: "post-2", Position: 0},
+			{ID: "post-1", Position: 1},
+		}, nil)
+		ls.On("CheckLikes", ctx, "viewer-1", []string{"post-2", "post-1"}).Return(map[string]bool{"post-1": true}, nil)
+
+		resp, err := ns.GetNotebookBySlug(ctx, "viewer-1", "joao", "ideias")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "joao", resp.OwnerUsername)
+		assert.Len(t, resp.Posts, 2)
+		assert.Equal(t, "post-2", resp.Posts[0].ID)
+		assert.True(t, resp.Posts[1].LikedByUser)
+		nr.AssertExpectations(t)
+		ls.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+)
+
+// PersonalAccessTokenService manages the long lived, scoped tokens users
+// create for scripts. Only the token hash is stored.
+type PersonalAccessTokenService interface {
+	CreateToken(ctx context.Context, userID string, payload *models.CreatePersonalAccessTokenPayload) (*models.CreatedPersonalAccessTokenResponse, error)
+	GetTokens(ctx context.Context, userID string) ([]*models.PersonalAccessTokenResponse, error)
+	RevokeToken(ctx context.Context, userID string, tokenID string) error
+	Authenticate(ctx context.Context, token string) (*models.PersonalAccessToken, error)
+}
+
+type personalAccessTokenService struct {
+	patr repositories.PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenService(personalAccessTokenRepository repositories.PersonalAccessTokenRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		patr: personalAccessTokenRepository,
+	}
+}
+
+func (p *personalAccessTokenService) CreateToken(ctx context.Context, userID string, payload *models.CreatePersonalAccessTokenPayload) (*models.CreatedPersonalAccessTokenResponse, error) {
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate token: %w", err)
+	}
+
+	raw := models.PersonalAccessTokenPrefix + secret
+
+	scopes := slices.Clone(payload.Scopes)
+	slices.Sort(scopes)
+
+	token := &models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        payload.Name,
+		TokenHash:   hashOpaqueToken(raw),
+		TokenPrefix: raw[:len(models.PersonalAccessTokenPrefix)+4],
+		Scopes:      slices.Compact(scopes),
+	}
+
+	if payload.ExpiresInDays != nil {
+		expiresAt := time.Now().UTC().AddDate(0, 0, *payload.ExpiresInDays)
+		token.ExpiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+	}
+
+	if err := p.patr.CreateToken(ctx, token); err != nil {
+		return nil, fmt.Errorf("create personal access token: %w", err)
+	}
+
+	return &models.CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: *toPersonalAccessTokenResponse(token),
+		Token:                       raw,
+	}, nil
+}
+
+func (p *personalAccessTokenService) GetTokens(ctx context.Context, userID string) ([]*models.PersonalAccessTokenResponse, error) {
+	tokens, err := p.patr.GetTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get personal access tokens by user id: %w", err)
+	}
+
+	responses := make([]*models.PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		responses[i] = toPersonalAccessTokenResponse(token)
+	}
+
+	return responses, nil
+}
+
+// RevokeToken reports tokens of other users as missing, so token IDs cannot
+// be probed.
+func (p *personalAccessTokenService) RevokeToken(ctx context.Context, userID string, tokenID string) error {
+	token, err := p.patr.GetTokenByID(ctx, tokenID)
+	if err != nil {
+		return fmt.Errorf("get personal access token by id %s: %w", tokenID, err)
+	}
+
+	if token == nil || token.UserID != userID {
+		return models.ErrPersonalAccessTokenNotFound
+	}
+
+	if err := p.patr.RevokeToken(ctx, token.ID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("revoke personal access token %s: %w", token.ID, err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a raw token to an active personal access token and
+// records its use. Unknown, revoked and expired tokens all return
+// ErrInvalidPersonalAccessToken.
+func (p *personalAccessTokenService) Authenticate(ctx context.Context, raw string) (*models.PersonalAccessToken, error) {
+	token, err := p.patr.GetTokenByHash(ctx, hashOpaqueToken(raw))
+	if err != nil {
+		return nil, fmt.Errorf("get personal access token by hash: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if token == nil || token.RevokedAt.Valid || (token.ExpiresAt.Valid && !token.ExpiresAt.Time.After(now)) {
+		return nil, models.ErrInvalidPersonalAccessToken
+	}
+
+	if err := p.patr.TouchToken(ctx, token.ID, now); err != nil {
+		return nil, fmt.Errorf("touch personal access token %s: %w", token.ID, err)
+	}
+
+	return token, nil
+}
+
+func toPersonalAccessTokenResponse(token *models.PersonalAccessToken) *models.PersonalAccessTokenResponse {
+	response := &models.PersonalAccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		CreatedAt:   token.CreatedAt,
+	}
+
+	if token.ExpiresAt.Valid {
+		response.ExpiresAt = &token.ExpiresAt.Time
+	}
+
+	if token.LastUsedAt.Valid {
+		response.LastUsedAt = &token.LastUsedAt.Time
+	}
+
+	if token.RevokedAt.Valid {
+		response.RevokedAt = &token.RevokedAt.Time
+	}
+
+	return response
+}
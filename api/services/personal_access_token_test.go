@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPersonalAccessTokenService_CreateToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store only the hash and return the token once", func(t *testing.T) {
+		patr := new(mocks.PersonalAccessTokenRepositoryMock)
+		pats := NewPersonalAccessTokenService(patr)
+
+		var stored *models.PersonalAccessToken
+		patr.On("CreateToken", ctx, mock.AnythingOfType("*models.PersonalAccessToken")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*models.PersonalAccessToken) }).
+			Return(nil)
+
+		days := 30
+		created, err := pats.CreateToken(ctx, "user-1", &models.CreatePersonalAccessTokenPayload{
+			Name:          "script",
+			Scopes:        []models.TokenScope{models.ScopePostsWrite, models.ScopePostsRead, models.ScopePostsWrite},
+			ExpiresInDays: &days,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Token, models.PersonalAccessTokenPrefix))
+		assert.True(t, strings.HasPrefix(created.Token, created.TokenPrefix))
+		assert.Equal(t, hashOpaqueToken(created.Token), stored.TokenHash)
+		assert.NotContains(t, stored.TokenHash, created.Token)
+		assert.Equal(t, []models.TokenScope{models.ScopePostsRead, models.ScopePostsWrite}, stored.Scopes)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *created.ExpiresAt, time.Minute)
+	})
+}
+
+func TestPersonalAccessTokenService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	const raw = models.PersonalAccessTokenPrefix + "secret"
+
+	t.Run("should return the token and record its use", func(t *testing.T) {
+		patr := new(mocks.PersonalAccessTokenRepositoryMock)
+		pats := NewPersonalAccessTokenService(patr)
+
+		token := &models.PersonalAccessToken{ID: "pat-1", UserID: "user-1"}
+		patr.On("GetTokenByHash", ctx, hashOpaqueToken(raw)).Return(token, nil)
+		patr.On("TouchToken", ctx, "pat-1", mock.AnythingOfType("time.Time")).Return(nil)
+
+		result, err := pats.Authenticate(ctx, raw)
+
+		assert.NoError(t, err)
+		assert.Equal(t, token, result)
+		patr.AssertExpectations(t)
+	})
+
+	t.Run("should reject revoked and expired tokens", func(t *testing.T) {
+		past := sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+		for _, token := range []*models.PersonalAccessToken{
+			{ID: "pat-1", RevokedAt: past},
+			{ID: "pat-2", ExpiresAt: past},
+			nil,
+		} {
+			patr := new(mocks.PersonalAccessTokenRepositoryMock)
+			pats := NewPersonalAccessTokenService(patr)
+
+			patr.On("GetTokenByHash", ctx, hashOpaqueToken(raw)).Return(token, nil)
+
+			_, err := pats.Authenticate(ctx, raw)
+
+			assert.ErrorIs(t, err, models.ErrInvalidPersonalAccessToken)
+			patr.AssertNotCalled(t, "TouchToken", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func TestPersonalAccessTokenService_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report tokens of other users as missing", func(t *testing.T) {
+		patr := new(mocks.PersonalAccessTokenRepositoryMock)
+		pats := NewPersonalAccessTokenService(patr)
+
+		patr.On("GetTokenByID", ctx, "pat-1").Return(&models.PersonalAccessToken{ID: "pat-1", UserID: "user-2"}, nil)
+
+		err := pats.RevokeToken(ctx, "user-1", "pat-1")
+
+		assert.ErrorIs(t, err, models.ErrPersonalAccessTokenNotFound)
+		patr.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+)
+
+// PollService manages the optional poll of a post. Responses only carry
+// tallies and the viewer's own vote, never who voted for what.
+type PollService interface {
+	CreatePoll(ctx context.Context, postID string, payload *models.CreatePollPayload) (*models.PollResponse, error)
+	GetPolls(ctx context.Context, userID string, postIDs []string) (map[string]*models.PollResponse, error)
+	Vote(ctx context.Context, userID string, postID string, optionID string) (*models.PollResponse, error)
+}
+
+type pollService struct {
+	pr  repositories.PostRepository
+	plr repositories.PollRepository
+}
+
+func NewPollService(
+	postRepository repositories.PostRepository,
+	pollRepository repositories.PollRepository) PollService {
+	return &pollService{
+		pr:  postRepository,
+		plr: pollRepository,
+	}
+}
+
+func (p *pollService) CreatePoll(ctx context.Context, postID string, payload *models.CreatePollPayload) (*models.PollResponse, error) {
+	if err := validatePollClosingTime(payload.ClosesAt, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	poll := &models.Poll{
+		PostID:   postID,
+		ClosesAt: payload.ClosesAt.UTC(),
+		Options:  make([]*models.PollOption, len(payload.Options)),
+	}
+	for i, text := range payload.Options {
+		poll.Options[i] = &models.PollOption{Text: text}
+	}
+
+	if err := p.plr.CreatePoll(ctx, poll); err != nil {
+		return nil, fmt.Errorf("create poll: %w", err)
+	}
+
+	return toPollResponse(poll, "", time.Now().UTC()), nil
+}
+
+// GetPolls maps each of postIDs that carries a poll to its current tallies.
+// Anonymous viewers, with an empty userID, have voted in none.
+func (p *pollService) GetPolls(ctx context.Context, userID string, postIDs []string) (map[string]*models.PollResponse, error) {
+	polls, err := p.plr.GetPollsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get polls by post ids: %w", err)
+	}
+
+	if len(polls) == 0 {
+		return map[string]*models.PollResponse{}, nil
+	}
+
+	voted := map[string]string{}
+	if userID != "" {
+		pollIDs := make([]string, len(polls))
+		for i, poll := range polls {
+			pollIDs[i] = poll.ID
+		}
+
+		voted, err = p.plr.GetVotedOptionIDs(ctx, userID, pollIDs)
+		if err != nil {
+			return nil, fmt.Errorf("get voted option ids: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	pollsMap := make(map[string]*models.PollResponse, len(polls))
+	for _, poll := range polls {
+		pollsMap[poll.PostID] = toPollResponse(poll, voted[poll.ID], now)
+	}
+
+	return pollsMap, nil
+}
+
+// Vote records the user's vote, replacing any earlier one, until the poll
+// closes. Polls on posts the user cannot see are reported as missing.
+func (p *pollService) Vote(ctx context.Context, userID string, postID string, optionID string) (*models.PollResponse, error) {
+	post, err := p.pr.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id %s: %w", postID, err)
+	}
+
+	if post == nil || !visibleTo(post, userID) {
+		return nil, models.ErrPostNotFound
+	}
+
+	poll, err := p.getPoll(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !time.Now().UTC().Before(poll.ClosesAt) {
+		return nil, models.ErrPollClosed
+	}
+
+	if !slices.ContainsFunc(poll.Options, func(option *models.PollOption) bool { return option.ID == optionID }) {
+		return nil, models.ErrPollOptionNotFound
+	}
+
+	vote := &models.PollVote{
+		PollID:   poll.ID,
+		UserID:   userID,
+		OptionID: optionID,
+	}
+
+	if err := p.plr.Vote(ctx, vote); err != nil {
+		return nil, fmt.Errorf("vote in poll %s: %w", poll.ID, err)
+	}
+
+	poll, err = p.getPoll(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPollResponse(poll, optionID, time.Now().UTC()), nil
+}
+
+func (p *pollService) getPoll(ctx context.Context, postID string) (*models.Poll, error) {
+	polls, err := p.plr.GetPollsByPostIDs(ctx, []string{postID})
+	if err != nil {
+		return nil, fmt.Errorf("get poll by post id %s: %w", postID, err)
+	}
+
+	if len(polls) == 0 {
+		return nil, models.ErrPollNotFound
+	}
+
+	return polls[0], nil
+}
+
+// validatePollClosingTime requires a poll to close in the future, within
+// MaxPollDuration.
+func validatePollClosingTime(closesAt time.Time, now time.Time) error {
+	if !closesAt.After(now) || closesAt.Sub(now) > models.MaxPollDuration {
+		return models.ErrInvalidPollClosingTime
+	}
+
+	return nil
+}
+
+func toPollResponse(poll *models.Poll, votedOptionID string, now time.Time) *models.PollResponse {
+	response := &models.PollResponse{
+		ID:       poll.ID,
+		Options:  make([]*models.PollOptionResponse, len(poll.Options)),
+		Closed:   !now.Before(poll.ClosesAt),
+		ClosesAt: poll.ClosesAt,
+	}
+
+	for i, option := range poll.Options {
+		response.Options[i] = &models.PollOptionResponse{
+			ID:    option.ID,
+			Text:  option.Text,
+			Votes: option.Votes,
+		}
+		response.TotalVotes += option.Votes
+	}
+
+	if votedOptionID != "" {
+		response.VotedOptionID = &votedOptionID
+	}
+
+	return response
+}
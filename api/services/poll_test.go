@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPollService_Vote(t *testing.T) {
+	ctx := context.Background()
+	post := &models.Post{ID: "post-1", AuthorID: "author-1", Visibility: models.PostVisibilityPublic}
+
+	openPoll := func() *models.Poll {
+		return &models.Poll{
+			ID:       "poll-1",
+			PostID:   "post-1",
+			ClosesAt: time.Now().Add(time.Hour),
+			Options: []*models.PollOption{
+				{ID: "option-1", Text: "Sim", Votes: 2},
+				{ID: "option-2", Text: "Não", Votes: 1},
+			},
+		}
+	}
+
+	t.Run("should record the vote and return the new tallies", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(pr, plr)
+
+		updated := openPoll()
+		updated.Options[1].Votes = 2
+
+		pr.On("GetPostByID", ctx, "post-1").Return(post, nil)
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1"}).Return([]*models.Poll{openPoll()}, nil).Once()
+		plr.On("Vote", ctx, &models.PollVote{PollID: "poll-1", UserID: "user-1", OptionID: "option-2"}).Return(nil)
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1"}).Return([]*models.Poll{updated}, nil).Once()
+
+		poll, err := ps.Vote(ctx, "user-1", "post-1", "option-2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, poll.TotalVotes)
+		assert.Equal(t, "option-2", *poll.VotedOptionID)
+		assert.False(t, poll.Closed)
+		plr.AssertExpectations(t)
+	})
+
+	t.Run("should reject votes after the poll closed", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(pr, plr)
+
+		closed := openPoll()
+		closed.ClosesAt = time.Now().Add(-time.Minute)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(post, nil)
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1"}).Return([]*models.Poll{closed}, nil)
+
+		_, err := ps.Vote(ctx, "user-1", "post-1", "option-1")
+
+		assert.ErrorIs(t, err, models.ErrPollClosed)
+		plr.AssertNotCalled(t, "Vote", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an option from another poll", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(pr, plr)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(post, nil)
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1"}).Return([]*models.Poll{openPoll()}, nil)
+
+		_, err := ps.Vote(ctx, "user-1", "post-1", "option-9")
+
+		assert.ErrorIs(t, err, models.ErrPollOptionNotFound)
+		plr.AssertNotCalled(t, "Vote", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrPollNotFound for a post without poll", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(pr, plr)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(post, nil)
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1"}).Return(nil, nil)
+
+		_, err := ps.Vote(ctx, "user-1", "post-1", "option-1")
+
+		assert.ErrorIs(t, err, models.ErrPollNotFound)
+	})
+
+	t.Run("should return ErrPostNotFound for a private post of another author", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(pr, plr)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "author-1", Visibility: models.PostVisibilityPrivate}, nil)
+
+		_, err := ps.Vote(ctx, "user-1", "post-1", "option-1")
+
+		assert.ErrorIs(t, err, models.ErrPostNotFound)
+		plr.AssertNotCalled(t, "GetPollsByPostIDs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPollService_GetPolls(t *testing.T) {
+	ctx := context.Background()
+	polls := []*models.Poll{{
+		ID:       "poll-1",
+		PostID:   "post-1",
+		ClosesAt: time.Now().Add(-time.Hour),
+		Options: []*models.PollOption{
+			{ID: "option-1", Text: "Sim", Votes: 2},
+			{ID: "option-2", Text: "Não", Votes: 1},
+		},
+	}}
+
+	t.Run("should include the viewer's vote", func(t *testing.T) {
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(nil, plr)
+
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1", "post-2"}).Return(polls, nil)
+		plr.On("GetVotedOptionIDs", ctx, "user-1", []string{"poll-1"}).Return(map[string]string{"poll-1": "option-1"}, nil)
+
+		result, err := ps.GetPolls(ctx, "user-1", []string{"post-1", "post-2"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 3, result["post-1"].TotalVotes)
+		assert.Equal(t, "option-1", *result["post-1"].VotedOptionID)
+		assert.True(t, result["post-1"].Closed)
+	})
+
+	t.Run("should not look up votes for anonymous viewers", func(t *testing.T) {
+		plr := new(mocks.PollRepositoryMock)
+		ps := NewPollService(nil, plr)
+
+		plr.On("GetPollsByPostIDs", ctx, []string{"post-1"}).Return(polls, nil)
+
+		result, err := ps.GetPolls(ctx, "", []string{"post-1"})
+
+		assert.NoError(t, err)
+		assert.Nil(t, result["post-1"].VotedOptionID)
+		plr.AssertNotCalled(t, "GetVotedOptionIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,67 +2,188 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/g-villarinho/tab-notes-api/repositories"
 )
 
 type PostService interface {
-	CreatePost(ctx context.Context, userID string, title string, content string) (*models.PostResponse, error)
+	CreatePost(ctx context.Context, userID string, title string, content string, visibility models.PostVisibility, poll *models.CreatePollPayload) (*models.PostResponse, error)
 	LikePost(ctx context.Context, userID string, postID string) error
 	UnlikePost(ctx context.Context, userID string, postID string) error
 	GetPostByID(ctx context.Context, userID string, ID string) (*models.PostResponse, error)
 	DeletePost(ctx context.Context, userID string, ID string) error
-	UpdatePost(ctx context.Context, userID string, ID string, title string, content string) error
+	UpdatePost(ctx context.Context, userID string, ID string, title string, content string, visibility models.PostVisibility, version int) (int, error)
+	PatchPost(ctx context.Context, userID string, ID string, payload *models.PatchPostPayload, version int) (int, error)
 	GetPostsByUsername(ctx context.Context, userID string, username string) ([]*models.PostResponse, error)
 	GetPostsByAuthorID(ctx context.Context, authorID string) ([]*models.PostResponse, error)
+	GetBacklinks(ctx context.Context, userID string, postID string) ([]*models.BacklinkResponse, error)
+	ImportPost(ctx context.Context, userID string, imported *models.ImportedPost) (bool, error)
+	GetPostEmbed(ctx context.Context, ID string) (*models.PostEmbed, error)
 }
 
 type postService struct {
-	ls LikeService
-	pr repositories.PostRepository
-	ur repositories.UserRepository
+	fs  FederationService
+	ls  LikeService
+	pos PollService
+	pls PostLinkService
+	pr  repositories.PostRepository
+	ur  repositories.UserRepository
+	mr  pkgs.MarkdownRenderer
 }
 
 func NewPostService(
+	federationService FederationService,
 	likeService LikeService,
+	pollService PollService,
+	postLinkService PostLinkService,
 	postRepository repositories.PostRepository,
-	userRepository repositories.UserRepository) PostService {
+	userRepository repositories.UserRepository,
+	markdownRenderer pkgs.MarkdownRenderer) PostService {
 	return &postService{
-		ls: likeService,
-		pr: postRepository,
-		ur: userRepository,
+		fs:  federationService,
+		ls:  likeService,
+		pos: pollService,
+		pls: postLinkService,
+		pr:  postRepository,
+		ur:  userRepository,
+		mr:  markdownRenderer,
 	}
 }
 
-func (p *postService) CreatePost(ctx context.Context, userID string, title string, content string) (*models.PostResponse, error) {
+func (p *postService) CreatePost(ctx context.Context, userID string, title string, content string, visibility models.PostVisibility, poll *models.CreatePollPayload) (*models.PostResponse, error) {
+	if poll != nil {
+		if err := validatePollClosingTime(poll.ClosesAt, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+	}
+
+	contentHTML, err := p.mr.Render(content)
+	if err != nil {
+		return nil, fmt.Errorf("render content: %w", err)
+	}
+
 	post := &models.Post{
-		Title:    title,
-		Content:  content,
-		AuthorID: userID,
+		Title:       title,
+		Content:     content,
+		ContentHTML: sql.NullString{String: contentHTML, Valid: true},
+		Visibility:  visibility,
+		AuthorID:    userID,
 	}
 
 	if err := p.pr.CreatePost(ctx, post); err != nil {
 		return nil, fmt.Errorf("create post: %w", err)
 	}
 
+	// The poll is created before anything else points at the post, so a
+	// failure can be undone by deleting the post alone.
+	var pollResponse *models.PollResponse
+	if poll != nil {
+		pollResponse, err = p.pos.CreatePoll(ctx, post.ID, poll)
+		if err != nil {
+			if deleteErr := p.pr.DeletePost(ctx, post.ID); deleteErr != nil {
+				slog.Error("delete post without its poll", slog.String("post_id", post.ID), slog.Any("error", deleteErr))
+			}
+			return nil, fmt.Errorf("create poll: %w", err)
+		}
+	}
+
+	if err := p.pls.SyncLinks(ctx, post); err != nil {
+		return nil, fmt.Errorf("sync links: %w", err)
+	}
+
+	if err := p.pls.ResolvePendingLinks(ctx, post); err != nil {
+		return nil, fmt.Errorf("resolve pending links: %w", err)
+	}
+
+	linksMap, err := p.pls.GetLinks(ctx, userID, []string{post.ID})
+	if err != nil {
+		return nil, fmt.Errorf("get links: %w", err)
+	}
+
+	// Federation is best effort: the post exists locally either way.
+	if err := p.fs.PublishPost(ctx, post); err != nil {
+		slog.Warn("publish post to remote followers", slog.String("post_id", post.ID), slog.Any("error", err))
+	}
+
 	return &models.PostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		Likes:     post.Likes,
-		CreatedAt: post.CreatedAt,
+		ID:          post.ID,
+		Title:       post.Title,
+		Content:     post.Content,
+		ContentHTML: post.ContentHTML.String,
+		Visibility:  post.Visibility,
+		Likes:       post.Likes,
+		Links:       linksMap[post.ID],
+		Poll:        pollResponse,
+		Version:     post.Version,
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   lastModified(post),
 	}, nil
 }
 
+// ImportPost creates a post from an imported note, keeping its original
+// timestamp. It returns false without creating anything when the author
+// already has a post with the same content hash.
+func (p *postService) ImportPost(ctx context.Context, userID string, imported *models.ImportedPost) (bool, error) {
+	existing, err := p.pr.GetPostByContentHash(ctx, userID, imported.ContentHash)
+	if err != nil {
+		return false, fmt.Errorf("get post by content hash: %w", err)
+	}
+
+	if existing != nil {
+		return false, nil
+	}
+
+	contentHTML, err := p.mr.Render(imported.Content)
+	if err != nil {
+		return false, fmt.Errorf("render content: %w", err)
+	}
+
+	post := &models.Post{
+		Title:       imported.Title,
+		Content:     imported.Content,
+		ContentHTML: sql.NullString{String: contentHTML, Valid: true},
+		ContentHash: sql.NullString{String: imported.ContentHash, Valid: true},
+		Visibility:  imported.Visibility,
+		AuthorID:    userID,
+		CreatedAt:   imported.CreatedAt,
+	}
+
+	// A retried upload running at the same time may insert the same note
+	// between the lookup above and this insert.
+	if err := p.pr.CreatePost(ctx, post); err != nil {
+		if errors.Is(err, models.ErrPostContentExists) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("create post: %w", err)
+	}
+
+	if err := p.pls.SyncLinks(ctx, post); err != nil {
+		return false, fmt.Errorf("sync links: %w", err)
+	}
+
+	if err := p.pls.ResolvePendingLinks(ctx, post); err != nil {
+		return false, fmt.Errorf("resolve pending links: %w", err)
+	}
+
+	return true, nil
+}
+
 func (p *postService) LikePost(ctx context.Context, userID string, postID string) error {
 	post, err := p.pr.GetPostByID(ctx, postID)
 	if err != nil {
 		return fmt.Errorf("get post by id: %w", err)
 	}
 
-	if post == nil {
+	if post == nil || !visibleTo(post, userID) {
 		return models.ErrPostNotFound
 	}
 
@@ -79,7 +200,7 @@ func (p *postService) UnlikePost(ctx context.Context, userID string, postID stri
 		return fmt.Errorf("get post by id: %w", err)
 	}
 
-	if post == nil {
+	if post == nil || !visibleTo(post, userID) {
 		return models.ErrPostNotFound
 	}
 
@@ -96,7 +217,7 @@ func (p *postService) GetPostByID(ctx context.Context, userID string, ID string)
 		return nil, fmt.Errorf("get post by id: %w", err)
 	}
 
-	if post == nil {
+	if post == nil || !visibleTo(post, userID) {
 		return nil, nil
 	}
 
@@ -105,18 +226,71 @@ func (p *postService) GetPostByID(ctx context.Context, userID string, ID string)
 		return nil, fmt.Errorf("check like: %w", err)
 	}
 
+	linksMap, err := p.pls.GetLinks(ctx, userID, []string{post.ID})
+	if err != nil {
+		return nil, fmt.Errorf("get links: %w", err)
+	}
+
+	pollsMap, err := p.pos.GetPolls(ctx, userID, []string{post.ID})
+	if err != nil {
+		return nil, fmt.Errorf("get polls: %w", err)
+	}
+
+	contentHTML, err := renderContent(p.mr, post.Content, post.ContentHTML)
+	if err != nil {
+		return nil, fmt.Errorf("render content: %w", err)
+	}
+
 	postResponse := &models.PostResponse{
 		ID:          post.ID,
 		Title:       post.Title,
 		Content:     post.Content,
+		ContentHTML: contentHTML,
+		Visibility:  post.Visibility,
 		Likes:       post.Likes,
 		LikedByUser: likedByUser,
+		Links:       linksMap[post.ID],
+		Poll:        pollsMap[post.ID],
+		Version:     post.Version,
 		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   lastModified(post),
 	}
 
 	return postResponse, nil
 }
 
+// GetPostEmbed returns the card data of a public post. Private and missing
+// posts are both reported as ErrPostNotFound.
+func (p *postService) GetPostEmbed(ctx context.Context, ID string) (*models.PostEmbed, error) {
+	post, err := p.pr.GetPostByID(ctx, ID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id: %w", err)
+	}
+
+	if post == nil || post.Visibility != models.PostVisibilityPublic {
+		return nil, models.ErrPostNotFound
+	}
+
+	author, err := p.ur.GetUserByID(ctx, post.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if author == nil {
+		return nil, models.ErrPostNotFound
+	}
+
+	return &models.PostEmbed{
+		ID:             post.ID,
+		Title:          post.Title,
+		AuthorName:     author.Name,
+		AuthorUsername: author.Username,
+		Likes:          post.Likes,
+		CreatedAt:      post.CreatedAt,
+		UpdatedAt:      lastModified(post),
+	}, nil
+}
+
 func (p *postService) DeletePost(ctx context.Context, userID string, ID string) error {
 	post, err := p.pr.GetPostByID(ctx, ID)
 	if err != nil {
@@ -138,28 +312,80 @@ func (p *postService) DeletePost(ctx context.Context, userID string, ID string)
 	return nil
 }
 
-func (p *postService) UpdatePost(ctx context.Context, userID string, ID string, title string, content string) error {
+func (p *postService) UpdatePost(ctx context.Context, userID string, ID string, title string, content string, visibility models.PostVisibility, version int) (int, error) {
+	payload := &models.PatchPostPayload{Title: &title, Content: &content}
+	if visibility != "" {
+		payload.Visibility = &visibility
+	}
+
+	return p.PatchPost(ctx, userID, ID, payload, version)
+}
+
+// PatchPost applies the fields set in payload only if the post is still at
+// the given version and returns the new version. A version of 0 skips the
+// check.
+func (p *postService) PatchPost(ctx context.Context, userID string, ID string, payload *models.PatchPostPayload, version int) (int, error) {
 	post, err := p.pr.GetPostByID(ctx, ID)
 	if err != nil {
-		return fmt.Errorf("get post by id %s: %w", ID, err)
+		return 0, fmt.Errorf("get post by id %s: %w", ID, err)
 	}
 
 	if post == nil {
-		return models.ErrPostNotFound
+		return 0, models.ErrPostNotFound
 	}
 
 	if post.AuthorID != userID {
-		return models.ErrPostNotBelongToUser
+		return 0, models.ErrPostNotBelongToUser
 	}
 
-	post.Title = title
-	post.Content = content
+	if version != 0 && post.Version != version {
+		return 0, models.ErrPostVersionMismatch
+	}
 
-	if err := p.pr.UpdatePost(ctx, post); err != nil {
-		return fmt.Errorf("update post %s: %w", ID, err)
+	if payload.Title == nil && payload.Content == nil && payload.Visibility == nil {
+		return post.Version, nil
 	}
 
-	return nil
+	titleChanged := payload.Title != nil && post.Title != *payload.Title
+
+	if payload.Title != nil {
+		post.Title = *payload.Title
+	}
+
+	if payload.Content != nil {
+		contentHTML, err := p.mr.Render(*payload.Content)
+		if err != nil {
+			return 0, fmt.Errorf("render content: %w", err)
+		}
+
+		post.Content = *payload.Content
+		post.ContentHTML = sql.NullString{String: contentHTML, Valid: true}
+	}
+
+	if payload.Visibility != nil {
+		post.Visibility = *payload.Visibility
+	}
+
+	updated, err := p.pr.UpdatePost(ctx, post)
+	if err != nil {
+		return 0, fmt.Errorf("update post %s: %w", ID, err)
+	}
+
+	if !updated {
+		return 0, models.ErrPostVersionMismatch
+	}
+
+	if err := p.pls.SyncLinks(ctx, post); err != nil {
+		return 0, fmt.Errorf("sync links %s: %w", ID, err)
+	}
+
+	if titleChanged {
+		if err := p.pls.ResolvePendingLinks(ctx, post); err != nil {
+			return 0, fmt.Errorf("resolve pending links %s: %w", ID, err)
+		}
+	}
+
+	return post.Version, nil
 }
 
 func (p *postService) GetPostsByUsername(ctx context.Context, userID string, username string) ([]*models.PostResponse, error) {
@@ -168,7 +394,7 @@ func (p *postService) GetPostsByUsername(ctx context.Context, userID string, use
 		return nil, fmt.Errorf("get user by username %s: %w", username, err)
 	}
 
-	if author == nil {
+	if hiddenUser(author) {
 		return nil, models.ErrUserNotFound
 	}
 
@@ -177,6 +403,10 @@ func (p *postService) GetPostsByUsername(ctx context.Context, userID string, use
 		return nil, fmt.Errorf("get posts by author id %s: %w", author.ID, err)
 	}
 
+	posts = slices.DeleteFunc(posts, func(post *models.Post) bool {
+		return !visibleTo(post, userID)
+	})
+
 	if len(posts) == 0 {
 		return []*models.PostResponse{}, nil
 	}
@@ -191,15 +421,36 @@ func (p *postService) GetPostsByUsername(ctx context.Context, userID string, use
 		return nil, fmt.Errorf("check likes: %w", err)
 	}
 
+	linksMap, err := p.pls.GetLinks(ctx, userID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get links: %w", err)
+	}
+
+	pollsMap, err := p.pos.GetPolls(ctx, userID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get polls: %w", err)
+	}
+
 	postResponses := make([]*models.PostResponse, len(posts))
 	for i, post := range posts {
+		contentHTML, err := renderContent(p.mr, post.Content, post.ContentHTML)
+		if err != nil {
+			return nil, fmt.Errorf("render content %s: %w", post.ID, err)
+		}
+
 		postResponses[i] = &models.PostResponse{
 			ID:          post.ID,
 			Title:       post.Title,
 			Content:     post.Content,
+			ContentHTML: contentHTML,
+			Visibility:  post.Visibility,
 			Likes:       post.Likes,
 			LikedByUser: likedMap[post.ID],
+			Links:       linksMap[post.ID],
+			Poll:        pollsMap[post.ID],
+			Version:     post.Version,
 			CreatedAt:   post.CreatedAt,
+			UpdatedAt:   lastModified(post),
 		}
 	}
 
@@ -222,17 +473,81 @@ func (p *postService) GetPostsByAuthorID(ctx context.Context, authorID string) (
 		return nil, fmt.Errorf("check likes: %w", err)
 	}
 
+	linksMap, err := p.pls.GetLinks(ctx, authorID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get links: %w", err)
+	}
+
+	pollsMap, err := p.pos.GetPolls(ctx, authorID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get polls: %w", err)
+	}
+
 	postResponses := make([]*models.PostResponse, len(posts))
 	for i, post := range posts {
+		contentHTML, err := renderContent(p.mr, post.Content, post.ContentHTML)
+		if err != nil {
+			return nil, fmt.Errorf("render content %s: %w", post.ID, err)
+		}
+
 		postResponses[i] = &models.PostResponse{
 			ID:          post.ID,
 			Title:       post.Title,
 			Content:     post.Content,
+			ContentHTML: contentHTML,
+			Visibility:  post.Visibility,
 			Likes:       post.Likes,
 			LikedByUser: likedMap[post.ID],
+			Links:       linksMap[post.ID],
+			Poll:        pollsMap[post.ID],
+			Version:     post.Version,
 			CreatedAt:   post.CreatedAt,
+			UpdatedAt:   lastModified(post),
 		}
 	}
 
 	return postResponses, nil
 }
+
+func (p *postService) GetBacklinks(ctx context.Context, userID string, postID string) ([]*models.BacklinkResponse, error) {
+	post, err := p.pr.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id %s: %w", postID, err)
+	}
+
+	if post == nil || !visibleTo(post, userID) {
+		return nil, models.ErrPostNotFound
+	}
+
+	backlinks, err := p.pls.GetBacklinks(ctx, userID, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get backlinks: %w", err)
+	}
+
+	return backlinks, nil
+}
+
+// lastModified is when the post was last edited, or created if it never was.
+func lastModified(post *models.Post) time.Time {
+	if post.UpdatedAt.Valid {
+		return post.UpdatedAt.Time
+	}
+
+	return post.CreatedAt
+}
+
+// visibleTo reports whether userID may read the post. Private posts are
+// only visible to their author.
+func visibleTo(post *models.Post, userID string) bool {
+	return post.Visibility != models.PostVisibilityPrivate || post.AuthorID == userID
+}
+
+// renderContent returns the HTML cached for the current revision. Only posts
+// written before content_html existed have to be rendered on read.
+func renderContent(mr pkgs.MarkdownRenderer, content string, cached sql.NullString) (string, error) {
+	if cached.Valid {
+		return cached.String, nil
+	}
+
+	return mr.Render(content)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+	"github.com/g-villarinho/tab-notes-api/utils"
+	"github.com/google/uuid"
+)
+
+type PostLinkService interface {
+	SyncLinks(ctx context.Context, post *models.Post) error
+	ResolvePendingLinks(ctx context.Context, post *models.Post) error
+	GetLinks(ctx context.Context, userID string, postIDs []string) (map[string][]*models.PostLinkResponse, error)
+	GetBacklinks(ctx context.Context, userID string, postID string) ([]*models.BacklinkResponse, error)
+}
+
+type postLinkService struct {
+	plr repositories.PostLinkRepository
+	pr  repositories.PostRepository
+}
+
+func NewPostLinkService(
+	postLinkRepository repositories.PostLinkRepository,
+	postRepository repositories.PostRepository) PostLinkService {
+	return &postLinkService{
+		plr: postLinkRepository,
+		pr:  postRepository,
+	}
+}
+
+func (l *postLinkService) SyncLinks(ctx context.Context, post *models.Post) error {
+	refs := utils.ParseWikiLinks(post.Content)
+	now := time.Now().UTC()
+
+	links := make([]*models.PostLink, 0, len(refs))
+	for _, ref := range refs {
+		target, err := l.resolveRef(ctx, post.AuthorID, ref)
+		if err != nil {
+			return fmt.Errorf("resolve link %q: %w", ref, err)
+		}
+
+		link := &models.PostLink{
+			SourcePostID: post.ID,
+			TargetRef:    ref,
+			CreatedAt:    now,
+		}
+
+		if target != nil && target.ID != post.ID {
+			link.TargetPostID = sql.NullString{String: target.ID, Valid: true}
+		}
+
+		links = append(links, link)
+	}
+
+	if err := l.plr.ReplacePostLinks(ctx, post.ID, links); err != nil {
+		return fmt.Errorf("replace post links %s: %w", post.ID, err)
+	}
+
+	return nil
+}
+
+func (l *postLinkService) ResolvePendingLinks(ctx context.Context, post *models.Post) error {
+	if err := l.plr.ResolvePendingLinks(ctx, post.AuthorID, post.Title, post.ID); err != nil {
+		return fmt.Errorf("resolve pending links to %s: %w", post.ID, err)
+	}
+
+	return nil
+}
+
+// GetLinks returns the outgoing links of each post as seen by userID. Links
+// to posts the user can't see keep their ref but lose the target.
+func (l *postLinkService) GetLinks(ctx context.Context, userID string, postIDs []string) (map[string][]*models.PostLinkResponse, error) {
+	links, err := l.plr.GetLinksBySourcePostIDs(ctx, userID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get links by source post ids: %w", err)
+	}
+
+	linksMap := make(map[string][]*models.PostLinkResponse, len(postIDs))
+	for _, id := range postIDs {
+		linksMap[id] = []*models.PostLinkResponse{}
+	}
+
+	for _, link := range links {
+		resp := &models.PostLinkResponse{
+			Ref: link.TargetRef,
+		}
+
+		if link.TargetPostID.Valid {
+			resp.PostID = link.TargetPostID.String
+			resp.Title = link.TargetTitle.String
+		}
+
+		linksMap[link.SourcePostID] = append(linksMap[link.SourcePostID], resp)
+	}
+
+	return linksMap, nil
+}
+
+func (l *postLinkService) GetBacklinks(ctx context.Context, userID string, postID string) ([]*models.BacklinkResponse, error) {
+	backlinks, err := l.plr.GetBacklinks(ctx, userID, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get backlinks %s: %w", postID, err)
+	}
+
+	if len(backlinks) == 0 {
+		return []*models.BacklinkResponse{}, nil
+	}
+
+	return backlinks, nil
+}
+
+// resolveRef looks a reference up as a post ID first and falls back to a
+// title match among the author's own posts, so titles never leak across users.
+// Other users' private posts are never resolved by ID.
+func (l *postLinkService) resolveRef(ctx context.Context, authorID string, ref string) (*models.Post, error) {
+	if _, err := uuid.Parse(ref); err == nil {
+		post, err := l.pr.GetPostByID(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if post != nil && visibleTo(post, authorID) {
+			return post, nil
+		}
+	}
+
+	return l.pr.GetPostByTitle(ctx, authorID, ref)
+}
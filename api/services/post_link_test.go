@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPostLinkService_SyncLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store resolved and unresolved links", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		s := NewPostLinkService(plr, pr)
+
+		targetID := "0196a8b0-4a4e-7c3a-9d7e-1f2a3b4c5d6e"
+		post := &models.Post{
+			ID:       "post-1",
+			AuthorID: "user-1",
+			Content:  "Veja [[Receitas]], [[" + targetID + "]] e [[Ainda não existe]]. De novo: [[receitas]]",
+		}
+
+		pr.On("GetPostByTitle", ctx, "user-1", "Receitas").
+			Return(&models.Post{ID: "post-2", Title: "Receitas"}, nil)
+		pr.On("GetPostByID", ctx, targetID).
+			Return(&models.Post{ID: targetID}, nil)
+		pr.On("GetPostByTitle", ctx, "user-1", "Ainda não existe").
+			Return(nil, nil)
+
+		plr.On("ReplacePostLinks", ctx, "post-1", mock.MatchedBy(func(links []*models.PostLink) bool {
+			return len(links) == 3 &&
+				links[0].TargetPostID == sql.NullString{String: "post-2", Valid: true} &&
+				links[1].TargetPostID == sql.NullString{String: targetID, Valid: true} &&
+				!links[2].TargetPostID.Valid
+		})).Return(nil)
+
+		err := s.SyncLinks(ctx, post)
+
+		assert.NoError(t, err)
+		pr.AssertExpectations(t)
+		plr.AssertExpectations(t)
+	})
+
+	t.Run("should not resolve a link to the post itself", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		s := NewPostLinkService(plr, pr)
+
+		post := &models.Post{ID: "post-1", AuthorID: "user-1", Title: "Eu", Content: "[[Eu]]"}
+
+		pr.On("GetPostByTitle", ctx, "user-1", "Eu").Return(post, nil)
+		plr.On("ReplacePostLinks", ctx, "post-1", mock.MatchedBy(func(links []*models.PostLink) bool {
+			return len(links) == 1 && !links[0].TargetPostID.Valid
+		})).Return(nil)
+
+		err := s.SyncLinks(ctx, post)
+
+		assert.NoError(t, err)
+		plr.AssertExpectations(t)
+	})
+
+	t.Run("should not resolve a link to another user's private post", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		s := NewPostLinkService(plr, pr)
+
+		targetID := "0196a8b0-4a4e-7c3a-9d7e-1f2a3b4c5d6e"
+		post := &models.Post{ID: "post-1", AuthorID: "user-1", Content: "[[" + targetID + "]]"}
+
+		pr.On("GetPostByID", ctx, targetID).
+			Return(&models.Post{ID: targetID, AuthorID: "user-2", Visibility: models.PostVisibilityPrivate}, nil)
+		pr.On("GetPostByTitle", ctx, "user-1", targetID).Return(nil, nil)
+		plr.On("ReplacePostLinks", ctx, "post-1", mock.MatchedBy(func(links []*models.PostLink) bool {
+			return len(links) == 1 && !links[0].TargetPostID.Valid
+		})).Return(nil)
+
+		err := s.SyncLinks(ctx, post)
+
+		assert.NoError(t, err)
+		plr.AssertExpectations(t)
+	})
+
+	t.Run("should return error if lookup fails", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		pr := new(mocks.PostRepositoryMock)
+		s := NewPostLinkService(plr, pr)
+
+		post := &models.Post{ID: "post-1", AuthorID: "user-1", Content: "[[Nota]]"}
+
+		pr.On("GetPostByTitle", ctx, "user-1", "Nota").Return(nil, errors.New("db error"))
+
+		err := s.SyncLinks(ctx, post)
+
+		assert.ErrorContains(t, err, "resolve link")
+		plr.AssertNotCalled(t, "ReplacePostLinks", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPostLinkService_GetLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should group links by source post", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		s := NewPostLinkService(plr, nil)
+
+		plr.On("GetLinksBySourcePostIDs", ctx, "user-1", []string{"post-1", "post-2"}).
+			Return([]*models.PostLink{
+				{
+					SourcePostID: "post-1",
+					TargetRef:    "Receitas",
+					TargetPostID: sql.NullString{String: "post-3", Valid: true},
+					TargetTitle:  sql.NullString{String: "Receitas", Valid: true},
+				},
+				{SourcePostID: "post-1", TargetRef: "Pendente"},
+			}, nil)
+
+		linksMap, err := s.GetLinks(ctx, "user-1", []string{"post-1", "post-2"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.PostLinkResponse{
+			{Ref: "Receitas", PostID: "post-3", Title: "Receitas"},
+			{Ref: "Pendente"},
+		}, linksMap["post-1"])
+		assert.NotNil(t, linksMap["post-2"])
+		assert.Empty(t, linksMap["post-2"])
+		plr.AssertExpectations(t)
+	})
+}
+
+func TestPostService_GetBacklinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrPostNotFound if post is nil", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(nil, nil)
+
+		backlinks, err := ps.GetBacklinks(ctx, "", "post-1")
+
+		assert.Nil(t, backlinks)
+		assert.ErrorIs(t, err, models.ErrPostNotFound)
+		pr.AssertExpectations(t)
+	})
+
+	t.Run("should return backlinks", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1"}, nil)
+		pls.On("GetBacklinks", ctx, "", "post-1").
+			Return([]*models.BacklinkResponse{{PostID: "post-2", Title: "Origem"}}, nil)
+
+		backlinks, err := ps.GetBacklinks(ctx, "", "post-1")
+
+		assert.NoError(t, err)
+		assert.Len(t, backlinks, 1)
+		pr.AssertExpectations(t)
+		pls.AssertExpectations(t)
+	})
+
+	t.Run("should include the viewer's own private backlinks", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").
+			Return(&models.Post{ID: "post-1", AuthorID: "user-1", Visibility: models.PostVisibilityPrivate}, nil)
+		pls.On("GetBacklinks", ctx, "user-1", "post-1").
+			Return([]*models.BacklinkResponse{{PostID: "post-2", Title: "Rascunho"}}, nil)
+
+		backlinks, err := ps.GetBacklinks(ctx, "user-1", "post-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "post-2", backlinks[0].PostID)
+		pls.AssertExpectations(t)
+	})
+}
+
+func TestPostLinkService_GetBacklinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should ask the repository for the viewer's backlinks", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		s := NewPostLinkService(plr, nil)
+
+		plr.On("GetBacklinks", ctx, "user-1", "post-1").
+			Return([]*models.BacklinkResponse{{PostID: "post-2", Title: "Rascunho"}}, nil)
+
+		backlinks, err := s.GetBacklinks(ctx, "user-1", "post-1")
+
+		assert.NoError(t, err)
+		assert.Len(t, backlinks, 1)
+		plr.AssertExpectations(t)
+	})
+
+	t.Run("should return an empty list when nothing links to the post", func(t *testing.T) {
+		plr := new(mocks.PostLinkRepositoryMock)
+		s := NewPostLinkService(plr, nil)
+
+		plr.On("GetBacklinks", ctx, "", "post-1").Return(nil, nil)
+
+		backlinks, err := s.GetBacklinks(ctx, "", "post-1")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, backlinks)
+		assert.Empty(t, backlinks)
+	})
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -17,34 +18,168 @@ func TestCreatePost(t *testing.T) {
 
 	t.Run("should return error if repository fails", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
+
+		renderer.
+			On("Render", mock.AnythingOfType("string")).
+			Return("<p>conteúdo</p>", nil)
 
 		postRepo.
 			On("CreatePost", ctx, mock.AnythingOfType("*models.Post")).
 			Return(errors.New("db error"))
 
-		_, err := ps.CreatePost(ctx, "user-123", "Meu título", "Meu conteúdo")
+		_, err := ps.CreatePost(ctx, "user-123", "Meu título", "Meu conteúdo", "", nil)
 
 		assert.ErrorContains(t, err, "create post")
 		postRepo.AssertExpectations(t)
 	})
 
 	t.Run("should create post successfully", func(t *testing.T) {
+		federationService := new(mocks.FederationServiceMock)
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(federationService, likeService, nil, linkService, postRepo, userRepo, renderer)
+
+		renderer.
+			On("Render", mock.AnythingOfType("string")).
+			Return("<p>conteúdo</p>", nil)
 
 		postRepo.
 			On("CreatePost", ctx, mock.AnythingOfType("*models.Post")).
 			Return(nil)
 
-		_, err := ps.CreatePost(ctx, "user-123", "Título válido", "Conteúdo válido")
+		linkService.
+			On("SyncLinks", ctx, mock.AnythingOfType("*models.Post")).
+			Return(nil)
+
+		linkService.
+			On("ResolvePendingLinks", ctx, mock.AnythingOfType("*models.Post")).
+			Return(nil)
+
+		linkService.
+			On("GetLinks", ctx, mock.Anything, mock.Anything).
+			Return(map[string][]*models.PostLinkResponse{}, nil)
+
+		federationService.
+			On("PublishPost", ctx, mock.AnythingOfType("*models.Post")).
+			Return(errors.New("remote down"))
+
+		_, err := ps.CreatePost(ctx, "user-123", "Título válido", "Conteúdo válido", "", nil)
 
 		assert.NoError(t, err)
 		postRepo.AssertExpectations(t)
+		linkService.AssertExpectations(t)
+		federationService.AssertExpectations(t)
+	})
+
+	t.Run("should return error if link sync fails", func(t *testing.T) {
+		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
+		postRepo := new(mocks.PostRepositoryMock)
+		userRepo := new(mocks.UserRepositoryMock)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
+
+		renderer.
+			On("Render", mock.AnythingOfType("string")).
+			Return("<p>conteúdo</p>", nil)
+
+		postRepo.
+			On("CreatePost", ctx, mock.AnythingOfType("*models.Post")).
+			Return(nil)
+
+		linkService.
+			On("SyncLinks", ctx, mock.AnythingOfType("*models.Post")).
+			Return(errors.New("db error"))
+
+		_, err := ps.CreatePost(ctx, "user-123", "Título", "Veja [[Outra nota]]", "", nil)
+
+		assert.ErrorContains(t, err, "sync links")
+		postRepo.AssertExpectations(t)
+		linkService.AssertExpectations(t)
+	})
+
+	t.Run("should reject a poll that is already closed before creating the post", func(t *testing.T) {
+		postRepo := new(mocks.PostRepositoryMock)
+		ps := NewPostService(nil, nil, nil, nil, postRepo, nil, nil)
+
+		poll := &models.CreatePollPayload{
+			Options:  []string{"Sim", "Não"},
+			ClosesAt: time.Now().Add(-time.Hour),
+		}
+
+		_, err := ps.CreatePost(ctx, "user-123", "Título", "Conteúdo", "", poll)
+
+		assert.ErrorIs(t, err, models.ErrInvalidPollClosingTime)
+		postRepo.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create the poll along with the post", func(t *testing.T) {
+		federationService := new(mocks.FederationServiceMock)
+		pollService := new(mocks.PollServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
+		postRepo := new(mocks.PostRepositoryMock)
+		ps := NewPostService(federationService, nil, pollService, linkService, postRepo, nil, renderer)
+
+		poll := &models.CreatePollPayload{
+			Options:  []string{"Sim", "Não"},
+			ClosesAt: time.Now().Add(24 * time.Hour),
+		}
+
+		renderer.On("Render", mock.AnythingOfType("string")).Return("<p>conteúdo</p>", nil)
+		postRepo.
+			On("CreatePost", ctx, mock.AnythingOfType("*models.Post")).
+			Run(func(args mock.Arguments) { args.Get(1).(*models.Post).ID = "post-1" }).
+			Return(nil)
+		linkService.On("SyncLinks", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+		linkService.On("ResolvePendingLinks", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+		linkService.On("GetLinks", ctx, "user-123", []string{"post-1"}).Return(map[string][]*models.PostLinkResponse{}, nil)
+		pollService.On("CreatePoll", ctx, "post-1", poll).Return(&models.PollResponse{ID: "poll-1"}, nil)
+		federationService.On("PublishPost", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+
+		post, err := ps.CreatePost(ctx, "user-123", "Título", "Conteúdo", "", poll)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "poll-1", post.Poll.ID)
+		pollService.AssertExpectations(t)
+	})
+
+	t.Run("should delete the post if the poll cannot be created", func(t *testing.T) {
+		federationService := new(mocks.FederationServiceMock)
+		pollService := new(mocks.PollServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
+		postRepo := new(mocks.PostRepositoryMock)
+		ps := NewPostService(federationService, nil, pollService, linkService, postRepo, nil, renderer)
+
+		poll := &models.CreatePollPayload{
+			Options:  []string{"Sim", "Não"},
+			ClosesAt: time.Now().Add(24 * time.Hour),
+		}
+
+		renderer.On("Render", mock.AnythingOfType("string")).Return("<p>conteúdo</p>", nil)
+		postRepo.
+			On("CreatePost", ctx, mock.AnythingOfType("*models.Post")).
+			Run(func(args mock.Arguments) { args.Get(1).(*models.Post).ID = "post-1" }).
+			Return(nil)
+		pollService.On("CreatePoll", ctx, "post-1", poll).Return(nil, errors.New("db error"))
+		postRepo.On("DeletePost", ctx, "post-1").Return(nil)
+
+		post, err := ps.CreatePost(ctx, "user-123", "Título", "Conteúdo", "", poll)
+
+		assert.Nil(t, post)
+		assert.ErrorContains(t, err, "create poll")
+		postRepo.AssertExpectations(t)
+		linkService.AssertNotCalled(t, "SyncLinks", mock.Anything, mock.Anything)
+		federationService.AssertNotCalled(t, "PublishPost", mock.Anything, mock.Anything)
 	})
 }
 
@@ -53,9 +188,11 @@ func TestLikePost(t *testing.T) {
 
 	t.Run("should return error if GetPostByID fails", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		postRepo.
 			On("GetPostByID", ctx, "post-123").
@@ -69,9 +206,11 @@ func TestLikePost(t *testing.T) {
 
 	t.Run("should return ErrPostNotFound if post is nil", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		postRepo.
 			On("GetPostByID", ctx, "post-123").
@@ -85,9 +224,11 @@ func TestLikePost(t *testing.T) {
 
 	t.Run("should return error if LikePost fails", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		post := &models.Post{ID: "post-123"}
 
@@ -108,9 +249,11 @@ func TestLikePost(t *testing.T) {
 
 	t.Run("should like post successfully", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		post := &models.Post{ID: "post-123"}
 
@@ -135,9 +278,11 @@ func TestUnlikePost(t *testing.T) {
 
 	t.Run("should return error if GetPostByID fails", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		postRepo.
 			On("GetPostByID", ctx, "post-123").
@@ -151,9 +296,11 @@ func TestUnlikePost(t *testing.T) {
 
 	t.Run("should return ErrPostNotFound if post is nil", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		postRepo.
 			On("GetPostByID", ctx, "post-123").
@@ -167,9 +314,11 @@ func TestUnlikePost(t *testing.T) {
 
 	t.Run("should return error if UnlikePost fails", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		post := &models.Post{ID: "post-123"}
 
@@ -190,9 +339,11 @@ func TestUnlikePost(t *testing.T) {
 
 	t.Run("should unlike post successfully", func(t *testing.T) {
 		likeService := new(mocks.LikeServiceMock)
+		linkService := new(mocks.PostLinkServiceMock)
+		renderer := new(mocks.MarkdownRendererMock)
 		postRepo := new(mocks.PostRepositoryMock)
 		userRepo := new(mocks.UserRepositoryMock)
-		ps := NewPostService(likeService, postRepo, userRepo)
+		ps := NewPostService(nil, likeService, nil, linkService, postRepo, userRepo, renderer)
 
 		post := &models.Post{ID: "post-123"}
 
@@ -218,7 +369,9 @@ func TestPostService_GetPostByID(t *testing.T) {
 	t.Run("should return error if repository fails", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
 		ls := new(mocks.LikeServiceMock)
-		ps := NewPostService(ls, pr, nil)
+		pls := new(mocks.PostLinkServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, ls, nil, pls, pr, nil, mr)
 
 		pr.On("GetPostByID", ctx, "123").Return(nil, errors.New("db error"))
 
@@ -232,7 +385,9 @@ func TestPostService_GetPostByID(t *testing.T) {
 	t.Run("should return nil if post not found", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
 		ls := new(mocks.LikeServiceMock)
-		ps := NewPostService(ls, pr, nil)
+		pls := new(mocks.PostLinkServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, ls, nil, pls, pr, nil, mr)
 
 		pr.On("GetPostByID", ctx, "123").Return(nil, nil)
 
@@ -243,10 +398,27 @@ func TestPostService_GetPostByID(t *testing.T) {
 		pr.AssertExpectations(t)
 	})
 
+	t.Run("should return nil for a private post of another author", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		ls := new(mocks.LikeServiceMock)
+		ps := NewPostService(nil, ls, nil, nil, pr, nil, nil)
+
+		pr.On("GetPostByID", ctx, "123").Return(&models.Post{ID: "123", AuthorID: "user2", Visibility: models.PostVisibilityPrivate}, nil)
+
+		post, err := ps.GetPostByID(ctx, "user1", "123")
+
+		assert.Nil(t, post)
+		assert.NoError(t, err)
+		ls.AssertNotCalled(t, "CheckLike", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("should return error if like check fails", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
 		ls := new(mocks.LikeServiceMock)
-		ps := NewPostService(ls, pr, nil)
+		pls := new(mocks.PostLinkServiceMock)
+		pos := new(mocks.PollServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, ls, pos, pls, pr, nil, mr)
 
 		mockPost := &models.Post{
 			ID:        "123",
@@ -270,7 +442,10 @@ func TestPostService_GetPostByID(t *testing.T) {
 	t.Run("should return post response successfully", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
 		ls := new(mocks.LikeServiceMock)
-		ps := NewPostService(ls, pr, nil)
+		pls := new(mocks.PostLinkServiceMock)
+		pos := new(mocks.PollServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, ls, pos, pls, pr, nil, mr)
 
 		mockPost := &models.Post{
 			ID:        "123",
@@ -282,6 +457,13 @@ func TestPostService_GetPostByID(t *testing.T) {
 
 		pr.On("GetPostByID", ctx, "123").Return(mockPost, nil)
 		ls.On("CheckLike", ctx, "user1", "123").Return(true, nil)
+		mr.On("Render", "Content").Return("<p>Content</p>", nil)
+		pls.On("GetLinks", ctx, "user1", []string{"123"}).Return(map[string][]*models.PostLinkResponse{
+			"123": {{Ref: "Other note", PostID: "456", Title: "Other note"}},
+		}, nil)
+		pos.On("GetPolls", ctx, "user1", []string{"123"}).Return(map[string]*models.PollResponse{
+			"123": {ID: "poll-1", TotalVotes: 3},
+		}, nil)
 
 		post, err := ps.GetPostByID(ctx, "user1", "123")
 
@@ -289,8 +471,14 @@ func TestPostService_GetPostByID(t *testing.T) {
 		assert.NotNil(t, post)
 		assert.Equal(t, mockPost.ID, post.ID)
 		assert.True(t, post.LikedByUser)
+		assert.Len(t, post.Links, 1)
+		assert.Equal(t, 3, post.Poll.TotalVotes)
+		assert.Equal(t, "<p>Content</p>", post.ContentHTML)
 		pr.AssertExpectations(t)
 		ls.AssertExpectations(t)
+		pls.AssertExpectations(t)
+		pos.AssertExpectations(t)
+		mr.AssertExpectations(t)
 	})
 }
 
@@ -299,7 +487,7 @@ func TestPostService_DeletePost(t *testing.T) {
 
 	t.Run("should return error if get post fails", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
-		ps := NewPostService(nil, pr, nil)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
 
 		pr.On("GetPostByID", ctx, "123").Return(nil, errors.New("db error"))
 
@@ -311,7 +499,7 @@ func TestPostService_DeletePost(t *testing.T) {
 
 	t.Run("should return ErrPostNotFound if post is nil", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
-		ps := NewPostService(nil, pr, nil)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
 
 		pr.On("GetPostByID", ctx, "123").Return(nil, nil)
 
@@ -323,7 +511,7 @@ func TestPostService_DeletePost(t *testing.T) {
 
 	t.Run("should return ErrPostNotBelongToUser if user is not the author", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
-		ps := NewPostService(nil, pr, nil)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
 
 		post := &models.Post{
 			ID:       "123",
@@ -340,7 +528,7 @@ func TestPostService_DeletePost(t *testing.T) {
 
 	t.Run("should return error if delete fails", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
-		ps := NewPostService(nil, pr, nil)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
 
 		post := &models.Post{
 			ID:       "123",
@@ -358,7 +546,7 @@ func TestPostService_DeletePost(t *testing.T) {
 
 	t.Run("should delete post successfully", func(t *testing.T) {
 		pr := new(mocks.PostRepositoryMock)
-		ps := NewPostService(nil, pr, nil)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
 
 		post := &models.Post{
 			ID:       "123",
@@ -374,3 +562,205 @@ func TestPostService_DeletePost(t *testing.T) {
 		pr.AssertExpectations(t)
 	})
 }
+
+func TestPostService_UpdatePost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrPostVersionMismatch if version is stale", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1", Version: 3}, nil)
+
+		version, err := ps.UpdatePost(ctx, "user-1", "post-1", "Título", "Conteúdo", "", 2)
+
+		assert.Zero(t, version)
+		assert.ErrorIs(t, err, models.ErrPostVersionMismatch)
+		pr.AssertNotCalled(t, "UpdatePost", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrPostVersionMismatch if post changed during the update", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, nil, nil, nil, pr, nil, mr)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1", Version: 2}, nil)
+		mr.On("Render", "Conteúdo").Return("<p>Conteúdo</p>", nil)
+		pr.On("UpdatePost", ctx, mock.Anything).Return(false, nil)
+
+		version, err := ps.UpdatePost(ctx, "user-1", "post-1", "Título", "Conteúdo", "", 2)
+
+		assert.Zero(t, version)
+		assert.ErrorIs(t, err, models.ErrPostVersionMismatch)
+	})
+
+	t.Run("should update post and return the new version", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, mr)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1", Title: "Título", Version: 2}, nil)
+		mr.On("Render", "Conteúdo").Return("<p>Conteúdo</p>", nil)
+		pr.On("UpdatePost", ctx, mock.MatchedBy(func(p *models.Post) bool {
+			return p.Version == 2
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Post).Version++
+		}).Return(true, nil)
+		pls.On("SyncLinks", ctx, mock.Anything).Return(nil)
+
+		version, err := ps.UpdatePost(ctx, "user-1", "post-1", "Título", "Conteúdo", "", 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, version)
+		pls.AssertNotCalled(t, "ResolvePendingLinks", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPostService_PatchPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep content when only the title is patched", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		mr := new(mocks.MarkdownRendererMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, mr)
+
+		title := "Novo título"
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1", Title: "Antigo", Content: "Texto", Version: 1}, nil)
+		pr.On("UpdatePost", ctx, mock.MatchedBy(func(p *models.Post) bool {
+			return p.Title == "Novo título" && p.Content == "Texto"
+		})).Return(true, nil)
+		pls.On("SyncLinks", ctx, mock.Anything).Return(nil)
+		pls.On("ResolvePendingLinks", ctx, mock.Anything).Return(nil)
+
+		_, err := ps.PatchPost(ctx, "user-1", "post-1", &models.PatchPostPayload{Title: &title}, 1)
+
+		assert.NoError(t, err)
+		mr.AssertNotCalled(t, "Render", mock.Anything)
+		pr.AssertExpectations(t)
+		pls.AssertExpectations(t)
+	})
+
+	t.Run("should make a post private", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		pls := new(mocks.PostLinkServiceMock)
+		ps := NewPostService(nil, nil, nil, pls, pr, nil, nil)
+
+		visibility := models.PostVisibilityPrivate
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1", Visibility: models.PostVisibilityPublic, Version: 1}, nil)
+		pr.On("UpdatePost", ctx, mock.MatchedBy(func(p *models.Post) bool {
+			return p.Visibility == models.PostVisibilityPrivate
+		})).Return(true, nil)
+		pls.On("SyncLinks", ctx, mock.Anything).Return(nil)
+
+		_, err := ps.PatchPost(ctx, "user-1", "post-1", &models.PatchPostPayload{Visibility: &visibility}, 1)
+
+		assert.NoError(t, err)
+		pr.AssertExpectations(t)
+	})
+}
+
+func TestPostService_GetPostsByUsername(t *testing.T) {
+	ctx := context.Background()
+	posts := []*models.Post{
+		{ID: "post-1", AuthorID: "user-1", Visibility: models.PostVisibilityPublic, ContentHTML: sql.NullString{String: "<p>a</p>", Valid: true}},
+		{ID: "post-2", AuthorID: "user-1", Visibility: models.PostVisibilityPrivate, ContentHTML: sql.NullString{String: "<p>b</p>", Valid: true}},
+	}
+
+	t.Run("should hide private posts from other viewers", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		ur := new(mocks.UserRepositoryMock)
+		ls := new(mocks.LikeServiceMock)
+		pls := new(mocks.PostLinkServiceMock)
+		pos := new(mocks.PollServiceMock)
+		ps := NewPostService(nil, ls, pos, pls, pr, ur, nil)
+
+		ur.On("GetUserByUsername", ctx, "joao").Return(&models.User{ID: "user-1", Username: "joao"}, nil)
+		pr.On("GetPostsByAuthorID", ctx, "user-1").Return(append([]*models.Post{}, posts...), nil)
+		ls.On("CheckLikes", ctx, "", []string{"post-1"}).Return(map[string]bool{}, nil)
+		pls.On("GetLinks", ctx, "", []string{"post-1"}).Return(map[string][]*models.PostLinkResponse{}, nil)
+		pos.On("GetPolls", ctx, "", []string{"post-1"}).Return(map[string]*models.PollResponse{}, nil)
+
+		result, err := ps.GetPostsByUsername(ctx, "", "joao")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "post-1", result[0].ID)
+	})
+
+	t.Run("should include private posts for their author", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		ur := new(mocks.UserRepositoryMock)
+		ls := new(mocks.LikeServiceMock)
+		pls := new(mocks.PostLinkServiceMock)
+		pos := new(mocks.PollServiceMock)
+		ps := NewPostService(nil, ls, pos, pls, pr, ur, nil)
+
+		ur.On("GetUserByUsername", ctx, "joao").Return(&models.User{ID: "user-1", Username: "joao"}, nil)
+		pr.On("GetPostsByAuthorID", ctx, "user-1").Return(append([]*models.Post{}, posts...), nil)
+		ls.On("CheckLikes", ctx, "user-1", []string{"post-1", "post-2"}).Return(map[string]bool{}, nil)
+		pls.On("GetLinks", ctx, "user-1", []string{"post-1", "post-2"}).Return(map[string][]*models.PostLinkResponse{}, nil)
+		pos.On("GetPolls", ctx, "user-1", []string{"post-1", "post-2"}).Return(map[string]*models.PollResponse{}, nil)
+
+		result, err := ps.GetPostsByUsername(ctx, "user-1", "joao")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, models.PostVisibilityPrivate, result[1].Visibility)
+	})
+}
+
+func TestGetPostEmbed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return card data for a public post", func(t *testing.T) {
+		postRepo := new(mocks.PostRepositoryMock)
+		userRepo := new(mocks.UserRepositoryMock)
+		ps := NewPostService(nil, nil, nil, nil, postRepo, userRepo, nil)
+
+		postRepo.
+			On("GetPostByID", ctx, "post-1").
+			Return(&models.Post{ID: "post-1", Title: "Olá", Visibility: models.PostVisibilityPublic, AuthorID: "user-1", Likes: 2}, nil)
+
+		userRepo.
+			On("GetUserByID", ctx, "user-1").
+			Return(&models.User{ID: "user-1", Name: "João", Username: "joao"}, nil)
+
+		embed, err := ps.GetPostEmbed(ctx, "post-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "joao", embed.AuthorUsername)
+		assert.Equal(t, 2, embed.Likes)
+	})
+
+	t.Run("should return ErrPostNotFound for private post", func(t *testing.T) {
+		postRepo := new(mocks.PostRepositoryMock)
+		userRepo := new(mocks.UserRepositoryMock)
+		ps := NewPostService(nil, nil, nil, nil, postRepo, userRepo, nil)
+
+		postRepo.
+			On("GetPostByID", ctx, "post-1").
+			Return(&models.Post{ID: "post-1", Visibility: models.PostVisibilityPrivate, AuthorID: "user-1"}, nil)
+
+		_, err := ps.GetPostEmbed(ctx, "post-1")
+
+		assert.ErrorIs(t, err, models.ErrPostNotFound)
+		userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrPostNotFound for deleted post", func(t *testing.T) {
+		postRepo := new(mocks.PostRepositoryMock)
+		ps := NewPostService(nil, nil, nil, nil, postRepo, nil, nil)
+
+		postRepo.
+			On("GetPostByID", ctx, "post-1").
+			Return(nil, nil)
+
+		_, err := ps.GetPostEmbed(ctx, "post-1")
+
+		assert.ErrorIs(t, err, models.ErrPostNotFound)
+	})
+}
@@ -2,33 +2,57 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"database/sql"
 	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
+	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/g-villarinho/tab-notes-api/repositories"
 )
 
+// maxTrackedSessions bounds the sessions TouchSession remembers before it
+// prunes stale ones.
+const maxTrackedSessions = 10_000
+
 type SessionService interface {
 	CreateSession(ctx context.Context, userID, email string) (string, error)
-	ValidSession(ctx context.Context, token string) (string, error)
+	CreateLoginSession(ctx context.Context, userID, email string, withCode bool) (*models.LoginSession, error)
+	ValidSession(ctx context.Context, token string, client *models.ClientInfo) (*models.AuthResponse, error)
+	RequiresApproval(ctx context.Context, token string, pollSecret string) (bool, error)
+	ApproveSession(ctx context.Context, token string, verificationCode string) error
+	ClaimApprovedSession(ctx context.Context, sessionID string, pollSecret string, client *models.ClientInfo) (*models.AuthResponse, error)
+	ValidSessionCode(ctx context.Context, userID string, code string, client *models.ClientInfo) (*models.AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.AuthResponse, error)
 	RevokeSession(ctx context.Context, sessionId string) error
 	IsSessionRevoked(ctx context.Context, sessionId string) (bool, error)
+	TouchSession(ctx context.Context, sessionID string) error
+	EnsureUserActive(ctx context.Context, userID string) error
 	GetUserSessions(ctx context.Context, userID string, currentSessionID string) ([]*models.SessionResponse, error)
 	RevokeUserSession(ctx context.Context, userID string, sessionID string) error
+	RevokeSessionFromLink(ctx context.Context, token string) error
 	RevokeAllUserSessions(ctx context.Context, userID string, currentSessionID string, revokeCurrent bool) error
+	RevokeAllSessionsByUserID(ctx context.Context, userID string) error
 }
 
 type sessionService struct {
 	ts TokenService
 	sr repositories.SessionRepository
+
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
 }
 
 func NewSessionService(tokenService TokenService, sessionRepository repositories.SessionRepository) SessionService {
 	return &sessionService{
-		ts: tokenService,
-		sr: sessionRepository,
+		ts:       tokenService,
+		sr:       sessionRepository,
+		lastSeen: make(map[string]time.Time),
 	}
 }
 
@@ -37,59 +61,486 @@ func (s *sessionService) CreateSession(ctx context.Context, userID string, email
 	session := models.Session{
 		UserID:    userID,
 		CreatedAt: now,
-		ExpiresAt: now.Add(time.Minute * 15),
+		ExpiresAt: now.Add(configs.Env.Session.MagicLink),
+	}
+
+	return s.storeSession(ctx, &session, email)
+}
+
+// CreateLoginSession creates the session of a login email requested through
+// /authenticate. Besides the magic link token it hands out a poll secret,
+// which lets the requesting device claim the session once the link is
+// approved from another device, and the verification code that approval
+// asks for. withCode adds a 6-digit code that signs the session in like the
+// link, within the same window.
+func (s *sessionService) CreateLoginSession(ctx context.Context, userID string, email string, withCode bool) (*models.LoginSession, error) {
+	var err error
+	login := &models.LoginSession{}
+
+	if withCode {
+		if login.Code, err = generateLoginCode(); err != nil {
+			return nil, fmt.Errorf("generate login code: %w", err)
+		}
+	}
+
+	if login.VerificationCode, err = generateLoginCode(); err != nil {
+		return nil, fmt.Errorf("generate verification code: %w", err)
+	}
+
+	if login.PollSecret, err = generateOpaqueToken(); err != nil {
+		return nil, fmt.Errorf("generate poll secret: %w", err)
+	}
+
+	now := time.Now().UTC()
+	session := models.Session{
+		UserID:           userID,
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(configs.Env.Session.MagicLink),
+		PollSecretHash:   sql.NullString{String: hashOpaqueToken(login.PollSecret), Valid: true},
+		ApprovalCodeHash: sql.NullString{String: hashLoginCode(userID, login.VerificationCode), Valid: true},
 	}
 
+	if withCode {
+		session.CodeHash = sql.NullString{String: hashLoginCode(userID, login.Code), Valid: true}
+	}
+
+	if login.Token, err = s.storeSession(ctx, &session, email); err != nil {
+		return nil, err
+	}
+
+	login.SessionID = session.ID
+	login.ExpiresAt = session.ExpiresAt
+
+	return login, nil
+}
+
+func (s *sessionService) storeSession(ctx context.Context, session *models.Session, email string) (string, error) {
 	tokenMagicLink, err := s.ts.GenerateMagicLinkToken(ctx, email, session.CreatedAt, session.ExpiresAt)
 	if err != nil {
 		return "", err
 	}
 
-	session.Token = tokenMagicLink
+	session.TokenHash = hashOpaqueToken(tokenMagicLink)
 
-	if err := s.sr.CreateSession(ctx, &session); err != nil {
+	if err := s.sr.CreateSession(ctx, session); err != nil {
 		return "", err
 	}
 
-	return session.Token, nil
+	return tokenMagicLink, nil
 }
 
-func (s *sessionService) ValidSession(ctx context.Context, token string) (string, error) {
+// ValidSession signs the user in with a magic link token, starting the
+// session's refresh token chain. The link works once.
+func (s *sessionService) ValidSession(ctx context.Context, token string, client *models.ClientInfo) (*models.AuthResponse, error) {
 	now := time.Now().UTC()
 
-	session, err := s.sr.GetSessionByToken(ctx, token)
+	session, err := s.sr.GetSessionByTokenHash(ctx, hashOpaqueToken(token))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if session == nil {
-		return "", models.ErrSessionNotFound
+	// An approved session belongs to the device that asked for it.
+	if session == nil || session.VerifiedAt.Valid || session.ApprovedAt.Valid {
+		return nil, models.ErrSessionNotFound
 	}
 
 	if session.ExpiresAt.Before(now) {
 		if err := s.sr.DeleteSession(ctx, session.ID); err != nil {
-			return "", fmt.Errorf("delete session %s: %w", session.ID, err)
+			return nil, fmt.Errorf("delete session %s: %w", session.ID, err)
 		}
 
-		return "", models.ErrSessionExpired
+		return nil, models.ErrSessionExpired
 	}
 
-	expiresAt := now.Add(time.Hour * 24 * 7)
+	return s.verifySession(ctx, session, client, now)
+}
 
-	authToken, err := s.ts.GenerateAuthToken(ctx, session.UserID, session.ID, session.CreatedAt, expiresAt)
+// RequiresApproval reports whether a magic link was opened away from the
+// device that asked for it, told by the poll secret that device keeps.
+// Sessions it cannot find are left for ValidSession to reject.
+func (s *sessionService) RequiresApproval(ctx context.Context, token string, pollSecret string) (bool, error) {
+	session, err := s.sr.GetSessionByTokenHash(ctx, hashOpaqueToken(token))
 	if err != nil {
-		return "", err
+		return false, fmt.Errorf("get session by token hash: %w", err)
+	}
+
+	if session == nil || !session.PollSecretHash.Valid {
+		return false, nil
+	}
+
+	return !matchesHash(hashOpaqueToken(pollSecret), session.PollSecretHash.String), nil
+}
+
+// ApproveSession lets the magic link hand its session to the device that
+// asked for it. The verification code shown on that device proves the
+// approver can see it, so a login requested by someone else cannot be
+// approved by just clicking through. Guesses count towards the same
+// MaxLoginCodeAttempts as the email code.
+func (s *sessionService) ApproveSession(ctx context.Context, token string, verificationCode string) error {
+	now := time.Now().UTC()
+
+	session, err := s.sr.GetSessionByTokenHash(ctx, hashOpaqueToken(token))
+	if err != nil {
+		return fmt.Errorf("get session by token hash: %w", err)
+	}
+
+	if session == nil || !session.ApprovalCodeHash.Valid {
+		return models.ErrSessionNotFound
+	}
+
+	if session.ApprovedAt.Valid {
+		return nil
+	}
+
+	if session.ExpiresAt.Before(now) {
+		return models.ErrSessionExpired
+	}
+
+	counted, err := s.sr.RecordCodeAttempt(ctx, session.ID, models.MaxLoginCodeAttempts)
+	if err != nil {
+		return fmt.Errorf("record code attempt for session %s: %w", session.ID, err)
+	}
+
+	if !counted {
+		return models.ErrLoginCodeAttemptsExceeded
+	}
+
+	if !matchesHash(hashLoginCode(session.UserID, verificationCode), session.ApprovalCodeHash.String) {
+		return models.ErrInvalidLoginCode
+	}
+
+	if err := s.sr.ApproveSession(ctx, session.ID, now); err != nil {
+		return fmt.Errorf("approve session %s: %w", session.ID, err)
+	}
+
+	return nil
+}
+
+// ClaimApprovedSession signs in the device polling a pending login. It
+// returns nil while the login waits for approval, and only the first poll
+// after it gets the tokens.
+func (s *sessionService) ClaimApprovedSession(ctx context.Context, sessionID string, pollSecret string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	now := time.Now().UTC()
+
+	session, err := s.sr.GetSessionById(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session by id %s: %w", sessionID, err)
+	}
+
+	if session == nil || !session.PollSecretHash.Valid || session.VerifiedAt.Valid || session.RevokedAt.Valid ||
+		!matchesHash(hashOpaqueToken(pollSecret), session.PollSecretHash.String) {
+		return nil, models.ErrPendingLoginNotFound
+	}
+
+	if !session.ApprovedAt.Valid {
+		if session.ExpiresAt.Before(now) {
+			return nil, models.ErrSessionExpired
+		}
+
+		return nil, nil
+	}
+
+	claimed, err := s.sr.ClaimApprovedSession(ctx, session.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("claim approved session %s: %w", session.ID, err)
+	}
+
+	if !claimed {
+		return nil, models.ErrPendingLoginNotFound
 	}
 
-	session.Token = authToken
+	return s.verifySession(ctx, session, client, now)
+}
+
+// ValidSessionCode signs the user in with the code of their latest login
+// email. Each session takes MaxLoginCodeAttempts guesses, counted before the
+// comparison, and the user MaxUserLoginCodeAttempts across all of them, so
+// minting new sessions does not reset the budget.
+func (s *sessionService) ValidSessionCode(ctx context.Context, userID string, code string, client *models.ClientInfo) (*models.AuthResponse, error) {
+	now := time.Now().UTC()
+
+	session, err := s.sr.GetPendingCodeSessionByUserID(ctx, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("get pending code session by user id %s: %w", userID, err)
+	}
+
+	if session == nil {
+		return nil, models.ErrInvalidLoginCode
+	}
+
+	attempts, err := s.sr.CountCodeAttemptsByUserID(ctx, userID, now.Add(-models.LoginCodeAttemptWindow))
+	if err != nil {
+		return nil, fmt.Errorf("count code attempts for user %s: %w", userID, err)
+	}
+
+	// Guesses in flight at the same time can overshoot the user budget by a
+	// few; the per-session limit below still holds for each of them.
+	if attempts >= models.MaxUserLoginCodeAttempts {
+		return nil, models.ErrLoginCodeAttemptsExceeded
+	}
+
+	counted, err := s.sr.RecordCodeAttempt(ctx, session.ID, models.MaxLoginCodeAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("record code attempt for session %s: %w", session.ID, err)
+	}
+
+	if !counted {
+		return nil, models.ErrLoginCodeAttemptsExceeded
+	}
+
+	if !matchesHash(hashLoginCode(userID, code), session.CodeHash.String) {
+		return nil, models.ErrInvalidLoginCode
+	}
+
+	return s.verifySession(ctx, session, client, now)
+}
+
+// verifySession marks a pending session signed in and starts its refresh
+// token chain. Its magic link token is replaced, so neither the link nor the
+// code work again; a request that loses the race to spend them gets
+// ErrSessionNotFound. The session is labelled with the client it was
+// verified from, the device that will hold it, and the response reports it
+// as a NewSignIn when the user had not signed in from that device or IP
+// address before.
+func (s *sessionService) verifySession(ctx context.Context, session *models.Session, client *models.ClientInfo, now time.Time) (*models.AuthResponse, error) {
+	if err := s.EnsureUserActive(ctx, session.UserID); err != nil {
+		return nil, err
+	}
+
+	magicLinkTokenHash := session.TokenHash
 	session.VerifiedAt = sql.NullTime{Time: now, Valid: true}
-	session.ExpiresAt = expiresAt
+	session.LastSeenAt = sql.NullTime{Time: now, Valid: true}
+	describeClient(session, client)
+	session.ExpiresAt = slideSessionExpiry(session, now)
+
+	unfamiliar, err := s.isUnfamiliarClient(ctx, session, client)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	accessExpiresAt := accessTokenExpiry(session, now)
+
+	authToken, err := s.ts.GenerateAuthToken(ctx, session.UserID, session.ID, session.CreatedAt, accessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session.TokenHash = hashOpaqueToken(authToken)
+
+	verified, err := s.sr.VerifySession(ctx, session, magicLinkTokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("verify session %s: %w", session.ID, err)
+	}
+
+	if !verified {
+		return nil, models.ErrSessionNotFound
+	}
+
+	if err := s.sr.CreateRefreshToken(ctx, &models.RefreshToken{
+		TokenHash: hashOpaqueToken(refreshToken),
+		SessionID: session.ID,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, fmt.Errorf("create refresh token for session %s: %w", session.ID, err)
+	}
+
+	response := newAuthResponse(session, authToken, accessExpiresAt, refreshToken, now)
+
+	if unfamiliar {
+		if response.NewSignIn, err = s.newSignIn(ctx, session, now); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.sr.UpdateSession(ctx, session); err != nil {
-		return "", fmt.Errorf("update session %s: %w", session.ID, err)
+	return response, nil
+}
+
+// isUnfamiliarClient reports whether the user's earlier sessions were all
+// signed in from other devices or IP addresses, or the other way round for
+// either. A user's first session is familiar, there being nothing to alert
+// about on sign up.
+func (s *sessionService) isUnfamiliarClient(ctx context.Context, session *models.Session, client *models.ClientInfo) (bool, error) {
+	if client == nil {
+		return false, nil
+	}
+
+	history, err := s.sr.GetSignInHistory(ctx, session.UserID, session.DeviceLabel, session.IPAddress)
+	if err != nil {
+		return false, fmt.Errorf("get sign in history of user %s: %w", session.UserID, err)
+	}
+
+	return history.Sessions > 0 && (!history.DeviceSeen || !history.IPSeen), nil
+}
+
+// newSignIn describes a verified session for its alert. The revoke link
+// lasts as long as the session can.
+func (s *sessionService) newSignIn(ctx context.Context, session *models.Session, now time.Time) (*models.NewSignIn, error) {
+	revokeToken, err := s.ts.GenerateSessionRevokeToken(ctx, session.UserID, session.ID, now, now.Add(configs.Env.Session.MaxLifetime))
+	if err != nil {
+		return nil, fmt.Errorf("generate session revoke token: %w", err)
+	}
+
+	return &models.NewSignIn{
+		SessionID:   session.ID,
+		UserID:      session.UserID,
+		Device:      session.DeviceLabel.String,
+		IPAddress:   session.IPAddress.String,
+		Location:    session.Location.String,
+		At:          now,
+		RevokeToken: revokeToken,
+	}, nil
+}
+
+// Refresh trades a refresh token for a new access token and refresh token,
+// sliding the session's expiry. A refresh token can be used once: presenting
+// a rotated one revokes the whole session, since either it or its successor
+// is in the wrong hands.
+func (s *sessionService) Refresh(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
+	now := time.Now().UTC()
+
+	current, err := s.sr.GetRefreshTokenByHash(ctx, hashOpaqueToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token by hash: %w", err)
+	}
+
+	if current == nil {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	if current.RotatedAt.Valid {
+		return nil, s.revokeReusedSession(ctx, current.SessionID, now)
+	}
+
+	session, err := s.sr.GetSessionById(ctx, current.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session by id %s: %w", current.SessionID, err)
+	}
+
+	if session == nil || session.RevokedAt.Valid || !session.VerifiedAt.Valid {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	if !session.ExpiresAt.After(now) {
+		return nil, models.ErrSessionExpired
+	}
+
+	if err := s.EnsureUserActive(ctx, session.UserID); err != nil {
+		return nil, err
+	}
+
+	session.ExpiresAt = slideSessionExpiry(session, now)
+
+	nextRefreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	accessExpiresAt := accessTokenExpiry(session, now)
+
+	authToken, err := s.ts.GenerateAuthToken(ctx, session.UserID, session.ID, now, accessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session.TokenHash = hashOpaqueToken(authToken)
+
+	next := &models.RefreshToken{
+		TokenHash: hashOpaqueToken(nextRefreshToken),
+		SessionID: session.ID,
+		CreatedAt: now,
+	}
+
+	rotated, err := s.sr.RotateRefreshToken(ctx, current.TokenHash, next, session)
+	if err != nil {
+		return nil, fmt.Errorf("rotate refresh token of session %s: %w", session.ID, err)
+	}
+
+	if !rotated {
+		return nil, s.revokeReusedSession(ctx, session.ID, now)
+	}
+
+	return newAuthResponse(session, authToken, accessExpiresAt, nextRefreshToken, now), nil
+}
+
+func (s *sessionService) revokeReusedSession(ctx context.Context, sessionID string, now time.Time) error {
+	if err := s.sr.RevokeSession(ctx, sessionID, now); err != nil {
+		return fmt.Errorf("revoke session %s after refresh token reuse: %w", sessionID, err)
+	}
+
+	return models.ErrRefreshTokenReused
+}
+
+// generateLoginCode returns a uniformly random 6-digit code.
+func generateLoginCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashLoginCode salts the code with its user, so equal codes of different
+// users hash apart. A 6-digit code stays guessable offline; the attempt limit
+// is what protects it online.
+func hashLoginCode(userID string, code string) string {
+	return hashOpaqueToken(userID + ":" + code)
+}
+
+func describeClient(session *models.Session, client *models.ClientInfo) {
+	if client == nil {
+		return
+	}
+
+	session.UserAgent = nullString(client.UserAgent)
+	session.DeviceLabel = nullString(pkgs.DeviceLabel(client.UserAgent))
+	session.IPAddress = nullString(client.IP)
+	session.Location = nullString(client.Location)
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func matchesHash(hash string, stored string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(stored)) == 1
+}
+
+// slideSessionExpiry moves a verified session's expiry to the idle timeout
+// from now, but never past its maximum lifetime.
+func slideSessionExpiry(session *models.Session, now time.Time) time.Time {
+	ttl := configs.Env.Session
+
+	expiresAt := now.Add(ttl.IdleTimeout)
+	if limit := session.VerifiedAt.Time.Add(ttl.MaxLifetime); limit.Before(expiresAt) {
+		return limit
+	}
+
+	return expiresAt
+}
+
+// accessTokenExpiry keeps access tokens from outliving their session.
+func accessTokenExpiry(session *models.Session, now time.Time) time.Time {
+	expiresAt := now.Add(configs.Env.Session.AccessToken)
+	if session.ExpiresAt.Before(expiresAt) {
+		return session.ExpiresAt
 	}
 
-	return session.Token, nil
+	return expiresAt
+}
+
+func newAuthResponse(session *models.Session, authToken string, accessExpiresAt time.Time, refreshToken string, now time.Time) *models.AuthResponse {
+	return &models.AuthResponse{
+		Token:            authToken,
+		ExpiresIn:        int(accessExpiresAt.Sub(now).Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int(session.ExpiresAt.Sub(now).Seconds()),
+	}
 }
 
 func (s *sessionService) RevokeSession(ctx context.Context, sessionId string) error {
@@ -120,6 +571,62 @@ func (s *sessionService) IsSessionRevoked(ctx context.Context, sessionId string)
 	return revoked, nil
 }
 
+// TouchSession records that a session is in use. Each session is written at
+// most once per LastSeenInterval by this service, and the repository skips
+// sessions another instance touched within it.
+func (s *sessionService) TouchSession(ctx context.Context, sessionID string) error {
+	now := time.Now().UTC()
+
+	if !s.markSeen(sessionID, now) {
+		return nil
+	}
+
+	if err := s.sr.TouchSession(ctx, sessionID, now, now.Add(-models.LastSeenInterval)); err != nil {
+		return fmt.Errorf("touch session %s: %w", sessionID, err)
+	}
+
+	return nil
+}
+
+// EnsureUserActive refuses users whose account is not active, with
+// ErrUserBanned or ErrUserInactive, and ErrUserNotFound for missing ones.
+// Sessions and personal access tokens are checked against it on every
+// request, since they outlive the status they were issued under.
+func (s *sessionService) EnsureUserActive(ctx context.Context, userID string) error {
+	status, err := s.sr.GetUserStatus(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get status of user %s: %w", userID, err)
+	}
+
+	if status == "" {
+		return models.ErrUserNotFound
+	}
+
+	return userStatusError(status)
+}
+
+// markSeen reports whether sessionID is due a write, forgetting sessions
+// not seen within LastSeenInterval once the map grows.
+func (s *sessionService) markSeen(sessionID string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if seen, ok := s.lastSeen[sessionID]; ok && now.Sub(seen) < models.LastSeenInterval {
+		return false
+	}
+
+	if len(s.lastSeen) >= maxTrackedSessions {
+		for id, seen := range s.lastSeen {
+			if now.Sub(seen) >= models.LastSeenInterval {
+				delete(s.lastSeen, id)
+			}
+		}
+	}
+
+	s.lastSeen[sessionID] = now
+	return true
+}
+
 func (s *sessionService) GetUserSessions(ctx context.Context, userID string, currentSessionID string) ([]*models.SessionResponse, error) {
 	sessions, err := s.sr.GetSessionsByUserID(ctx, userID)
 	if err != nil {
@@ -133,10 +640,18 @@ func (s *sessionService) GetUserSessions(ctx context.Context, userID string, cur
 	sessionResponses := make([]*models.SessionResponse, 0, len(sessions))
 	for _, session := range sessions {
 		resp := &models.SessionResponse{
-			ID:               session.ID,
-			ExpiresAt:        session.ExpiresAt,
-			CreatedAt:        session.CreatedAt,
-			CurrentSessionID: currentSessionID,
+			ID:        session.ID,
+			ExpiresAt: session.ExpiresAt,
+			CreatedAt: session.CreatedAt,
+			IsCurrent: session.ID == currentSessionID,
+			Device:    session.DeviceLabel.String,
+			UserAgent: session.UserAgent.String,
+			IPAddress: session.IPAddress.String,
+			Location:  session.Location.String,
+		}
+
+		if session.LastSeenAt.Valid {
+			resp.LastSeenAt = &session.LastSeenAt.Time
 		}
 
 		if session.VerifiedAt.Valid {
@@ -174,20 +689,35 @@ func (s *sessionService) RevokeUserSession(ctx context.Context, userID string, s
 	return nil
 }
 
-func (s *sessionService) RevokeAllUserSessions(ctx context.Context, userID string, currentSessionID string, revokeCurrent bool) error {
-	now := time.Now().UTC()
+// RevokeSessionFromLink revokes the session named by the link of a new
+// sign-in alert, without the user being signed in.
+func (s *sessionService) RevokeSessionFromLink(ctx context.Context, token string) error {
+	claims, err := s.ts.ParseSessionRevokeToken(ctx, token)
+	if err != nil {
+		return err
+	}
 
-	if revokeCurrent {
-		if err := s.sr.RevokeAllSessionsByUserID(ctx, userID, now); err != nil {
-			return fmt.Errorf("revoke all sessions by user id %s: %w", userID, err)
-		}
+	return s.RevokeUserSession(ctx, claims.UserID, claims.Subject)
+}
 
-		return nil
+func (s *sessionService) RevokeAllUserSessions(ctx context.Context, userID string, currentSessionID string, revokeCurrent bool) error {
+	if revokeCurrent {
+		return s.RevokeAllSessionsByUserID(ctx, userID)
 	}
 
-	if err := s.sr.RevokeAllSessionByUserIDExceptCurrent(ctx, userID, currentSessionID, now); err != nil {
+	if err := s.sr.RevokeAllSessionByUserIDExceptCurrent(ctx, userID, currentSessionID, time.Now().UTC()); err != nil {
 		return fmt.Errorf("revoke all sessions by user id %s except current %s: %w", userID, currentSessionID, err)
 	}
 
 	return nil
 }
+
+// RevokeAllSessionsByUserID signs the user out everywhere. Refresh tokens
+// stop working along with the sessions they belong to.
+func (s *sessionService) RevokeAllSessionsByUserID(ctx context.Context, userID string) error {
+	if err := s.sr.RevokeAllSessionsByUserID(ctx, userID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("revoke all sessions by user id %s: %w", userID, err)
+	}
+
+	return nil
+}
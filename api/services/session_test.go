@@ -2,13 +2,17 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/mocks"
 	"github.com/g-villarinho/tab-notes-api/models"
 	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,7 +22,7 @@ func FakeSession(userID string, token string) *models.Session {
 	return &models.Session{
 		ID:        "sess-123",
 		UserID:    userID,
-		Token:     token,
+		TokenHash: hashOpaqueToken(token),
 		CreatedAt: now,
 		ExpiresAt: now.Add(15 * time.Minute),
 	}
@@ -53,7 +57,7 @@ func TestCreateSession(t *testing.T) {
 			Return("fake-jwt-token", nil)
 
 		sessionRepo.
-			On("CreateSession", ctx, pkgs.MockSessionWithToken("fake-jwt-token")).
+			On("CreateSession", ctx, pkgs.MockSessionWithTokenHash(hashOpaqueToken("fake-jwt-token"))).
 			Return(errors.New("db error"))
 
 		token, err := s.CreateSession(ctx, "user-1", "joao@example.com")
@@ -74,7 +78,7 @@ func TestCreateSession(t *testing.T) {
 			Return("valid-token", nil)
 
 		sessionRepo.
-			On("CreateSession", ctx, pkgs.MockSessionWithToken("valid-token")).
+			On("CreateSession", ctx, pkgs.MockSessionWithTokenHash(hashOpaqueToken("valid-token"))).
 			Return(nil)
 
 		token, err := s.CreateSession(ctx, "user-1", "joao@example.com")
@@ -95,27 +99,44 @@ func TestValidSession(t *testing.T) {
 		sr := new(mocks.SessionRepositoryMock)
 		s := NewSessionService(ts, sr)
 
-		sr.On("GetSessionByToken", ctx, "token-123").
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("token-123")).
 			Return(nil, errors.New("repo fail"))
 
-		token, err := s.ValidSession(ctx, "token-123")
+		resp, err := s.ValidSession(ctx, "token-123", nil)
 
-		assert.Empty(t, token)
+		assert.Nil(t, resp)
 		assert.ErrorContains(t, err, "repo fail")
 		sr.AssertExpectations(t)
 	})
 
+	t.Run("should not sign in a banned user", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := FakeSession("user-1", "magic-token")
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(session, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusBanned, nil)
+
+		resp, err := s.ValidSession(ctx, "magic-token", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrUserBanned)
+		sr.AssertNotCalled(t, "VerifySession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("should return ErrSessionNotFound", func(t *testing.T) {
 		ts := new(mocks.TokenServiceMock)
 		sr := new(mocks.SessionRepositoryMock)
 		s := NewSessionService(ts, sr)
 
-		sr.On("GetSessionByToken", ctx, "token-abc").
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("token-abc")).
 			Return(nil, nil)
 
-		token, err := s.ValidSession(ctx, "token-abc")
+		resp, err := s.ValidSession(ctx, "token-abc", nil)
 
-		assert.Empty(t, token)
+		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, models.ErrSessionNotFound)
 		sr.AssertExpectations(t)
 	})
@@ -128,15 +149,15 @@ func TestValidSession(t *testing.T) {
 		session := FakeSession("user-1", "token-expired")
 		session.ExpiresAt = now.Add(-1 * time.Minute)
 
-		sr.On("GetSessionByToken", ctx, "token-expired").
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("token-expired")).
 			Return(session, nil)
 
 		sr.On("DeleteSession", ctx, session.ID).
 			Return(nil)
 
-		token, err := s.ValidSession(ctx, "token-expired")
+		resp, err := s.ValidSession(ctx, "token-expired", nil)
 
-		assert.Empty(t, token)
+		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, models.ErrSessionExpired)
 		sr.AssertExpectations(t)
 	})
@@ -148,15 +169,16 @@ func TestValidSession(t *testing.T) {
 
 		session := FakeSession("user-1", "magic-token")
 
-		sr.On("GetSessionByToken", ctx, "magic-token").
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).
 			Return(session, nil)
 
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
 		ts.On("GenerateAuthToken", ctx, session.UserID, session.ID, session.CreatedAt, mock.Anything).
 			Return("", errors.New("sign error"))
 
-		token, err := s.ValidSession(ctx, "magic-token")
+		resp, err := s.ValidSession(ctx, "magic-token", nil)
 
-		assert.Empty(t, token)
+		assert.Nil(t, resp)
 		assert.ErrorContains(t, err, "sign error")
 		sr.AssertExpectations(t)
 		ts.AssertExpectations(t)
@@ -169,19 +191,20 @@ func TestValidSession(t *testing.T) {
 
 		session := FakeSession("user-1", "magic-token")
 
-		sr.On("GetSessionByToken", ctx, "magic-token").
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).
 			Return(session, nil)
 
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
 		ts.On("GenerateAuthToken", ctx, session.UserID, session.ID, session.CreatedAt, mock.Anything).
 			Return("new-token", nil)
 
-		sr.On("UpdateSession", ctx, mock.MatchedBy(func(s *models.Session) bool {
-			return s.Token == "new-token"
-		})).Return(errors.New("update error"))
+		sr.On("VerifySession", ctx, mock.MatchedBy(func(s *models.Session) bool {
+			return s.TokenHash == hashOpaqueToken("new-token")
+		}), hashOpaqueToken("magic-token")).Return(false, errors.New("update error"))
 
-		token, err := s.ValidSession(ctx, "magic-token")
+		resp, err := s.ValidSession(ctx, "magic-token", nil)
 
-		assert.Empty(t, token)
+		assert.Nil(t, resp)
 		assert.ErrorContains(t, err, "update error")
 	})
 
@@ -192,23 +215,631 @@ func TestValidSession(t *testing.T) {
 
 		session := FakeSession("user-1", "magic-token")
 
-		sr.On("GetSessionByToken", ctx, "magic-token").
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).
 			Return(session, nil)
 
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
 		ts.On("GenerateAuthToken", ctx, session.UserID, session.ID, session.CreatedAt, mock.Anything).
 			Return("new-auth-token", nil)
 
-		sr.On("UpdateSession", ctx, mock.MatchedBy(func(s *models.Session) bool {
-			return s.Token == "new-auth-token" && s.VerifiedAt.Valid
+		sr.On("VerifySession", ctx, mock.MatchedBy(func(s *models.Session) bool {
+			return s.TokenHash == hashOpaqueToken("new-auth-token") && s.VerifiedAt.Valid &&
+				s.DeviceLabel.String == "Firefox · Linux" && s.IPAddress.String == "198.51.100.1" &&
+				s.Location.String == "Recife, PE, BR" && s.LastSeenAt.Valid
+		}), hashOpaqueToken("magic-token")).Return(true, nil)
+
+		sr.On("GetSignInHistory", ctx, "user-1",
+			sql.NullString{String: "Firefox · Linux", Valid: true},
+			sql.NullString{String: "198.51.100.1", Valid: true}).
+			Return(&models.SignInHistory{Sessions: 3, DeviceSeen: true, IPSeen: true}, nil)
+
+		sr.On("CreateRefreshToken", ctx, mock.MatchedBy(func(rt *models.RefreshToken) bool {
+			return rt.SessionID == session.ID && rt.TokenHash != ""
 		})).Return(nil)
 
-		token, err := s.ValidSession(ctx, "magic-token")
+		resp, err := s.ValidSession(ctx, "magic-token", &models.ClientInfo{
+			IP:        "198.51.100.1",
+			UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0",
+			Location:  "Recife, PE, BR",
+		})
 
 		assert.NoError(t, err)
-		assert.Equal(t, "new-auth-token", token)
+		assert.Equal(t, "new-auth-token", resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.Nil(t, resp.NewSignIn)
 		sr.AssertExpectations(t)
 		ts.AssertExpectations(t)
 	})
+
+	t.Run("should report a sign-in from a new IP address", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := FakeSession("user-1", "magic-token")
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(session, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, session.UserID, session.ID, session.CreatedAt, mock.Anything).
+			Return("new-auth-token", nil)
+		sr.On("GetSignInHistory", ctx, "user-1", mock.Anything, mock.Anything).
+			Return(&models.SignInHistory{Sessions: 3, DeviceSeen: true, IPSeen: false}, nil)
+		sr.On("VerifySession", ctx, mock.Anything, hashOpaqueToken("magic-token")).Return(true, nil)
+		sr.On("CreateRefreshToken", ctx, mock.Anything).Return(nil)
+		ts.On("GenerateSessionRevokeToken", ctx, "user-1", session.ID, mock.Anything, mock.Anything).
+			Return("revoke-token", nil)
+
+		resp, err := s.ValidSession(ctx, "magic-token", &models.ClientInfo{
+			IP:        "203.0.113.9",
+			UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0",
+			Location:  "Lisboa, PT",
+		})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, resp.NewSignIn) {
+			assert.Equal(t, session.ID, resp.NewSignIn.SessionID)
+			assert.Equal(t, "user-1", resp.NewSignIn.UserID)
+			assert.Equal(t, "Firefox · Linux", resp.NewSignIn.Device)
+			assert.Equal(t, "203.0.113.9", resp.NewSignIn.IPAddress)
+			assert.Equal(t, "Lisboa, PT", resp.NewSignIn.Location)
+			assert.Equal(t, "revoke-token", resp.NewSignIn.RevokeToken)
+		}
+		sr.AssertExpectations(t)
+		ts.AssertExpectations(t)
+	})
+
+	t.Run("should not report a user's first sign-in", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := FakeSession("user-1", "magic-token")
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(session, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, session.UserID, session.ID, session.CreatedAt, mock.Anything).
+			Return("new-auth-token", nil)
+		sr.On("GetSignInHistory", ctx, "user-1", mock.Anything, mock.Anything).
+			Return(&models.SignInHistory{}, nil)
+		sr.On("VerifySession", ctx, mock.Anything, hashOpaqueToken("magic-token")).Return(true, nil)
+		sr.On("CreateRefreshToken", ctx, mock.Anything).Return(nil)
+
+		resp, err := s.ValidSession(ctx, "magic-token", &models.ClientInfo{IP: "203.0.113.9"})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.NewSignIn)
+		ts.AssertNotCalled(t, "GenerateSessionRevokeToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("should not sign in twice with the same link", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := FakeSession("user-1", "magic-token")
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).
+			Return(session, nil)
+
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, session.UserID, session.ID, session.CreatedAt, mock.Anything).
+			Return("new-auth-token", nil)
+
+		// Another request spent the link between the lookup and the update.
+		sr.On("VerifySession", ctx, mock.Anything, hashOpaqueToken("magic-token")).
+			Return(false, nil)
+
+		resp, err := s.ValidSession(ctx, "magic-token", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+		sr.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a link of a verified session", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := FakeSession("user-1", "magic-token")
+		session.VerifiedAt = pkgs.SQLNullTime(now)
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).
+			Return(session, nil)
+
+		resp, err := s.ValidSession(ctx, "magic-token", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+		ts.AssertNotCalled(t, "GenerateAuthToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestValidSessionCode(t *testing.T) {
+	ctx := context.Background()
+
+	pendingSession := func(code string) *models.Session {
+		session := FakeSession("user-1", "magic-token")
+		session.CodeHash = sql.NullString{String: hashLoginCode("user-1", code), Valid: true}
+		return session
+	}
+
+	t.Run("should sign in with the right code", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := pendingSession("042137")
+
+		sr.On("GetPendingCodeSessionByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(session, nil)
+		sr.On("CountCodeAttemptsByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(0, nil)
+		sr.On("RecordCodeAttempt", ctx, session.ID, models.MaxLoginCodeAttempts).Return(true, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, "user-1", session.ID, session.CreatedAt, mock.Anything).Return("auth-token", nil)
+		sr.On("VerifySession", ctx, mock.MatchedBy(func(s *models.Session) bool {
+			return s.TokenHash == hashOpaqueToken("auth-token") && s.VerifiedAt.Valid
+		}), hashOpaqueToken("magic-token")).Return(true, nil)
+		sr.On("CreateRefreshToken", ctx, mock.Anything).Return(nil)
+
+		resp, err := s.ValidSessionCode(ctx, "user-1", "042137", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "auth-token", resp.Token)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrInvalidLoginCode for a wrong code", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := pendingSession("042137")
+
+		sr.On("GetPendingCodeSessionByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(session, nil)
+		sr.On("CountCodeAttemptsByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(0, nil)
+		sr.On("RecordCodeAttempt", ctx, session.ID, models.MaxLoginCodeAttempts).Return(true, nil)
+
+		resp, err := s.ValidSessionCode(ctx, "user-1", "999999", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidLoginCode)
+		sr.AssertNotCalled(t, "VerifySession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should stop checking codes after too many attempts", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := pendingSession("042137")
+
+		sr.On("GetPendingCodeSessionByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(session, nil)
+		sr.On("CountCodeAttemptsByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(0, nil)
+		sr.On("RecordCodeAttempt", ctx, session.ID, models.MaxLoginCodeAttempts).Return(false, nil)
+
+		resp, err := s.ValidSessionCode(ctx, "user-1", "042137", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrLoginCodeAttemptsExceeded)
+		sr.AssertNotCalled(t, "VerifySession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrInvalidLoginCode without a pending session", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		sr.On("GetPendingCodeSessionByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(nil, nil)
+
+		resp, err := s.ValidSessionCode(ctx, "user-1", "042137", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidLoginCode)
+	})
+
+	t.Run("should share the attempt budget across the user's sessions", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		// Every login email mints a new session with fresh per-session
+		// attempts, while the repository keeps the user's running total.
+		sessions := []*models.Session{pendingSession("042137"), pendingSession("042137"), pendingSession("042137")}
+		for i, session := range sessions {
+			session.ID = fmt.Sprintf("session-%d", i+1)
+		}
+
+		current, total := 0, 0
+		sr.On("GetPendingCodeSessionByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).
+			Return(func(context.Context, string, time.Time) *models.Session { return sessions[current] }, nil)
+		sr.On("CountCodeAttemptsByUserID", ctx, "user-1", mock.AnythingOfType("time.Time")).
+			Return(func(context.Context, string, time.Time) int { return total }, nil)
+		sr.On("RecordCodeAttempt", ctx, mock.AnythingOfType("string"), models.MaxLoginCodeAttempts).
+			Return(func(_ context.Context, id string, maxAttempts int) bool {
+				session := sessions[current]
+				if session.CodeAttempts >= maxAttempts {
+					return false
+				}
+				session.CodeAttempts++
+				total++
+				return true
+			}, nil)
+
+		for range models.MaxUserLoginCodeAttempts / models.MaxLoginCodeAttempts {
+			for range models.MaxLoginCodeAttempts {
+				_, err := s.ValidSessionCode(ctx, "user-1", "999999", nil)
+				assert.ErrorIs(t, err, models.ErrInvalidLoginCode)
+			}
+			current++
+		}
+
+		resp, err := s.ValidSessionCode(ctx, "user-1", "042137", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrLoginCodeAttemptsExceeded)
+		assert.Zero(t, sessions[2].CodeAttempts)
+		sr.AssertNotCalled(t, "VerifySession", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateLoginSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store only the hashes of the secrets of a pending login", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		var stored *models.Session
+		ts.On("GenerateMagicLinkToken", ctx, "joao@example.com", pkgs.MockAnyTime(), pkgs.MockAnyTime()).Return("magic-token", nil)
+		sr.On("CreateSession", ctx, mock.AnythingOfType("*models.Session")).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(*models.Session)
+				stored.ID = "sess-123"
+			}).
+			Return(nil)
+
+		login, err := s.CreateLoginSession(ctx, "user-1", "joao@example.com", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sess-123", login.SessionID)
+		assert.Equal(t, "magic-token", login.Token)
+		assert.Empty(t, login.Code)
+		assert.False(t, stored.CodeHash.Valid)
+		assert.Regexp(t, `^[0-9]{6}$`, login.VerificationCode)
+		assert.Equal(t, hashLoginCode("user-1", login.VerificationCode), stored.ApprovalCodeHash.String)
+		assert.NotEmpty(t, login.PollSecret)
+		assert.Equal(t, hashOpaqueToken(login.PollSecret), stored.PollSecretHash.String)
+	})
+
+	t.Run("should store only the hash of a 6-digit code", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		var stored *models.Session
+		ts.On("GenerateMagicLinkToken", ctx, "joao@example.com", pkgs.MockAnyTime(), pkgs.MockAnyTime()).Return("magic-token", nil)
+		sr.On("CreateSession", ctx, mock.AnythingOfType("*models.Session")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*models.Session) }).
+			Return(nil)
+
+		login, err := s.CreateLoginSession(ctx, "user-1", "joao@example.com", true)
+
+		assert.NoError(t, err)
+		assert.Regexp(t, `^[0-9]{6}$`, login.Code)
+		assert.Equal(t, hashLoginCode("user-1", login.Code), stored.CodeHash.String)
+	})
+}
+
+func TestRequiresApproval(t *testing.T) {
+	ctx := context.Background()
+
+	pendingSession := FakeSession("user-1", "magic-token")
+	pendingSession.PollSecretHash = sql.NullString{String: hashOpaqueToken("poll-secret"), Valid: true}
+
+	t.Run("should not require approval on the device that asked for the link", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(pendingSession, nil)
+
+		required, err := s.RequiresApproval(ctx, "magic-token", "poll-secret")
+
+		assert.NoError(t, err)
+		assert.False(t, required)
+	})
+
+	t.Run("should require approval on another device", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(pendingSession, nil)
+
+		required, err := s.RequiresApproval(ctx, "magic-token", "")
+
+		assert.NoError(t, err)
+		assert.True(t, required)
+	})
+
+	t.Run("should not require approval for sessions without a pending login", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("signup-token")).Return(FakeSession("user-1", "signup-token"), nil)
+
+		required, err := s.RequiresApproval(ctx, "signup-token", "")
+
+		assert.NoError(t, err)
+		assert.False(t, required)
+	})
+}
+
+func TestApproveSession(t *testing.T) {
+	ctx := context.Background()
+
+	pendingSession := func() *models.Session {
+		session := FakeSession("user-1", "magic-token")
+		session.ApprovalCodeHash = sql.NullString{String: hashLoginCode("user-1", "042137"), Valid: true}
+		return session
+	}
+
+	t.Run("should approve with the verification code", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		session := pendingSession()
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(session, nil)
+		sr.On("RecordCodeAttempt", ctx, session.ID, models.MaxLoginCodeAttempts).Return(true, nil)
+		sr.On("ApproveSession", ctx, session.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		err := s.ApproveSession(ctx, "magic-token", "042137")
+
+		assert.NoError(t, err)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrInvalidLoginCode for a wrong code", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		session := pendingSession()
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(session, nil)
+		sr.On("RecordCodeAttempt", ctx, session.ID, models.MaxLoginCodeAttempts).Return(true, nil)
+
+		err := s.ApproveSession(ctx, "magic-token", "999999")
+
+		assert.ErrorIs(t, err, models.ErrInvalidLoginCode)
+		sr.AssertNotCalled(t, "ApproveSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should stop checking codes after too many attempts", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		session := pendingSession()
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("magic-token")).Return(session, nil)
+		sr.On("RecordCodeAttempt", ctx, session.ID, models.MaxLoginCodeAttempts).Return(false, nil)
+
+		err := s.ApproveSession(ctx, "magic-token", "042137")
+
+		assert.ErrorIs(t, err, models.ErrLoginCodeAttemptsExceeded)
+		sr.AssertNotCalled(t, "ApproveSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrSessionNotFound without a pending login", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionByTokenHash", ctx, hashOpaqueToken("signup-token")).Return(FakeSession("user-1", "signup-token"), nil)
+
+		err := s.ApproveSession(ctx, "signup-token", "042137")
+
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+	})
+}
+
+func TestClaimApprovedSession(t *testing.T) {
+	ctx := context.Background()
+
+	pendingSession := func(approved bool) *models.Session {
+		session := FakeSession("user-1", "magic-token")
+		session.PollSecretHash = sql.NullString{String: hashOpaqueToken("poll-secret"), Valid: true}
+		session.ApprovedAt = sql.NullTime{Time: time.Now().UTC(), Valid: approved}
+		return session
+	}
+
+	t.Run("should return nil while waiting for approval", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionById", ctx, "sess-123").Return(pendingSession(false), nil)
+
+		resp, err := s.ClaimApprovedSession(ctx, "sess-123", "poll-secret", nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp)
+		sr.AssertNotCalled(t, "ClaimApprovedSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should hide the pending login from other devices", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionById", ctx, "sess-123").Return(pendingSession(true), nil)
+
+		resp, err := s.ClaimApprovedSession(ctx, "sess-123", "stolen-guess", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrPendingLoginNotFound)
+		sr.AssertNotCalled(t, "ClaimApprovedSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should sign in once approved", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := pendingSession(true)
+
+		sr.On("GetSessionById", ctx, "sess-123").Return(session, nil)
+		sr.On("ClaimApprovedSession", ctx, "sess-123", mock.AnythingOfType("time.Time")).Return(true, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, "user-1", session.ID, session.CreatedAt, mock.Anything).Return("auth-token", nil)
+		sr.On("VerifySession", ctx, mock.MatchedBy(func(s *models.Session) bool {
+			return s.TokenHash == hashOpaqueToken("auth-token") && s.VerifiedAt.Valid
+		}), hashOpaqueToken("magic-token")).Return(true, nil)
+		sr.On("CreateRefreshToken", ctx, mock.Anything).Return(nil)
+
+		resp, err := s.ClaimApprovedSession(ctx, "sess-123", "poll-secret", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "auth-token", resp.Token)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("should let only one poll claim the session", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetSessionById", ctx, "sess-123").Return(pendingSession(true), nil)
+		sr.On("ClaimApprovedSession", ctx, "sess-123", mock.AnythingOfType("time.Time")).Return(false, nil)
+
+		resp, err := s.ClaimApprovedSession(ctx, "sess-123", "poll-secret", nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrPendingLoginNotFound)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	configs.Env.Session = models.SessionTTL{
+		AccessToken: 15 * time.Minute,
+		IdleTimeout: 7 * 24 * time.Hour,
+		MaxLifetime: 30 * 24 * time.Hour,
+	}
+
+	const refreshToken = "refresh-token"
+
+	verifiedSession := func(verifiedAt time.Time) *models.Session {
+		session := FakeSession("user-1", "auth-token")
+		session.VerifiedAt = sql.NullTime{Time: verifiedAt, Valid: true}
+		session.ExpiresAt = time.Now().UTC().Add(time.Hour)
+		return session
+	}
+
+	t.Run("should return ErrInvalidRefreshToken for an unknown token", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		sr.On("GetRefreshTokenByHash", ctx, hashOpaqueToken(refreshToken)).Return(nil, nil)
+
+		resp, err := s.Refresh(ctx, refreshToken)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should revoke the session when a rotated token is reused", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		sr.On("GetRefreshTokenByHash", ctx, hashOpaqueToken(refreshToken)).Return(&models.RefreshToken{
+			TokenHash: hashOpaqueToken(refreshToken),
+			SessionID: "sess-123",
+			RotatedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		}, nil)
+		sr.On("RevokeSession", ctx, "sess-123", mock.AnythingOfType("time.Time")).Return(nil)
+
+		resp, err := s.Refresh(ctx, refreshToken)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+		sr.AssertExpectations(t)
+		ts.AssertNotCalled(t, "GenerateAuthToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should rotate the token and slide the session up to its maximum lifetime", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		verifiedAt := time.Now().UTC().Add(-29 * 24 * time.Hour)
+		session := verifiedSession(verifiedAt)
+		maxExpiresAt := verifiedAt.Add(30 * 24 * time.Hour)
+
+		sr.On("GetRefreshTokenByHash", ctx, hashOpaqueToken(refreshToken)).Return(&models.RefreshToken{
+			TokenHash: hashOpaqueToken(refreshToken),
+			SessionID: session.ID,
+		}, nil)
+		sr.On("GetSessionById", ctx, session.ID).Return(session, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, "user-1", session.ID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return("new-auth-token", nil)
+		sr.On("RotateRefreshToken", ctx, hashOpaqueToken(refreshToken), mock.MatchedBy(func(rt *models.RefreshToken) bool {
+			return rt.SessionID == session.ID && rt.TokenHash != hashOpaqueToken(refreshToken)
+		}), mock.MatchedBy(func(s *models.Session) bool {
+			return s.TokenHash == hashOpaqueToken("new-auth-token") && s.ExpiresAt.Equal(maxExpiresAt)
+		})).Return(true, nil)
+
+		resp, err := s.Refresh(ctx, refreshToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-auth-token", resp.Token)
+		assert.NotEqual(t, refreshToken, resp.RefreshToken)
+		assert.InDelta(t, 15*60, resp.ExpiresIn, 1)
+		assert.InDelta(t, 24*60*60, resp.RefreshExpiresIn, 1)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("should revoke the session when a concurrent refresh rotated the token first", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := verifiedSession(time.Now().UTC())
+
+		sr.On("GetRefreshTokenByHash", ctx, hashOpaqueToken(refreshToken)).Return(&models.RefreshToken{
+			TokenHash: hashOpaqueToken(refreshToken),
+			SessionID: session.ID,
+		}, nil)
+		sr.On("GetSessionById", ctx, session.ID).Return(session, nil)
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+		ts.On("GenerateAuthToken", ctx, "user-1", session.ID, mock.Anything, mock.Anything).Return("new-auth-token", nil)
+		sr.On("RotateRefreshToken", ctx, hashOpaqueToken(refreshToken), mock.Anything, mock.Anything).Return(false, nil)
+		sr.On("RevokeSession", ctx, session.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		resp, err := s.Refresh(ctx, refreshToken)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrInvalidRefreshToken for a revoked session", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		session := verifiedSession(time.Now().UTC())
+		session.RevokedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+		sr.On("GetRefreshTokenByHash", ctx, hashOpaqueToken(refreshToken)).Return(&models.RefreshToken{
+			TokenHash: hashOpaqueToken(refreshToken),
+			SessionID: session.ID,
+		}, nil)
+		sr.On("GetSessionById", ctx, session.ID).Return(session, nil)
+
+		resp, err := s.Refresh(ctx, refreshToken)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrInvalidRefreshToken)
+		sr.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestRevokeSession(t *testing.T) {
@@ -281,6 +912,118 @@ func TestRevokeSession(t *testing.T) {
 	})
 }
 
+func TestRevokeSessionFromLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should revoke the session named by the token", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		ts.On("ParseSessionRevokeToken", ctx, "revoke-token").Return(&models.SessionRevokeTokenClaims{
+			UserID:           "user-1",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "sess-1"},
+		}, nil)
+		sr.On("GetSessionById", ctx, "sess-1").Return(&models.Session{ID: "sess-1", UserID: "user-1"}, nil)
+		sr.On("RevokeSession", ctx, "sess-1", pkgs.MockAnyTime()).Return(nil)
+
+		err := s.RevokeSessionFromLink(ctx, "revoke-token")
+
+		assert.NoError(t, err)
+		sr.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid token", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(ts, sr)
+
+		ts.On("ParseSessionRevokeToken", ctx, "bad-token").Return(nil, models.ErrInvalidSessionRevokeToken)
+
+		err := s.RevokeSessionFromLink(ctx, "bad-token")
+
+		assert.ErrorIs(t, err, models.ErrInvalidSessionRevokeToken)
+		sr.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEnsureUserActive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should accept an active user", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusActive, nil)
+
+		assert.NoError(t, s.EnsureUserActive(ctx, "user-1"))
+	})
+
+	t.Run("should return ErrUserBanned for a banned user", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusBanned, nil)
+
+		assert.ErrorIs(t, s.EnsureUserActive(ctx, "user-1"), models.ErrUserBanned)
+	})
+
+	t.Run("should return ErrUserInactive for an inactive user", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("GetUserStatus", ctx, "user-1").Return(models.UserStatusInactive, nil)
+
+		assert.ErrorIs(t, s.EnsureUserActive(ctx, "user-1"), models.ErrUserInactive)
+	})
+}
+
+func TestTouchSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should write last seen at most once per interval", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		sr.On("TouchSession", ctx, "sess-1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		assert.NoError(t, s.TouchSession(ctx, "sess-1"))
+		assert.NoError(t, s.TouchSession(ctx, "sess-1"))
+
+		sr.AssertNumberOfCalls(t, "TouchSession", 1)
+	})
+}
+
+func TestGetUserSessions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should describe each device and flag the current session", func(t *testing.T) {
+		sr := new(mocks.SessionRepositoryMock)
+		s := NewSessionService(nil, sr)
+
+		lastSeen := time.Now().UTC()
+		sr.On("GetSessionsByUserID", ctx, "user-1").Return([]*models.Session{
+			{
+				ID:          "sess-1",
+				DeviceLabel: sql.NullString{String: "Safari · iPhone", Valid: true},
+				Location:    sql.NullString{String: "Recife, PE, BR", Valid: true},
+				LastSeenAt:  pkgs.SQLNullTime(lastSeen),
+			},
+			{ID: "sess-2"},
+		}, nil)
+
+		sessions, err := s.GetUserSessions(ctx, "user-1", "sess-2")
+
+		assert.NoError(t, err)
+		assert.False(t, sessions[0].IsCurrent)
+		assert.Equal(t, "Safari · iPhone", sessions[0].Device)
+		assert.Equal(t, "Recife, PE, BR", sessions[0].Location)
+		assert.Equal(t, &lastSeen, sessions[0].LastSeenAt)
+		assert.True(t, sessions[1].IsCurrent)
+		assert.Nil(t, sessions[1].LastSeenAt)
+	})
+}
+
 func TestIsSessionRevoked(t *testing.T) {
 	ctx := context.Background()
 
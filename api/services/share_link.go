@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
+	"github.com/g-villarinho/tab-notes-api/repositories"
+)
+
+// ShareLinkService lets an author show a single post, private or not, to
+// anyone holding a link, until the link expires or is revoked.
+type ShareLinkService interface {
+	CreateShareLink(ctx context.Context, userID string, postID string, payload *models.CreateShareLinkPayload) (*models.ShareLinkResponse, error)
+	GetShareLinks(ctx context.Context, userID string, postID string) ([]*models.ShareLinkResponse, error)
+	RevokeShareLink(ctx context.Context, userID string, postID string, shareLinkID string) error
+	GetSharedPost(ctx context.Context, token string) (*models.PostResponse, error)
+}
+
+type shareLinkService struct {
+	ts  TokenService
+	pr  repositories.PostRepository
+	slr repositories.ShareLinkRepository
+	mr  pkgs.MarkdownRenderer
+}
+
+func NewShareLinkService(
+	tokenService TokenService,
+	postRepository repositories.PostRepository,
+	shareLinkRepository repositories.ShareLinkRepository,
+	markdownRenderer pkgs.MarkdownRenderer) ShareLinkService {
+	return &shareLinkService{
+		ts:  tokenService,
+		pr:  postRepository,
+		slr: shareLinkRepository,
+		mr:  markdownRenderer,
+	}
+}
+
+func (s *shareLinkService) CreateShareLink(ctx context.Context, userID string, postID string, payload *models.CreateShareLinkPayload) (*models.ShareLinkResponse, error) {
+	if _, err := s.getOwnedPost(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+
+	link := &models.ShareLink{PostID: postID}
+	if payload.ExpiresInHours != nil {
+		expiresAt := time.Now().UTC().Add(time.Duration(*payload.ExpiresInHours) * time.Hour)
+		link.ExpiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+	}
+
+	if err := s.slr.CreateShareLink(ctx, link); err != nil {
+		return nil, fmt.Errorf("create share link: %w", err)
+	}
+
+	return s.toShareLinkResponse(ctx, link, time.Now().UTC())
+}
+
+func (s *shareLinkService) GetShareLinks(ctx context.Context, userID string, postID string) ([]*models.ShareLinkResponse, error) {
+	if _, err := s.getOwnedPost(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.slr.GetShareLinksByPostID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get share links by post id %s: %w", postID, err)
+	}
+
+	now := time.Now().UTC()
+	responses := make([]*models.ShareLinkResponse, len(links))
+	for i, link := range links {
+		response, err := s.toShareLinkResponse(ctx, link, now)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = response
+	}
+
+	return responses, nil
+}
+
+func (s *shareLinkService) RevokeShareLink(ctx context.Context, userID string, postID string, shareLinkID string) error {
+	if _, err := s.getOwnedPost(ctx, userID, postID); err != nil {
+		return err
+	}
+
+	link, err := s.slr.GetShareLinkByID(ctx, shareLinkID)
+	if err != nil {
+		return fmt.Errorf("get share link by id %s: %w", shareLinkID, err)
+	}
+
+	if link == nil || link.PostID != postID {
+		return models.ErrShareLinkNotFound
+	}
+
+	if err := s.slr.RevokeShareLink(ctx, link.ID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("revoke share link %s: %w", link.ID, err)
+	}
+
+	return nil
+}
+
+// GetSharedPost resolves a share link token to its post and counts the
+// access. Unknown, revoked and expired links all return ErrInvalidShareLink.
+func (s *shareLinkService) GetSharedPost(ctx context.Context, token string) (*models.PostResponse, error) {
+	claims, err := s.ts.ParseShareLinkToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.slr.GetShareLinkByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("get share link by id %s: %w", claims.Subject, err)
+	}
+
+	now := time.Now().UTC()
+	if link == nil || link.PostID != claims.PostID || !shareLinkActive(link, now) {
+		return nil, models.ErrInvalidShareLink
+	}
+
+	post, err := s.pr.GetPostByID(ctx, link.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id %s: %w", link.PostID, err)
+	}
+
+	if post == nil {
+		return nil, models.ErrInvalidShareLink
+	}
+
+	if err := s.slr.RecordAccess(ctx, link.ID, now); err != nil {
+		return nil, fmt.Errorf("record share link access %s: %w", link.ID, err)
+	}
+
+	contentHTML, err := renderContent(s.mr, post.Content, post.ContentHTML)
+	if err != nil {
+		return nil, fmt.Errorf("render content: %w", err)
+	}
+
+	return &models.PostResponse{
+		ID:          post.ID,
+		Title:       post.Title,
+		Content:     post.Content,
+		ContentHTML: contentHTML,
+		Visibility:  post.Visibility,
+		Likes:       post.Likes,
+		Links:       []*models.PostLinkResponse{},
+		Version:     post.Version,
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   lastModified(post),
+	}, nil
+}
+
+func (s *shareLinkService) getOwnedPost(ctx context.Context, userID string, postID string) (*models.Post, error) {
+	post, err := s.pr.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("get post by id %s: %w", postID, err)
+	}
+
+	if post == nil {
+		return nil, models.ErrPostNotFound
+	}
+
+	if post.AuthorID != userID {
+		return nil, models.ErrPostNotBelongToUser
+	}
+
+	return post, nil
+}
+
+func (s *shareLinkService) toShareLinkResponse(ctx context.Context, link *models.ShareLink, now time.Time) (*models.ShareLinkResponse, error) {
+	response := &models.ShareLinkResponse{
+		ID:          link.ID,
+		AccessCount: link.AccessCount,
+		CreatedAt:   link.CreatedAt,
+	}
+
+	if link.LastAccessedAt.Valid {
+		response.LastAccessedAt = &link.LastAccessedAt.Time
+	}
+
+	if link.ExpiresAt.Valid {
+		response.ExpiresAt = &link.ExpiresAt.Time
+	}
+
+	if link.RevokedAt.Valid {
+		response.RevokedAt = &link.RevokedAt.Time
+	}
+
+	if shareLinkActive(link, now) {
+		token, err := s.ts.GenerateShareLinkToken(ctx, link.PostID, link.ID, now, link.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("generate share link token: %w", err)
+		}
+		response.URL = fmt.Sprintf("%s/shared/%s", configs.Env.APIURL, token)
+	}
+
+	return response, nil
+}
+
+func shareLinkActive(link *models.ShareLink, now time.Time) bool {
+	if link.RevokedAt.Valid {
+		return false
+	}
+
+	return !link.ExpiresAt.Valid || link.ExpiresAt.Time.After(now)
+}
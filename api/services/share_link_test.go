@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/g-villarinho/tab-notes-api/configs"
+	"github.com/g-villarinho/tab-notes-api/mocks"
+	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShareLinkService_CreateShareLink(t *testing.T) {
+	ctx := context.Background()
+	privatePost := &models.Post{ID: "post-1", AuthorID: "user-1", Visibility: models.PostVisibilityPrivate}
+
+	t.Run("should create an expiring link with its url", func(t *testing.T) {
+		configs.Env.APIURL = "http://api.test"
+		ts := new(mocks.TokenServiceMock)
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(ts, pr, slr, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(privatePost, nil)
+		slr.On("CreateShareLink", ctx, mock.AnythingOfType("*models.ShareLink")).
+			Run(func(args mock.Arguments) { args.Get(1).(*models.ShareLink).ID = "link-1" }).
+			Return(nil)
+		ts.On("GenerateShareLinkToken", ctx, "post-1", "link-1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("sql.NullTime")).
+			Return("signed-token", nil)
+
+		hours := 24
+		link, err := sls.CreateShareLink(ctx, "user-1", "post-1", &models.CreateShareLinkPayload{ExpiresInHours: &hours})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "http://api.test/shared/signed-token", link.URL)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), *link.ExpiresAt, time.Minute)
+	})
+
+	t.Run("should return ErrPostNotBelongToUser for another author", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(nil, pr, slr, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(privatePost, nil)
+
+		_, err := sls.CreateShareLink(ctx, "user-2", "post-1", &models.CreateShareLinkPayload{})
+
+		assert.ErrorIs(t, err, models.ErrPostNotBelongToUser)
+		slr.AssertNotCalled(t, "CreateShareLink", mock.Anything, mock.Anything)
+	})
+}
+
+func TestShareLinkService_GetShareLinks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should omit the url of revoked links", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(ts, pr, slr, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1"}, nil)
+		slr.On("GetShareLinksByPostID", ctx, "post-1").Return([]*models.ShareLink{
+			{ID: "link-1", PostID: "post-1", AccessCount: 3},
+			{ID: "link-2", PostID: "post-1", RevokedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		}, nil)
+		ts.On("GenerateShareLinkToken", ctx, "post-1", "link-1", mock.AnythingOfType("time.Time"), sql.NullTime{}).
+			Return("signed-token", nil)
+
+		links, err := sls.GetShareLinks(ctx, "user-1", "post-1")
+
+		assert.NoError(t, err)
+		assert.Len(t, links, 2)
+		assert.Equal(t, 3, links[0].AccessCount)
+		assert.NotEmpty(t, links[0].URL)
+		assert.Empty(t, links[1].URL)
+		assert.NotNil(t, links[1].RevokedAt)
+		ts.AssertNumberOfCalls(t, "GenerateShareLinkToken", 1)
+	})
+}
+
+func TestShareLinkService_RevokeShareLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return ErrShareLinkNotFound for a link of another post", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(nil, pr, slr, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1"}, nil)
+		slr.On("GetShareLinkByID", ctx, "link-9").Return(&models.ShareLink{ID: "link-9", PostID: "post-2"}, nil)
+
+		err := sls.RevokeShareLink(ctx, "user-1", "post-1", "link-9")
+
+		assert.ErrorIs(t, err, models.ErrShareLinkNotFound)
+		slr.AssertNotCalled(t, "RevokeShareLink", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should revoke the link", func(t *testing.T) {
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(nil, pr, slr, nil)
+
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{ID: "post-1", AuthorID: "user-1"}, nil)
+		slr.On("GetShareLinkByID", ctx, "link-1").Return(&models.ShareLink{ID: "link-1", PostID: "post-1"}, nil)
+		slr.On("RevokeShareLink", ctx, "link-1", mock.AnythingOfType("time.Time")).Return(nil)
+
+		err := sls.RevokeShareLink(ctx, "user-1", "post-1", "link-1")
+
+		assert.NoError(t, err)
+		slr.AssertExpectations(t)
+	})
+}
+
+func TestShareLinkService_GetSharedPost(t *testing.T) {
+	ctx := context.Background()
+	claims := &models.ShareLinkTokenClaims{PostID: "post-1"}
+	claims.Subject = "link-1"
+
+	t.Run("should return a private post and count the access", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(ts, pr, slr, nil)
+
+		ts.On("ParseShareLinkToken", ctx, "token").Return(claims, nil)
+		slr.On("GetShareLinkByID", ctx, "link-1").Return(&models.ShareLink{ID: "link-1", PostID: "post-1"}, nil)
+		pr.On("GetPostByID", ctx, "post-1").Return(&models.Post{
+			ID:          "post-1",
+			Title:       "Segredo",
+			ContentHTML: sql.NullString{String: "<p>oi</p>", Valid: true},
+			Visibility:  models.PostVisibilityPrivate,
+		}, nil)
+		slr.On("RecordAccess", ctx, "link-1", mock.AnythingOfType("time.Time")).Return(nil)
+
+		post, err := sls.GetSharedPost(ctx, "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Segredo", post.Title)
+		assert.Equal(t, models.PostVisibilityPrivate, post.Visibility)
+		slr.AssertExpectations(t)
+	})
+
+	t.Run("should reject revoked and expired links", func(t *testing.T) {
+		for _, link := range []*models.ShareLink{
+			{ID: "link-1", PostID: "post-1", RevokedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+			{ID: "link-1", PostID: "post-1", ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}},
+		} {
+			ts := new(mocks.TokenServiceMock)
+			pr := new(mocks.PostRepositoryMock)
+			slr := new(mocks.ShareLinkRepositoryMock)
+			sls := NewShareLinkService(ts, pr, slr, nil)
+
+			ts.On("ParseShareLinkToken", ctx, "token").Return(claims, nil)
+			slr.On("GetShareLinkByID", ctx, "link-1").Return(link, nil)
+
+			_, err := sls.GetSharedPost(ctx, "token")
+
+			assert.ErrorIs(t, err, models.ErrInvalidShareLink)
+			slr.AssertNotCalled(t, "RecordAccess", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("should reject a token for another post", func(t *testing.T) {
+		ts := new(mocks.TokenServiceMock)
+		pr := new(mocks.PostRepositoryMock)
+		slr := new(mocks.ShareLinkRepositoryMock)
+		sls := NewShareLinkService(ts, pr, slr, nil)
+
+		ts.On("ParseShareLinkToken", ctx, "token").Return(claims, nil)
+		slr.On("GetShareLinkByID", ctx, "link-1").Return(&models.ShareLink{ID: "link-1", PostID: "post-2"}, nil)
+
+		_, err := sls.GetSharedPost(ctx, "token")
+
+		assert.ErrorIs(t, err, models.ErrInvalidShareLink)
+	})
+}
@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
-	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/models"
 	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/golang-jwt/jwt/v5"
@@ -13,22 +17,23 @@ import (
 type TokenService interface {
 	GenerateAuthToken(ctx context.Context, userID string, sessionID string, iat, exp time.Time) (string, error)
 	GenerateMagicLinkToken(ctx context.Context, email string, iat, exp time.Time) (string, error)
+	GenerateExportToken(ctx context.Context, userID string, exportID string, iat, exp time.Time) (string, error)
+	ParseExportToken(ctx context.Context, token string) (*models.ExportTokenClaims, error)
+	GenerateShareLinkToken(ctx context.Context, postID string, shareLinkID string, iat time.Time, exp sql.NullTime) (string, error)
+	ParseShareLinkToken(ctx context.Context, token string) (*models.ShareLinkTokenClaims, error)
+	GenerateSessionRevokeToken(ctx context.Context, userID string, sessionID string, iat, exp time.Time) (string, error)
+	ParseSessionRevokeToken(ctx context.Context, token string) (*models.SessionRevokeTokenClaims, error)
 }
 
 type tokenService struct {
-	kp pkgs.EcdsaKeyPair
+	kr pkgs.Keyring
 }
 
-func NewTokenService(kp pkgs.EcdsaKeyPair) TokenService {
-	return &tokenService{kp: kp}
+func NewTokenService(keyring pkgs.Keyring) TokenService {
+	return &tokenService{kr: keyring}
 }
 
 func (t *tokenService) GenerateAuthToken(ctx context.Context, userID string, sessionID string, iat, exp time.Time) (string, error) {
-	privateKey, err := t.kp.ParseECDSAPrivateKey(configs.Env.Key.PrivateKey)
-	if err != nil {
-		return "", err
-	}
-
 	claims := models.AuthTokenClaims{
 		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -38,16 +43,10 @@ func (t *tokenService) GenerateAuthToken(ctx context.Context, userID string, ses
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
-	return token.SignedString(privateKey)
+	return t.sign(claims)
 }
 
 func (t *tokenService) GenerateMagicLinkToken(ctx context.Context, email string, iat, exp time.Time) (string, error) {
-	privateKey, err := t.kp.ParseECDSAPrivateKey(configs.Env.Key.PrivateKey)
-	if err != nil {
-		return "", err
-	}
-
 	claims := models.MagicLinkTokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   email,
@@ -56,6 +55,121 @@ func (t *tokenService) GenerateMagicLinkToken(ctx context.Context, email string,
 		},
 	}
 
+	return t.sign(claims)
+}
+
+func (t *tokenService) GenerateExportToken(ctx context.Context, userID string, exportID string, iat, exp time.Time) (string, error) {
+	claims := models.ExportTokenClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   exportID,
+			IssuedAt:  jwt.NewNumericDate(iat),
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+	}
+
+	return t.sign(claims)
+}
+
+func (t *tokenService) ParseExportToken(ctx context.Context, tokenStr string) (*models.ExportTokenClaims, error) {
+	var claims models.ExportTokenClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, t.kr.Keyfunc, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+
+	if err != nil || !token.Valid {
+		return nil, models.ErrInvalidExportToken
+	}
+
+	return &claims, nil
+}
+
+// GenerateShareLinkToken signs a token for one share link of a post. Links
+// without an expiry get a token without exp; revocation and expiry are
+// enforced against the stored link anyway.
+func (t *tokenService) GenerateShareLinkToken(ctx context.Context, postID string, shareLinkID string, iat time.Time, exp sql.NullTime) (string, error) {
+	claims := models.ShareLinkTokenClaims{
+		PostID: postID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  shareLinkID,
+			IssuedAt: jwt.NewNumericDate(iat),
+		},
+	}
+
+	if exp.Valid {
+		claims.ExpiresAt = jwt.NewNumericDate(exp.Time)
+	}
+
+	return t.sign(claims)
+}
+
+func (t *tokenService) ParseShareLinkToken(ctx context.Context, tokenStr string) (*models.ShareLinkTokenClaims, error) {
+	var claims models.ShareLinkTokenClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, t.kr.Keyfunc, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+
+	if err != nil || !token.Valid || claims.Subject == "" || claims.PostID == "" {
+		return nil, models.ErrInvalidShareLink
+	}
+
+	return &claims, nil
+}
+
+// sessionRevokeAudience keeps other tokens carrying a user and a subject,
+// like export tokens, from passing as session revoke tokens.
+const sessionRevokeAudience = "session-revoke"
+
+func (t *tokenService) GenerateSessionRevokeToken(ctx context.Context, userID string, sessionID string, iat, exp time.Time) (string, error) {
+	claims := models.SessionRevokeTokenClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sessionID,
+			Audience:  jwt.ClaimStrings{sessionRevokeAudience},
+			IssuedAt:  jwt.NewNumericDate(iat),
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+	}
+
+	return t.sign(claims)
+}
+
+func (t *tokenService) ParseSessionRevokeToken(ctx context.Context, tokenStr string) (*models.SessionRevokeTokenClaims, error) {
+	var claims models.SessionRevokeTokenClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, t.kr.Keyfunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithAudience(sessionRevokeAudience),
+	)
+
+	if err != nil || !token.Valid || claims.Subject == "" || claims.UserID == "" {
+		return nil, models.ErrInvalidSessionRevokeToken
+	}
+
+	return &claims, nil
+}
+
+// sign issues an ES256 token with the current signing key, named in the kid
+// header.
+func (t *tokenService) sign(claims jwt.Claims) (string, error) {
+	kid, privateKey := t.kr.SigningKey()
+
 	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
 	return token.SignedString(privateKey)
 }
+
+// opaqueTokenBytes is the entropy of opaque tokens, which makes an unsalted
+// hash safe to store and look up.
+const opaqueTokenBytes = 32
+
+// generateOpaqueToken returns a random, URL safe token for secrets that are
+// looked up by hash rather than verified by signature.
+func generateOpaqueToken() (string, error) {
+	secret := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashOpaqueToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -5,45 +5,31 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"errors"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
 	"testing"
 	"time"
 
-	"github.com/g-villarinho/tab-notes-api/configs"
 	"github.com/g-villarinho/tab-notes-api/mocks"
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGenerateAuthToken(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("should return error if private key is invalid", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
-		ts := NewTokenService(kp)
-
-		kp.
-			On("ParseECDSAPrivateKey", configs.Env.Key.PrivateKey).
-			Return(nil, errors.New("invalid key"))
-
-		token, err := ts.GenerateAuthToken(ctx, "user-1", "session-1", time.Now(), time.Now().Add(time.Hour))
-
-		assert.Empty(t, token)
-		assert.ErrorContains(t, err, "invalid key")
-		kp.AssertExpectations(t)
-	})
-
 	t.Run("should generate signed JWT with correct claims", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
-		ts := NewTokenService(kp)
+		kr := new(mocks.KeyringMock)
+		ts := NewTokenService(kr)
 
 		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		assert.NoError(t, err)
 
-		kp.
-			On("ParseECDSAPrivateKey", configs.Env.Key.PrivateKey).
-			Return(privateKey, nil)
+		kr.On("SigningKey").Return("key-1", privateKey)
 
 		iat := time.Now().UTC()
 		exp := iat.Add(10 * time.Minute)
@@ -69,31 +55,14 @@ func TestGenerateAuthToken(t *testing.T) {
 func TestGenerateMagicLinkToken(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("should return error if private key is invalid", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
-		ts := NewTokenService(kp)
-
-		kp.
-			On("ParseECDSAPrivateKey", configs.Env.Key.PrivateKey).
-			Return(nil, errors.New("invalid key"))
-
-		token, err := ts.GenerateMagicLinkToken(ctx, "joao@example.com", time.Now(), time.Now().Add(time.Minute))
-
-		assert.Empty(t, token)
-		assert.ErrorContains(t, err, "invalid key")
-		kp.AssertExpectations(t)
-	})
-
 	t.Run("should generate signed JWT with correct claims", func(t *testing.T) {
-		kp := new(mocks.EcdsaKeyPairMock)
-		ts := NewTokenService(kp)
+		kr := new(mocks.KeyringMock)
+		ts := NewTokenService(kr)
 
 		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		assert.NoError(t, err)
 
-		kp.
-			On("ParseECDSAPrivateKey", configs.Env.Key.PrivateKey).
-			Return(privateKey, nil)
+		kr.On("SigningKey").Return("key-1", privateKey)
 
 		iat := time.Now().UTC()
 		exp := iat.Add(15 * time.Minute)
@@ -116,3 +85,182 @@ func TestGenerateMagicLinkToken(t *testing.T) {
 		assert.WithinDuration(t, exp, claims.ExpiresAt.Time, time.Second)
 	})
 }
+
+func TestShareLinkToken(t *testing.T) {
+	ctx := context.Background()
+
+	newTokenService := func(t *testing.T) (TokenService, *ecdsa.PrivateKey) {
+		kr := new(mocks.KeyringMock)
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+
+		kr.On("SigningKey").Return("key-1", privateKey)
+		kr.On("Keyfunc", mock.Anything).Return(&privateKey.PublicKey, nil)
+
+		return NewTokenService(kr), privateKey
+	}
+
+	t.Run("should round trip a token without expiry", func(t *testing.T) {
+		ts, _ := newTokenService(t)
+
+		token, err := ts.GenerateShareLinkToken(ctx, "post-1", "link-1", time.Now(), sql.NullTime{})
+		assert.NoError(t, err)
+
+		claims, err := ts.ParseShareLinkToken(ctx, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "post-1", claims.PostID)
+		assert.Equal(t, "link-1", claims.Subject)
+		assert.Nil(t, claims.ExpiresAt)
+	})
+
+	t.Run("should reject an expired token", func(t *testing.T) {
+		ts, _ := newTokenService(t)
+		exp := sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+		token, err := ts.GenerateShareLinkToken(ctx, "post-1", "link-1", time.Now().Add(-time.Hour), exp)
+		assert.NoError(t, err)
+
+		_, err = ts.ParseShareLinkToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrInvalidShareLink)
+	})
+
+	t.Run("should reject a token signed with another key", func(t *testing.T) {
+		ts, _ := newTokenService(t)
+		other, _ := newTokenService(t)
+
+		token, err := other.GenerateShareLinkToken(ctx, "post-1", "link-1", time.Now(), sql.NullTime{})
+		assert.NoError(t, err)
+
+		_, err = ts.ParseShareLinkToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrInvalidShareLink)
+	})
+}
+
+func TestSessionRevokeToken(t *testing.T) {
+	ctx := context.Background()
+
+	newTokenService := func(t *testing.T) TokenService {
+		kr := new(mocks.KeyringMock)
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+
+		kr.On("SigningKey").Return("key-1", privateKey)
+		kr.On("Keyfunc", mock.Anything).Return(&privateKey.PublicKey, nil)
+
+		return NewTokenService(kr)
+	}
+
+	t.Run("should round trip a token", func(t *testing.T) {
+		ts := newTokenService(t)
+
+		token, err := ts.GenerateSessionRevokeToken(ctx, "user-1", "session-1", time.Now(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		claims, err := ts.ParseSessionRevokeToken(ctx, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", claims.UserID)
+		assert.Equal(t, "session-1", claims.Subject)
+	})
+
+	t.Run("should reject an expired token", func(t *testing.T) {
+		ts := newTokenService(t)
+
+		token, err := ts.GenerateSessionRevokeToken(ctx, "user-1", "session-1", time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+
+		_, err = ts.ParseSessionRevokeToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrInvalidSessionRevokeToken)
+	})
+
+	t.Run("should reject an export token", func(t *testing.T) {
+		ts := newTokenService(t)
+
+		token, err := ts.GenerateExportToken(ctx, "user-1", "export-1", time.Now(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		_, err = ts.ParseSessionRevokeToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrInvalidSessionRevokeToken)
+	})
+}
+
+func TestTokenService_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+
+	newKeyPEM := func(t *testing.T) (string, string) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+
+		privateDER, err := x509.MarshalECPrivateKey(privateKey)
+		assert.NoError(t, err)
+		publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		assert.NoError(t, err)
+
+		return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateDER})),
+			string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	}
+
+	oldPrivate, oldPublic := newKeyPEM(t)
+	newPrivate, newPublic := newKeyPEM(t)
+
+	oldKeyring, err := pkgs.NewKeyring(pkgs.NewEcdsaKeyPair(), models.Key{PrivateKey: oldPrivate, PublicKey: oldPublic})
+	assert.NoError(t, err)
+
+	token, err := NewTokenService(oldKeyring).GenerateExportToken(ctx, "user-1", "export-1", time.Now(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	rotate := func(t *testing.T, retiredAt time.Time) pkgs.Keyring {
+		keyring, err := pkgs.NewKeyring(pkgs.NewEcdsaKeyPair(), models.Key{
+			PrivateKey:  newPrivate,
+			PublicKey:   newPublic,
+			Retired:     []models.RetiredKey{{PublicKey: oldPublic, RetiredAt: retiredAt}},
+			GracePeriod: 24 * time.Hour,
+		})
+		assert.NoError(t, err)
+		return keyring
+	}
+
+	t.Run("should name the signing key in the kid header", func(t *testing.T) {
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &models.ExportTokenClaims{})
+		assert.NoError(t, err)
+
+		kid, _ := oldKeyring.SigningKey()
+		assert.Equal(t, kid, parsed.Header["kid"])
+	})
+
+	t.Run("should accept tokens of a retired key within the grace period", func(t *testing.T) {
+		keyring := rotate(t, time.Now().Add(-time.Hour))
+
+		claims, err := NewTokenService(keyring).ParseExportToken(ctx, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "export-1", claims.Subject)
+		assert.Len(t, keyring.JWKS().Keys, 2)
+	})
+
+	t.Run("should reject tokens of a retired key after the grace period", func(t *testing.T) {
+		keyring := rotate(t, time.Now().Add(-48*time.Hour))
+
+		_, err := NewTokenService(keyring).ParseExportToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrInvalidExportToken)
+		assert.Len(t, keyring.JWKS().Keys, 1)
+	})
+
+	t.Run("should accept tokens issued before key ids existed", func(t *testing.T) {
+		_, privateKey := oldKeyring.SigningKey()
+		legacy, err := jwt.NewWithClaims(jwt.SigningMethodES256, models.ExportTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "export-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		}).SignedString(privateKey)
+		assert.NoError(t, err)
+
+		_, err = NewTokenService(rotate(t, time.Now().Add(-time.Hour))).ParseExportToken(ctx, legacy)
+
+		assert.NoError(t, err)
+	})
+}
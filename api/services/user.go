@@ -12,10 +12,13 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, name string, username string, email string) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
 	GetProfile(ctx context.Context, id string) (*models.UserResponse, error)
 	SearchUsers(ctx context.Context, query string) ([]*models.SearchUserResponse, error)
 	GetProfileByUsername(ctx context.Context, username string, viewerID string) (*models.UserProfileResponse, error)
 	UpdateUser(ctx context.Context, id string, name string, username string) error
+	PatchUser(ctx context.Context, id string, payload *models.PatchUserPayload) error
+	BanUser(ctx context.Context, id string) error
 }
 
 type userService struct {
@@ -79,6 +82,19 @@ func (u *userService) GetUserByEmail(ctx context.Context, email string) (*models
 	return user, nil
 }
 
+func (u *userService) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	user, err := u.ur.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user == nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
 func (u *userService) GetProfile(ctx context.Context, id string) (*models.UserResponse, error) {
 	user, err := u.ur.GetUserByID(ctx, id)
 	if err != nil {
@@ -130,7 +146,7 @@ func (u *userService) GetProfileByUsername(ctx context.Context, username string,
 		return nil, fmt.Errorf("get user by username: %w", err)
 	}
 
-	if user == nil {
+	if hiddenUser(user) {
 		return nil, models.ErrUserNotFound
 	}
 
@@ -150,6 +166,11 @@ func (u *userService) GetProfileByUsername(ctx context.Context, username string,
 }
 
 func (u *userService) UpdateUser(ctx context.Context, id string, name string, username string) error {
+	return u.PatchUser(ctx, id, &models.PatchUserPayload{Name: &name, Username: &username})
+}
+
+// PatchUser changes only the fields set in payload.
+func (u *userService) PatchUser(ctx context.Context, id string, payload *models.PatchUserPayload) error {
 	user, err := u.ur.GetUserByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("get user by id %s: %w", id, err)
@@ -159,8 +180,12 @@ func (u *userService) UpdateUser(ctx context.Context, id string, name string, us
 		return models.ErrUserNotFound
 	}
 
-	if user.Username != username {
-		userFromUsername, err := u.ur.GetUserByUsername(ctx, username)
+	if payload.Name == nil && payload.Username == nil {
+		return nil
+	}
+
+	if payload.Username != nil && user.Username != *payload.Username {
+		userFromUsername, err := u.ur.GetUserByUsername(ctx, *payload.Username)
 		if err != nil {
 			return fmt.Errorf("get user by username: %w", err)
 		}
@@ -169,13 +194,52 @@ func (u *userService) UpdateUser(ctx context.Context, id string, name string, us
 			return models.ErrUsernameAlreadyExists
 		}
 
-		user.Username = username
+		user.Username = *payload.Username
+	}
+
+	if payload.Name != nil {
+		user.Name = *payload.Name
 	}
 
-	user.Name = name
 	if err := u.ur.UpdateUser(ctx, user); err != nil {
 		return fmt.Errorf("update user: %w", err)
 	}
 
 	return nil
 }
+
+// BanUser marks the user banned. Signing them out is up to the caller.
+func (u *userService) BanUser(ctx context.Context, id string) error {
+	user, err := u.ur.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get user by id %s: %w", id, err)
+	}
+
+	if user == nil {
+		return models.ErrUserNotFound
+	}
+
+	if err := u.ur.BanUser(ctx, user.ID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("ban user %s: %w", user.ID, err)
+	}
+
+	return nil
+}
+
+// userStatusError refuses accounts that are not active.
+func userStatusError(status models.UserStatus) error {
+	switch status {
+	case models.UserStatusActive:
+		return nil
+	case models.UserStatusBanned:
+		return models.ErrUserBanned
+	default:
+		return models.ErrUserInactive
+	}
+}
+
+// hiddenUser reports whether a looked up user should be treated as missing:
+// banned users and their profiles are no longer public.
+func hiddenUser(user *models.User) bool {
+	return user == nil || user.Status == models.UserStatusBanned
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/g-villarinho/tab-notes-api/mocks"
 	"github.com/g-villarinho/tab-notes-api/models"
+	"github.com/g-villarinho/tab-notes-api/pkgs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -380,6 +381,22 @@ func TestGetProfileByUsername(t *testing.T) {
 		userRepo.AssertExpectations(t)
 	})
 
+	t.Run("should return ErrUserNotFound if user is banned", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		followerService := new(mocks.FollowerServiceMock)
+		userService := NewUserService(followerService, userRepo)
+
+		userRepo.
+			On("GetUserByUsername", ctx, "joaodasilva").
+			Return(&models.User{ID: "user-1", Username: "joaodasilva", Status: models.UserStatusBanned}, nil)
+
+		resp, err := userService.GetProfileByUsername(ctx, "joaodasilva", "123")
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		followerService.AssertNotCalled(t, "GetFollowStats", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("should return profile data if user exists", func(t *testing.T) {
 		userRepo := new(mocks.UserRepositoryMock)
 		followerService := new(mocks.FollowerServiceMock)
@@ -464,3 +481,81 @@ func TestGetProfileByUsername(t *testing.T) {
 		followerService.AssertExpectations(t)
 	})
 }
+
+func TestPatchUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should change only the name when username is absent", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		userService := NewUserService(nil, userRepo)
+
+		name := "João Silva"
+
+		userRepo.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1", Name: "João", Username: "joao"}, nil)
+		userRepo.On("UpdateUser", ctx, mock.MatchedBy(func(u *models.User) bool {
+			return u.Name == "João Silva" && u.Username == "joao"
+		})).Return(nil)
+
+		err := userService.PatchUser(ctx, "user-1", &models.PatchUserPayload{Name: &name})
+
+		assert.NoError(t, err)
+		userRepo.AssertNotCalled(t, "GetUserByUsername", mock.Anything, mock.Anything)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrUsernameAlreadyExists if username is taken", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		userService := NewUserService(nil, userRepo)
+
+		username := "maria"
+
+		userRepo.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1", Name: "João", Username: "joao"}, nil)
+		userRepo.On("GetUserByUsername", ctx, "maria").Return(&models.User{ID: "user-2"}, nil)
+
+		err := userService.PatchUser(ctx, "user-1", &models.PatchUserPayload{Username: &username})
+
+		assert.ErrorIs(t, err, models.ErrUsernameAlreadyExists)
+		userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not write anything for an empty patch", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		userService := NewUserService(nil, userRepo)
+
+		userRepo.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1"}, nil)
+
+		err := userService.PatchUser(ctx, "user-1", &models.PatchUserPayload{})
+
+		assert.NoError(t, err)
+		userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBanUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should ban the user", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		userService := NewUserService(nil, userRepo)
+
+		userRepo.On("GetUserByID", ctx, "user-1").Return(&models.User{ID: "user-1", Status: models.UserStatusActive}, nil)
+		userRepo.On("BanUser", ctx, "user-1", pkgs.MockAnyTime()).Return(nil)
+
+		err := userService.BanUser(ctx, "user-1")
+
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return ErrUserNotFound if user doesn't exist", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		userService := NewUserService(nil, userRepo)
+
+		userRepo.On("GetUserByID", ctx, "missing").Return(nil, nil)
+
+		err := userService.BanUser(ctx, "missing")
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		userRepo.AssertNotCalled(t, "BanUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,15 @@
+-- Wiki-style links between posts. Existing posts get their links the next
+-- time they are saved.
+CREATE TABLE post_links (
+  source_post_id CHAR(36) NOT NULL,
+  target_ref VARCHAR(50) NOT NULL,
+  target_post_id CHAR(36) NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (source_post_id, target_ref),
+  INDEX idx_post_links_target_post_id (target_post_id),
+  INDEX idx_post_links_target_ref (target_ref),
+
+  FOREIGN KEY (source_post_id) REFERENCES posts(id) ON DELETE CASCADE,
+  FOREIGN KEY (target_post_id) REFERENCES posts(id) ON DELETE SET NULL
+) ENGINE=InnoDB;
@@ -0,0 +1,27 @@
+-- Notebooks group a user's posts into ordered collections.
+CREATE TABLE notebooks (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  owner_id CHAR(36) NOT NULL,
+  title VARCHAR(100) NOT NULL,
+  slug VARCHAR(120) NOT NULL,
+  description VARCHAR(500) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NULL DEFAULT NULL,
+
+  UNIQUE KEY uq_notebooks_owner_slug (owner_id, slug),
+
+  FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE notebook_posts (
+  notebook_id CHAR(36) NOT NULL,
+  post_id CHAR(36) NOT NULL,
+  position INT NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (notebook_id, post_id),
+  INDEX idx_notebook_posts_post_id (post_id),
+
+  FOREIGN KEY (notebook_id) REFERENCES notebooks(id) ON DELETE CASCADE,
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,3 @@
+-- Posts cache their rendered Markdown. Existing posts keep a NULL cache and
+-- are rendered on read until they are saved again.
+ALTER TABLE posts ADD COLUMN content_html MEDIUMTEXT NULL DEFAULT NULL AFTER content;
@@ -0,0 +1,15 @@
+-- Account data exports, built in the background and downloaded through a
+-- signed link.
+CREATE TABLE exports (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  status ENUM('pending', 'completed', 'failed') NOT NULL DEFAULT 'pending',
+  file_path VARCHAR(255) NULL DEFAULT NULL,
+  expires_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+  completed_at DATETIME NULL DEFAULT NULL,
+
+  INDEX idx_exports_user_id_status (user_id, status),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,22 @@
+-- Imported posts record the SHA-256 of their content, so importing the same
+-- archive twice skips what is already there. Existing posts keep a NULL hash,
+-- which the unique key allows any number of times.
+ALTER TABLE posts
+  ADD COLUMN content_hash CHAR(64) NULL DEFAULT NULL AFTER content_html,
+  ADD UNIQUE KEY uq_posts_author_content_hash (author_id, content_hash);
+
+CREATE TABLE imports (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  status ENUM('pending', 'completed', 'failed') NOT NULL DEFAULT 'pending',
+  total_files INT NOT NULL DEFAULT 0,
+  imported_count INT NOT NULL DEFAULT 0,
+  skipped_count INT NOT NULL DEFAULT 0,
+  errors JSON NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+  completed_at DATETIME NULL DEFAULT NULL,
+
+  INDEX idx_imports_user_id (user_id),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,3 @@
+-- Posts carry a version for If-Match checks. Existing posts start at 1, the
+-- same as new ones.
+ALTER TABLE posts ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER likes;
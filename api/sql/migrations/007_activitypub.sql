@@ -0,0 +1,48 @@
+-- Signing keys for local actors and the remote actors that follow or like
+-- them over ActivityPub. Keys for existing users are created on first use.
+CREATE TABLE actor_keys (
+  user_id CHAR(36) NOT NULL PRIMARY KEY,
+  public_key_pem TEXT NOT NULL,
+  private_key_pem TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE remote_actors (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  actor_uri VARCHAR(512) NOT NULL,
+  inbox VARCHAR(512) NOT NULL,
+  shared_inbox VARCHAR(512) NULL DEFAULT NULL,
+  key_id VARCHAR(512) NOT NULL,
+  public_key_pem TEXT NOT NULL,
+  preferred_username VARCHAR(255) NOT NULL DEFAULT '',
+  fetched_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  UNIQUE KEY uq_remote_actors_actor_uri (actor_uri)
+) ENGINE=InnoDB;
+
+CREATE TABLE remote_followers (
+  user_id CHAR(36) NOT NULL,
+  remote_actor_id CHAR(36) NOT NULL,
+  activity_id VARCHAR(512) NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (user_id, remote_actor_id),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (remote_actor_id) REFERENCES remote_actors(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE remote_likes (
+  post_id CHAR(36) NOT NULL,
+  remote_actor_id CHAR(36) NOT NULL,
+  activity_id VARCHAR(512) NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (post_id, remote_actor_id),
+
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+  FOREIGN KEY (remote_actor_id) REFERENCES remote_actors(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
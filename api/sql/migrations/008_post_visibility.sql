@@ -0,0 +1,16 @@
+-- Posts can be private. Existing posts stay public.
+ALTER TABLE posts ADD COLUMN visibility VARCHAR(10) NOT NULL DEFAULT 'public' AFTER content_hash;
+
+CREATE TABLE share_links (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  post_id CHAR(36) NOT NULL,
+  access_count INT NOT NULL DEFAULT 0,
+  last_accessed_at DATETIME NULL DEFAULT NULL,
+  expires_at DATETIME NULL DEFAULT NULL,
+  revoked_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  INDEX idx_share_links_post_id (post_id),
+
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
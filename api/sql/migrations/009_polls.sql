@@ -0,0 +1,37 @@
+-- Polls attached to posts, their options and one vote per user.
+CREATE TABLE polls (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  post_id CHAR(36) NOT NULL,
+  closes_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  UNIQUE KEY uq_polls_post_id (post_id),
+
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE poll_options (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  poll_id CHAR(36) NOT NULL,
+  position INT NOT NULL,
+  text VARCHAR(80) NOT NULL,
+  votes INT NOT NULL DEFAULT 0,
+
+  UNIQUE KEY uq_poll_options_poll_position (poll_id, position),
+
+  FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE poll_votes (
+  poll_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  option_id CHAR(36) NOT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NULL DEFAULT NULL,
+
+  PRIMARY KEY (poll_id, user_id),
+
+  FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (option_id) REFERENCES poll_options(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,18 @@
+-- Personal access tokens, stored as the SHA-256 of the token.
+CREATE TABLE personal_access_tokens (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  token_hash CHAR(64) NOT NULL,
+  token_prefix VARCHAR(12) NOT NULL,
+  scopes VARCHAR(255) NOT NULL,
+  expires_at DATETIME NULL DEFAULT NULL,
+  last_used_at DATETIME NULL DEFAULT NULL,
+  revoked_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  UNIQUE KEY uq_personal_access_tokens_token_hash (token_hash),
+  INDEX idx_personal_access_tokens_user_id (user_id),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
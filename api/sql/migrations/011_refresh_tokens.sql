@@ -0,0 +1,12 @@
+-- Refresh tokens rotate on every use. Sessions created before this keep
+-- working until their access token expires, then sign in again.
+CREATE TABLE refresh_tokens (
+  token_hash CHAR(64) NOT NULL PRIMARY KEY,
+  session_id CHAR(36) NOT NULL,
+  rotated_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  INDEX idx_refresh_tokens_session_id (session_id),
+
+  FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
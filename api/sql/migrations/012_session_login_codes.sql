@@ -0,0 +1,5 @@
+-- Sessions can be verified with a one-time code as well as the magic link.
+-- Pending sessions created before this have no code and only take the link.
+ALTER TABLE sessions
+  ADD COLUMN code_hash CHAR(64) NULL DEFAULT NULL AFTER updated_at,
+  ADD COLUMN code_attempts INT NOT NULL DEFAULT 0 AFTER code_hash;
@@ -0,0 +1,7 @@
+-- Magic links opened on another device wait for approval. Pending sessions
+-- created before this have no poll secret, so their links keep working on
+-- any device, as before.
+ALTER TABLE sessions
+  ADD COLUMN poll_secret_hash CHAR(64) NULL DEFAULT NULL AFTER code_attempts,
+  ADD COLUMN approval_code_hash CHAR(64) NULL DEFAULT NULL AFTER poll_secret_hash,
+  ADD COLUMN approved_at DATETIME NULL DEFAULT NULL AFTER approval_code_hash;
@@ -0,0 +1,11 @@
+-- Sessions keep only the SHA-256 of their tokens, hex encoded like the
+-- hashes the API computes. Magic links still pending when this runs keep
+-- working, since their hash is looked up the same way.
+ALTER TABLE sessions ADD COLUMN token_hash CHAR(64) NULL AFTER id;
+
+UPDATE sessions SET token_hash = SHA2(token, 256);
+
+ALTER TABLE sessions
+  MODIFY token_hash CHAR(64) NOT NULL,
+  ADD UNIQUE INDEX idx_sessions_token_hash (token_hash),
+  DROP COLUMN token;
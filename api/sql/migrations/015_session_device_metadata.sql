@@ -0,0 +1,8 @@
+-- Sessions record the device that signed them in and when they were last
+-- used. Existing sessions stay unlabelled until they are replaced.
+ALTER TABLE sessions
+  ADD COLUMN user_agent VARCHAR(512) NULL DEFAULT NULL,
+  ADD COLUMN device_label VARCHAR(100) NULL DEFAULT NULL,
+  ADD COLUMN ip_address VARCHAR(45) NULL DEFAULT NULL,
+  ADD COLUMN location VARCHAR(255) NULL DEFAULT NULL,
+  ADD COLUMN last_seen_at DATETIME NULL DEFAULT NULL;
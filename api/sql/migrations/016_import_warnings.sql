@@ -0,0 +1,3 @@
+-- Imports list per-file warnings, such as front matter that was dropped.
+-- Imports finished before this report none.
+ALTER TABLE imports ADD COLUMN warnings JSON NULL DEFAULT NULL AFTER errors;
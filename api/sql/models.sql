@@ -11,14 +11,25 @@ CREATE TABLE users (
 
 CREATE TABLE sessions (
   id CHAR(36) NOT NULL PRIMARY KEY,
-  token TEXT NOT NULL,
+  token_hash CHAR(64) NOT NULL,
   expires_at DATETIME NOT NULL,
   user_id CHAR(36) NOT NULL,
   revoked_at DATETIME NULL DEFAULT NULL,
   verified_at DATETIME NULL DEFAULT NULL,
   created_at DATETIME NOT NULL,
   updated_at DATETIME NULL DEFAULT NULL,
+  code_hash CHAR(64) NULL DEFAULT NULL,
+  code_attempts INT NOT NULL DEFAULT 0,
+  poll_secret_hash CHAR(64) NULL DEFAULT NULL,
+  approval_code_hash CHAR(64) NULL DEFAULT NULL,
+  approved_at DATETIME NULL DEFAULT NULL,
+  user_agent VARCHAR(512) NULL DEFAULT NULL,
+  device_label VARCHAR(100) NULL DEFAULT NULL,
+  ip_address VARCHAR(45) NULL DEFAULT NULL,
+  location VARCHAR(255) NULL DEFAULT NULL,
+  last_seen_at DATETIME NULL DEFAULT NULL,
 
+  UNIQUE INDEX idx_sessions_token_hash (token_hash),
   FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 )ENGINE=INNODB;
 
@@ -39,12 +50,18 @@ CREATE TABLE posts (
 	id CHAR(36) NOT NULL PRIMARY KEY,
 	title VARCHAR(50) NOT NULL,
 	content VARCHAR(2000) NOT NULL,
+	content_html MEDIUMTEXT NULL DEFAULT NULL,
+	content_hash CHAR(64) NULL DEFAULT NULL,
+	visibility VARCHAR(10) NOT NULL DEFAULT 'public',
 	author_id  CHAR(36) NOT NULL,
 	created_at DATETIME NOT NULL,
 	updated_at DATETIME NULL DEFAULT NULL,
 	
 	likes INT DEFAULT 0,
+	version INT NOT NULL DEFAULT 1,
 	
+	UNIQUE KEY uq_posts_author_content_hash (author_id, content_hash),
+
 	FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE
 )ENGINE=INNODB;
 
@@ -58,3 +75,202 @@ CREATE TABLE likes (
   FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
   FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
 ) ENGINE=InnoDB;
+
+CREATE TABLE post_links (
+  source_post_id CHAR(36) NOT NULL,
+  target_ref VARCHAR(50) NOT NULL,
+  target_post_id CHAR(36) NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (source_post_id, target_ref),
+  INDEX idx_post_links_target_post_id (target_post_id),
+  INDEX idx_post_links_target_ref (target_ref),
+
+  FOREIGN KEY (source_post_id) REFERENCES posts(id) ON DELETE CASCADE,
+  FOREIGN KEY (target_post_id) REFERENCES posts(id) ON DELETE SET NULL
+) ENGINE=InnoDB;
+
+CREATE TABLE notebooks (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  owner_id CHAR(36) NOT NULL,
+  title VARCHAR(100) NOT NULL,
+  slug VARCHAR(120) NOT NULL,
+  description VARCHAR(500) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NULL DEFAULT NULL,
+
+  UNIQUE KEY uq_notebooks_owner_slug (owner_id, slug),
+
+  FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE notebook_posts (
+  notebook_id CHAR(36) NOT NULL,
+  post_id CHAR(36) NOT NULL,
+  position INT NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (notebook_id, post_id),
+  INDEX idx_notebook_posts_post_id (post_id),
+
+  FOREIGN KEY (notebook_id) REFERENCES notebooks(id) ON DELETE CASCADE,
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE exports (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  status ENUM('pending', 'completed', 'failed') NOT NULL DEFAULT 'pending',
+  file_path VARCHAR(255) NULL DEFAULT NULL,
+  expires_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+  completed_at DATETIME NULL DEFAULT NULL,
+
+  INDEX idx_exports_user_id_status (user_id, status),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE imports (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  status ENUM('pending', 'completed', 'failed') NOT NULL DEFAULT 'pending',
+  total_files INT NOT NULL DEFAULT 0,
+  imported_count INT NOT NULL DEFAULT 0,
+  skipped_count INT NOT NULL DEFAULT 0,
+  errors JSON NULL DEFAULT NULL,
+  warnings JSON NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+  completed_at DATETIME NULL DEFAULT NULL,
+
+  INDEX idx_imports_user_id (user_id),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE actor_keys (
+  user_id CHAR(36) NOT NULL PRIMARY KEY,
+  public_key_pem TEXT NOT NULL,
+  private_key_pem TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE remote_actors (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  actor_uri VARCHAR(512) NOT NULL,
+  inbox VARCHAR(512) NOT NULL,
+  shared_inbox VARCHAR(512) NULL DEFAULT NULL,
+  key_id VARCHAR(512) NOT NULL,
+  public_key_pem TEXT NOT NULL,
+  preferred_username VARCHAR(255) NOT NULL DEFAULT '',
+  fetched_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  UNIQUE KEY uq_remote_actors_actor_uri (actor_uri)
+) ENGINE=InnoDB;
+
+CREATE TABLE remote_followers (
+  user_id CHAR(36) NOT NULL,
+  remote_actor_id CHAR(36) NOT NULL,
+  activity_id VARCHAR(512) NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (user_id, remote_actor_id),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (remote_actor_id) REFERENCES remote_actors(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE remote_likes (
+  post_id CHAR(36) NOT NULL,
+  remote_actor_id CHAR(36) NOT NULL,
+  activity_id VARCHAR(512) NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  PRIMARY KEY (post_id, remote_actor_id),
+
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+  FOREIGN KEY (remote_actor_id) REFERENCES remote_actors(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE share_links (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  post_id CHAR(36) NOT NULL,
+  access_count INT NOT NULL DEFAULT 0,
+  last_accessed_at DATETIME NULL DEFAULT NULL,
+  expires_at DATETIME NULL DEFAULT NULL,
+  revoked_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  INDEX idx_share_links_post_id (post_id),
+
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE polls (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  post_id CHAR(36) NOT NULL,
+  closes_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+
+  UNIQUE KEY uq_polls_post_id (post_id),
+
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE poll_options (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  poll_id CHAR(36) NOT NULL,
+  position INT NOT NULL,
+  text VARCHAR(80) NOT NULL,
+  votes INT NOT NULL DEFAULT 0,
+
+  UNIQUE KEY uq_poll_options_poll_position (poll_id, position),
+
+  FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE poll_votes (
+  poll_id CHAR(36) NOT NULL,
+  user_id CHAR(36) NOT NULL,
+  option_id CHAR(36) NOT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NULL DEFAULT NULL,
+
+  PRIMARY KEY (poll_id, user_id),
+
+  FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (option_id) REFERENCES poll_options(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE personal_access_tokens (
+  id CHAR(36) NOT NULL PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  token_hash CHAR(64) NOT NULL,
+  token_prefix VARCHAR(12) NOT NULL,
+  scopes VARCHAR(255) NOT NULL,
+  expires_at DATETIME NULL DEFAULT NULL,
+  last_used_at DATETIME NULL DEFAULT NULL,
+  revoked_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  UNIQUE KEY uq_personal_access_tokens_token_hash (token_hash),
+  INDEX idx_personal_access_tokens_user_id (user_id),
+
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE refresh_tokens (
+  token_hash CHAR(64) NOT NULL PRIMARY KEY,
+  session_id CHAR(36) NOT NULL,
+  rotated_at DATETIME NULL DEFAULT NULL,
+  created_at DATETIME NOT NULL,
+
+  INDEX idx_refresh_tokens_session_id (session_id),
+
+  FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
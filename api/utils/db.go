@@ -4,8 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
 )
 
+const mysqlDuplicateEntry = 1062
+
 type QueryExecutor interface {
 	QueryRowContext(context.Context, string, ...any) *sql.Row
 }
@@ -24,3 +29,14 @@ func QueryRowScan[T any](ctx context.Context, db QueryExecutor, query string, sc
 
 	return result, nil
 }
+
+// IsDuplicateKey reports whether err is MySQL rejecting a row that collides
+// with the unique key named key.
+func IsDuplicateKey(err error, key string) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlDuplicateEntry {
+		return false
+	}
+
+	return strings.Contains(mysqlErr.Message, "'"+key+"'") || strings.Contains(mysqlErr.Message, "."+key+"'")
+}
@@ -0,0 +1,35 @@
+package utils
+
+import "strings"
+
+const (
+	frontMatterOpen  = "---\n"
+	frontMatterClose = "\n---\n"
+)
+
+// SplitFrontMatter separates a leading "---" delimited block from the rest of
+// a Markdown document. When there is no front matter the whole source is
+// returned as the body.
+func SplitFrontMatter(source string) (frontMatter string, body string) {
+	source = strings.TrimPrefix(source, "\ufeff")
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+
+	if !strings.HasPrefix(source, frontMatterOpen) {
+		return "", source
+	}
+
+	rest := source[len(frontMatterOpen):]
+	if strings.HasPrefix(rest, frontMatterOpen) {
+		return "", rest[len(frontMatterOpen):]
+	}
+
+	end := strings.Index(rest, frontMatterClose)
+	if end == -1 {
+		if strings.HasSuffix(rest, "\n---") {
+			return strings.TrimSuffix(rest, "\n---"), ""
+		}
+		return "", source
+	}
+
+	return rest[:end], rest[end+len(frontMatterClose):]
+}
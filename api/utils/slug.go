@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"strings"
+)
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// Slugify turns a title into a lowercase, hyphen-separated ASCII slug.
+func Slugify(title string) string {
+	s := accentReplacer.Replace(strings.ToLower(strings.TrimSpace(title)))
+
+	var b strings.Builder
+	lastHyphen := true
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastHyphen = false
+			continue
+		}
+
+		if !lastHyphen {
+			b.WriteByte('-')
+			lastHyphen = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const MaxWikiLinkRefLength = 50
+
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+
+// ParseWikiLinks returns the distinct references found in [[...]] links,
+// in order of first appearance. References are trimmed and compared
+// case-insensitively; empty or overlong references are skipped.
+func ParseWikiLinks(content string) []string {
+	matches := wikiLinkPattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(matches))
+	refs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		ref := strings.TrimSpace(match[1])
+		if ref == "" || utf8.RuneCountInString(ref) > MaxWikiLinkRefLength {
+			continue
+		}
+
+		key := strings.ToLower(ref)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
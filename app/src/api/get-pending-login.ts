@@ -0,0 +1,17 @@
+import { api } from "@/lib/axios";
+
+export interface GetPendingLoginRequest {
+  pendingLoginId: string;
+}
+
+export interface GetPendingLoginResponse {
+  status: "pending" | "approved";
+}
+
+export async function getPendingLogin({ pendingLoginId }: GetPendingLoginRequest) {
+  const response = await api.get<GetPendingLoginResponse>(
+    `/authenticate/pending/${pendingLoginId}`
+  );
+
+  return response.data;
+}
@@ -2,9 +2,15 @@ import { api } from "@/lib/axios";
 
 export interface GetUserSessionsResponse {
   id: string;
-  verifiedAt: string;
-  currentSessionId: string;
-  RevokedAt?: string | null;
+  isCurrent: boolean;
+  device?: string;
+  userAgent?: string;
+  ipAddress?: string;
+  location?: string;
+  lastSeenAt?: string | null;
+  verifiedAt?: string | null;
+  revokedAt?: string | null;
+  expiresAt: string;
   createdAt: string;
 }
 
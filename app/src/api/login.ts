@@ -4,6 +4,15 @@ export interface LoginRequest {
     email: string
 }
 
+export interface LoginResponse {
+    pendingLoginId: string
+    verificationCode: string
+    expiresIn: number
+    pollInterval: number
+}
+
 export async function login({ email }: LoginRequest) {
-    await api.post("/authenticate", { email })
-}
\ No newline at end of file
+    const response = await api.post<LoginResponse>("/authenticate", { email })
+
+    return response.data
+}
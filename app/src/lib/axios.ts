@@ -1,4 +1,4 @@
-import axios from "axios";
+import axios, { isAxiosError, type InternalAxiosRequestConfig } from "axios";
 import camelcaseKeys from "camelcase-keys";
 import { env } from "@/env";
 
@@ -29,3 +29,43 @@ api.interceptors.response.use((response) => {
 
   return response;
 });
+
+// Access tokens are short lived: on a 401, renew them once through the
+// refresh cookie and replay the request. Concurrent failures share a single
+// refresh, since each refresh token can only be spent once.
+let refreshing: Promise<void> | null = null;
+
+function refreshSession() {
+  refreshing ??= api
+    .post("/auth/refresh")
+    .then(() => undefined)
+    .finally(() => {
+      refreshing = null;
+    });
+
+  return refreshing;
+}
+
+api.interceptors.response.use(undefined, async (error) => {
+  if (!isAxiosError(error) || error.response?.status !== 401) {
+    throw error;
+  }
+
+  const config = error.config as
+    | (InternalAxiosRequestConfig & { _retried?: boolean })
+    | undefined;
+
+  if (!config || config._retried || config.url === "/auth/refresh") {
+    throw error;
+  }
+
+  config._retried = true;
+
+  try {
+    await refreshSession();
+  } catch {
+    throw error;
+  }
+
+  return api(config);
+});
@@ -76,8 +76,8 @@ export function SecurityAndAccountAcessPage() {
           {sessions && sessions.length > 0 && (
             <div className="space-y-2">
               {sessions.map((session) => {
-                const isRevoked = Boolean(session.RevokedAt);
-                const isCurrent = session.id === session.currentSessionId;
+                const isRevoked = Boolean(session.revokedAt);
+                const isCurrent = session.isCurrent;
 
                 return (
                   <div
@@ -86,14 +86,23 @@ export function SecurityAndAccountAcessPage() {
                   >
                     <div className="flex flex-col text-sm">
                       <span className="font-medium">
-                        Sessão iniciada em {formatDate(session.createdAt)}
+                        {session.device || "Dispositivo desconhecido"}
                       </span>
+                      {(session.location || session.ipAddress) && (
+                        <span className="text-muted-foreground">
+                          {[session.location, session.ipAddress]
+                            .filter(Boolean)
+                            .join(" · ")}
+                        </span>
+                      )}
                       <span className="text-muted-foreground">
-                        Verificada em {formatDate(session.verifiedAt)}
+                        Iniciada em {formatDate(session.createdAt)}
+                        {session.lastSeenAt &&
+                          ` · Último acesso em ${formatDate(session.lastSeenAt)}`}
                       </span>
                       {isRevoked && (
                         <span className="text-xs text-destructive">
-                          Revogada em {formatDate(session.RevokedAt)}
+                          Revogada em {formatDate(session.revokedAt)}
                         </span>
                       )}
                     </div>
@@ -1,3 +1,4 @@
+import { useEffect, useState } from "react";
 import { Link, useNavigate } from "react-router-dom";
 import { useForm } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
@@ -9,8 +10,9 @@ import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { FormValidationError } from "@/components/form-validation-error";
-import { useMutation } from "@tanstack/react-query";
-import { login } from "@/api/login";
+import { useMutation, useQuery } from "@tanstack/react-query";
+import { login, type LoginResponse } from "@/api/login";
+import { getPendingLogin } from "@/api/get-pending-login";
 
 const loginSchema = z.object({
   email: z
@@ -35,13 +37,41 @@ export function LoginForm() {
     },
   });
 
+  const [pendingLogin, setPendingLogin] = useState<LoginResponse | null>(null);
+
   const { mutateAsync: loginFn } = useMutation({
     mutationFn: login,
   });
 
+  // Waits for the link to be opened on another device, which approves this
+  // one with the verification code shown below.
+  const { data: pendingLoginStatus, isError: isPendingLoginGone } = useQuery({
+    queryKey: ["pending-login", pendingLogin?.pendingLoginId],
+    queryFn: () =>
+      getPendingLogin({ pendingLoginId: pendingLogin!.pendingLoginId }),
+    enabled: !!pendingLogin,
+    retry: false,
+    refetchInterval: (query) =>
+      query.state.data?.status === "approved"
+        ? false
+        : (pendingLogin?.pollInterval ?? 3) * 1000,
+  });
+
+  useEffect(() => {
+    if (pendingLoginStatus?.status === "approved") {
+      navigate("/");
+    }
+  }, [pendingLoginStatus, navigate]);
+
+  useEffect(() => {
+    if (isPendingLoginGone) {
+      setPendingLogin(null);
+    }
+  }, [isPendingLoginGone]);
+
   async function handleLogin(data: LoginFormSchema) {
     try {
-      await loginFn(data);
+      setPendingLogin(await loginFn(data));
       toast.success(
         "Um link de acesso foi enviado para o seu e-mail. Verifique sua caixa de entrada.",
         {
@@ -98,6 +128,18 @@ export function LoginForm() {
         </Button>
       </form>
 
+      {pendingLogin && (
+        <div className="rounded-lg border p-4 text-center">
+          <p className="text-sm text-muted-foreground">
+            Vai abrir o link em outro dispositivo? Digite este código lá para
+            entrar aqui:
+          </p>
+          <p className="mt-2 font-mono text-3xl font-bold tracking-[0.5em]">
+            {pendingLogin.verificationCode}
+          </p>
+        </div>
+      )}
+
       <p className="text-muted-foreground *:[a]:hover:text-primary text-center text-xs text-balance *:[a]:underline *:[a]:underline-offset-4">
         Ao continuar, você concorda com os nossos{" "}
         <Link to="/termos">Termos de Uso</Link> e{" "}